@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/db"
+)
+
+// CreateApiKey creates a key to call the API with and prints it as JSON, the first admin key can be created only this way.
+// It returns the exit code of the program.
+func CreateApiKey(args []string) int {
+	flags := flag.NewFlagSet("create-api-key", flag.ContinueOnError)
+	title := flags.String("title", "", "what the key is for")
+	role := flags.String("role", app.RoleAdmin, "admin, analyst, teacher or student")
+	student := flags.String("student", "", "id of the student the key belongs to, for the student role")
+	teacher := flags.String("teacher", "", "id of the teacher the key belongs to, for the teacher role")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: create-api-key -title title [-role role] [-student id] [-teacher id]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 || *title == "" {
+		flags.Usage()
+		return 2
+	}
+	var studentId uuid.UUID
+	if *student != "" {
+		var err error
+		if studentId, err = uuid.FromString(*student); err != nil {
+			slog.Error("wrong student id", "error", err)
+			return 2
+		}
+	}
+	var teacherId uuid.UUID
+	if *teacher != "" {
+		var err error
+		if teacherId, err = uuid.FromString(*teacher); err != nil {
+			slog.Error("wrong teacher id", "error", err)
+			return 2
+		}
+	}
+
+	db, err := db.CreateConnection()
+	if err != nil {
+		slog.Error("unable to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	apiKey, err := app.New(db).PostApiKey(*title, *role, studentId, teacherId)
+	if err != nil {
+		slog.Error("unable to create the key", "error", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(apiKey); err != nil {
+		slog.Error("unable to write the key", "error", err)
+		return 1
+	}
+	return 0
+}
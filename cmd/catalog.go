@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/db"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// ExportCatalog writes the catalog bundle to the file given in args or to the standard output.
+// It returns the exit code of the program.
+func ExportCatalog(args []string) int {
+	flags := flag.NewFlagSet("export-catalog", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: export-catalog [file.json]")
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	db, err := db.CreateConnection()
+	if err != nil {
+		slog.Error("unable to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	bundle, err := app.New(db).ExportCatalog()
+	if err != nil {
+		slog.Error("unable to export the catalog", "error", err)
+		return 1
+	}
+
+	var out io.Writer = os.Stdout
+	if flags.NArg() == 1 {
+		file, err := os.Create(flags.Arg(0))
+		if err != nil {
+			slog.Error("unable to create the file", "error", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(bundle); err != nil {
+		slog.Error("unable to write the bundle", "error", err)
+		return 1
+	}
+	return 0
+}
+
+// ImportCatalog applies the catalog bundle from the file given in args and prints the result as JSON.
+// It returns the exit code of the program.
+func ImportCatalog(args []string) int {
+	flags := flag.NewFlagSet("import-catalog", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: import-catalog file.json")
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		slog.Error("unable to read the file", "error", err)
+		return 1
+	}
+	var bundle model.CatalogBundle
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		slog.Error("unable to decode the bundle", "error", err)
+		return 1
+	}
+
+	db, err := db.CreateConnection()
+	if err != nil {
+		slog.Error("unable to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	resp, err := app.New(db).ImportCatalog(bundle)
+	if err != nil {
+		slog.Error("unable to import the catalog", "error", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		slog.Error("error converting data to JSON format", "error", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/db"
+)
+
+// ImportMatrix imports the competency matrix from the CSV or XLSX file given in args and prints the result as JSON.
+// It returns the exit code of the program: 1 when nothing was imported because of errors.
+func ImportMatrix(args []string) int {
+	flags := flag.NewFlagSet("import-matrix", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "only show what would be created")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: import-matrix [-dry-run] file.csv|file.xlsx")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		slog.Error("unable to read the file", "error", err)
+		return 1
+	}
+	records, err := app.ReadMatrix(data)
+	if err != nil {
+		slog.Error("unable to read the competency matrix", "error", err)
+		return 1
+	}
+
+	db, err := db.CreateConnection()
+	if err != nil {
+		slog.Error("unable to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	resp, err := app.New(db).ImportMatrix(records, *dryRun)
+	if err != nil {
+		slog.Error("unable to import the competency matrix", "error", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		slog.Error("error converting data to JSON format", "error", err)
+		return 1
+	}
+	if len(resp.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
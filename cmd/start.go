@@ -3,6 +3,7 @@ package cmd
 import (
 	"log/slog"
 	"net/http"
+	"os"
 
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/db"
@@ -13,14 +14,19 @@ import (
 func StartApp() {
 	db, err := db.CreateConnection()
 	if err != nil {
-		slog.Error("unable to connect to the database", err)
+		slog.Error("unable to connect to the database", "error", err)
 		return
 	}
 	defer db.Close()
 
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		slog.Warn("empty environment variable JWT_SECRET, only API keys are accepted")
+	}
+
 	service := app.New(db)
-	handler := rest.New(service)
-	if err = http.ListenAndServe(":8080", handler.Router); err != nil {
-		slog.Error("an error occurred during the execution of the program", err)
+	handler := rest.New(service, []byte(secret))
+	if err = http.ListenAndServe(":8080", handler); err != nil {
+		slog.Error("an error occurred during the execution of the program", "error", err)
 	}
 }
@@ -15,9 +15,17 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/competency/": {
+        "/api/v1/apiKey/": {
             "post": {
-                "description": "post single competency",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a key to call the API with the role: admin, analyst, teacher or student. A student key belongs to the student,\na teacher key to the teacher. The key is shown only in this response. Admins only",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,17 +33,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "competency"
+                    "auth"
                 ],
-                "summary": "Post competency",
+                "summary": "Post API key",
                 "parameters": [
                     {
-                        "description": "Competency request",
+                        "description": "API key data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostCompetency"
+                            "$ref": "#/definitions/model.PostApiKey"
                         }
                     }
                 ],
@@ -43,38 +51,58 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetCompetency"
+                            "$ref": "#/definitions/model.GetApiKey"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/competency/{id}": {
-            "get": {
-                "description": "get single competency by ID",
-                "consumes": [
-                    "application/json"
+        "/api/v1/apiKey/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "revoke the key together with the tokens issued for it. Admins only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "competency"
+                    "auth"
                 ],
-                "summary": "Show competency",
+                "summary": "Delete API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Competency ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -84,24 +112,53 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetCompetency"
+                            "$ref": "#/definitions/model.GetDeleteReport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/competencyProfession/": {
+        "/api/v1/calendarPeriod/": {
             "post": {
-                "description": "post single competency-profession connection",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "add holiday or examination session to the organization calendar, both dates are inclusive",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,39 +166,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "competencyProfession"
+                    "calendar"
                 ],
-                "summary": "Post competency-profession connection",
+                "summary": "Add holiday or session",
                 "parameters": [
                     {
-                        "description": "CompetencyProfession data",
+                        "description": "Calendar period request",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostCompetencyProfession"
+                            "$ref": "#/definitions/model.PostCalendarPeriod"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCalendarPeriod"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/course/": {
+        "/api/v1/calendarSemester/": {
             "post": {
-                "description": "post single course",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "set dates of the autumn (1) or spring (2) semester of the academic year and the window students enroll in courses in, previous dates are replaced.\nOnce an organization sets enrollment windows, students enroll in its courses only within them",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,17 +220,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
+                    "calendar"
                 ],
-                "summary": "Post course",
+                "summary": "Set semester dates",
                 "parameters": [
                     {
-                        "description": "Course data",
+                        "description": "Calendar semester request",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostCourse"
+                            "$ref": "#/definitions/model.PostCalendarSemester"
                         }
                     }
                 ],
@@ -167,24 +238,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetCourse"
+                            "$ref": "#/definitions/model.GetCalendarSemester"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/course/{id}": {
+        "/api/v1/catalog/": {
             "get": {
-                "description": "get single course by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get organizations, educational programs, disciplines, courses, technologies, knowledge, competencies,\nprofessions and their links as a versioned JSON bundle with references by title",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,80 +274,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "course"
-                ],
-                "summary": "Show course",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "catalog"
                 ],
+                "summary": "Export catalog",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetCourse"
+                            "$ref": "#/definitions/model.CatalogBundle"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/courseCompetency/": {
+            },
             "post": {
-                "description": "post single course-competency connection",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "courseCompetency"
-                ],
-                "summary": "Post course-competency connection",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Course-competency data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostCourseCompetency"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Bad Request"
+                        "ApiKeyAuth": []
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/api/v1/discipline/": {
-            "post": {
-                "description": "post single discipline",
+                ],
+                "description": "upsert the exported bundle by titles in one transaction: missing entities and links are created,\ncolumns of existing entities and levels of existing competency links are replaced, nothing is deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -273,17 +309,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "discipline"
+                    "catalog"
                 ],
-                "summary": "Post discipline",
+                "summary": "Import catalog",
                 "parameters": [
                     {
-                        "description": "Discipline data",
+                        "description": "Catalog bundle",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostDiscipline"
+                            "$ref": "#/definitions/model.CatalogBundle"
                         }
                     }
                 ],
@@ -291,24 +327,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetDiscipline"
+                            "$ref": "#/definitions/model.GetCatalogImport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/discipline/{id}": {
+        "/api/v1/competency/": {
             "get": {
-                "description": "get single discipline by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of competencies, optionally filtered by main technology",
                 "consumes": [
                     "application/json"
                 ],
@@ -316,40 +363,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "discipline"
+                    "competency"
                 ],
-                "summary": "Show discipline",
+                "summary": "List competencies",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Main technology ID",
+                        "name": "mainTechnologyId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetDiscipline"
+                            "$ref": "#/definitions/model.GetList-model_GetCompetency"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
-                    },
-                    "404": {
-                        "description": "Not Found"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/educationalProgram/": {
+            },
             "post": {
-                "description": "post single educational program",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single competency",
                 "consumes": [
                     "application/json"
                 ],
@@ -357,17 +446,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "educational program"
+                    "competency"
                 ],
-                "summary": "Post educational program",
+                "summary": "Post competency",
                 "parameters": [
                     {
-                        "description": "Educational program data",
+                        "description": "Competency request",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostEducationalProgram"
+                            "$ref": "#/definitions/model.PostCompetency"
                         }
                     }
                 ],
@@ -375,24 +464,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetEducationalProgram"
+                            "$ref": "#/definitions/model.GetCompetency"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/educationalProgram/{id}": {
+        "/api/v1/competency/{id}": {
             "get": {
-                "description": "get single educational program by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single competency by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -400,13 +500,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "educational program"
+                    "competency"
                 ],
-                "summary": "Show educational program",
+                "summary": "Show competency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Educational program ID",
+                        "description": "Competency ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -416,24 +516,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetEducationalProgram"
+                            "$ref": "#/definitions/model.GetCompetency"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/knowledge/": {
-            "post": {
-                "description": "post single knowledge",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single competency",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,123 +556,129 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge"
+                    "competency"
                 ],
-                "summary": "Post knowledge",
+                "summary": "Update competency",
                 "parameters": [
                     {
-                        "description": "Knowledge request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostKnowledge"
-                        }
-                    }
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Competency request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCompetency"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetKnowledge"
+                            "$ref": "#/definitions/model.GetCompetency"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/knowledge/{id}": {
-            "get": {
-                "description": "get single knowledge by ID",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "delete single competency, cascade deletion has to be confirmed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge"
+                    "competency"
                 ],
-                "summary": "Show knowledge",
+                "summary": "Delete competency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Knowledge ID",
+                        "description": "Competency ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetKnowledge"
+                            "$ref": "#/definitions/model.GetDeleteReport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/api/v1/knowledgeCompetency/": {
-            "post": {
-                "description": "post single knowledge-competency connection",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "knowledgeCompetency"
-                ],
-                "summary": "Post knowledge-competency connection",
-                "parameters": [
-                    {
-                        "description": "Knowledge-competency request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.PostKnowledgeCompetency"
+                            "$ref": "#/definitions/model.GetProblem"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/organization/": {
-            "post": {
-                "description": "post single organization",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single competency",
                 "consumes": [
                     "application/json"
                 ],
@@ -565,17 +686,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "competency"
                 ],
-                "summary": "Post organization",
+                "summary": "Update competency",
                 "parameters": [
                     {
-                        "description": "Organization data",
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Competency request",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostOrganization"
+                            "$ref": "#/definitions/model.PostCompetency"
                         }
                     }
                 ],
@@ -583,24 +711,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetOrganization"
+                            "$ref": "#/definitions/model.GetCompetency"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/organization/{id}": {
+        "/api/v1/competency/{id}/tree": {
             "get": {
-                "description": "get single organization by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the competency with its child competencies ordered by title. Counts of every node are aggregated\nover its subtree, a skill, knowledge or course shared by several competencies is counted once",
                 "consumes": [
                     "application/json"
                 ],
@@ -608,13 +753,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "competency"
                 ],
-                "summary": "Show organization",
+                "summary": "Show competency tree",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID",
+                        "description": "Competency ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -624,94 +769,100 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetOrganization"
+                            "$ref": "#/definitions/model.GetCompetencyTree"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/portfolio/": {
+        "/api/v1/competencyMatrix/": {
             "post": {
-                "description": "post single portfolio",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "portfolio"
-                ],
-                "summary": "Post portfolio",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.PostPortfolio"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/api/v1/portfolio/{id}": {
-            "get": {
-                "description": "get single portfolio by ID",
+                ],
+                "description": "import the analysts' table with profession, competency, skills, knowledge and technology columns from CSV or XLSX.\nEntities are found by title or created, with their links, in one transaction. Blank profession and competency cells\nrepeat the row above, knowledge cells may list several items separated with semicolons, skills cells with commas. An optional level column\ngives the level (aware, basic, intermediate or advanced) the profession requires, basic when blank, existing links keep their levels.\nA dry run and a file with wrong rows write nothing, the response shows what would be created and errors of the rows",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "portfolio"
+                    "import"
                 ],
-                "summary": "Show portfolio",
+                "summary": "Import competency matrix",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Portfolio ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "CSV or XLSX file, the request body is read when there is no form",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only show what would be created",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetPortfolio"
+                            "$ref": "#/definitions/model.GetMatrixImport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
-                    },
-                    "404": {
-                        "description": "Not Found"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/profession/": {
+        "/api/v1/competencyProfession/": {
             "post": {
-                "description": "post single profession",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single competency-profession connection with the level (aware, basic, intermediate or advanced) the profession requires, basic by default",
                 "consumes": [
                     "application/json"
                 ],
@@ -719,42 +870,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profession"
+                    "competencyProfession"
                 ],
-                "summary": "Post profession",
+                "summary": "Post competency-profession connection",
                 "parameters": [
                     {
-                        "description": "Profession data",
+                        "description": "CompetencyProfession data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostProfession"
+                            "$ref": "#/definitions/model.PostCompetencyProfession"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.GetProfession"
-                        }
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/profession/{id}": {
-            "get": {
-                "description": "get single profession by ID",
+        "/api/v1/competencyProfession/{competencyId}/{professionId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the profession requires the competency at, basic when omitted on PUT",
                 "consumes": [
                     "application/json"
                 ],
@@ -762,83 +921,124 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profession"
+                    "competencyProfession"
                 ],
-                "summary": "Show profession",
+                "summary": "Update competency-profession connection",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Profession ID",
-                        "name": "id",
+                        "name": "professionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Required level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetProfession"
+                            "$ref": "#/definitions/model.PostCompetencyProfession"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/project/": {
-            "post": {
-                "description": "post single project",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "tags": [
-                    "project"
+                    "competencyProfession"
                 ],
-                "summary": "Post project",
+                "summary": "Delete competency-profession connection",
                 "parameters": [
                     {
-                        "description": "Project data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostProject"
-                        }
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "professionId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/model.GetProject"
+                            "$ref": "#/definitions/model.GetProblem"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/project/{id}": {
-            "get": {
-                "description": "get single project by ID",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the profession requires the competency at, basic when omitted on PUT",
                 "consumes": [
                     "application/json"
                 ],
@@ -846,40 +1046,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "competencyProfession"
                 ],
-                "summary": "Show project",
+                "summary": "Update competency-profession connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "professionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Required level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetProject"
+                            "$ref": "#/definitions/model.PostCompetencyProfession"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/projectPortfolio/": {
-            "post": {
-                "description": "post single project-portfolio connection",
+        "/api/v1/course/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of courses, optionally filtered by discipline",
                 "consumes": [
                     "application/json"
                 ],
@@ -887,79 +1120,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "projectPortfolio"
+                    "course"
                 ],
-                "summary": "Post project-portfolio connection",
+                "summary": "List courses",
                 "parameters": [
                     {
-                        "description": "Personal project data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostProjectPortfolio"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "disciplineId",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
                     },
-                    "502": {
-                        "description": "Bad Gateway"
-                    }
-                }
-            }
-        },
-        "/api/v1/projectPortfolioCompetency/": {
-            "post": {
-                "description": "post single project-portfolio-competency connection",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "projectPortfolioCompetency"
-                ],
-                "summary": "Post project-portfolio-competency connection",
-                "parameters": [
                     {
-                        "description": "Personal project competency",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostProjectPortfolioCompetency"
-                        }
+                        "enum": [
+                            "title",
+                            "id",
+                            "teacher"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetCourse"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/student/": {
+            },
             "post": {
-                "description": "post single student",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single course with its teachers and workload in credits and academic hours",
                 "consumes": [
                     "application/json"
                 ],
@@ -967,17 +1204,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "student"
+                    "course"
                 ],
-                "summary": "Post student",
+                "summary": "Post course",
                 "parameters": [
                     {
-                        "description": "Student` + "`" + `s data",
+                        "description": "Course data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostStudent"
+                            "$ref": "#/definitions/model.PostCourse"
                         }
                     }
                 ],
@@ -985,24 +1222,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetStudent"
+                            "$ref": "#/definitions/model.GetCourse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/student/{id}": {
+        "/api/v1/course/{id}": {
             "get": {
-                "description": "get single student by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single course by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1010,13 +1258,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "student"
+                    "course"
                 ],
-                "summary": "Show student",
+                "summary": "Show course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1026,24 +1274,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetStudent"
+                            "$ref": "#/definitions/model.GetCourse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/studyGroup/": {
-            "post": {
-                "description": "Student` + "`" + `s course in current semester",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single course",
                 "consumes": [
                     "application/json"
                 ],
@@ -1051,96 +1314,265 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "studyGroup"
+                    "course"
                 ],
-                "summary": "Post student` + "`" + `s course in current semester",
+                "summary": "Update course",
                 "parameters": [
                     {
-                        "description": "Personal current student` + "`" + `s project",
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Course data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostStudyGroup"
+                            "$ref": "#/definitions/model.PostCourse"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCourse"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/technology/": {
-            "post": {
-                "description": "post single technology",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "delete single course, cascade deletion has to be confirmed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "technology"
+                    "course"
                 ],
-                "summary": "Post technology",
+                "summary": "Delete course",
                 "parameters": [
                     {
-                        "description": "Technology request",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PostTechnology"
-                        }
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetTechnology"
+                            "$ref": "#/definitions/model.GetDeleteReport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
-                    "502": {
-                        "description": "Bad Gateway"
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single course",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "course"
+                ],
+                "summary": "Update course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Course data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCourse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCourse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/technology/{id}": {
+        "/api/v1/course/{id}/prerequisites": {
             "get": {
-                "description": "get single technology by ID",
-                "consumes": [
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get courses the course requires ordered by title",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "coursePrerequisite"
+                ],
+                "summary": "Show course prerequisites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.GetCoursePrerequisite"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/course/{id}/studyGroup": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get students studying the course in the current semester ordered by full name. Available to analysts and the teacher of the course",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "technology"
+                    "studyGroup"
                 ],
-                "summary": "Show technology",
+                "summary": "Show course study group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Technology ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1150,24 +1582,53 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetTechnology"
+                            "$ref": "#/definitions/model.GetCourseStudyGroup"
                         }
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/trajectory/": {
+        "/api/v1/courseCompetency/": {
             "post": {
-                "description": "post single student` + "`" + `s archive course",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single course-competency connection with the level (aware, basic, intermediate or advanced) the course gives, basic by default",
                 "consumes": [
                     "application/json"
                 ],
@@ -1175,42 +1636,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "trajectory"
+                    "courseCompetency"
                 ],
-                "summary": "Post student` + "`" + `s archive course",
+                "summary": "Post course-competency connection",
                 "parameters": [
                     {
-                        "description": "Trajectory` + "`" + `s data",
+                        "description": "Course-competency data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PostTrajectory"
+                            "$ref": "#/definitions/model.PostCourseCompetency"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.GetTrajectory"
-                        }
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "502": {
-                        "description": "Bad Gateway"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/trajectory/{id}": {
-            "get": {
-                "description": "get single trajectory by ID",
+        "/api/v1/courseCompetency/{courseId}/{competencyId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the course gives the competency at, basic when omitted on PUT",
                 "consumes": [
                     "application/json"
                 ],
@@ -1218,453 +1687,10525 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "trajectory"
+                    "courseCompetency"
                 ],
-                "summary": "Show trajectory",
+                "summary": "Update course-competency connection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Trajectory ID",
-                        "name": "id",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Given level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetTrajectory"
+                            "$ref": "#/definitions/model.PostCourseCompetency"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "courseCompetency"
+                ],
+                "summary": "Delete course-competency connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Bad Request"
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "404": {
-                        "description": "Not Found"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the course gives the competency at, basic when omitted on PUT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "courseCompetency"
+                ],
+                "summary": "Update course-competency connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Given level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCourseCompetency"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coursePrerequisite/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "make the course require the prerequisite course, hard prerequisites are mandatory and soft ones are recommended. The kind of the existing prerequisite is updated, prerequisites forming a cycle are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coursePrerequisite"
+                ],
+                "summary": "Post course prerequisite",
+                "parameters": [
+                    {
+                        "description": "Course prerequisite data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCoursePrerequisite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCoursePrerequisite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coursePrerequisite/{courseId}/{prerequisiteId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "coursePrerequisite"
+                ],
+                "summary": "Delete course prerequisite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prerequisite course ID",
+                        "name": "prerequisiteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/courseSession/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post lecture, practice or lab of the course with weekly hours, hours of the existing session of the kind are updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Post course session",
+                "parameters": [
+                    {
+                        "description": "Course session data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCourseSession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCourseSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/curriculum/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "place the discipline into the semester of the curriculum of the educational program, the discipline is required unless the elective pool of the same program is given. The discipline already in the curriculum is moved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "curriculum"
+                ],
+                "summary": "Post curriculum discipline",
+                "parameters": [
+                    {
+                        "description": "Curriculum discipline data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCurriculumDiscipline"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/discipline/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of disciplines, optionally filtered by educational program",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "List disciplines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "educationalProgramId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetDiscipline"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single discipline",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Post discipline",
+                "parameters": [
+                    {
+                        "description": "Discipline data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostDiscipline"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDiscipline"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/discipline/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single discipline by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Show discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDiscipline"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single discipline",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Update discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Discipline data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostDiscipline"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDiscipline"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single discipline, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Delete discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single discipline",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Update discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Discipline data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostDiscipline"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDiscipline"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/discipline/{id}/alternatives": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get interchangeable courses of the discipline, a student takes one of them. Competencies every course gives\nare common, for each course the competencies it gives besides them and the ones only other courses give are listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discipline"
+                ],
+                "summary": "Compare alternative courses of discipline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDisciplineAlternatives"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/educationalProgram/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of educational programs, optionally filtered by organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "List educational programs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organizationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetEducationalProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single educational program, min and max semester credits limit the workload of its students, max 0 means no limit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "Post educational program",
+                "parameters": [
+                    {
+                        "description": "Educational program data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostEducationalProgram"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEducationalProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/educationalProgram/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single educational program by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "Show educational program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEducationalProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single educational program",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "Update educational program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Educational program data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostEducationalProgram"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEducationalProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single educational program, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "Delete educational program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single educational program",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "educational program"
+                ],
+                "summary": "Update educational program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Educational program data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostEducationalProgram"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEducationalProgram"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/educationalProgram/{id}/curriculum": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get required disciplines of the educational program and its elective pools ordered by semester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "curriculum"
+                ],
+                "summary": "Show curriculum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Educational program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCurriculum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/electivePool/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post a pool of disciplines of the educational program the student chooses from, the student takes choose of them. The choose of the pool with the same title is updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "curriculum"
+                ],
+                "summary": "Post elective pool",
+                "parameters": [
+                    {
+                        "description": "Elective pool data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostElectivePool"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetElectivePool"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/enrollment/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "enroll the student in the educational program from the start date (today by default). The open enrollment of the student in another program is closed as transferred on that date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollment"
+                ],
+                "summary": "Post enrollment",
+                "parameters": [
+                    {
+                        "description": "Enrollment data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostEnrollment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEnrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gradingScale/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "set grades the organization gives and the lowest passing one, the previous scale is replaced. Outcomes recorded before keep their results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Set organization grading scale",
+                "parameters": [
+                    {
+                        "description": "Grading scale",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostGradingScale"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetGradingScale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of knowledge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "List knowledge",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetKnowledge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single knowledge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Post knowledge",
+                "parameters": [
+                    {
+                        "description": "Knowledge request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostKnowledge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetKnowledge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single knowledge by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Show knowledge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetKnowledge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single knowledge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Update knowledge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Knowledge request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostKnowledge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetKnowledge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single knowledge, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Delete knowledge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single knowledge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Update knowledge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Knowledge request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostKnowledge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetKnowledge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge/{id}/tree": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the knowledge with its child knowledge ordered by title. Counts of every node are aggregated\nover its subtree, a competency made of several knowledge of the subtree is counted once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "Show knowledge tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetKnowledgeTree"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledgeCompetency/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single knowledge-competency connection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledgeCompetency"
+                ],
+                "summary": "Post knowledge-competency connection",
+                "parameters": [
+                    {
+                        "description": "Knowledge-competency request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostKnowledgeCompetency"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledgeCompetency/{knowledgeId}/{competencyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "knowledgeCompetency"
+                ],
+                "summary": "Delete knowledge-competency connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge ID",
+                        "name": "knowledgeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organization/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of organizations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "List organizations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Post organization",
+                "parameters": [
+                    {
+                        "description": "Organization data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostOrganization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organization/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single organization by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Show organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Update organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostOrganization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single organization, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Delete organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Update organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostOrganization"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetOrganization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organization/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get configured semesters, holidays and sessions of the organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Show organization calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organization/{id}/gradingScale": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get grades the organization gives and the lowest passing one, organizations without their own scale grade from 2 to 5 and pass with 3",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Show organization grading scale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetGradingScale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolio/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single portfolio",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolio"
+                ],
+                "summary": "Post portfolio",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostPortfolio"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/portfolio/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single portfolio by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolio"
+                ],
+                "summary": "Show portfolio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetPortfolio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single portfolio, cascade deletion (including students) has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolio"
+                ],
+                "summary": "Delete portfolio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profession/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of professions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "List professions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetProfession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single profession",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "Post profession",
+                "parameters": [
+                    {
+                        "description": "Profession data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProfession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProfession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profession/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single profession by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "Show profession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProfession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single profession",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "Update profession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profession request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProfession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProfession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single profession, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "Delete profession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single profession",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profession"
+                ],
+                "summary": "Update profession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profession ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profession request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProfession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProfession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of projects, optionally filtered by main technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "List projects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Main technology ID",
+                        "name": "mainTechnologyId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Post project",
+                "parameters": [
+                    {
+                        "description": "Project data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single project by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Show project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Update project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single project, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Delete project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Update project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Project request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projectPortfolio/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single project-portfolio connection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolio"
+                ],
+                "summary": "Post project-portfolio connection",
+                "parameters": [
+                    {
+                        "description": "Personal project data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projectPortfolio/{projectId}/{portfolioId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) student` + "`" + `s role and semester in the project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolio"
+                ],
+                "summary": "Update project-portfolio connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Personal project data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutProjectPortfolio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "remove project from the portfolio together with competencies confirmed by it",
+                "tags": [
+                    "projectPortfolio"
+                ],
+                "summary": "Delete project-portfolio connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) student` + "`" + `s role and semester in the project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolio"
+                ],
+                "summary": "Update project-portfolio connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Personal project data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutProjectPortfolio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projectPortfolioCompetency/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single project-portfolio-competency connection with the level (aware, basic, intermediate or advanced) the project confirms, basic by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolioCompetency"
+                ],
+                "summary": "Post project-portfolio-competency connection",
+                "parameters": [
+                    {
+                        "description": "Personal project competency",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolioCompetency"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projectPortfolioCompetency/{projectId}/{portfolioId}/{competencyId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the project of the portfolio confirms the competency at, basic when omitted on PUT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolioCompetency"
+                ],
+                "summary": "Update personal project competency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Confirmed level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolioCompetency"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "projectPortfolioCompetency"
+                ],
+                "summary": "Delete project-portfolio-competency connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) the level the project of the portfolio confirms the competency at, basic when omitted on PUT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projectPortfolioCompetency"
+                ],
+                "summary": "Update personal project competency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "portfolioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Competency ID",
+                        "name": "competencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Confirmed level",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PutCompetencyLevel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PostProjectPortfolioCompetency"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/room/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single room, capacity of the existing room with the title is updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Post room",
+                "parameters": [
+                    {
+                        "description": "Room data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostRoom"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetRoom"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "find knowledge, technologies, competencies, professions, projects, organizations, educational programs,\ndisciplines and courses by words of their titles, descriptions and skills in Russian. Words are found\nin any form and titles despite typos. Hits are grouped by kind of entity, groups go in order of their best hit,\nmatched words of the snippet are wrapped in \u003cb\u003e\u003c/b\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search the catalog",
+                "parameters": [
+                    {
+                        "minLength": 2,
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of hits of every kind",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/skill/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of skills",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skill"
+                ],
+                "summary": "List skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetSkill"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/skill/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single skill by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skill"
+                ],
+                "summary": "Show skill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetSkill"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single skill with the competency links, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skill"
+                ],
+                "summary": "Delete skill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Skill ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of students",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "List students",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "fullName",
+                            "id",
+                            "admition"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetStudent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Post student",
+                "parameters": [
+                    {
+                        "description": "Student` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostStudent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetStudent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single student by ID with the current educational program, the semester is counted from the first enrollment without academic leaves",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetStudent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Update student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostStudent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetStudent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single student, cascade deletion has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Delete student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Update student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostStudent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetStudent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/alternatives/{disciplineId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get interchangeable courses of the discipline compared by competencies, the course the student takes or took is chosen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Compare alternative courses of discipline for student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Discipline ID",
+                        "name": "disciplineId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDisciplineAlternatives"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/competencyGap": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "compare competencies required by the profession with the ones the student already has through portfolio projects, passed courses and current study groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show student` + "`" + `s competency gap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desired profession ID",
+                        "name": "professionId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCompetencyGap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/curriculumCheck": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "compare passed courses of past trajectories, current study groups and planned courses of the student with the curriculum of the educational program, by default the one the student is enrolled in or most of the student courses belong to. A discipline is covered by any of its courses, an elective pool by as many disciplines as it chooses. Required disciplines and pools still outstanding before graduation are listed, disciplines covered after their semester are marked late",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Check student curriculum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Planned courses",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostCurriculumCheck"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetCurriculumCheck"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/enrollmentStatus": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "send the student on academic leave, return the student from it, expel the student or mark the graduation on the date (today by default). Only the open enrollment changes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollment"
+                ],
+                "summary": "Change enrollment status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostEnrollmentStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEnrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/enrollments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get enrollments of the student with academic leaves ordered by start, transfers between programs close enrollments as transferred and the last enrollment is the current one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollment"
+                ],
+                "summary": "Show student enrollments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetEnrollments"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/plan": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "build semester-by-semester course list that covers competencies required by the profession and not given by passed or current courses, semesters keep within the maximum credits of the student` + "`" + `s educational program and semesters out of its limits are reported",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Build student` + "`" + `s educational plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desired profession ID",
+                        "name": "professionId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/planValidation": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "check that past trajectories, current study groups and planned courses of the student come after their prerequisites. Prerequisites that are missing or taken in the same or a later semester are reported, the plan is valid without hard violations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Validate student plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Planned courses",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostPlanValidation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetPlanValidation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "rank courses the student has not taken for the desired profession. The score from 0 to 100 is the weighted sum\nof factors: coverage (share of the missing competencies of the profession the course gives, weight 0.5),\ntechnology (share of technologies of the course used in portfolio projects, 0.2), readiness (share\nof prerequisites taken, hard ones count twice, 0.2) and workload (share of the course credits fitting into\nthe next semester, 0.1). Every factor is explained. Only courses giving a missing competency are returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Recommend courses to student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desired profession ID",
+                        "name": "professionId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of courses",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetRecommendations"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/schedule.ics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the timetable of the student for the current semester as iCalendar (RFC 5545) file with weekly events\nbounded by semester dates, holidays are excluded. Calendar applications may subscribe to the URL to refresh it,\nthey can not send headers and pass the feed token from the subscription links in the feedToken query parameter.\nThe SOURCE of the calendar is the URL without query parameters, credentials are never written into the file",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Export student schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token of the student from the subscription links",
+                        "name": "feedToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key allowed to read the schedule, for calendar applications that can not send headers",
+                        "name": "apiKey",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/scheduleSubscription": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get links to subscribe to the schedule of the student in a calendar application. The links carry the feed token\nof the student, it opens the schedule and nothing else. Feeds need the JWT secret of the service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show schedule subscription links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetScheduleSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/semester": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the semester the student studies in on the date (today by default) with its dates, holidays and sessions. Semesters are counted from the first enrollment, terms started during academic leaves are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show student semester on date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date in format 2006-01-02",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetSemesterDates"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/semester/{semester}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get dates, holidays and sessions of the semester of the student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show student semester dates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester number",
+                        "name": "semester",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetSemesterDates"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/student/{id}/timetable": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the part of the saved timetable with courses the student studies in the current semester",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Show student timetable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTimetable"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/studyGroup/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Student` + "`" + `s course in current semester. Courses over the maximum semester credits of the educational program are rejected with the violation,\nan alternative course is rejected when the student studies another alternative course of the discipline.\nWhen the course has no free seats the student is put at the end of its waitlist. Outside the enrollment windows of the organization enrollment is closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "studyGroup"
+                ],
+                "summary": "Post student` + "`" + `s course in current semester",
+                "parameters": [
+                    {
+                        "description": "Personal current student` + "`" + `s project",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostStudyGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetStudyGroupEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/studyGroup/{courseId}/{studentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "drop the student from the course or from its waitlist, the freed seat goes to the first student of the waitlist who can enroll in the course",
+                "tags": [
+                    "studyGroup"
+                ],
+                "summary": "Delete student` + "`" + `s course in current semester",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "studentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacher/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of teachers, optionally filtered by organization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "List teachers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organizationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "fullName",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetTeacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single teacher of the organization with contacts, the teacher with the same full name is updated. Admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Post teacher",
+                "parameters": [
+                    {
+                        "description": "Teacher data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTeacher"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacher/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single teacher by ID with the time slots the teacher is available in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Show teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single teacher. Admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Update teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Teacher data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTeacher"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single teacher with the course links, availability and API keys, cascade deletion has to be confirmed. Admins only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Delete teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single teacher. Admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Update teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Teacher data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTeacher"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacher/{id}/courses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get courses of the teacher ordered by title with weekly hours and students of the current semester. Available to analysts and the teacher",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Show teacher courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacherCourses"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacher/{id}/hours": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get total academic hours of the teacher courses and weekly hours of the courses studied in the current semester. Available to analysts and the teacher",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Show teacher hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacherHours"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacher/{id}/studyGroups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get students of the current semester studying courses of the teacher, grouped by course. Available to analysts and the teacher",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Show teacher study groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTeacherStudyGroups"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/teacherAvailability/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post time slot the teacher can work in, teachers without available slots can work any time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Post teacher availability",
+                "parameters": [
+                    {
+                        "description": "Teacher availability data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTeacherAvailability"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/technology/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get page of technologies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "List technologies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of skipped items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "id"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetList-model_GetTechnology"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "Post technology",
+                "parameters": [
+                    {
+                        "description": "Technology request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTechnology"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTechnology"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/technology/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single technology by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "Show technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTechnology"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "Update technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Technology request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTechnology"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTechnology"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single technology, cascade deletion of competencies has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "Delete technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technology"
+                ],
+                "summary": "Update technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Technology request",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTechnology"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTechnology"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timeSlot/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post weekly time slot, weekday 1 is Monday. The slot starting at the same time of the weekday is updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Post time slot",
+                "parameters": [
+                    {
+                        "description": "Time slot data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTimeSlot"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTimeSlot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timetable/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the saved weekly timetable",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Show timetable",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTimetable"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "place sessions of the courses students study in into time slots and rooms without conflicts and save the timetable.\nSessions that could not be placed are listed in problems with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timetable"
+                ],
+                "summary": "Build timetable",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTimetable"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/token/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "exchange the API key given in the X-API-Key header for a JWT to send as \"Authorization: Bearer \u003ctoken\u003e\".\nThe token carries the role of the key and expires in an hour, a token can not be exchanged for another one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Issue access token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetToken"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/trajectory/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "post single student` + "`" + `s archive course, its outcome is recorded separately. Courses over the maximum semester credits of the educational program are rejected with the violation.\nA course the student already took is rejected, the next attempt at it is added as a retake",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Post student` + "`" + `s archive course",
+                "parameters": [
+                    {
+                        "description": "Trajectory` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTrajectory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/trajectory/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get single trajectory by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Show trajectory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trajectory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single student` + "`" + `s archive course. The student and the course\ncan not be changed once the outcome is recorded or the course is retaken, nor to a course the student already took",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Update student` + "`" + `s archive course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trajectory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trajectory` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTrajectory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete single student` + "`" + `s archive course, deletion of the first attempt of a retaken course with its retakes has to be confirmed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Delete student` + "`" + `s archive course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trajectory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete dependent rows too",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetDeleteReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace (PUT) or partially update (PATCH) single student` + "`" + `s archive course. The student and the course\ncan not be changed once the outcome is recorded or the course is retaken, nor to a course the student already took",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Update student` + "`" + `s archive course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trajectory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trajectory` + "`" + `s data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTrajectory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/trajectory/{id}/outcome": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "record the grade, the result and the completion date (today by default) of the course the student took, a previous outcome is replaced.\nA grade has to fit the grading scale of the organization of the course and decides whether the course is passed, courses without grades are marked passed or failed. Only passed courses cover competencies and count as taken. The outcome of an attempt stays once the course is retaken",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Record course outcome",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trajectory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome of the course",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTrajectoryOutcome"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/trajectory/{id}/retake": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "add the next attempt of the course whose last attempt the student failed, the attempt is linked to the first one. The retake can not come before the failed attempt and keeps within the maximum semester credits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trajectory"
+                ],
+                "summary": "Retake failed course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of any attempt of the course",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Semester of the retake",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostTrajectoryRetake"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetTrajectory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetProblem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.BundleCompetency": {
+            "type": "object",
+            "properties": {
+                "competencyKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "знание 1",
+                        "знание 2"
+                    ]
+                },
+                "competencyMainTechnology": {
+                    "type": "string",
+                    "example": "Название технологии"
+                },
+                "competencyParent": {
+                    "type": "string",
+                    "example": "Название родительской компетенции"
+                },
+                "competencySkillTitles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "навык 1",
+                        "навык 2"
+                    ]
+                },
+                "competencySkills": {
+                    "description": "skills of version 1 and 2 bundles separated by commas",
+                    "type": "string",
+                    "example": "навык 1, навык 2"
+                },
+                "competencyTitle": {
+                    "type": "string",
+                    "example": "Название компетенции"
+                }
+            }
+        },
+        "model.BundleCourse": {
+            "type": "object",
+            "properties": {
+                "courseAlternative": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "courseCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "компетенция 1",
+                        "компетенция 2"
+                    ]
+                },
+                "courseCompetencyLevels": {
+                    "description": "levels of the competencies by title, the ones not listed are basic",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "courseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "courseDescription": {
+                    "type": "string",
+                    "example": "Описание курса"
+                },
+                "courseDiscipline": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                },
+                "courseHours": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "courseTeacher": {
+                    "description": "the only teacher of version 1 bundles",
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "courseTeachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Фамилия Имя Отчество"
+                    ]
+                },
+                "courseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                }
+            }
+        },
+        "model.BundleDiscipline": {
+            "type": "object",
+            "properties": {
+                "disciplineDescription": {
+                    "type": "string",
+                    "example": "Описание дисциплины"
+                },
+                "disciplineEducationalProgram": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                },
+                "disciplineTitle": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                }
+            }
+        },
+        "model.BundleEducationalProgram": {
+            "type": "object",
+            "properties": {
+                "educationalProgramDescription": {
+                    "type": "string",
+                    "example": "Описание образовательной программы"
+                },
+                "educationalProgramMaxSemesterCredits": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "educationalProgramMinSemesterCredits": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "educationalProgramOrganization": {
+                    "type": "string",
+                    "example": "Название организации"
+                },
+                "educationalProgramTitle": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                }
+            }
+        },
+        "model.BundleKnowledge": {
+            "type": "object",
+            "properties": {
+                "knowledgeParent": {
+                    "type": "string",
+                    "example": "Название родительского знания"
+                },
+                "knowledgeTitle": {
+                    "type": "string",
+                    "example": "Название знания"
+                }
+            }
+        },
+        "model.BundleOrganization": {
+            "type": "object",
+            "properties": {
+                "organizationTitle": {
+                    "type": "string",
+                    "example": "Название организации"
+                }
+            }
+        },
+        "model.BundleProfession": {
+            "type": "object",
+            "properties": {
+                "professionCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "компетенция 1",
+                        "компетенция 2"
+                    ]
+                },
+                "professionCompetencyLevels": {
+                    "description": "levels of the competencies by title, the ones not listed are basic",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "professionDescription": {
+                    "type": "string",
+                    "example": "Описание профессии"
+                },
+                "professionTitle": {
+                    "type": "string",
+                    "example": "Название профессии"
+                }
+            }
+        },
+        "model.BundleTechnology": {
+            "type": "object",
+            "properties": {
+                "technologyTitle": {
+                    "type": "string",
+                    "example": "Название технологии"
+                }
+            }
+        },
+        "model.CatalogBundle": {
+            "type": "object",
+            "properties": {
+                "bundleCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleCompetency"
+                    }
+                },
+                "bundleCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleCourse"
+                    }
+                },
+                "bundleDisciplines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleDiscipline"
+                    }
+                },
+                "bundleEducationalPrograms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleEducationalProgram"
+                    }
+                },
+                "bundleExportedAt": {
+                    "type": "string",
+                    "example": "2024-02-01T12:00:00Z"
+                },
+                "bundleFormat": {
+                    "type": "string",
+                    "example": "smart-schedule-former/catalog"
+                },
+                "bundleKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleKnowledge"
+                    }
+                },
+                "bundleOrganizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleOrganization"
+                    }
+                },
+                "bundleProfessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleProfession"
+                    }
+                },
+                "bundleTechnologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleTechnology"
+                    }
+                },
+                "bundleVersion": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.GetAcademicLeave": {
+            "type": "object",
+            "properties": {
+                "leaveEndDate": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "leaveId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "leaveStartDate": {
+                    "type": "string",
+                    "example": "2024-02-01"
+                }
+            }
+        },
+        "model.GetAlternativeCourse": {
+            "type": "object",
+            "properties": {
+                "alternativeCourseChosen": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "alternativeCourseCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "компетенция 1",
+                        "компетенция 2"
+                    ]
+                },
+                "alternativeCourseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "alternativeCourseExtraCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции",
+                        " которые дают не все варианты"
+                    ]
+                },
+                "alternativeCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "alternativeCourseMissingCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции",
+                        " которые дают только другие варианты"
+                    ]
+                },
+                "alternativeCourseTeachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Фамилия Имя Отчество"
+                    ]
+                },
+                "alternativeCourseTitle": {
+                    "type": "string",
+                    "example": "Go от geekbrains"
+                }
+            }
+        },
+        "model.GetApiKey": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "type": "string",
+                    "example": "3q2-7wAAAAC9eXh9b2tlbi1rZXktZXhhbXBsZQ"
+                },
+                "apiKeyId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "apiKeyRole": {
+                    "type": "string",
+                    "example": "analyst"
+                },
+                "apiKeyStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "apiKeyTeacherId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "apiKeyTitle": {
+                    "type": "string",
+                    "example": "Ключ куратора образовательной программы"
+                }
+            }
+        },
+        "model.GetCalendar": {
+            "type": "object",
+            "properties": {
+                "calendarOrganizationId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "calendarPeriods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCalendarPeriod"
+                    }
+                },
+                "calendarSemesters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCalendarSemester"
+                    }
+                }
+            }
+        },
+        "model.GetCalendarPeriod": {
+            "type": "object",
+            "properties": {
+                "periodEndDate": {
+                    "type": "string",
+                    "example": "2024-01-08"
+                },
+                "periodId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "periodKind": {
+                    "type": "string",
+                    "example": "holiday"
+                },
+                "periodStartDate": {
+                    "type": "string",
+                    "example": "2023-12-30"
+                },
+                "periodTitle": {
+                    "type": "string",
+                    "example": "Новогодние каникулы"
+                }
+            }
+        },
+        "model.GetCalendarSemester": {
+            "type": "object",
+            "properties": {
+                "calendarEndDate": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "calendarEnrollmentEndDate": {
+                    "type": "string",
+                    "example": "2023-09-15"
+                },
+                "calendarEnrollmentStartDate": {
+                    "type": "string",
+                    "example": "2023-08-20"
+                },
+                "calendarStartDate": {
+                    "type": "string",
+                    "example": "2023-09-01"
+                },
+                "calendarTerm": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "calendarYear": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "model.GetCatalogImport": {
+            "type": "object",
+            "properties": {
+                "catalogCreated": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "catalogLinks": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "catalogUnchanged": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "catalogUpdated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.GetCompetency": {
+            "type": "object",
+            "properties": {
+                "competencyId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "competencyKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "знание 1",
+                        " знание 2..."
+                    ]
+                },
+                "competencyMainTechnology": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "competencyParentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "competencySkills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "навык 1",
+                        "навык 2"
+                    ]
+                },
+                "competencyTitle": {
+                    "type": "string",
+                    "example": "Название компетенции"
+                }
+            }
+        },
+        "model.GetCompetencyCoverage": {
+            "type": "object",
+            "properties": {
+                "competencyAchievedLevel": {
+                    "description": "the highest level the student has",
+                    "type": "string",
+                    "example": "intermediate"
+                },
+                "competencyCovered": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "competencyCoveredBy": {
+                    "description": "sources reaching the required level",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCoverageSource"
+                    }
+                },
+                "competencyId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "competencyKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "знание 1",
+                        " знание 2..."
+                    ]
+                },
+                "competencyLevel": {
+                    "description": "the level the profession requires",
+                    "type": "string",
+                    "example": "advanced"
+                },
+                "competencyTitle": {
+                    "type": "string",
+                    "example": "Название компетенции"
+                }
+            }
+        },
+        "model.GetCompetencyGap": {
+            "type": "object",
+            "properties": {
+                "gapCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCompetencyCoverage"
+                    }
+                },
+                "gapCoverage": {
+                    "type": "number",
+                    "example": 66.67
+                },
+                "gapProfession": {
+                    "type": "string",
+                    "example": "Название профессии"
+                },
+                "gapProfessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "gapStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetCompetencyTree": {
+            "type": "object",
+            "properties": {
+                "treeChildren": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCompetencyTree"
+                    }
+                },
+                "treeCompetencies": {
+                    "description": "the competency and its descendants",
+                    "type": "integer",
+                    "example": 3
+                },
+                "treeCompetencyId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "treeCompetencyKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "знание 1",
+                        "знание 2"
+                    ]
+                },
+                "treeCompetencySkills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "навык 1",
+                        "навык 2"
+                    ]
+                },
+                "treeCompetencyTitle": {
+                    "type": "string",
+                    "example": "Название компетенции"
+                },
+                "treeCourseCount": {
+                    "description": "courses giving any competency of the subtree",
+                    "type": "integer",
+                    "example": 2
+                },
+                "treeKnowledgeCount": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "treeSkillCount": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "model.GetCourse": {
+            "type": "object",
+            "properties": {
+                "courseAlternative": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "courseCapacity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "courseCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "компетенция 1",
+                        " компетенция 2..."
+                    ]
+                },
+                "courseCompetencyLevels": {
+                    "description": "given levels by competency title",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "courseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "courseDescription": {
+                    "type": "string",
+                    "example": "Описание курса"
+                },
+                "courseDiscipline": {
+                    "type": "string",
+                    "example": "Дисциплина, к которой отностися курс"
+                },
+                "courseEnrolled": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "courseHours": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "courseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "courseTeachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Фамилия Имя Отчество 1",
+                        " Фамилия Имя Отчество 2..."
+                    ]
+                },
+                "courseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "courseWaitlisted": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.GetCoursePrerequisite": {
+            "type": "object",
+            "properties": {
+                "prerequisiteCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "prerequisiteCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "prerequisiteId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "prerequisiteKind": {
+                    "type": "string",
+                    "example": "hard"
+                },
+                "prerequisiteTitle": {
+                    "type": "string",
+                    "example": "Название курса, который нужно пройти раньше"
+                }
+            }
+        },
+        "model.GetCourseRecommendation": {
+            "type": "object",
+            "properties": {
+                "recommendationCourseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "recommendationCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "recommendationCourseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "recommendationFactors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetRecommendationFactor"
+                    }
+                },
+                "recommendationScore": {
+                    "description": "from 0 to 100",
+                    "type": "number",
+                    "example": 78.33
+                }
+            }
+        },
+        "model.GetCourseSession": {
+            "type": "object",
+            "properties": {
+                "sessionCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "sessionCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "sessionKind": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "sessionWeeklyHours": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "model.GetCourseStudyGroup": {
+            "type": "object",
+            "properties": {
+                "courseStudyGroupCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "courseStudyGroupCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "courseStudyGroupStudents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetStudyGroupStudent"
+                    }
+                },
+                "courseStudyGroupTeachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Фамилия Имя Отчество"
+                    ]
+                }
+            }
+        },
+        "model.GetCoverageSource": {
+            "type": "object",
+            "properties": {
+                "coverageId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "coverageKind": {
+                    "type": "string",
+                    "example": "portfolioProject"
+                },
+                "coverageLevel": {
+                    "type": "string",
+                    "example": "intermediate"
+                },
+                "coverageTitle": {
+                    "type": "string",
+                    "example": "Название проекта или курса"
+                }
+            }
+        },
+        "model.GetCurriculum": {
+            "type": "object",
+            "properties": {
+                "curriculumEducationalProgram": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                },
+                "curriculumEducationalProgramId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "curriculumElectivePools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetElectivePool"
+                    }
+                },
+                "curriculumRequired": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCurriculumDiscipline"
+                    }
+                }
+            }
+        },
+        "model.GetCurriculumCheck": {
+            "type": "object",
+            "properties": {
+                "checkComplete": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "checkEducationalProgram": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                },
+                "checkEducationalProgramId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "checkElectivePools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetElectivePoolStatus"
+                    }
+                },
+                "checkOutstanding": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetOutstandingRequirement"
+                    }
+                },
+                "checkRequired": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCurriculumStatus"
+                    }
+                },
+                "checkStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetCurriculumDiscipline": {
+            "type": "object",
+            "properties": {
+                "curriculumDiscipline": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                },
+                "curriculumDisciplineId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "curriculumSemester": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GetCurriculumStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "taken",
+                        "current",
+                        "planned",
+                        "outstanding"
+                    ],
+                    "example": "taken"
+                },
+                "statusCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "statusCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "statusCourseSemester": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "statusDiscipline": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                },
+                "statusDisciplineId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "statusLate": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "statusSemester": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GetDeleteReport": {
+            "type": "object",
+            "properties": {
+                "deletedCascade": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetDependent"
+                    }
+                },
+                "deletedId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetDependent": {
+            "type": "object",
+            "properties": {
+                "dependentCount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "dependentRestrict": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "dependentTable": {
+                    "type": "string",
+                    "example": "competencies"
+                }
+            }
+        },
+        "model.GetDiscipline": {
+            "type": "object",
+            "properties": {
+                "disciplineDescription": {
+                    "type": "string",
+                    "example": "Описание дисциплины"
+                },
+                "disciplineEducationalProgram": {
+                    "type": "string",
+                    "example": "Образовательная программа, в которую входит цисциплина"
+                },
+                "disciplineId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "disciplineTitle": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                }
+            }
+        },
+        "model.GetDisciplineAlternatives": {
+            "type": "object",
+            "properties": {
+                "alternativesCommonCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции",
+                        " которые дают все варианты"
+                    ]
+                },
+                "alternativesCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetAlternativeCourse"
+                    }
+                },
+                "alternativesDiscipline": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                },
+                "alternativesDisciplineId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetEducationalProgram": {
+            "type": "object",
+            "properties": {
+                "educationalProgramDexcription": {
+                    "type": "string",
+                    "example": "Описание образовательной программы"
+                },
+                "educationalProgramId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "educationalProgramMaxSemesterCredits": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "educationalProgramMinSemesterCredits": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "educationalProgramOrganization": {
+                    "type": "string",
+                    "example": "организация, отвечающая за образовательную программу"
+                },
+                "educationalProgramTitle": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                }
+            }
+        },
+        "model.GetElectivePool": {
+            "type": "object",
+            "properties": {
+                "poolChoose": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poolDisciplines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCurriculumDiscipline"
+                    }
+                },
+                "poolId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "poolTitle": {
+                    "type": "string",
+                    "example": "Иностранный язык"
+                }
+            }
+        },
+        "model.GetElectivePoolStatus": {
+            "type": "object",
+            "properties": {
+                "poolChoose": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "poolChosen": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poolDisciplines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCurriculumStatus"
+                    }
+                },
+                "poolId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "poolOutstanding": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "poolTitle": {
+                    "type": "string",
+                    "example": "Иностранный язык"
+                }
+            }
+        },
+        "model.GetEnrollment": {
+            "type": "object",
+            "properties": {
+                "enrollmentEducationalProgram": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                },
+                "enrollmentEducationalProgramId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "enrollmentEndDate": {
+                    "type": "string",
+                    "example": "2024-02-01"
+                },
+                "enrollmentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "enrollmentLeaves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetAcademicLeave"
+                    }
+                },
+                "enrollmentStartDate": {
+                    "type": "string",
+                    "example": "2023-09-01"
+                },
+                "enrollmentStatus": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "academicLeave",
+                        "expelled",
+                        "graduated",
+                        "transferred"
+                    ],
+                    "example": "active"
+                }
+            }
+        },
+        "model.GetEnrollments": {
+            "type": "object",
+            "properties": {
+                "enrollments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetEnrollment"
+                    }
+                },
+                "enrollmentsStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetFieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "tooLong"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "courseTitle"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be at most 255 characters"
+                }
+            }
+        },
+        "model.GetGradingScale": {
+            "type": "object",
+            "properties": {
+                "gradingDefault": {
+                    "description": "the organization has not set its own scale",
+                    "type": "boolean",
+                    "example": true
+                },
+                "gradingMaxGrade": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "gradingMinGrade": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "gradingOrganizationId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "gradingPassGrade": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GetKnowledge": {
+            "type": "object",
+            "properties": {
+                "knowledgeId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "knowledgeParentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "knowledgeTitle": {
+                    "type": "string",
+                    "example": "Название знания"
+                }
+            }
+        },
+        "model.GetKnowledgeTree": {
+            "type": "object",
+            "properties": {
+                "treeCompetencyCount": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "treeKnowledge": {
+                    "description": "the knowledge and its descendants",
+                    "type": "integer",
+                    "example": 4
+                },
+                "treeKnowledgeChildren": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetKnowledgeTree"
+                    }
+                },
+                "treeKnowledgeId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "treeKnowledgeTitle": {
+                    "type": "string",
+                    "example": "Название знания"
+                }
+            }
+        },
+        "model.GetList-model_GetCompetency": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCompetency"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetCourse": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCourse"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetDiscipline": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetDiscipline"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetEducationalProgram": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetEducationalProgram"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetKnowledge": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetKnowledge"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetOrganization": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetOrganization"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetProfession": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetProfession"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetProject": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetProject"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetSkill": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetSkill"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetStudent": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetStudent"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetTeacher": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTeacher"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetList-model_GetTechnology": {
+            "type": "object",
+            "properties": {
+                "listItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTechnology"
+                    }
+                },
+                "listLimit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "listOffset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "listTotal": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetMatrixDiff": {
+            "type": "object",
+            "properties": {
+                "diffCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diffCompetencyProfessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetMatrixLink"
+                    }
+                },
+                "diffKnowledge": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diffKnowledgeCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetMatrixLink"
+                    }
+                },
+                "diffProfessions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diffTechnologies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.GetMatrixImport": {
+            "type": "object",
+            "properties": {
+                "importApplied": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "importCreated": {
+                    "$ref": "#/definitions/model.GetMatrixDiff"
+                },
+                "importDryRun": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "importErrors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetMatrixRowError"
+                    }
+                },
+                "importRows": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.GetMatrixLink": {
+            "type": "object",
+            "properties": {
+                "linkFrom": {
+                    "type": "string",
+                    "example": "Название знания"
+                },
+                "linkTo": {
+                    "type": "string",
+                    "example": "Название компетенции"
+                }
+            }
+        },
+        "model.GetMatrixRowError": {
+            "type": "object",
+            "properties": {
+                "rowColumn": {
+                    "type": "string",
+                    "example": "competency"
+                },
+                "rowMessage": {
+                    "type": "string",
+                    "example": "competency is empty"
+                },
+                "rowNumber": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.GetOrganization": {
+            "type": "object",
+            "properties": {
+                "organizationId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "organizationTitle": {
+                    "type": "string",
+                    "example": "Название организации"
+                }
+            }
+        },
+        "model.GetOutstandingRequirement": {
+            "type": "object",
+            "properties": {
+                "outstandingCount": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outstandingId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "outstandingKind": {
+                    "type": "string",
+                    "enum": [
+                        "discipline",
+                        "electivePool"
+                    ],
+                    "example": "discipline"
+                },
+                "outstandingSemester": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "outstandingTitle": {
+                    "type": "string",
+                    "example": "Название дисциплины или группы по выбору"
+                }
+            }
+        },
+        "model.GetPersonalProject": {
+            "type": "object",
+            "properties": {
+                "personalProjectCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции участика в этом проекте"
+                    ]
+                },
+                "personalProjectCompetencyLevels": {
+                    "description": "confirmed levels by competency title",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "personalProjectMainTechnology": {
+                    "type": "string",
+                    "example": "Основная технология проекта"
+                },
+                "personalProjectSemester": {
+                    "type": "string",
+                    "example": "Семестр, в котором участик работал над проектом"
+                },
+                "personalProjectTeamRole": {
+                    "type": "string",
+                    "example": "Роль участника в команде"
+                },
+                "projectDescription": {
+                    "type": "string",
+                    "example": "Курс направлен на изучение поведения капибар в дикой природе..."
+                },
+                "projectId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "projectLifeScenarion": {
+                    "type": "string",
+                    "example": "Проект позволит подобрать идеальную длину шерстки для ваших капибар"
+                },
+                "projectResult": {
+                    "type": "string",
+                    "example": "Данные о зависимости длины шерстки капибар от продолжительности их жизни"
+                },
+                "projectTitle": {
+                    "type": "string",
+                    "example": "Исследование зивисимости длины шерстки капибар от продолжительности их жизни"
+                }
+            }
+        },
+        "model.GetPlan": {
+            "type": "object",
+            "properties": {
+                "planProfession": {
+                    "type": "string",
+                    "example": "Название профессии"
+                },
+                "planProfessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "planSemesters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetPlanSemester"
+                    }
+                },
+                "planStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "planUncoveredCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции",
+                        " которые не закрывает ни один курс"
+                    ]
+                },
+                "planWorkloadViolations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetWorkloadViolation"
+                    }
+                }
+            }
+        },
+        "model.GetPlanAlternative": {
+            "type": "object",
+            "properties": {
+                "planAlternativeCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "planAlternativeId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "planAlternativeTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                }
+            }
+        },
+        "model.GetPlanCourse": {
+            "type": "object",
+            "properties": {
+                "planCourseAlternatives": {
+                    "description": "courses of the same discipline the course may be swapped for",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetPlanAlternative"
+                    }
+                },
+                "planCourseCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Компетенции из профессии",
+                        " которые закрывает курс"
+                    ]
+                },
+                "planCourseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "planCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "planCourseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                }
+            }
+        },
+        "model.GetPlanSemester": {
+            "type": "object",
+            "properties": {
+                "planSemester": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "planSemesterCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetPlanCourse"
+                    }
+                },
+                "planSemesterCredits": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "planSemesterEndDate": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "planSemesterStartDate": {
+                    "type": "string",
+                    "example": "2024-09-01"
+                }
+            }
+        },
+        "model.GetPlanValidation": {
+            "type": "object",
+            "properties": {
+                "validationStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "validationValid": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "validationViolations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetPrerequisiteViolation"
+                    }
+                }
+            }
+        },
+        "model.GetPortfolio": {
+            "type": "object",
+            "properties": {
+                "portfolioId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "portfolioProjects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetPersonalProject"
+                    }
+                }
+            }
+        },
+        "model.GetPrerequisiteViolation": {
+            "type": "object",
+            "properties": {
+                "violationCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "violationCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "violationKind": {
+                    "type": "string",
+                    "example": "hard"
+                },
+                "violationPrerequisite": {
+                    "type": "string",
+                    "example": "Название пререквизита"
+                },
+                "violationPrerequisiteId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "violationPrerequisiteSemester": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "violationReason": {
+                    "type": "string",
+                    "example": "laterSemester"
+                },
+                "violationSemester": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GetProblem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "disciplineNotFound"
+                },
+                "dependents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetDependent"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetFieldError"
+                    }
+                },
+                "field": {
+                    "type": "string",
+                    "example": "courseDisciplineId"
+                },
+                "import": {
+                    "$ref": "#/definitions/model.GetMatrixImport"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "discipline does not exist"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                },
+                "workload": {
+                    "$ref": "#/definitions/model.GetWorkloadViolation"
+                }
+            }
+        },
+        "model.GetProfession": {
+            "type": "object",
+            "properties": {
+                "professionCompetencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "компетенция 1",
+                        " компетенция 2..."
+                    ]
+                },
+                "professionCompetencyLevels": {
+                    "description": "required levels by competency title",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "professionDescription": {
+                    "type": "string",
+                    "example": "Описание профессии"
+                },
+                "professionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "professionTitle": {
+                    "type": "string",
+                    "example": "Название профессии"
+                }
+            }
+        },
+        "model.GetProject": {
+            "type": "object",
+            "properties": {
+                "projectDescription": {
+                    "type": "string",
+                    "example": "Курс направлен на изучение поведения капибар в дикой природе..."
+                },
+                "projectId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "projectLifeScenarion": {
+                    "type": "string",
+                    "example": "Проект позволит подобрать идеальную длину шерстки для ваших капибар"
+                },
+                "projectMainTechnology": {
+                    "type": "string",
+                    "example": "Название технологии"
+                },
+                "projectResult": {
+                    "type": "string",
+                    "example": "Данные о зависимости длины шерстки капибар от продолжительности их жизни"
+                },
+                "projectTitle": {
+                    "type": "string",
+                    "example": "Исследование зивисимости длины шерстки капибар от продолжительности их жизни"
+                }
+            }
+        },
+        "model.GetRecommendationFactor": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "type": "string",
+                    "enum": [
+                        "coverage",
+                        "technology",
+                        "readiness",
+                        "workload"
+                    ],
+                    "example": "coverage"
+                },
+                "factorItems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "given competencies",
+                        " known technologies or prerequisites not taken"
+                    ]
+                },
+                "factorReason": {
+                    "type": "string",
+                    "example": "gives 2 of 3 missing competencies of the profession"
+                },
+                "factorScore": {
+                    "description": "the part of the course score, value * weight * 100",
+                    "type": "number",
+                    "example": 33.33
+                },
+                "factorValue": {
+                    "description": "from 0 to 1",
+                    "type": "number",
+                    "example": 0.67
+                },
+                "factorWeight": {
+                    "type": "number",
+                    "example": 0.5
+                }
+            }
+        },
+        "model.GetRecommendations": {
+            "type": "object",
+            "properties": {
+                "recommendationCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCourseRecommendation"
+                    }
+                },
+                "recommendationProfession": {
+                    "type": "string",
+                    "example": "Название профессии"
+                },
+                "recommendationProfessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "recommendationSemester": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "recommendationStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetRoom": {
+            "type": "object",
+            "properties": {
+                "roomCapacity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "roomId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "roomTitle": {
+                    "type": "string",
+                    "example": "Р-237"
+                }
+            }
+        },
+        "model.GetScheduleSubscription": {
+            "type": "object",
+            "properties": {
+                "scheduleIcsUrl": {
+                    "type": "string",
+                    "example": "https://example.com/api/v1/student/00000000-0000-0000-0000-000000000000/schedule.ics?feedToken=token"
+                },
+                "scheduleRefreshInterval": {
+                    "type": "string",
+                    "example": "PT12H"
+                },
+                "scheduleWebcalUrl": {
+                    "type": "string",
+                    "example": "webcal://example.com/api/v1/student/00000000-0000-0000-0000-000000000000/schedule.ics?feedToken=token"
+                }
+            }
+        },
+        "model.GetSearch": {
+            "type": "object",
+            "properties": {
+                "searchGroups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetSearchGroup"
+                    }
+                },
+                "searchQuery": {
+                    "type": "string",
+                    "example": "нейронные сети"
+                }
+            }
+        },
+        "model.GetSearchGroup": {
+            "type": "object",
+            "properties": {
+                "groupHits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetSearchHit"
+                    }
+                },
+                "groupKind": {
+                    "type": "string",
+                    "enum": [
+                        "knowledge",
+                        "technology",
+                        "competency",
+                        "profession",
+                        "project",
+                        "organization",
+                        "educationalProgram",
+                        "discipline",
+                        "course"
+                    ],
+                    "example": "course"
+                }
+            }
+        },
+        "model.GetSearchHit": {
+            "type": "object",
+            "properties": {
+                "hitId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "hitRank": {
+                    "type": "number",
+                    "example": 1.35
+                },
+                "hitSnippet": {
+                    "type": "string",
+                    "example": "\u003cb\u003eНейронные\u003c/b\u003e \u003cb\u003eсети\u003c/b\u003e и градиентный бустинг"
+                },
+                "hitTitle": {
+                    "type": "string",
+                    "example": "Машинное обучение"
+                }
+            }
+        },
+        "model.GetSemesterDates": {
+            "type": "object",
+            "properties": {
+                "semesterEndDate": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "semesterHolidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCalendarPeriod"
+                    }
+                },
+                "semesterNumber": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "semesterSessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetCalendarPeriod"
+                    }
+                },
+                "semesterStartDate": {
+                    "type": "string",
+                    "example": "2023-09-01"
+                },
+                "semesterStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "semesterTerm": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "semesterYear": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "model.GetSkill": {
+            "type": "object",
+            "properties": {
+                "skillId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "skillTitle": {
+                    "type": "string",
+                    "example": "Название навыка"
+                }
+            }
+        },
+        "model.GetStudent": {
+            "type": "object",
+            "properties": {
+                "studentEducationalProgram": {
+                    "type": "string",
+                    "example": "Название образовательной программы"
+                },
+                "studentEducationalProgramId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "studentEnrollmentStatus": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "academicLeave",
+                        "expelled",
+                        "graduated",
+                        "transferred"
+                    ],
+                    "example": "active"
+                },
+                "studentFullName": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "studentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "studentPortfolio": {
+                    "$ref": "#/definitions/model.GetPortfolio"
+                },
+                "studentSemester": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GetStudyGroupEntry": {
+            "type": "object",
+            "properties": {
+                "studyGroupCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "studyGroupCourseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "studyGroupDropDate": {
+                    "type": "string",
+                    "example": "2024-02-01"
+                },
+                "studyGroupPosition": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "studyGroupStatus": {
+                    "type": "string",
+                    "enum": [
+                        "enrolled",
+                        "waitlisted",
+                        "dropped"
+                    ],
+                    "example": "waitlisted"
+                }
+            }
+        },
+        "model.GetStudyGroupStudent": {
+            "type": "object",
+            "properties": {
+                "studyGroupStudentFullName": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "studyGroupStudentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetTeacher": {
+            "type": "object",
+            "properties": {
+                "teacherAvailability": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTimeSlot"
+                    }
+                },
+                "teacherEmail": {
+                    "type": "string",
+                    "example": "teacher@urfu.ru"
+                },
+                "teacherFullName": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "teacherId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "teacherOrganization": {
+                    "type": "string",
+                    "example": "Название организации"
+                },
+                "teacherOrganizationId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "teacherPhone": {
+                    "type": "string",
+                    "example": "+7 343 000-00-00"
+                }
+            }
+        },
+        "model.GetTeacherCourse": {
+            "type": "object",
+            "properties": {
+                "teacherCourseCredits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "teacherCourseDiscipline": {
+                    "type": "string",
+                    "example": "Название дисциплины"
+                },
+                "teacherCourseHours": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "teacherCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "teacherCourseStudents": {
+                    "description": "students of the current semester",
+                    "type": "integer",
+                    "example": 25
+                },
+                "teacherCourseTitle": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "teacherCourseWeeklyHours": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "model.GetTeacherCourses": {
+            "type": "object",
+            "properties": {
+                "teacherCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTeacherCourse"
+                    }
+                },
+                "teacherCoursesTeacher": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "teacherCoursesTeacherId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.GetCompetency": {
+        },
+        "model.GetTeacherHours": {
             "type": "object",
             "properties": {
-                "competencyId": {
+                "teacherHours": {
+                    "type": "integer",
+                    "example": 540
+                },
+                "teacherHoursCourses": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "teacherHoursStudents": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "teacherHoursStudiedCourses": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacherHoursTeacher": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "teacherHoursTeacherId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "competencyKnowledge": {
+                "teacherHoursWeekly": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.GetTeacherStudyGroups": {
+            "type": "object",
+            "properties": {
+                "teacherStudyGroups": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "знание 1",
-                        " знание 2..."
-                    ]
+                        "$ref": "#/definitions/model.GetCourseStudyGroup"
+                    }
                 },
-                "competencyMainTechnology": {
+                "teacherStudyGroupsTeacher": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                },
+                "teacherStudyGroupsTeacherId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.GetTechnology": {
+            "type": "object",
+            "properties": {
+                "technologyId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "competencySkills": {
+                "technologyTitle": {
+                    "type": "string",
+                    "example": "Название технологии"
+                }
+            }
+        },
+        "model.GetTimeSlot": {
+            "type": "object",
+            "properties": {
+                "timeSlotEnd": {
                     "type": "string",
-                    "example": "навык 1, навык 2..."
+                    "example": "10:00"
                 },
-                "competencyTitle": {
+                "timeSlotId": {
                     "type": "string",
-                    "example": "Название компетенции"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "timeSlotStart": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "timeSlotWeekday": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "model.GetCourse": {
+        "model.GetTimetable": {
             "type": "object",
             "properties": {
-                "courseCompetencies": {
+                "timetableEntries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTimetableEntry"
+                    }
+                },
+                "timetableProblems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GetTimetableProblem"
+                    }
+                }
+            }
+        },
+        "model.GetTimetableEntry": {
+            "type": "object",
+            "properties": {
+                "entryCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "entryCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "entryKind": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "entryRoom": {
+                    "$ref": "#/definitions/model.GetRoom"
+                },
+                "entrySessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "entryTeachers": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "компетенция 1",
-                        " компетенция 2..."
+                        "Фамилия Имя Отчество"
                     ]
                 },
-                "courseDescription": {
+                "entryTimeSlot": {
+                    "$ref": "#/definitions/model.GetTimeSlot"
+                }
+            }
+        },
+        "model.GetTimetableProblem": {
+            "type": "object",
+            "properties": {
+                "problemCourse": {
                     "type": "string",
-                    "example": "Описание курса"
+                    "example": "Название курса"
                 },
-                "courseDiscipline": {
+                "problemCourseId": {
                     "type": "string",
-                    "example": "Дисциплина, к которой отностися курс"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "courseId": {
+                "problemKind": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "lecture"
                 },
-                "courseTeacher": {
+                "problemMessage": {
                     "type": "string",
-                    "example": "Преподаватель"
+                    "example": "no room fits 120 students"
                 },
-                "courseTitle": {
+                "problemReason": {
                     "type": "string",
-                    "example": "Название курса"
+                    "example": "noRoom"
+                },
+                "problemSessionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "problemUnplaced": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "model.GetDiscipline": {
+        "model.GetToken": {
             "type": "object",
             "properties": {
-                "disciplineDescription": {
+                "accessToken": {
                     "type": "string",
-                    "example": "Описание дисциплины"
-                },
-                "disciplineEducationalProgram": {
-                    "type": "string",
-                    "example": "Образовательная программа, в которую входит цисциплина"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "disciplineId": {
+                "tokenExpiresAt": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "example": "2024-02-10T13:00:00Z"
                 },
-                "disciplineTitle": {
+                "tokenType": {
                     "type": "string",
-                    "example": "Название дисциплины"
+                    "example": "Bearer"
                 }
             }
         },
-        "model.GetEducationalProgram": {
+        "model.GetTrajectory": {
             "type": "object",
             "properties": {
-                "educationalProgramDexcription": {
+                "trajectoryAttempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "trajectoryCompletionDate": {
                     "type": "string",
-                    "example": "Описание образовательной программы"
+                    "example": "2024-01-25"
                 },
-                "educationalProgramId": {
+                "trajectoryCourse": {
+                    "type": "string",
+                    "example": "Название курса"
+                },
+                "trajectoryGrade": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "trajectoryId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "educationalProgramOrganization": {
+                "trajectoryResult": {
+                    "description": "omitted until the outcome is recorded",
                     "type": "string",
-                    "example": "организация, отвечающая за образовательную программу"
+                    "enum": [
+                        "passed",
+                        "failed"
+                    ],
+                    "example": "passed"
                 },
-                "educationalProgramTitle": {
+                "trajectoryRetakeOf": {
+                    "description": "the first attempt of the course",
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "trajectorySemester": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "trajectoryStudent": {
+                    "type": "string",
+                    "example": "Фамилия Имя Отчество"
+                }
+            }
+        },
+        "model.GetWorkloadViolation": {
+            "type": "object",
+            "properties": {
+                "workloadCredits": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "workloadEducationalProgram": {
                     "type": "string",
                     "example": "Название образовательной программы"
+                },
+                "workloadMaxCredits": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "workloadMinCredits": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "workloadReason": {
+                    "type": "string",
+                    "example": "aboveMaximum"
+                },
+                "workloadSemester": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "model.GetKnowledge": {
+        "model.PostApiKey": {
             "type": "object",
+            "required": [
+                "apiKeyRole",
+                "apiKeyTitle"
+            ],
             "properties": {
-                "knowledgeId": {
+                "apiKeyRole": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "analyst",
+                        "teacher",
+                        "student"
+                    ],
+                    "example": "analyst"
+                },
+                "apiKeyStudentId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "knowledgeTitle": {
+                "apiKeyTeacherId": {
                     "type": "string",
-                    "example": "Название знания"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "apiKeyTitle": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Ключ куратора образовательной программы"
                 }
             }
         },
-        "model.GetOrganization": {
+        "model.PostCalendarPeriod": {
             "type": "object",
+            "required": [
+                "periodEndDate",
+                "periodKind",
+                "periodOrganizationId",
+                "periodStartDate"
+            ],
             "properties": {
-                "organizationId": {
+                "periodEndDate": {
+                    "type": "string",
+                    "example": "2024-01-08"
+                },
+                "periodKind": {
+                    "type": "string",
+                    "enum": [
+                        "holiday",
+                        "session"
+                    ],
+                    "example": "holiday"
+                },
+                "periodOrganizationId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "organizationTitle": {
+                "periodStartDate": {
                     "type": "string",
-                    "example": "Название организации"
+                    "example": "2023-12-30"
+                },
+                "periodTitle": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Новогодние каникулы"
                 }
             }
         },
-        "model.GetPersonalProject": {
+        "model.PostCalendarSemester": {
             "type": "object",
+            "required": [
+                "calendarEndDate",
+                "calendarOrganizationId",
+                "calendarStartDate",
+                "calendarTerm",
+                "calendarYear"
+            ],
             "properties": {
-                "personalProjectCompetencies": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Компетенции участика в этом проекте"
-                    ]
+                "calendarEndDate": {
+                    "type": "string",
+                    "example": "2024-01-31"
                 },
-                "personalProjectMainTechnology": {
+                "calendarEnrollmentEndDate": {
                     "type": "string",
-                    "example": "Основная технология проекта"
+                    "example": "2023-09-15"
                 },
-                "personalProjectSemester": {
+                "calendarEnrollmentStartDate": {
+                    "description": "students enroll in courses of the semester between the dates, both are omitted when enrollment is not limited",
                     "type": "string",
-                    "example": "Семестр, в котором участик работал над проектом"
+                    "example": "2023-08-20"
                 },
-                "personalProjectTeamRole": {
+                "calendarOrganizationId": {
                     "type": "string",
-                    "example": "Роль участника в команде"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectDescription": {
+                "calendarStartDate": {
                     "type": "string",
-                    "example": "Курс направлен на изучение поведения капибар в дикой природе..."
+                    "example": "2023-09-01"
                 },
-                "projectId": {
+                "calendarTerm": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "example": 1
+                },
+                "calendarYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2023
+                }
+            }
+        },
+        "model.PostCompetency": {
+            "type": "object",
+            "required": [
+                "competencyTitle"
+            ],
+            "properties": {
+                "competencyMainTechnology": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectLifeScenarion": {
+                "competencyParentId": {
                     "type": "string",
-                    "example": "Проект позволит подобрать идеальную длину шерстки для ваших капибар"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectResult": {
+                "competencySkills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "навык 1",
+                        "навык 2"
+                    ]
+                },
+                "competencyTitle": {
                     "type": "string",
-                    "example": "Данные о зависимости длины шерстки капибар от продолжительности их жизни"
+                    "maxLength": 255,
+                    "example": "Название компетенции"
+                }
+            }
+        },
+        "model.PostCompetencyProfession": {
+            "type": "object",
+            "required": [
+                "competencyId",
+                "professionId"
+            ],
+            "properties": {
+                "competencyId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectTitle": {
+                "competencyLevel": {
+                    "description": "basic when omitted",
                     "type": "string",
-                    "example": "Исследование зивисимости длины шерстки капибар от продолжительности их жизни"
+                    "enum": [
+                        "aware",
+                        "basic",
+                        "intermediate",
+                        "advanced"
+                    ],
+                    "example": "advanced"
+                },
+                "professionId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "model.GetPortfolio": {
+        "model.PostCourse": {
             "type": "object",
+            "required": [
+                "courseDisciplineId",
+                "courseTitle"
+            ],
             "properties": {
-                "portfolioId": {
+                "courseAlternative": {
+                    "description": "one of interchangeable courses of the discipline, a student takes one of them",
+                    "type": "boolean",
+                    "example": true
+                },
+                "courseCapacity": {
+                    "description": "seats in the study group, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "example": 30
+                },
+                "courseCredits": {
+                    "type": "integer",
+                    "maximum": 30,
+                    "example": 5
+                },
+                "courseDescription": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Описание курса"
+                },
+                "courseDisciplineId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "portfolioProjects": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.GetPersonalProject"
-                    }
-                }
-            }
-        },
-        "model.GetProfession": {
-            "type": "object",
-            "properties": {
-                "professionCompetencies": {
+                "courseHours": {
+                    "type": "integer",
+                    "maximum": 1080,
+                    "example": 180
+                },
+                "courseTeacherIds": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "компетенция 1",
-                        " компетенция 2..."
+                        "00000000-0000-0000-0000-000000000000"
                     ]
                 },
-                "professionDescription": {
-                    "type": "string",
-                    "example": "Описание профессии"
-                },
-                "professionId": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "professionTitle": {
+                "courseTitle": {
                     "type": "string",
-                    "example": "Название профессии"
+                    "maxLength": 255,
+                    "example": "Название курса"
                 }
             }
         },
-        "model.GetProject": {
+        "model.PostCourseCompetency": {
             "type": "object",
+            "required": [
+                "competencyId",
+                "courseId"
+            ],
             "properties": {
-                "projectDescription": {
-                    "type": "string",
-                    "example": "Курс направлен на изучение поведения капибар в дикой природе..."
-                },
-                "projectId": {
+                "competencyId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectLifeScenarion": {
+                "competencyLevel": {
+                    "description": "basic when omitted",
                     "type": "string",
-                    "example": "Проект позволит подобрать идеальную длину шерстки для ваших капибар"
+                    "enum": [
+                        "aware",
+                        "basic",
+                        "intermediate",
+                        "advanced"
+                    ],
+                    "example": "basic"
                 },
-                "projectMainTechnology": {
+                "courseId": {
                     "type": "string",
-                    "example": "Название технологии"
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.PostCoursePrerequisite": {
+            "type": "object",
+            "required": [
+                "prerequisiteCourseId",
+                "prerequisiteId",
+                "prerequisiteKind"
+            ],
+            "properties": {
+                "prerequisiteCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectResult": {
+                "prerequisiteId": {
                     "type": "string",
-                    "example": "Данные о зависимости длины шерстки капибар от продолжительности их жизни"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "projectTitle": {
+                "prerequisiteKind": {
                     "type": "string",
-                    "example": "Исследование зивисимости длины шерстки капибар от продолжительности их жизни"
+                    "enum": [
+                        "hard",
+                        "soft"
+                    ],
+                    "example": "hard"
                 }
             }
         },
-        "model.GetStudent": {
+        "model.PostCourseSession": {
             "type": "object",
+            "required": [
+                "sessionCourseId",
+                "sessionKind",
+                "sessionWeeklyHours"
+            ],
             "properties": {
-                "studentFullName": {
-                    "type": "string",
-                    "example": "Фамилия Имя Отчество"
-                },
-                "studentId": {
+                "sessionCourseId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "studentPortfolio": {
-                    "$ref": "#/definitions/model.GetPortfolio"
+                "sessionKind": {
+                    "type": "string",
+                    "enum": [
+                        "lecture",
+                        "practice",
+                        "lab"
+                    ],
+                    "example": "lecture"
                 },
-                "studentSemester": {
+                "sessionWeeklyHours": {
                     "type": "integer",
-                    "example": 3
+                    "maximum": 40,
+                    "minimum": 1,
+                    "example": 4
                 }
             }
         },
-        "model.GetTechnology": {
+        "model.PostCurriculumCheck": {
             "type": "object",
             "properties": {
-                "technologyId": {
+                "checkEducationalProgramId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "technologyTitle": {
-                    "type": "string",
-                    "example": "Название технологии"
+                "checkPlannedCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PostPlannedCourse"
+                    }
                 }
             }
         },
-        "model.GetTrajectory": {
+        "model.PostCurriculumDiscipline": {
             "type": "object",
+            "required": [
+                "curriculumDisciplineId",
+                "curriculumEducationalProgramId",
+                "curriculumSemester"
+            ],
             "properties": {
-                "trajectoryCourse": {
+                "curriculumDisciplineId": {
                     "type": "string",
-                    "example": "Название курса"
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "trajectoryId": {
+                "curriculumEducationalProgramId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "trajectorySemester": {
+                "curriculumElectivePoolId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "curriculumSemester": {
                     "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
                     "example": 3
-                },
-                "trajectoryStudent": {
-                    "type": "string",
-                    "example": "Фамилия Имя Отчество"
                 }
             }
         },
-        "model.PostCompetency": {
+        "model.PostDiscipline": {
             "type": "object",
+            "required": [
+                "disciplineEducationalProgramId",
+                "disciplineTitle"
+            ],
             "properties": {
-                "competencyMainTechnology": {
+                "disciplineDescription": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "maxLength": 5000,
+                    "example": "Описание дисциплины"
                 },
-                "competencySkills": {
+                "disciplineEducationalProgramId": {
                     "type": "string",
-                    "example": "навык 1, навык 2..."
+                    "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "competencyTitle": {
+                "disciplineTitle": {
                     "type": "string",
-                    "example": "Название компетенции"
+                    "maxLength": 255,
+                    "example": "Название дисциплины"
                 }
             }
         },
-        "model.PostCompetencyProfession": {
+        "model.PostEducationalProgram": {
             "type": "object",
+            "required": [
+                "educationalProgramOrganizationId",
+                "educationalProgramTitle"
+            ],
             "properties": {
-                "competencyId": {
+                "educationalProgramDescription": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "maxLength": 5000,
+                    "example": "Описание образовательной программы"
                 },
-                "professionId": {
+                "educationalProgramMaxSemesterCredits": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "example": 35
+                },
+                "educationalProgramMinSemesterCredits": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "example": 20
+                },
+                "educationalProgramOrganizationId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "educationalProgramTitle": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Название образовательной программы"
                 }
             }
         },
-        "model.PostCourse": {
+        "model.PostElectivePool": {
             "type": "object",
+            "required": [
+                "poolChoose",
+                "poolEducationalProgramId",
+                "poolTitle"
+            ],
             "properties": {
-                "courseDescription": {
-                    "type": "string",
-                    "example": "Описание курса"
+                "poolChoose": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
                 },
-                "courseDisciplineId": {
+                "poolEducationalProgramId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "courseTeacher": {
-                    "type": "string",
-                    "example": "Фамилия Имя Отчество"
-                },
-                "courseTitle": {
+                "poolTitle": {
                     "type": "string",
-                    "example": "Название курса"
+                    "maxLength": 255,
+                    "example": "Иностранный язык"
                 }
             }
         },
-        "model.PostCourseCompetency": {
+        "model.PostEnrollment": {
             "type": "object",
+            "required": [
+                "enrollmentEducationalProgramId",
+                "enrollmentStudentId"
+            ],
             "properties": {
-                "competencyId": {
+                "enrollmentEducationalProgramId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "courseId": {
+                "enrollmentStartDate": {
+                    "description": "today when omitted",
+                    "type": "string",
+                    "example": "2024-09-01"
+                },
+                "enrollmentStudentId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
-        "model.PostDiscipline": {
+        "model.PostEnrollmentStatus": {
             "type": "object",
+            "required": [
+                "enrollmentStatus"
+            ],
             "properties": {
-                "disciplineDescription": {
-                    "type": "string",
-                    "example": "Описание дисциплины"
-                },
-                "disciplineEducationalProgramId": {
+                "enrollmentStatus": {
                     "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "enum": [
+                        "active",
+                        "academicLeave",
+                        "expelled",
+                        "graduated"
+                    ],
+                    "example": "academicLeave"
                 },
-                "disciplineTitle": {
+                "enrollmentStatusDate": {
+                    "description": "today when omitted",
                     "type": "string",
-                    "example": "Название дисциплины"
+                    "example": "2024-02-01"
                 }
             }
         },
-        "model.PostEducationalProgram": {
+        "model.PostGradingScale": {
             "type": "object",
+            "required": [
+                "gradingMaxGrade",
+                "gradingMinGrade",
+                "gradingOrganizationId",
+                "gradingPassGrade"
+            ],
             "properties": {
-                "educationalProgramDescription": {
-                    "type": "string",
-                    "example": "Описание образовательной программы"
+                "gradingMaxGrade": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "example": 5
                 },
-                "educationalProgramOrganizationId": {
+                "gradingMinGrade": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "example": 2
+                },
+                "gradingOrganizationId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
-                "educationalProgramTitle": {
-                    "type": "string",
-                    "example": "Название образовательной программы"
+                "gradingPassGrade": {
+                    "description": "the lowest grade a course is passed with",
+                    "type": "integer",
+                    "maximum": 100,
+                    "example": 3
                 }
             }
         },
         "model.PostKnowledge": {
             "type": "object",
+            "required": [
+                "knowledgeTitle"
+            ],
             "properties": {
+                "knowledgeParentId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
                 "knowledgeTitle": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Название знания"
                 }
             }
         },
         "model.PostKnowledgeCompetency": {
             "type": "object",
+            "required": [
+                "competencyId",
+                "knowledgeId"
+            ],
             "properties": {
                 "competencyId": {
                     "type": "string",
@@ -1678,13 +12219,47 @@ const docTemplate = `{
         },
         "model.PostOrganization": {
             "type": "object",
+            "required": [
+                "organizationTitle"
+            ],
             "properties": {
                 "organizationTitle": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Название организации"
                 }
             }
         },
+        "model.PostPlanValidation": {
+            "type": "object",
+            "properties": {
+                "validationPlannedCourses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PostPlannedCourse"
+                    }
+                }
+            }
+        },
+        "model.PostPlannedCourse": {
+            "type": "object",
+            "required": [
+                "plannedCourseId",
+                "plannedSemester"
+            ],
+            "properties": {
+                "plannedCourseId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "plannedSemester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
         "model.PostPortfolio": {
             "type": "object",
             "properties": {
@@ -1696,26 +12271,36 @@ const docTemplate = `{
         },
         "model.PostProfession": {
             "type": "object",
+            "required": [
+                "professionTitle"
+            ],
             "properties": {
                 "professionDescription": {
                     "type": "string",
+                    "maxLength": 5000,
                     "example": "Описание профессии"
                 },
                 "professionTitle": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Название профессии"
                 }
             }
         },
         "model.PostProject": {
             "type": "object",
+            "required": [
+                "projectTitle"
+            ],
             "properties": {
                 "projectDescription": {
                     "type": "string",
+                    "maxLength": 5000,
                     "example": "Курс направлен на изучение поведения капибар в дикой природе..."
                 },
                 "projectLifeScenarion": {
                     "type": "string",
+                    "maxLength": 5000,
                     "example": "Проект позволит подобрать идеальную длину шерстки для ваших капибар"
                 },
                 "projectMainTechnologyId": {
@@ -1724,16 +12309,23 @@ const docTemplate = `{
                 },
                 "projectResult": {
                     "type": "string",
+                    "maxLength": 5000,
                     "example": "Данные о зависимости длины шерстки капибар от продолжительности их жизни"
                 },
                 "projectTitle": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Исследование зивисимости длины шерстки капибар от продолжительности их жизни"
                 }
             }
         },
         "model.PostProjectPortfolio": {
             "type": "object",
+            "required": [
+                "PortfolioId",
+                "ProjectId",
+                "projectSemester"
+            ],
             "properties": {
                 "PortfolioId": {
                     "type": "string",
@@ -1745,16 +12337,24 @@ const docTemplate = `{
                 },
                 "TeamRole": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Роль в команде"
                 },
                 "projectSemester": {
                     "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
                     "example": 3
                 }
             }
         },
         "model.PostProjectPortfolioCompetency": {
             "type": "object",
+            "required": [
+                "CompetencyId",
+                "PortfolioId",
+                "projectId"
+            ],
             "properties": {
                 "CompetencyId": {
                     "type": "string",
@@ -1764,21 +12364,57 @@ const docTemplate = `{
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 },
+                "competencyLevel": {
+                    "description": "basic when omitted",
+                    "type": "string",
+                    "enum": [
+                        "aware",
+                        "basic",
+                        "intermediate",
+                        "advanced"
+                    ],
+                    "example": "intermediate"
+                },
                 "projectId": {
                     "type": "string",
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
         },
+        "model.PostRoom": {
+            "type": "object",
+            "required": [
+                "roomCapacity",
+                "roomTitle"
+            ],
+            "properties": {
+                "roomCapacity": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 30
+                },
+                "roomTitle": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Р-237"
+                }
+            }
+        },
         "model.PostStudent": {
             "type": "object",
+            "required": [
+                "studentFullName"
+            ],
             "properties": {
                 "studentAdmitionDate": {
+                    "description": "can not be in the future, today when omitted",
                     "type": "string",
                     "example": "2024-01-19"
                 },
                 "studentFullName": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Фамилия Имя Отчество"
                 },
                 "studentPortfolioId": {
@@ -1789,6 +12425,10 @@ const docTemplate = `{
         },
         "model.PostStudyGroup": {
             "type": "object",
+            "required": [
+                "courseId",
+                "studentId"
+            ],
             "properties": {
                 "courseId": {
                     "type": "string",
@@ -1800,17 +12440,95 @@ const docTemplate = `{
                 }
             }
         },
+        "model.PostTeacher": {
+            "type": "object",
+            "required": [
+                "teacherFullName",
+                "teacherOrganizationId"
+            ],
+            "properties": {
+                "teacherEmail": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "teacher@urfu.ru"
+                },
+                "teacherFullName": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Фамилия Имя Отчество"
+                },
+                "teacherOrganizationId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "teacherPhone": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "+7 343 000-00-00"
+                }
+            }
+        },
+        "model.PostTeacherAvailability": {
+            "type": "object",
+            "required": [
+                "availabilityTeacherId",
+                "availabilityTimeSlotId"
+            ],
+            "properties": {
+                "availabilityTeacherId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "availabilityTimeSlotId": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
         "model.PostTechnology": {
             "type": "object",
+            "required": [
+                "technologyTitle"
+            ],
             "properties": {
                 "technologyTitle": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Название технологии"
                 }
             }
         },
+        "model.PostTimeSlot": {
+            "type": "object",
+            "required": [
+                "timeSlotEnd",
+                "timeSlotStart",
+                "timeSlotWeekday"
+            ],
+            "properties": {
+                "timeSlotEnd": {
+                    "type": "string",
+                    "example": "10:00"
+                },
+                "timeSlotStart": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "timeSlotWeekday": {
+                    "type": "integer",
+                    "maximum": 7,
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
         "model.PostTrajectory": {
             "type": "object",
+            "required": [
+                "trajectoryCourseId",
+                "trajectorySemester",
+                "trajectoryStudentId"
+            ],
             "properties": {
                 "trajectoryCourseId": {
                     "type": "string",
@@ -1818,6 +12536,8 @@ const docTemplate = `{
                 },
                 "trajectorySemester": {
                     "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
                     "example": 3
                 },
                 "trajectoryStudentId": {
@@ -1825,6 +12545,92 @@ const docTemplate = `{
                     "example": "00000000-0000-0000-0000-000000000000"
                 }
             }
+        },
+        "model.PostTrajectoryOutcome": {
+            "type": "object",
+            "properties": {
+                "trajectoryCompletionDate": {
+                    "description": "today when omitted",
+                    "type": "string",
+                    "example": "2024-01-25"
+                },
+                "trajectoryGrade": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "example": 4
+                },
+                "trajectoryResult": {
+                    "type": "string",
+                    "enum": [
+                        "passed",
+                        "failed"
+                    ],
+                    "example": "passed"
+                }
+            }
+        },
+        "model.PostTrajectoryRetake": {
+            "type": "object",
+            "required": [
+                "trajectorySemester"
+            ],
+            "properties": {
+                "trajectorySemester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
+        "model.PutCompetencyLevel": {
+            "type": "object",
+            "properties": {
+                "competencyLevel": {
+                    "description": "basic when omitted",
+                    "type": "string",
+                    "enum": [
+                        "aware",
+                        "basic",
+                        "intermediate",
+                        "advanced"
+                    ],
+                    "example": "intermediate"
+                }
+            }
+        },
+        "model.PutProjectPortfolio": {
+            "type": "object",
+            "required": [
+                "projectSemester"
+            ],
+            "properties": {
+                "TeamRole": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Роль в команде"
+                },
+                "projectSemester": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key created by an admin or with the create-api-key command",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by the token issued at /api/v1/token/ for the API key",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrAlternativeChosen = errors.New("another alternative course of the discipline is chosen")
+
+// AlternativeError names the alternative course of the discipline the student already studies.
+type AlternativeError struct {
+	Discipline string
+	Chosen     string
+	Course     string
+}
+
+func (e *AlternativeError) Error() string {
+	return fmt.Sprintf("%s: student studies %q instead of %q in discipline %q", ErrAlternativeChosen, e.Chosen, e.Course, e.Discipline)
+}
+
+func (e *AlternativeError) Unwrap() error {
+	return ErrAlternativeChosen
+}
+
+// checkAlternative refuses to add the alternative course to study groups of the student who already studies
+// another alternative course of the discipline. Unknown courses are left to the foreign keys.
+func (app *App) checkAlternative(studentId uuid.UUID, courseId uuid.UUID) error {
+	course, err := app.store.GetCourse(courseId)
+	if errors.Is(err, store.ErrNotFound) || err == nil && !course.Alternative {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	groups, err := app.store.GetStudyGroupCourses(studentId)
+	if err != nil {
+		return err
+	}
+	for _, chosen := range groups {
+		if chosen.Id == course.Id || !chosen.Alternative || chosen.DisciplineId != course.DisciplineId {
+			continue
+		}
+		discipline, err := app.store.GetDiscipline(course.DisciplineId)
+		if err != nil {
+			return err
+		}
+		return &AlternativeError{Discipline: discipline.Title, Chosen: chosen.Title, Course: course.Title}
+	}
+	return nil
+}
+
+// GetDisciplineAlternatives compares alternative courses of the discipline by competencies: the ones every course gives,
+// the ones a course gives besides them and the ones only other courses give. When the student is given,
+// the course the student takes or took is marked as chosen.
+func (app *App) GetDisciplineAlternatives(disciplineId uuid.UUID, studentId uuid.UUID) (model.GetDisciplineAlternatives, error) {
+	resp := model.GetDisciplineAlternatives{DisciplineId: disciplineId, Courses: []model.GetAlternativeCourse{}}
+	if disciplineId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	discipline, err := app.store.GetDiscipline(disciplineId)
+	if err != nil {
+		return resp, err
+	}
+	resp.Discipline = discipline.Title
+
+	taken := studentCourses{ids: make(map[uuid.UUID]bool)}
+	if studentId != uuid.Nil {
+		if _, err = app.store.GetStudent(studentId); err != nil {
+			return resp, err
+		}
+		if taken, err = app.getStudentCourses(studentId); err != nil {
+			return resp, err
+		}
+	}
+
+	courses, err := app.store.GetCoursesByDiscipline(disciplineId)
+	if err != nil {
+		return resp, err
+	}
+	given := make(map[string]int)
+	for _, course := range courses {
+		if !course.Alternative {
+			continue
+		}
+		competencies, err := app.store.GetCompetenciesByCourse(course.Id)
+		if err != nil {
+			return resp, err
+		}
+		for _, competency := range competencies {
+			given[competency.Title]++
+		}
+		teachers, err := app.getTeacherNames(course.Id)
+		if err != nil {
+			return resp, err
+		}
+		resp.Courses = append(resp.Courses, model.GetAlternativeCourse{
+			Id:           course.Id,
+			Title:        course.Title,
+			Teachers:     teachers,
+			Credits:      course.Credits,
+			Chosen:       taken.ids[course.Id],
+			Competencies: competencyTitles(competencies),
+		})
+	}
+
+	titles := make([]string, 0, len(given))
+	for title := range given {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	for i := range resp.Courses {
+		own := make(map[string]bool)
+		for _, title := range resp.Courses[i].Competencies {
+			own[title] = true
+		}
+		for _, title := range titles {
+			switch {
+			case given[title] == len(resp.Courses):
+				if i == 0 {
+					resp.Common = append(resp.Common, title)
+				}
+			case own[title]:
+				resp.Courses[i].Extra = append(resp.Courses[i].Extra, title)
+			default:
+				resp.Courses[i].Missing = append(resp.Courses[i].Missing, title)
+			}
+		}
+	}
+	return resp, nil
+}
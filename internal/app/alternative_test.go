@@ -0,0 +1,82 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+func (f fixture) alternative(t *testing.T, title string, competencyIds ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	course, err := f.app.PostCourse(title, "", nil, f.disciplineId, 0, 0, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, competencyId := range competencyIds {
+		if err = f.app.PostCourseCompetency(course.Id, competencyId, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return course.Id
+}
+
+func TestAlternatives(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	a, b, c := f.competency(t, "A", true), f.competency(t, "B", true), f.competency(t, "C", true)
+	goId := f.alternative(t, "Go", a, b)
+	javaId := f.alternative(t, "Java", a, b)
+	f.alternative(t, "Kotlin", a, c)
+	algorithmsId := f.course(t, "Алгоритмы")
+
+	// one option of the discipline is planned, the others covering the same competencies may replace it
+	plan, err := f.app.GetStudentPlan(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.GetPlanCourse{{Id: goId, Title: "Go", Competencies: []string{"A", "B"},
+		Alternatives: []model.GetPlanAlternative{{Id: javaId, Title: "Java"}}}}
+	if len(plan.Semesters) != 1 || !reflect.DeepEqual(plan.Semesters[0].Courses, want) {
+		t.Fatalf("expected courses %+v, got %+v", want, plan.Semesters)
+	}
+	if !reflect.DeepEqual(plan.Uncovered, []string{"C"}) {
+		t.Fatalf("expected C left uncovered by other options, got %v", plan.Uncovered)
+	}
+
+	if _, err = f.app.PostStudyGroup(javaId, studentId); err != nil {
+		t.Fatal(err)
+	}
+	var alternativeErr *AlternativeError
+	if _, err = f.app.PostStudyGroup(goId, studentId); !errors.As(err, &alternativeErr) || !errors.Is(err, ErrAlternativeChosen) ||
+		alternativeErr.Chosen != "Java" || alternativeErr.Discipline != "Программирование" {
+		t.Fatalf("expected AlternativeError for the second option, got %v", err)
+	}
+	if _, err = f.app.PostStudyGroup(algorithmsId, studentId); err != nil {
+		t.Fatalf("expected a course besides options to be added, got %v", err)
+	}
+
+	alternatives, err := f.app.GetDisciplineAlternatives(f.disciplineId, studentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(alternatives.Common, []string{"A"}) || len(alternatives.Courses) != 3 {
+		t.Fatalf("unexpected alternatives %+v", alternatives)
+	}
+	java, kotlin := alternatives.Courses[1], alternatives.Courses[2]
+	if !java.Chosen || !reflect.DeepEqual(java.Extra, []string{"B"}) || !reflect.DeepEqual(java.Missing, []string{"C"}) {
+		t.Fatalf("unexpected chosen option %+v", java)
+	}
+	if kotlin.Chosen || !reflect.DeepEqual(kotlin.Extra, []string{"C"}) || !reflect.DeepEqual(kotlin.Missing, []string{"B"}) {
+		t.Fatalf("unexpected option %+v", kotlin)
+	}
+
+	// C is given only by another option of the chosen discipline
+	recommendations, err := f.app.RecommendCourses(studentId, f.professionId, 0)
+	if err != nil || len(recommendations.Courses) != 0 {
+		t.Fatalf("expected no recommendations, got %+v, %v", recommendations.Courses, err)
+	}
+}
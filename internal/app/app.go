@@ -4,88 +4,90 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"slices"
+	"strconv"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store/postgres"
 )
 
+// App keeps entities in the store.
 type App struct {
-	db *sql.DB
+	store store.Store
 }
 
 func New(db *sql.DB) *App {
-	return &App{db: db}
+	return &App{store: postgres.New(db)}
+}
+
+// NewWithStore creates App on top of any store, e.g. the in-memory one in tests.
+func NewWithStore(s store.Store) *App {
+	return &App{store: s}
 }
 
 var ErrEmptyTitle = errors.New("empty title")
 var ErrEmptyId = errors.New("empty id")
 
-func (app *App) GetAllKnowledges() ([]string, error) {
-	sqlKnow, err := app.db.Query(`SELECT knowledge_pk FROM knowledge`)
-	if err != nil {
-		return nil, err
+func competencyTitles(competencies []store.Competency) []string {
+	var titles []string
+	for _, competency := range competencies {
+		titles = append(titles, competency.Title)
 	}
+	return titles
+}
 
-	temp := ""
-	var strKnow []string
-	for sqlKnow.Next() {
-		if err = sqlKnow.Scan(&temp); err != nil {
-			return nil, err
-		}
-		strKnow = append(strKnow, temp)
+// competencyLevels maps titles of competencies read through links to names of the link levels.
+func competencyLevels(competencies []store.Competency) map[string]string {
+	levels := make(map[string]string, len(competencies))
+	for _, competency := range competencies {
+		levels[competency.Title] = levelName(competency.Level)
 	}
-	return strKnow, nil
+	return levels
 }
 
 func (app *App) GetKnowledgeByIndex(id uuid.UUID) (model.GetKnowledge, error) {
-	var resp model.GetKnowledge
-	data := app.db.QueryRow(`SELECT knowledge_id, title FROM knowledge WHERE knowledge_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title)
-	return resp, err
+	knowledge, err := app.store.GetKnowledge(id)
+	return model.GetKnowledge{Id: knowledge.Id, Title: knowledge.Title, ParentId: knowledge.ParentId}, err
 }
 
 func (app *App) getKnowledgeByCompetency(competencyId uuid.UUID) ([]string, error) {
-	var knowledge []string
-	rows, err := app.db.Query(`SELECT title FROM knowledge WHERE knowledge_id in 
-		(SELECT knowledge_id FROM knowledge_competency WHERE competency_id = $1)`, competencyId)
+	knowledge, err := app.store.GetKnowledgeByCompetency(competencyId)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var title string
-		if err = rows.Scan(&title); err != nil {
-			return nil, err
-		}
 
-		knowledge = append(knowledge, title)
+	var titles []string
+	for _, item := range knowledge {
+		titles = append(titles, item.Title)
 	}
-
-	return knowledge, nil
+	return titles, nil
 }
 
 func (app *App) GetTechnolgyById(id uuid.UUID) (model.GetTechnology, error) {
-	var resp model.GetTechnology
-	data := app.db.QueryRow(`SELECT technology_id, title FROM technologies WHERE technology_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title)
-	return resp, err
+	technology, err := app.store.GetTechnology(id)
+	return model.GetTechnology{Id: technology.Id, Title: technology.Title}, err
 }
 
 func (app *App) GetCompetencyById(id uuid.UUID) (model.GetCompetency, error) {
 	var resp model.GetCompetency
-	row := app.db.QueryRow(`SELECT competency_id, title, skills, main_technology_id FROM competencies WHERE competency_id = $1`, id)
-	var mainTechnologyId uuid.UUID
-	err := row.Scan(&resp.Id, &resp.Title, &resp.Skills, &mainTechnologyId)
+	competency, err := app.store.GetCompetency(id)
 	if err != nil {
 		return resp, err
 	}
+	resp.Id = competency.Id
+	resp.Title = competency.Title
+	resp.ParentId = competency.ParentId
+	if resp.Skills, err = app.getSkillsByCompetency(id); err != nil {
+		return resp, err
+	}
 
-	if mainTechnologyId != uuid.Nil {
+	if competency.MainTechnologyId != uuid.Nil {
 		var technology model.GetTechnology
-		if technology, err = app.GetTechnolgyById(mainTechnologyId); err != nil {
+		if technology, err = app.GetTechnolgyById(competency.MainTechnologyId); err != nil {
 			return resp, err
 		}
 
@@ -96,51 +98,40 @@ func (app *App) GetCompetencyById(id uuid.UUID) (model.GetCompetency, error) {
 	return resp, err
 }
 
-func (app *App) getCompetenciesByProfession(professionId uuid.UUID) ([]string, error) {
-	var competencies []string
-	rows, err := app.db.Query(`SELECT title FROM competencies WHERE competency_id in 
-		(SELECT competency_id FROM competency_profession WHERE profession_id = $1)`, professionId)
-	if err != nil {
-		return competencies, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var title string
-		if err = rows.Scan(&title); err != nil {
-			return competencies, err
-		}
-
-		competencies = append(competencies, title)
-	}
-
-	return competencies, nil
+func (app *App) getCompetenciesByProfession(professionId uuid.UUID) ([]string, map[string]string, error) {
+	competencies, err := app.store.GetCompetenciesByProfession(professionId)
+	return competencyTitles(competencies), competencyLevels(competencies), err
 }
 
 func (app *App) GetProfessionById(id uuid.UUID) (model.GetProfession, error) {
 	var resp model.GetProfession
-	data := app.db.QueryRow(`SELECT profession_id, title, description FROM professions WHERE profession_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title, &resp.Description)
+	profession, err := app.store.GetProfession(id)
 	if err != nil {
 		return resp, err
 	}
+	resp.Id = profession.Id
+	resp.Title = profession.Title
+	resp.Description = profession.Description
 
-	resp.Competencies, err = app.getCompetenciesByProfession(id)
+	resp.Competencies, resp.CompetencyLevels, err = app.getCompetenciesByProfession(id)
 	return resp, err
 }
 
 func (app *App) GetProjectById(id uuid.UUID) (model.GetProject, error) {
 	var resp model.GetProject
-	data := app.db.QueryRow(`SELECT project_id, title, description, result, life_scenario, main_technology_id FROM projects WHERE project_id = $1`, id)
-	var mainTechnologyId uuid.UUID
-	err := data.Scan(&resp.Id, &resp.Title, &resp.Description, &resp.Result, &resp.LifeScenario, mainTechnologyId)
+	project, err := app.store.GetProject(id)
 	if err != nil {
 		return resp, err
 	}
+	resp.Id = project.Id
+	resp.Title = project.Title
+	resp.Description = project.Description
+	resp.Result = project.Result
+	resp.LifeScenario = project.LifeScenario
 
-	if mainTechnologyId != uuid.Nil {
+	if project.MainTechnologyId != uuid.Nil {
 		var technology model.GetTechnology
-		if technology, err = app.GetTechnolgyById(mainTechnologyId); err != nil {
+		if technology, err = app.GetTechnolgyById(project.MainTechnologyId); err != nil {
 			return resp, err
 		}
 
@@ -151,23 +142,24 @@ func (app *App) GetProjectById(id uuid.UUID) (model.GetProject, error) {
 }
 
 func (app *App) GetOrganizationById(id uuid.UUID) (model.GetOrganization, error) {
-	var resp model.GetOrganization
-	data := app.db.QueryRow(`SELECT organization_id, title FROM organizations WHERE organization_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title)
-	return resp, err
+	organization, err := app.store.GetOrganization(id)
+	return model.GetOrganization{Id: organization.Id, Title: organization.Title}, err
 }
 
 func (app *App) GetEducationalProgramById(id uuid.UUID) (model.GetEducationalProgram, error) {
 	var resp model.GetEducationalProgram
-	var organizationId uuid.UUID
-	data := app.db.QueryRow(`SELECT educational_program_id, title, description, organizations_id FROM educational_programs WHERE educational_program_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title, &resp.Description, &organizationId)
+	educationalProgram, err := app.store.GetEducationalProgram(id)
 	if err != nil {
 		return resp, err
 	}
+	resp.Id = educationalProgram.Id
+	resp.Title = educationalProgram.Title
+	resp.Description = educationalProgram.Description
+	resp.MinCredits = educationalProgram.MinSemesterCredits
+	resp.MaxCredits = educationalProgram.MaxSemesterCredits
 
 	var organization model.GetOrganization
-	if organization, err = app.GetOrganizationById(organizationId); err != nil {
+	if organization, err = app.GetOrganizationById(educationalProgram.OrganizationId); err != nil {
 		return resp, err
 	}
 
@@ -177,15 +169,16 @@ func (app *App) GetEducationalProgramById(id uuid.UUID) (model.GetEducationalPro
 
 func (app *App) GetDisciplineById(id uuid.UUID) (model.GetDiscipline, error) {
 	var resp model.GetDiscipline
-	var educationalProgramId uuid.UUID
-	data := app.db.QueryRow(`SELECT discipline_id, title, description, educational_program_id FROM disciplines WHERE discipline_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title, &resp.Description, &educationalProgramId)
+	discipline, err := app.store.GetDiscipline(id)
 	if err != nil {
 		return resp, err
 	}
+	resp.Id = discipline.Id
+	resp.Title = discipline.Title
+	resp.Description = discipline.Description
 
 	var educationalProgram model.GetEducationalProgram
-	if educationalProgram, err = app.GetEducationalProgramById(educationalProgramId); err != nil {
+	if educationalProgram, err = app.GetEducationalProgramById(discipline.EducationalProgramId); err != nil {
 		return resp, err
 	}
 
@@ -193,99 +186,83 @@ func (app *App) GetDisciplineById(id uuid.UUID) (model.GetDiscipline, error) {
 	return resp, nil
 }
 
-func (app *App) getCompetenciesByCourse(courseId uuid.UUID) ([]string, error) {
-	var competencies []string
-	rows, err := app.db.Query(`SELECT title FROM competencies WHERE competency_id in 
-		(SELECT competency_id FROM course_competency WHERE course_id = $1)`, courseId)
-	if err != nil {
-		return competencies, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var title string
-		if err = rows.Scan(&title); err != nil {
-			return competencies, err
-		}
-
-		competencies = append(competencies, title)
-	}
-
-	return competencies, nil
+func (app *App) getCompetenciesByCourse(courseId uuid.UUID) ([]string, map[string]string, error) {
+	competencies, err := app.store.GetCompetenciesByCourse(courseId)
+	return competencyTitles(competencies), competencyLevels(competencies), err
 }
 
 func (app *App) GetCourseById(id uuid.UUID) (model.GetCourse, error) {
 	var resp model.GetCourse
-	var disciplineId uuid.UUID
-	data := app.db.QueryRow(`SELECT course_id, title, description, teacher, discipline_id FROM courses WHERE course_id = $1`, id)
-	err := data.Scan(&resp.Id, &resp.Title, &resp.Description, &resp.Teacher, &disciplineId)
+	course, err := app.store.GetCourse(id)
 	if err != nil {
-		return resp, nil
+		return resp, err
+	}
+	resp.Id = course.Id
+	resp.Title = course.Title
+	resp.Description = course.Description
+	resp.Credits = course.Credits
+	resp.Hours = course.Hours
+	resp.Alternative = course.Alternative
+	resp.Capacity = course.Capacity
+	if resp.Enrolled, err = app.store.CountStudyGroup(id); err != nil {
+		return resp, err
+	}
+	waitlist, err := app.store.GetCourseWaitlist(id)
+	if err != nil {
+		return resp, err
 	}
+	resp.Waitlisted = len(waitlist)
 
-	if disciplineId != uuid.Nil {
+	if course.DisciplineId != uuid.Nil {
 		var discipline model.GetDiscipline
-		if discipline, err = app.GetDisciplineById(disciplineId); err != nil {
+		if discipline, err = app.GetDisciplineById(course.DisciplineId); err != nil {
 			return resp, err
 		}
 
 		resp.Discipline = discipline.Title
 	}
+	if resp.Teachers, err = app.getTeacherNames(id); err != nil {
+		return resp, err
+	}
 
-	resp.Competencies, err = app.getCompetenciesByCourse(id)
+	resp.Competencies, resp.CompetencyLevels, err = app.getCompetenciesByCourse(id)
 	return resp, err
 }
 
-func (app *App) getCompetenciesByPersonalProject(portfolioId uuid.UUID, projectId uuid.UUID) ([]string, error) {
-	var resp []string
-	rows, err := app.db.Query(`SELECT title FROM competencies WHERE competency_id in 
-		(SELECT competency_id FROM project_portfolio_competency WHERE portfolio_id = $1 and project_id = $2)`, portfolioId, projectId)
-	if err != nil {
-		return resp, nil
-	}
-
-	var competency string
-	for rows.Next() {
-		if err = rows.Scan(&competency); err != nil {
-			return resp, err
-		}
-
-		resp = append(resp, competency)
-	}
-
-	return resp, nil
+func (app *App) getCompetenciesByPersonalProject(portfolioId uuid.UUID, projectId uuid.UUID) ([]string, map[string]string, error) {
+	competencies, err := app.store.GetCompetenciesByPersonalProject(portfolioId, projectId)
+	return competencyTitles(competencies), competencyLevels(competencies), err
 }
 
 func (app *App) GetPersonalProjectsByPortfolio(portfolioId uuid.UUID) ([]model.GetPersonalProject, error) {
 	var resp []model.GetPersonalProject
-	rows, err := app.db.Query(`SELECT project_id, team_role, semester FROM project_portfolio WHERE portfolio_id = $1`, portfolioId)
+	projects, err := app.store.GetProjectPortfolios(portfolioId)
 	if err != nil {
-		return resp, nil
+		return resp, err
 	}
 
-	for rows.Next() {
-		var personlaProject model.GetPersonalProject
-		var projectId uuid.UUID
-		if err := rows.Scan(projectId, personlaProject.TeamRole, personlaProject.Semester); err != nil {
-			return resp, err
-		}
-
-		project, err := app.GetProjectById(projectId)
+	for _, projectPortfolio := range projects {
+		project, err := app.GetProjectById(projectPortfolio.ProjectId)
 		if err != nil {
 			return resp, err
 		}
 
-		personlaProject.Title = project.Title
-		personlaProject.Description = project.Description
-		personlaProject.Result = project.Result
-		personlaProject.LifeScenario = project.LifeScenario
-		personlaProject.MainTechnology = project.MainTechnology
-		personlaProject.Competencies, err = app.getCompetenciesByPersonalProject(portfolioId, projectId)
+		personalProject := model.GetPersonalProject{
+			Id:             project.Id,
+			Title:          project.Title,
+			Description:    project.Description,
+			Result:         project.Result,
+			LifeScenario:   project.LifeScenario,
+			MainTechnology: project.MainTechnology,
+			TeamRole:       projectPortfolio.TeamRole,
+			Semester:       strconv.Itoa(int(projectPortfolio.Semester)),
+		}
+		personalProject.Competencies, personalProject.CompetencyLevels, err = app.getCompetenciesByPersonalProject(portfolioId, project.Id)
 		if err != nil {
-			return resp, nil
+			return resp, err
 		}
 
-		resp = append(resp, personlaProject)
+		resp = append(resp, personalProject)
 	}
 
 	return resp, nil
@@ -296,55 +273,32 @@ func (app *App) GetPortfolioById(id uuid.UUID) (model.GetPortfolio, error) {
 	var err error
 	resp.Id = id
 	resp.Projects, err = app.GetPersonalProjectsByPortfolio(id)
-	if err != nil {
-		return resp, nil
-	}
-
-	return resp, nil
+	return resp, err
 }
 
 func (app *App) GetStudentById(id uuid.UUID) (model.GetStudent, error) {
 	var resp model.GetStudent
-	var portfolioId uuid.UUID
-	var admition time.Time
-	var err error
-	data := app.db.QueryRow(`SELECT student_id, full_name, portfolio_id, admition FROM students WHERE student_id = $1`, id)
-	if err = data.Scan(&resp.Id, &resp.FullName, &portfolioId, &admition); err != nil {
+	student, err := app.store.GetStudent(id)
+	if err != nil {
 		return resp, err
 	}
-	if admition.After(time.Now()) {
-		slog.Error("admition date - ", admition.String(), ", now - ", time.Now().String(), errors.New("incorrect admition date"))
+	if student.Admition.After(time.Now()) {
+		slog.Error("incorrect admition date " + student.Admition.String() + ", now " + time.Now().String())
 		return resp, errors.New("incorrect admition date")
 	}
-
-	resp.Semester = (uint8(time.Now().Year())-uint8(admition.Year()))*2 + 1
-	if time.Now().Month() > time.January && time.Now().Month() < time.September { // approximate date
-		resp.Semester += 1
-	}
-
-	resp.Portfolio, err = app.GetPortfolioById(portfolioId)
+	resp.Id = student.Id
+	resp.FullName = student.FullName
+	resp.Semester, err = app.getStudentSemester(student.Id, student.Admition, time.Now())
 	if err != nil {
 		return resp, err
 	}
-
-	return resp, nil
-}
-
-func (app *App) GetStudyGroupsByStudent(studentId uuid.UUID) (model.GetStudyGroups, error) {
-	var resp model.GetStudyGroups
-	rows, err := app.db.Query(`SELECT title FROM courses WHERE course_id in
-		(SELECT course_id FROM study_groups WHERE student_id = $1)`, studentId)
-	if err != nil {
+	if err = app.getStudentProgram(&resp); err != nil {
 		return resp, err
 	}
 
-	var course string
-	for rows.Next() {
-		if err = rows.Scan(&course); err != nil {
-			return resp, err
-		}
-
-		resp.Courses = append(resp.Courses, course)
+	resp.Portfolio, err = app.GetPortfolioById(student.PortfolioId)
+	if err != nil {
+		return resp, err
 	}
 
 	return resp, nil
@@ -352,41 +306,56 @@ func (app *App) GetStudyGroupsByStudent(studentId uuid.UUID) (model.GetStudyGrou
 
 func (app *App) GetTrajectoryById(trajectoryId uuid.UUID) (model.GetTrajectory, error) {
 	var resp model.GetTrajectory
-	var studentId uuid.UUID
-	var courseId uuid.UUID
-	var err error
-	data := app.db.QueryRow(`SELECT student_id, course_id, semester FROM trajectories WHERE trajectory_id = $1`, trajectoryId)
-	if err = data.Scan(&studentId, &courseId, resp.Semester); err != nil {
+	trajectory, err := app.store.GetTrajectory(trajectoryId)
+	if err != nil {
 		return resp, err
 	}
+	resp.Id = trajectory.Id
+	resp.Semester = trajectory.Semester
+	resp.Grade = trajectory.Grade
+	resp.Result = trajectory.Result
+	resp.Attempt = trajectory.Attempt
+	resp.CompletionDate = optionalDate(trajectory.CompletionDate)
+	resp.RetakeOf = trajectory.RetakeOf
 
-	studentData := app.db.QueryRow(`SELECT full_name FROM students WHERE student_id = $1`, studentId)
-	if err = studentData.Scan(&resp.Student); err != nil {
+	student, err := app.store.GetStudent(trajectory.StudentId)
+	if err != nil {
 		return resp, err
 	}
+	resp.Student = student.FullName
 
-	courseData := app.db.QueryRow(`SELECT title FROM courses WHERE course_id = $1`, courseId)
-	if err = courseData.Scan(&resp.Course); err != nil {
+	course, err := app.store.GetCourse(trajectory.CourseId)
+	if err != nil {
 		return resp, err
 	}
+	resp.Course = course.Title
 
 	return resp, nil
 }
 
-func (app *App) PostKnowledge(knowledge string) (model.GetKnowledge, error) {
+// PostKnowledge creates the knowledge under the parent, the existing knowledge with the title is left as it is.
+func (app *App) PostKnowledge(knowledge string, parentId uuid.UUID) (model.GetKnowledge, error) {
 	var resp model.GetKnowledge
 	if knowledge == "" {
 		return resp, ErrEmptyTitle
 	}
 
-	resp.Id = uuid.Nil
-	resp.Title = knowledge
-	knowledgeData := app.db.QueryRow(`INSERT INTO knowledge (title) VALUES ($1) ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING knowledge_id`, knowledge)
-	if err := knowledgeData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
+	err := app.store.Transaction(func(tx store.Store) error {
+		existing, err := tx.GetKnowledgeByTitle(knowledge)
+		if err == nil {
+			resp = model.GetKnowledge{Id: existing.Id, Title: existing.Title, ParentId: existing.ParentId}
+			return nil
+		} else if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
 
-	return resp, nil
+		resp = model.GetKnowledge{Title: knowledge, ParentId: parentId}
+		if resp.Id, err = tx.CreateKnowledge(knowledge); err != nil || parentId == uuid.Nil {
+			return err
+		}
+		return tx.UpdateKnowledgeParent(resp.Id, parentId)
+	})
+	return resp, err
 }
 
 func (app *App) PostTechnology(technology string) (model.GetTechnology, error) {
@@ -395,18 +364,15 @@ func (app *App) PostTechnology(technology string) (model.GetTechnology, error) {
 		return resp, ErrEmptyTitle
 	}
 
-	resp.Id = uuid.Nil
+	var err error
 	resp.Title = technology
-	technologyData := app.db.QueryRow(`INSERT INTO technologies (title) VALUES ($1) ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING technology_id `, technology)
-	if err := technologyData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	resp.Id, err = app.store.CreateTechnology(technology)
+	return resp, err
 }
 
-//
-
-func (app *App) PostCompetency(comptency string, skills string, technologyId uuid.UUID) (model.GetCompetency, error) {
+// PostCompetency creates the competency under the parent with the skills, the missing skills are created.
+// The existing competency with the title keeps its columns and gets the skills.
+func (app *App) PostCompetency(comptency string, parentId uuid.UUID, skills []string, technologyId uuid.UUID) (model.GetCompetency, error) {
 	var resp model.GetCompetency
 	if comptency == "" {
 		return resp, ErrEmptyTitle
@@ -414,15 +380,18 @@ func (app *App) PostCompetency(comptency string, skills string, technologyId uui
 
 	resp.MainTechnologyId = technologyId
 	resp.Title = comptency
-	resp.Skills = skills
-	resp.Id = uuid.Nil
-	competencyData := app.db.QueryRow(`INSERT INTO competencies (title, skills, main_technology_id) VALUES ($1, $2, $3)
-								ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING competency_id`, comptency, skills, technologyId)
-	if err := competencyData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-
-	return resp, nil
+	resp.ParentId = parentId
+	resp.Skills = skillTitles(skills)
+	slices.Sort(resp.Skills)
+	err := app.store.Transaction(func(tx store.Store) error {
+		var err error
+		resp.Id, err = tx.CreateCompetency(store.Competency{Title: comptency, ParentId: parentId, MainTechnologyId: technologyId})
+		if err != nil {
+			return err
+		}
+		return addSkills(tx, resp.Id, resp.Skills)
+	})
+	return resp, err
 }
 
 func (app *App) PostKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
@@ -433,8 +402,7 @@ func (app *App) PostKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid
 		return ErrEmptyId
 	}
 
-	_, err := app.db.Exec(`INSERT INTO knowledge_competency (knowledge_id, competency_id) VALUES ($1, $2)`, knowledgeId, competencyId)
-	return err
+	return app.store.CreateKnowledgeCompetency(knowledgeId, competencyId)
 }
 
 func (app *App) PostProfession(profession string, description string) (model.GetProfession, error) {
@@ -443,27 +411,27 @@ func (app *App) PostProfession(profession string, description string) (model.Get
 		return resp, ErrEmptyTitle
 	}
 
-	resp.Id = uuid.Nil
+	var err error
 	resp.Title = profession
 	resp.Description = description
-	professionData := app.db.QueryRow(`INSERT INTO professions (title, description) VALUES ($1, $2)
-				 ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING profession_id `, profession, description)
-	if err := professionData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	resp.Id, err = app.store.CreateProfession(store.Profession{Title: profession, Description: description})
+	return resp, err
 }
 
-func (app *App) PostCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID) error {
+// PostCompetencyProfession links the competency to the profession that requires it at the level, basic by default.
+func (app *App) PostCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level string) error {
 	if professionId == uuid.Nil {
 		return ErrEmptyId
 	}
 	if competencyId == uuid.Nil {
 		return ErrEmptyId
 	}
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
 
-	_, err := app.db.Exec(`INSERT INTO competency_profession (competency_id, profession_id) VALUES ($1, $2)`, competencyId, professionId)
-	return err
+	return app.store.CreateCompetencyProfession(competencyId, professionId, proficiency)
 }
 
 func (app *App) PostProject(project string, description string, result string, lifeScenario string, technologyId uuid.UUID) (model.GetProject, error) {
@@ -472,22 +440,27 @@ func (app *App) PostProject(project string, description string, result string, l
 		return resp, ErrEmptyTitle
 	}
 
-	resp.Id = uuid.Nil
 	resp.Title = project
 	resp.Description = description
-
-	technology, err := app.GetTechnolgyById(technologyId)
-	if err != nil {
-		return resp, err
+	resp.Result = result
+	resp.LifeScenario = lifeScenario
+	if technologyId != uuid.Nil {
+		technology, err := app.GetTechnolgyById(technologyId)
+		if err != nil {
+			return resp, err
+		}
+		resp.MainTechnology = technology.Title
 	}
-	resp.MainTechnology = technology.Title
 
-	projectData := app.db.QueryRow(`INSERT INTO projects (title, description, result, life_scenario, main_technology_id) VALUES ($1, $2, $3, $4, $5)
-				 ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING project_id `, project, description, result, lifeScenario, technologyId)
-	if err := projectData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	var err error
+	resp.Id, err = app.store.CreateProject(store.Project{
+		Title:            project,
+		Description:      description,
+		Result:           result,
+		LifeScenario:     lifeScenario,
+		MainTechnologyId: technologyId,
+	})
+	return resp, err
 }
 
 func (app *App) PostOrganization(organization string) (model.GetOrganization, error) {
@@ -496,23 +469,23 @@ func (app *App) PostOrganization(organization string) (model.GetOrganization, er
 		return resp, ErrEmptyTitle
 	}
 
-	resp.Id = uuid.Nil
+	var err error
 	resp.Title = organization
-	organizationData := app.db.QueryRow(`INSERT INTO organizations (title) VALUES ($1) ON CONFLICT (title)
-							 DO UPDATE SET title = excluded.title RETURNING organization_id `, organization)
-	if err := organizationData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	resp.Id, err = app.store.CreateOrganization(organization)
+	return resp, err
 }
 
-func (app *App) PostEducationalProgram(educationalProgram string, description string, organizationId uuid.UUID) (model.GetEducationalProgram, error) {
+func (app *App) PostEducationalProgram(educationalProgram string, description string, organizationId uuid.UUID, minCredits uint8, maxCredits uint8) (model.GetEducationalProgram, error) {
 	var resp model.GetEducationalProgram
 	if educationalProgram == "" {
 		return resp, ErrEmptyTitle
 	}
+	if maxCredits != 0 && minCredits > maxCredits {
+		return resp, ErrWrongCredits
+	}
 	resp.Title = educationalProgram
 	resp.Description = description
+	resp.MinCredits, resp.MaxCredits = minCredits, maxCredits
 	if organizationId != uuid.Nil {
 		organization, err := app.GetOrganizationById(organizationId)
 		if err != nil {
@@ -521,12 +494,15 @@ func (app *App) PostEducationalProgram(educationalProgram string, description st
 		resp.Organization = organization.Title
 	}
 
-	educationalProgramData := app.db.QueryRow(`INSERT INTO educational_programs (title, description, organizations_id) VALUES ($1, $2, $3) ON CONFLICT (title)
-							 	DO UPDATE SET title = excluded.title RETURNING educational_program_id `, educationalProgram, description, organizationId)
-	if err := educationalProgramData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	var err error
+	resp.Id, err = app.store.CreateEducationalProgram(store.EducationalProgram{
+		Title:              educationalProgram,
+		Description:        description,
+		OrganizationId:     organizationId,
+		MinSemesterCredits: minCredits,
+		MaxSemesterCredits: maxCredits,
+	})
+	return resp, err
 }
 
 func (app *App) PostDiscipline(discipline string, description string, educationalProgramId uuid.UUID) (model.GetDiscipline, error) {
@@ -545,20 +521,25 @@ func (app *App) PostDiscipline(discipline string, description string, educationa
 		resp.EducationalProgram = educationalProgram.Title
 	}
 
-	disciplineData := app.db.QueryRow(`INSERT INTO disciplines (title, description, educational_program_id) VALUES ($1, $2, $3) ON CONFLICT (title)
-							 	DO UPDATE SET title = excluded.title RETURNING discipline_id `, discipline, description, educationalProgramId)
-	if err := disciplineData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-
-	return resp, nil
+	var err error
+	resp.Id, err = app.store.CreateDiscipline(store.Discipline{
+		Title:                discipline,
+		Description:          description,
+		EducationalProgramId: educationalProgramId,
+	})
+	return resp, err
 }
 
-func (app *App) PostCourse(course string, description string, teacher string, disciplineId uuid.UUID) (model.GetCourse, error) {
+// PostCourse creates the course taught by the teachers.
+func (app *App) PostCourse(course string, description string, teacherIds []uuid.UUID, disciplineId uuid.UUID, credits uint8, hours uint16,
+	alternative bool, capacity uint16) (model.GetCourse, error) {
 	var resp model.GetCourse
 	if course == "" {
 		return resp, ErrEmptyTitle
 	}
+	if slices.Contains(teacherIds, uuid.Nil) {
+		return resp, ErrEmptyId
+	}
 	if disciplineId != uuid.Nil {
 		discipline, err := app.GetDisciplineById(disciplineId)
 		if err != nil {
@@ -567,56 +548,80 @@ func (app *App) PostCourse(course string, description string, teacher string, di
 		resp.Discipline = discipline.Title
 	}
 
+	var err error
 	resp.Title = course
 	resp.Description = description
-	resp.Teacher = teacher
-	courseData := app.db.QueryRow(`INSERT INTO courses (title, description, teacher, discipline_id) VALUES ($1, $2, $3, $4) ON CONFLICT (title)
-							 	DO UPDATE SET title = excluded.title RETURNING course_id`, course, description, teacher, disciplineId)
-	if err := courseData.Scan(&resp.Id); err != nil {
+	resp.Credits, resp.Hours, resp.Alternative, resp.Capacity = credits, hours, alternative, capacity
+	err = app.store.Transaction(func(tx store.Store) error {
+		resp.Id, err = tx.CreateCourse(store.Course{
+			Title:        course,
+			Description:  description,
+			DisciplineId: disciplineId,
+			Credits:      credits,
+			Hours:        hours,
+			Alternative:  alternative,
+			Capacity:     capacity,
+		})
+		if err != nil {
+			return err
+		}
+		for _, teacherId := range teacherIds {
+			if err = tx.CreateCourseTeacher(resp.Id, teacherId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return resp, err
 	}
-	return resp, nil
+
+	resp.Teachers, err = app.getTeacherNames(resp.Id)
+	return resp, err
 }
 
-func (app *App) PostCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID) error {
+// PostCourseCompetency links the competency to the course that gives it at the level, basic by default.
+func (app *App) PostCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level string) error {
 	if courseId == uuid.Nil {
 		return ErrEmptyId
 	}
 	if competencyId == uuid.Nil {
 		return ErrEmptyId
 	}
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
 
-	_, err := app.db.Exec(`INSERT INTO course_competency (course_id, competency_id) VALUES ($1, $2)`, courseId, competencyId)
-	return err
+	return app.store.CreateCourseCompetency(courseId, competencyId, proficiency)
 }
 
 func (app *App) PostPortfolio() (model.PostPortfolio, error) {
 	var resp model.PostPortfolio
-	portfolioData := app.db.QueryRow(`INSERT INTO portfolios (portfolio_id) VALUES (DEFAULT) RETURNING portfolio_id`)
-	if err := portfolioData.Scan(&resp.Id); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	var err error
+	resp.Id, err = app.store.CreatePortfolio()
+	return resp, err
 }
 
 func (app *App) PostProjectPortolio(projectId uuid.UUID, portfolioId uuid.UUID, teamRole string, semester uint8) (model.PostProjectPortfolio, error) {
-	var resp model.PostProjectPortfolio
+	resp := model.PostProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, TeamRole: teamRole, Semester: semester}
 	if projectId == uuid.Nil {
 		return resp, ErrEmptyId
 	}
 	if portfolioId == uuid.Nil {
 		return resp, ErrEmptyId
 	}
-
-	projectPortfolioData := app.db.QueryRow(`INSERT INTO project_portfolio (project_id, portfolio_id, team_role, semester) VALUES ($1, $2, $3, $4)
-								RETURNING project_id, portfolio_id, team_role, semester`, projectId, portfolioId, teamRole, semester)
-	if err := projectPortfolioData.Scan(&resp.PortfolioId, resp.PortfolioId, resp.TeamRole, resp.Semester); err != nil {
-		return resp, err
+	if semester == 0 {
+		return resp, ErrWrongSemester
 	}
-	return resp, nil
+
+	err := app.store.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, TeamRole: teamRole, Semester: semester})
+	return resp, err
 }
 
-func (app *App) PostProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) error {
+// PostProjectPortfolioCompetency links the competency to the project of the portfolio that confirms it at the level,
+// basic by default.
+func (app *App) PostProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level string) error {
 	if projectId == uuid.Nil {
 		return ErrEmptyId
 	}
@@ -626,21 +631,12 @@ func (app *App) PostProjectPortfolioCompetency(projectId uuid.UUID, portfolioId
 	if competencyId == uuid.Nil {
 		return ErrEmptyId
 	}
-
-	_, err := app.db.Exec(`INSERT INTO project_portfolio_competency (competency_id, project_id, portfolio_id) VALUES ($1, $2, $3)`, competencyId, projectId, portfolioId)
-	return err
-}
-
-func (app *App) PostStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
-	if studentId == uuid.Nil {
-		return ErrEmptyId
-	}
-	if courseId == uuid.Nil {
-		return ErrEmptyId
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return err
 	}
 
-	_, err := app.db.Exec(`INSERT INTO study_groups (course_id, student_id) VALUES ($1, $2)`, courseId, studentId)
-	return err
+	return app.store.CreateProjectPortfolioCompetency(projectId, portfolioId, competencyId, proficiency)
 }
 
 func (app *App) PostStudent(fullName string, admition time.Time, portfolioId uuid.UUID) (model.GetStudent, error) {
@@ -668,28 +664,18 @@ func (app *App) PostStudent(fullName string, admition time.Time, portfolioId uui
 		return resp, err
 	}
 	resp.Portfolio = portfolio
+	resp.FullName = fullName
+	// a new student has no courses yet, so the organization calendar is unknown
+	resp.Semester = newCalendar(nil, nil).semesterOn(admition, time.Now())
 
-	studentData := app.db.QueryRow(`INSERT INTO students (full_name, portfolio_id, admition) VALUES ($1, $2, $3)
-								RETURNING student_id, full_name`, fullName, portfolioId, admition)
-
-	var semesterChange time.Time = time.Time.AddDate(time.Now(), 0, 1, 0)
-	resp.Semester = uint8(time.Now().Year()) - uint8(admition.Year()) + 1
-	if time.Now().Month() > semesterChange.Month() {
-		resp.Semester += 1
-	} else if time.Now().Month() == semesterChange.Month() && time.Now().Day() > semesterChange.Day() {
-		resp.Semester += 1
-	}
-
-	if err := studentData.Scan(&resp.Id, &resp.FullName); err != nil {
-		return resp, err
-	}
-	return resp, nil
+	resp.Id, err = app.store.CreateStudent(store.Student{FullName: fullName, PortfolioId: portfolioId, Admition: admition})
+	return resp, err
 }
 
 func (app *App) PostTrajectory(semester uint8, studentId uuid.UUID, courseId uuid.UUID) (model.GetTrajectory, error) {
 	var resp model.GetTrajectory
 	if semester <= 0 {
-		return resp, errors.New("semester must be positive")
+		return resp, ErrWrongSemester
 	}
 
 	if studentId == uuid.Nil {
@@ -699,26 +685,62 @@ func (app *App) PostTrajectory(semester uint8, studentId uuid.UUID, courseId uui
 	if courseId == uuid.Nil {
 		return resp, ErrEmptyId
 	}
-	slog.Info("id check passed")
 
-	trajectoryData := app.db.QueryRow(`INSERT INTO trajectories (student_id, course_id, semester) VALUES ($1, $2, $3)
-								RETURNING trajectory_id`, studentId, courseId, semester)
+	if err := app.checkTrajectorySemester(studentId, semester); err != nil {
+		return resp, err
+	}
+	if err := app.checkStudentWorkload(studentId, courseId, semester, uuid.Nil); err != nil {
+		return resp, err
+	}
 
-	student, err := app.GetStudentById(studentId)
+	var trajectoryId uuid.UUID
+	err := app.store.Transaction(func(tx store.Store) error {
+		err := checkCourseTaken(tx, studentId, courseId, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		trajectoryId, err = tx.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: courseId, Semester: semester, Attempt: 1})
+		return err
+	})
 	if err != nil {
-		return resp, nil
+		return resp, err
 	}
-	resp.Student = student.FullName
-	resp.Semester = semester
 
-	course, err := app.GetCourseById(courseId)
+	return app.GetTrajectoryById(trajectoryId)
+}
+
+// checkCourseTaken refuses the first attempt at the course the student already took, the replaced trajectory aside,
+// the next attempts are added as retakes.
+func checkCourseTaken(s store.Store, studentId uuid.UUID, courseId uuid.UUID, replaced uuid.UUID) error {
+	trajectories, err := s.GetStudentTrajectories(studentId)
 	if err != nil {
-		return resp, nil
+		return err
 	}
-	resp.Course = course.Title
+	for _, trajectory := range trajectories {
+		if trajectory.CourseId == courseId && trajectory.Id != replaced {
+			return ErrCourseTaken
+		}
+	}
+	return nil
+}
 
-	if err := trajectoryData.Scan(&resp.Id); err != nil {
-		return resp, err
+// checkTrajectorySemester refuses trajectories of semesters the student has not reached yet,
+// trajectories keep the history of studies. Unknown students are left to the foreign key.
+func (app *App) checkTrajectorySemester(studentId uuid.UUID, semester uint8) error {
+	student, err := app.store.GetStudent(studentId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
 	}
-	return resp, nil
+	if err != nil {
+		return err
+	}
+
+	current, err := app.getStudentSemester(studentId, student.Admition, time.Now())
+	if err != nil {
+		return err
+	}
+	if semester > current {
+		return ErrWrongSemester
+	}
+	return nil
 }
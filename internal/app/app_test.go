@@ -0,0 +1,248 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store/memory"
+)
+
+func newTestApp() *App {
+	return NewWithStore(memory.New())
+}
+
+// fixture is a minimal catalog: one organization with a discipline to put courses into and one profession.
+type fixture struct {
+	app            *App
+	organizationId uuid.UUID
+	disciplineId   uuid.UUID
+	professionId   uuid.UUID
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	f := fixture{app: newTestApp()}
+	organization, err := f.app.PostOrganization("УрФУ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, err := f.app.PostEducationalProgram("Программная инженерия", "", organization.Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	discipline, err := f.app.PostDiscipline("Программирование", "", program.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profession, err := f.app.PostProfession("Backend-разработчик", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.organizationId = organization.Id
+	f.disciplineId = discipline.Id
+	f.professionId = profession.Id
+	return f
+}
+
+// competency creates competency, optionally required by the profession of the fixture.
+func (f fixture) competency(t *testing.T, title string, required bool) uuid.UUID {
+	t.Helper()
+	competency, err := f.app.PostCompetency(title, uuid.Nil, nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if required {
+		if err = f.app.PostCompetencyProfession(competency.Id, f.professionId, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return competency.Id
+}
+
+func (f fixture) course(t *testing.T, title string, competencyIds ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	course, err := f.app.PostCourse(title, "", nil, f.disciplineId, 0, 0, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, competencyId := range competencyIds {
+		if err = f.app.PostCourseCompetency(course.Id, competencyId, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return course.Id
+}
+
+func (f fixture) teacher(t *testing.T, fullName string) uuid.UUID {
+	t.Helper()
+	teacher, err := f.app.PostTeacher(fullName, f.organizationId, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teacher.Id
+}
+
+func (f fixture) student(t *testing.T) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+	student, err := f.app.PostStudent("Иванов Иван Иванович", time.Now().AddDate(-1, 0, 0), uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return student.Id, student.Portfolio.Id
+}
+
+// passed adds the course the student passed in the semester to the trajectory of the student.
+func (f fixture) passed(t *testing.T, semester uint8, studentId uuid.UUID, courseId uuid.UUID) uuid.UUID {
+	t.Helper()
+	trajectory, err := f.app.PostTrajectory(semester, studentId, courseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectoryOutcome(trajectory.Id, 5, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	return trajectory.Id
+}
+
+func TestEmptyTitle(t *testing.T) {
+	app := newTestApp()
+	tests := map[string]func() error{
+		"knowledge":           func() error { _, err := app.PostKnowledge("", uuid.Nil); return err },
+		"technology":          func() error { _, err := app.PostTechnology(""); return err },
+		"competency":          func() error { _, err := app.PostCompetency("", uuid.Nil, nil, uuid.Nil); return err },
+		"profession":          func() error { _, err := app.PostProfession("", ""); return err },
+		"project":             func() error { _, err := app.PostProject("", "", "", "", uuid.Nil); return err },
+		"organization":        func() error { _, err := app.PostOrganization(""); return err },
+		"educational program": func() error { _, err := app.PostEducationalProgram("", "", uuid.Nil, 0, 0); return err },
+		"discipline":          func() error { _, err := app.PostDiscipline("", "", uuid.Nil); return err },
+		"course":              func() error { _, err := app.PostCourse("", "", nil, uuid.Nil, 0, 0, false, 0); return err },
+		"student":             func() error { _, err := app.PostStudent("", time.Time{}, uuid.Nil); return err },
+		"teacher":             func() error { _, err := app.PostTeacher("", uuid.Nil, "", ""); return err },
+	}
+
+	for name, post := range tests {
+		if err := post(); !errors.Is(err, ErrEmptyTitle) {
+			t.Errorf("%s: expected ErrEmptyTitle, got %v", name, err)
+		}
+	}
+}
+
+func TestEmptyId(t *testing.T) {
+	app := newTestApp()
+	id := uuid.NewV4()
+	tests := map[string]func() error{
+		"knowledge competency":         func() error { return app.PostKnowledgeCompetency(uuid.Nil, id) },
+		"competency profession":        func() error { return app.PostCompetencyProfession(id, uuid.Nil, "") },
+		"course competency":            func() error { return app.PostCourseCompetency(uuid.Nil, id, "") },
+		"project portfolio":            func() error { _, err := app.PostProjectPortolio(id, uuid.Nil, "", 1); return err },
+		"project portfolio competency": func() error { return app.PostProjectPortfolioCompetency(id, id, uuid.Nil, "") },
+		"study group":                  func() error { _, err := app.PostStudyGroup(id, uuid.Nil); return err },
+		"trajectory":                   func() error { _, err := app.PostTrajectory(1, uuid.Nil, id); return err },
+		"plan":                         func() error { _, err := app.GetStudentPlan(id, uuid.Nil); return err },
+		"competency gap":               func() error { _, err := app.CompetencyGap(uuid.Nil, id); return err },
+		"teacher organization":         func() error { _, err := app.PostTeacher("teacher", uuid.Nil, "", ""); return err },
+		"course teacher": func() error {
+			_, err := app.PostCourse("course", "", []uuid.UUID{uuid.Nil}, id, 0, 0, false, 0)
+			return err
+		},
+	}
+
+	for name, post := range tests {
+		if err := post(); !errors.Is(err, ErrEmptyId) {
+			t.Errorf("%s: expected ErrEmptyId, got %v", name, err)
+		}
+	}
+}
+
+func TestWrongSemester(t *testing.T) {
+	app := newTestApp()
+	id := uuid.NewV4()
+	if _, err := app.PostTrajectory(0, id, id); !errors.Is(err, ErrWrongSemester) {
+		t.Errorf("trajectory: expected ErrWrongSemester, got %v", err)
+	}
+	if _, err := app.PostProjectPortolio(id, id, "", 0); !errors.Is(err, ErrWrongSemester) {
+		t.Errorf("project portfolio: expected ErrWrongSemester, got %v", err)
+	}
+}
+
+func TestPostStudent(t *testing.T) {
+	app := newTestApp()
+	admition := time.Now().AddDate(-2, 0, 0)
+	student, err := app.PostStudent("Иванов Иван Иванович", admition, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if student.Portfolio.Id == uuid.Nil {
+		t.Fatal("expected new portfolio to be created")
+	}
+
+	got, err := app.GetStudentById(student.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := newCalendar(nil, nil).semesterOn(admition, time.Now())
+	if got.Semester != want || student.Semester != want {
+		t.Fatalf("expected semester %d, got %d on creation and %d on reading", want, student.Semester, got.Semester)
+	}
+	if got.FullName != student.FullName || got.Portfolio.Id != student.Portfolio.Id {
+		t.Fatalf("expected %+v, got %+v", student, got)
+	}
+}
+
+func TestPostTrajectory(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	courseId := f.course(t, "Go")
+
+	trajectory, err := f.app.PostTrajectory(2, studentId, courseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trajectory.Id == uuid.Nil || trajectory.Semester != 2 || trajectory.Course != "Go" || trajectory.Student != "Иванов Иван Иванович" {
+		t.Fatalf("unexpected trajectory %+v", trajectory)
+	}
+
+	if _, err = f.app.PostTrajectory(2, uuid.NewV4(), courseId); err == nil {
+		t.Fatal("expected error for unknown student")
+	}
+}
+
+func TestGetCourseById(t *testing.T) {
+	f := newFixture(t)
+	b := f.competency(t, "b", false)
+	a := f.competency(t, "a", false)
+	courseId := f.course(t, "Go", b, a)
+
+	course, err := f.app.GetCourseById(courseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if course.Discipline != "Программирование" || len(course.Competencies) != 2 || course.Competencies[0] != "a" {
+		t.Fatalf("unexpected course %+v", course)
+	}
+}
+
+func TestDeleteRestricted(t *testing.T) {
+	app := newTestApp()
+	technology, err := app.PostTechnology("Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.PostProject("Планировщик", "", "", "", technology.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	// the project keeps its main technology even when the cascade is confirmed
+	var dependentsErr *DependentsError
+	report, err := app.DeleteTechnology(technology.Id, true)
+	if !errors.As(err, &dependentsErr) {
+		t.Fatalf("expected DependentsError, got %v", err)
+	}
+	if len(report.Cascade) != 1 || report.Cascade[0].Table != "projects" || !report.Cascade[0].Restrict {
+		t.Fatalf("expected the project to restrict the deletion, got %+v", report)
+	}
+}
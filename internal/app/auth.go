@@ -0,0 +1,245 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongRole = errors.New("wrong role")
+var ErrUnauthenticated = errors.New("unknown api key or invalid token")
+var ErrForbidden = errors.New("access denied")
+
+// Roles of the callers of the API.
+const (
+	RoleAdmin   = store.RoleAdmin
+	RoleAnalyst = store.RoleAnalyst
+	RoleTeacher = store.RoleTeacher
+	RoleStudent = store.RoleStudent
+)
+
+var Roles = []string{RoleAdmin, RoleAnalyst, RoleTeacher, RoleStudent}
+
+// TokenLifetime is how long an issued token is accepted.
+const TokenLifetime = time.Hour
+
+// Principal is the caller of the API the key or the token belongs to.
+type Principal struct {
+	ApiKeyId  uuid.UUID
+	Role      string
+	StudentId uuid.UUID // the student the caller is, set only for the student role
+	TeacherId uuid.UUID // the teacher the caller is, set only for the teacher role
+	FromToken bool      // the caller was authenticated with a token, not with the key itself
+}
+
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// PostApiKey creates a key for the role, a student key belongs to the student, a teacher key to the teacher.
+func (app *App) PostApiKey(title string, role string, studentId uuid.UUID, teacherId uuid.UUID) (model.GetApiKey, error) {
+	resp := model.GetApiKey{Title: title, Role: role, StudentId: studentId, TeacherId: teacherId}
+	if title == "" {
+		return resp, ErrEmptyTitle
+	}
+	if !slices.Contains(Roles, role) {
+		return resp, fmt.Errorf("%w %q, expected one of %s", ErrWrongRole, role, strings.Join(Roles, ", "))
+	}
+	if (role == RoleStudent) != (studentId != uuid.Nil) {
+		return resp, fmt.Errorf("%w: the student id is given for the student role only and is required there", ErrWrongRole)
+	}
+	if (role == RoleTeacher) != (teacherId != uuid.Nil) {
+		return resp, fmt.Errorf("%w: the teacher id is given for the teacher role only and is required there", ErrWrongRole)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return resp, err
+	}
+	resp.Key = base64.RawURLEncoding.EncodeToString(secret)
+
+	var err error
+	resp.Id, err = app.store.CreateApiKey(store.ApiKey{Hash: hashApiKey(resp.Key), Title: title, Role: role,
+		StudentId: studentId, TeacherId: teacherId})
+	return resp, err
+}
+
+func (app *App) DeleteApiKey(id uuid.UUID) (model.GetDeleteReport, error) {
+	return app.deleteById("api_keys", id, false)
+}
+
+// Authenticate returns the owner of the key, ErrUnauthenticated means there is no such key.
+func (app *App) Authenticate(key string) (Principal, error) {
+	apiKey, err := app.store.GetApiKeyByHash(hashApiKey(key))
+	if errors.Is(err, store.ErrNotFound) {
+		return Principal{}, ErrUnauthenticated
+	} else if err != nil {
+		return Principal{}, err
+	}
+
+	return Principal{ApiKeyId: apiKey.Id, Role: apiKey.Role, StudentId: apiKey.StudentId, TeacherId: apiKey.TeacherId}, nil
+}
+
+// tokenClaims is the payload of the JWT.
+type tokenClaims struct {
+	Subject   uuid.UUID `json:"sub"`
+	Role      string    `json:"role"`
+	StudentId uuid.UUID `json:"student"`
+	TeacherId uuid.UUID `json:"teacher"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// tokenHeader is the only JWT header the service issues and accepts.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func signToken(secret []byte, unsigned string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// IssueToken signs a JWT (HS256) of the principal valid for TokenLifetime since now.
+func IssueToken(secret []byte, principal Principal, now time.Time) (model.GetToken, error) {
+	expires := now.Add(TokenLifetime)
+	payload, err := json.Marshal(tokenClaims{
+		Subject:   principal.ApiKeyId,
+		Role:      principal.Role,
+		StudentId: principal.StudentId,
+		TeacherId: principal.TeacherId,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return model.GetToken{}, err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return model.GetToken{Token: unsigned + "." + signToken(secret, unsigned), Type: "Bearer", ExpiresAt: expires.UTC()}, nil
+}
+
+// ParseToken checks the signature and the expiration of the token issued by IssueToken.
+// Every problem with the token is reported as ErrUnauthenticated.
+func ParseToken(secret []byte, token string, now time.Time) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Principal{}, fmt.Errorf("%w: malformed token", ErrUnauthenticated)
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(signToken(secret, parts[0]+"."+parts[1]))) {
+		return Principal{}, fmt.Errorf("%w: wrong signature", ErrUnauthenticated)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: malformed token", ErrUnauthenticated)
+	}
+	var claims tokenClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return Principal{}, fmt.Errorf("%w: malformed token", ErrUnauthenticated)
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return Principal{}, fmt.Errorf("%w: token expired", ErrUnauthenticated)
+	}
+
+	return Principal{ApiKeyId: claims.Subject, Role: claims.Role, StudentId: claims.StudentId, TeacherId: claims.TeacherId,
+		FromToken: true}, nil
+}
+
+// AuthenticateToken returns the caller of the token issued by IssueToken. Deleting the API key revokes its tokens,
+// so the token is accepted only while the key it was issued for exists.
+func (app *App) AuthenticateToken(secret []byte, token string, now time.Time) (Principal, error) {
+	principal, err := ParseToken(secret, token, now)
+	if err != nil {
+		return Principal{}, err
+	}
+	_, err = app.store.GetApiKey(principal.ApiKeyId)
+	if errors.Is(err, store.ErrNotFound) {
+		return Principal{}, fmt.Errorf("%w: api key of the token is deleted", ErrUnauthenticated)
+	} else if err != nil {
+		return Principal{}, err
+	}
+	return principal, nil
+}
+
+// GetTrajectoryStudent returns the student the trajectory belongs to.
+func (app *App) GetTrajectoryStudent(id uuid.UUID) (uuid.UUID, error) {
+	trajectory, err := app.store.GetTrajectory(id)
+	return trajectory.StudentId, err
+}
+
+// GetTrajectoryTeachers returns teachers of the course of the trajectory.
+func (app *App) GetTrajectoryTeachers(id uuid.UUID) ([]uuid.UUID, error) {
+	trajectory, err := app.store.GetTrajectory(id)
+	if err != nil {
+		return nil, err
+	}
+	return app.GetCourseTeachers(trajectory.CourseId)
+}
+
+// GetPortfolioStudent returns the student the portfolio belongs to.
+func (app *App) GetPortfolioStudent(id uuid.UUID) (uuid.UUID, error) {
+	student, err := app.store.GetStudentByPortfolio(id)
+	return student.Id, err
+}
+
+// GetCourseTeachers returns teachers of the course.
+func (app *App) GetCourseTeachers(id uuid.UUID) ([]uuid.UUID, error) {
+	if _, err := app.store.GetCourse(id); err != nil {
+		return nil, err
+	}
+	teachers, err := app.store.GetTeachersByCourse(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(teachers))
+	for _, teacher := range teachers {
+		ids = append(ids, teacher.Id)
+	}
+	return ids, nil
+}
+
+// GetCourseStudyGroup returns students of the current semester studying the course ordered by full name.
+func (app *App) GetCourseStudyGroup(courseId uuid.UUID) (model.GetCourseStudyGroup, error) {
+	resp := model.GetCourseStudyGroup{CourseId: courseId, Students: []model.GetStudyGroupStudent{}}
+	course, err := app.store.GetCourse(courseId)
+	if err != nil {
+		return resp, err
+	}
+	resp.Course = course.Title
+	if resp.Teachers, err = app.getTeacherNames(courseId); err != nil {
+		return resp, err
+	}
+
+	groups, err := app.store.GetStudyGroups()
+	if err != nil {
+		return resp, err
+	}
+	for _, group := range groups {
+		if group.CourseId != courseId {
+			continue
+		}
+		student, err := app.store.GetStudent(group.StudentId)
+		if err != nil {
+			return resp, err
+		}
+		resp.Students = append(resp.Students, model.GetStudyGroupStudent{Id: student.Id, FullName: student.FullName})
+	}
+
+	sort.Slice(resp.Students, func(i, j int) bool { return resp.Students[i].FullName < resp.Students[j].FullName })
+	return resp, nil
+}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestPostApiKey(t *testing.T) {
+	f := newFixture(t)
+	studentId, portfolioId := f.student(t)
+	teacherId := f.teacher(t, "Петров Пётр Петрович")
+
+	tests := map[string]struct {
+		role      string
+		studentId uuid.UUID
+		teacherId uuid.UUID
+	}{
+		"unknown role":         {role: "guest"},
+		"student without id":   {role: RoleStudent},
+		"teacher without id":   {role: RoleTeacher},
+		"analyst with student": {role: RoleAnalyst, studentId: studentId},
+		"admin with teacher":   {role: RoleAdmin, teacherId: teacherId},
+		"student with teacher": {role: RoleStudent, studentId: studentId, teacherId: teacherId},
+		"teacher with student": {role: RoleTeacher, studentId: studentId, teacherId: teacherId},
+	}
+	for name, tt := range tests {
+		if _, err := f.app.PostApiKey(name, tt.role, tt.studentId, tt.teacherId); !errors.Is(err, ErrWrongRole) {
+			t.Errorf("%s: expected ErrWrongRole, got %v", name, err)
+		}
+	}
+	if _, err := f.app.PostApiKey("", RoleAdmin, uuid.Nil, uuid.Nil); !errors.Is(err, ErrEmptyTitle) {
+		t.Fatalf("expected ErrEmptyTitle, got %v", err)
+	}
+
+	apiKey, err := f.app.PostApiKey("Студент", RoleStudent, studentId, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKey.Key == "" || apiKey.Id == uuid.Nil {
+		t.Fatalf("expected the key and its id, got %+v", apiKey)
+	}
+	principal, err := f.app.Authenticate(apiKey.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Principal{ApiKeyId: apiKey.Id, Role: RoleStudent, StudentId: studentId}
+	if principal != want {
+		t.Fatalf("expected %+v, got %+v", want, principal)
+	}
+	if _, err = f.app.Authenticate(apiKey.Key + "x"); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+
+	if owner, err := f.app.GetPortfolioStudent(portfolioId); err != nil || owner != studentId {
+		t.Fatalf("expected the portfolio of %s, got %s, %v", studentId, owner, err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2024, time.February, 10, 12, 0, 0, 0, time.UTC)
+	principal := Principal{ApiKeyId: uuid.NewV4(), Role: RoleTeacher, TeacherId: uuid.NewV4()}
+
+	token, err := IssueToken(secret, principal, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Type != "Bearer" || !token.ExpiresAt.Equal(now.Add(TokenLifetime)) {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	got, err := ParseToken(secret, token.Token, now.Add(TokenLifetime-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal.FromToken = true
+	if got != principal {
+		t.Fatalf("expected %+v, got %+v", principal, got)
+	}
+
+	parts := strings.Split(token.Token, ".")
+	forged, err := IssueToken(secret, Principal{ApiKeyId: principal.ApiKeyId, Role: RoleAdmin}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		secret []byte
+		token  string
+		now    time.Time
+	}{
+		"expired":         {secret, token.Token, now.Add(TokenLifetime)},
+		"wrong secret":    {[]byte("other"), token.Token, now},
+		"changed payload": {secret, parts[0] + "." + strings.Split(forged.Token, ".")[1] + "." + parts[2], now},
+		"malformed":       {secret, "token", now},
+		"no signature":    {secret, parts[0] + "." + parts[1] + ".", now},
+	}
+	for name, tt := range tests {
+		if _, err := ParseToken(tt.secret, tt.token, tt.now); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s: expected ErrUnauthenticated, got %v", name, err)
+		}
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	f := newFixture(t)
+	secret := []byte("secret")
+	apiKey, err := f.app.PostApiKey("analyst", RoleAnalyst, uuid.Nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal, err := f.app.Authenticate(apiKey.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := IssueToken(secret, principal, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := f.app.AuthenticateToken(secret, token.Token, time.Now()); err != nil || got.ApiKeyId != apiKey.Id || !got.FromToken {
+		t.Fatalf("expected the principal of the key, got %+v, %v", got, err)
+	}
+	if _, err = f.app.DeleteApiKey(apiKey.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.AuthenticateToken(secret, token.Token, time.Now()); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated for the token of the deleted key, got %v", err)
+	}
+}
+
+func TestGetCourseStudyGroup(t *testing.T) {
+	f := newFixture(t)
+	teacherId := f.teacher(t, "Петров Пётр Петрович")
+	course, err := f.app.PostCourse("Базы данных", "", []uuid.UUID{teacherId}, f.disciplineId, 0, 0, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := f.course(t, "Алгоритмы")
+	studentId, _ := f.student(t)
+	classmate, err := f.app.PostStudent("Алексеев Алексей Алексеевич", time.Now().AddDate(-1, 0, 0), uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range [][2]uuid.UUID{{course.Id, studentId}, {course.Id, classmate.Id}, {other, studentId}} {
+		if _, err = f.app.PostStudyGroup(group[0], group[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if teachers, err := f.app.GetCourseTeachers(course.Id); err != nil || len(teachers) != 1 || teachers[0] != teacherId {
+		t.Fatalf("unexpected teachers %v, %v", teachers, err)
+	}
+	group, err := f.app.GetCourseStudyGroup(course.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Course != "Базы данных" || len(group.Teachers) != 1 || group.Teachers[0] != "Петров Пётр Петрович" || len(group.Students) != 2 ||
+		group.Students[0].Id != classmate.Id || group.Students[1].Id != studentId {
+		t.Fatalf("expected both students ordered by full name, got %+v", group)
+	}
+	if _, err = f.app.GetCourseStudyGroup(uuid.NewV4()); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,662 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// CatalogBundleFormat names the format of the catalog bundle, CatalogBundleVersion grows with incompatible changes of it.
+// Version 2 lists teachers of a course instead of the only teacher, version 3 lists skills of a competency
+// instead of the comma separated string and gives parents of competencies and knowledge. Older bundles are still imported.
+const (
+	CatalogBundleFormat  = "smart-schedule-former/catalog"
+	CatalogBundleVersion = 3
+)
+
+var ErrWrongBundle = errors.New("wrong catalog bundle")
+
+// ExportCatalog returns the whole catalog with references by title, entities and links are ordered by title.
+func (app *App) ExportCatalog() (model.CatalogBundle, error) {
+	bundle := model.CatalogBundle{Format: CatalogBundleFormat, Version: CatalogBundleVersion, ExportedAt: time.Now().UTC()}
+	catalog, err := app.store.GetCatalog()
+	if err != nil {
+		return bundle, err
+	}
+
+	titles := make(map[uuid.UUID]string)
+	bundle.Organizations = make([]model.BundleOrganization, 0, len(catalog.Organizations))
+	for _, organization := range catalog.Organizations {
+		titles[organization.Id] = organization.Title
+		bundle.Organizations = append(bundle.Organizations, model.BundleOrganization{Title: organization.Title})
+	}
+	bundle.Technologies = make([]model.BundleTechnology, 0, len(catalog.Technologies))
+	for _, technology := range catalog.Technologies {
+		titles[technology.Id] = technology.Title
+		bundle.Technologies = append(bundle.Technologies, model.BundleTechnology{Title: technology.Title})
+	}
+	for _, knowledge := range catalog.Knowledge {
+		titles[knowledge.Id] = knowledge.Title
+	}
+	bundle.Knowledge = make([]model.BundleKnowledge, 0, len(catalog.Knowledge))
+	for _, knowledge := range catalog.Knowledge {
+		bundle.Knowledge = append(bundle.Knowledge, model.BundleKnowledge{Title: knowledge.Title, Parent: titles[knowledge.ParentId]})
+	}
+	for _, competency := range catalog.Competencies {
+		titles[competency.Id] = competency.Title
+	}
+	for _, skill := range catalog.Skills {
+		titles[skill.Id] = skill.Title
+	}
+	// contacts and organizations of teachers are personal data and stay out of the bundle
+	for _, teacher := range catalog.Teachers {
+		titles[teacher.Id] = teacher.FullName
+	}
+
+	bundle.EducationalPrograms = make([]model.BundleEducationalProgram, 0, len(catalog.EducationalPrograms))
+	for _, educationalProgram := range catalog.EducationalPrograms {
+		titles[educationalProgram.Id] = educationalProgram.Title
+		bundle.EducationalPrograms = append(bundle.EducationalPrograms, model.BundleEducationalProgram{
+			Title:        educationalProgram.Title,
+			Description:  educationalProgram.Description,
+			Organization: titles[educationalProgram.OrganizationId],
+			MinCredits:   educationalProgram.MinSemesterCredits,
+			MaxCredits:   educationalProgram.MaxSemesterCredits,
+		})
+	}
+	bundle.Disciplines = make([]model.BundleDiscipline, 0, len(catalog.Disciplines))
+	for _, discipline := range catalog.Disciplines {
+		titles[discipline.Id] = discipline.Title
+		bundle.Disciplines = append(bundle.Disciplines, model.BundleDiscipline{
+			Title:              discipline.Title,
+			Description:        discipline.Description,
+			EducationalProgram: titles[discipline.EducationalProgramId],
+		})
+	}
+
+	// links are listed by the entity they belong to: knowledge and skills of competencies and competencies of courses and professions
+	linked := func(links []store.CatalogLink, byTo bool) map[uuid.UUID][]string {
+		result := make(map[uuid.UUID][]string)
+		for _, link := range links {
+			owner, item := link.FromId, link.ToId
+			if byTo {
+				owner, item = link.ToId, link.FromId
+			}
+			result[owner] = append(result[owner], titles[item])
+		}
+		for _, items := range result {
+			slices.Sort(items)
+		}
+		return result
+	}
+	// only levels other than basic are listed
+	levels := func(links []store.CatalogLink, byTo bool) map[uuid.UUID]map[string]string {
+		result := make(map[uuid.UUID]map[string]string)
+		for _, link := range links {
+			owner, item := link.FromId, link.ToId
+			if byTo {
+				owner, item = link.ToId, link.FromId
+			}
+			if link.Level == store.LevelBasic {
+				continue
+			}
+			if result[owner] == nil {
+				result[owner] = make(map[string]string)
+			}
+			result[owner][titles[item]] = levelName(link.Level)
+		}
+		return result
+	}
+	competencyKnowledge := linked(catalog.KnowledgeCompetencies, true)
+	competencySkills := linked(catalog.SkillCompetencies, true)
+	professionCompetencies := linked(catalog.CompetencyProfessions, true)
+	courseCompetencies := linked(catalog.CourseCompetencies, false)
+	courseTeachers := linked(catalog.CourseTeachers, false)
+	professionLevels := levels(catalog.CompetencyProfessions, true)
+	courseLevels := levels(catalog.CourseCompetencies, false)
+
+	bundle.Courses = make([]model.BundleCourse, 0, len(catalog.Courses))
+	for _, course := range catalog.Courses {
+		bundle.Courses = append(bundle.Courses, model.BundleCourse{
+			Title:            course.Title,
+			Description:      course.Description,
+			Teachers:         courseTeachers[course.Id],
+			Discipline:       titles[course.DisciplineId],
+			Credits:          course.Credits,
+			Hours:            course.Hours,
+			Alternative:      course.Alternative,
+			Competencies:     courseCompetencies[course.Id],
+			CompetencyLevels: courseLevels[course.Id],
+		})
+	}
+	bundle.Competencies = make([]model.BundleCompetency, 0, len(catalog.Competencies))
+	for _, competency := range catalog.Competencies {
+		bundle.Competencies = append(bundle.Competencies, model.BundleCompetency{
+			Title:          competency.Title,
+			Parent:         titles[competency.ParentId],
+			SkillTitles:    competencySkills[competency.Id],
+			MainTechnology: titles[competency.MainTechnologyId],
+			Knowledge:      competencyKnowledge[competency.Id],
+		})
+	}
+	bundle.Professions = make([]model.BundleProfession, 0, len(catalog.Professions))
+	for _, profession := range catalog.Professions {
+		bundle.Professions = append(bundle.Professions, model.BundleProfession{
+			Title:            profession.Title,
+			Description:      profession.Description,
+			Competencies:     professionCompetencies[profession.Id],
+			CompetencyLevels: professionLevels[profession.Id],
+		})
+	}
+
+	return bundle, nil
+}
+
+// sortedByTitle checks titles of the bundle section are given and unique and returns the section ordered by title,
+// so the result of the import does not depend on the order of the bundle.
+func sortedByTitle[T any](section string, items []T, title func(T) string) ([]T, error) {
+	sorted := slices.Clone(items)
+	slices.SortFunc(sorted, func(a, b T) int { return strings.Compare(title(a), title(b)) })
+	for i, item := range sorted {
+		if strings.TrimSpace(title(item)) == "" {
+			return nil, fmt.Errorf("%w: empty title in %s", ErrWrongBundle, section)
+		}
+		if i > 0 && title(sorted[i-1]) == title(item) {
+			return nil, fmt.Errorf("%w: duplicated %s %q", ErrWrongBundle, section, title(item))
+		}
+	}
+	return sorted, nil
+}
+
+// bundleTeachers returns full names of the course teachers, the only teacher of version 1 bundles included.
+func bundleTeachers(course model.BundleCourse) []string {
+	var names []string
+	for _, name := range append([]string{course.Teacher}, course.Teachers...) {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// bundleSkills returns titles of the competency skills, the comma separated skills of older bundles included.
+func bundleSkills(competency model.BundleCompetency) []string {
+	return skillTitles(append(splitSkills(competency.Skills), competency.SkillTitles...))
+}
+
+// catalogImport keeps what the import found and saved in the transaction.
+type catalogImport struct {
+	// ids of existing and saved rows: table -> title -> id
+	ids map[string]map[string]uuid.UUID
+	// levels of existing and saved links: table -> link without the level -> level
+	links map[string]map[store.CatalogLink]uint8
+	resp  model.GetCatalogImport
+}
+
+// ref returns id of the row the entity refers to by title.
+func (imp *catalogImport) ref(table string, title string, owner string) (uuid.UUID, error) {
+	id, ok := imp.ids[table][title]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%w: %s refers to missing %s %q", ErrWrongBundle, owner, table, title)
+	}
+	return id, nil
+}
+
+// save calls upsert unless the row with the title exists and is not changed.
+func (imp *catalogImport) save(table string, title string, changed func(id uuid.UUID) bool, upsert func() (uuid.UUID, error)) error {
+	id, exists := imp.ids[table][title]
+	switch {
+	case !exists:
+		imp.resp.Created++
+	case changed(id):
+		imp.resp.Updated++
+	default:
+		imp.resp.Unchanged++
+		return nil
+	}
+
+	id, err := upsert()
+	if err != nil {
+		return err
+	}
+	imp.ids[table][title] = id
+	return nil
+}
+
+// link creates the missing links of the owner and replaces levels of the existing ones, table is the link table.
+// save is told whether the link exists.
+func (imp *catalogImport) link(table string, owner string, links []store.CatalogLink, save func(link store.CatalogLink, exists bool) error) error {
+	for _, link := range links {
+		key := store.CatalogLink{FromId: link.FromId, ToId: link.ToId}
+		level, exists := imp.links[table][key]
+		if exists && level == link.Level {
+			continue
+		}
+		if err := save(link, exists); err != nil {
+			return fmt.Errorf("%s: %w", owner, err)
+		}
+		imp.links[table][key] = link.Level
+		imp.resp.Links++
+	}
+	return nil
+}
+
+// levels sets levels of the competency links resolved from titles, competencies without a level are basic.
+func (imp *catalogImport) levels(links []store.CatalogLink, titles []string, levels map[string]string, owner string) error {
+	for title := range levels {
+		if !slices.Contains(titles, title) {
+			return fmt.Errorf("%w: %s gives the level of unlisted competency %q", ErrWrongBundle, owner, title)
+		}
+	}
+	for i, title := range titles {
+		level, err := parseLevel(levels[title])
+		if err != nil {
+			return fmt.Errorf("%w: %s: competency %q: %s", ErrWrongBundle, owner, title, err.Error())
+		}
+		links[i].Level = level
+	}
+	return nil
+}
+
+// bundleParent is the parent the bundle names for the entity, both by title.
+type bundleParent struct {
+	title  string
+	parent string
+}
+
+// reparent gives the entities of the table the parents the bundle names unless they have them already, current are
+// parents of the existing entities. Cycles are looked for in the tree the bundle leaves, so entities may swap places,
+// parentOf gives parents of the entities out of the bundle. A cycle is an error of the bundle.
+func (imp *catalogImport) reparent(table string, parents []bundleParent, current map[uuid.UUID]uuid.UUID,
+	parentOf func(id uuid.UUID) (uuid.UUID, error), update func(id uuid.UUID, parentId uuid.UUID) error) error {
+	resolved := make(map[uuid.UUID]uuid.UUID)
+	for _, item := range parents {
+		var parentId uuid.UUID
+		if item.parent != "" {
+			var err error
+			if parentId, err = imp.ref(table, item.parent, table+" "+item.title); err != nil {
+				return err
+			}
+		}
+		resolved[imp.ids[table][item.title]] = parentId
+	}
+	final := func(id uuid.UUID) (uuid.UUID, error) {
+		if parentId, ok := resolved[id]; ok {
+			return parentId, nil
+		}
+		return parentOf(id)
+	}
+
+	for _, item := range parents {
+		id := imp.ids[table][item.title]
+		if resolved[id] == current[id] {
+			continue
+		}
+		if err := checkParent(id, resolved[id], final); errors.Is(err, ErrTaxonomyCycle) {
+			return fmt.Errorf("%w: %s %q: %s", ErrWrongBundle, table, item.title, err.Error())
+		} else if err != nil {
+			return err
+		}
+		if err := update(id, resolved[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refs resolves titles of linked entities, links go from the owner unless reversed.
+func (imp *catalogImport) refs(table string, titles []string, ownerId uuid.UUID, owner string, reversed bool) ([]store.CatalogLink, error) {
+	var links []store.CatalogLink
+	for _, title := range titles {
+		id, err := imp.ref(table, title, owner)
+		if err != nil {
+			return nil, err
+		}
+		if reversed {
+			links = append(links, store.CatalogLink{FromId: id, ToId: ownerId})
+		} else {
+			links = append(links, store.CatalogLink{FromId: ownerId, ToId: id})
+		}
+	}
+	return links, nil
+}
+
+// ImportCatalog upserts the bundle by titles in one transaction: missing entities and links are created,
+// columns of existing entities and levels of existing competency links are replaced. Nothing is deleted,
+// so the bundle may be applied to any database. Parents are replaced by bundles of version 3 only,
+// older bundles do not know them.
+func (app *App) ImportCatalog(bundle model.CatalogBundle) (model.GetCatalogImport, error) {
+	if bundle.Format != CatalogBundleFormat {
+		return model.GetCatalogImport{}, fmt.Errorf("%w: unknown format %q", ErrWrongBundle, bundle.Format)
+	}
+	if bundle.Version < 1 || bundle.Version > CatalogBundleVersion {
+		return model.GetCatalogImport{}, fmt.Errorf("%w: unsupported version %d", ErrWrongBundle, bundle.Version)
+	}
+
+	organizations, err := sortedByTitle("organization", bundle.Organizations, func(item model.BundleOrganization) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	educationalPrograms, err := sortedByTitle("educational program", bundle.EducationalPrograms,
+		func(item model.BundleEducationalProgram) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	disciplines, err := sortedByTitle("discipline", bundle.Disciplines, func(item model.BundleDiscipline) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	courses, err := sortedByTitle("course", bundle.Courses, func(item model.BundleCourse) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	technologies, err := sortedByTitle("technology", bundle.Technologies, func(item model.BundleTechnology) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	knowledge, err := sortedByTitle("knowledge", bundle.Knowledge, func(item model.BundleKnowledge) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	competencies, err := sortedByTitle("competency", bundle.Competencies, func(item model.BundleCompetency) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+	professions, err := sortedByTitle("profession", bundle.Professions, func(item model.BundleProfession) string { return item.Title })
+	if err != nil {
+		return model.GetCatalogImport{}, err
+	}
+
+	var resp model.GetCatalogImport
+	err = app.store.Transaction(func(tx store.Store) error {
+		catalog, err := tx.GetCatalog()
+		if err != nil {
+			return err
+		}
+
+		imp := catalogImport{
+			ids:   make(map[string]map[string]uuid.UUID),
+			links: make(map[string]map[store.CatalogLink]uint8),
+		}
+		existing := make(map[uuid.UUID]any)
+		index := func(table string, id uuid.UUID, title string, row any) {
+			imp.ids[table][title] = id
+			existing[id] = row
+		}
+		for _, table := range []string{"organization", "educational program", "discipline", "course", "technology", "knowledge",
+			"competency", "profession", "teacher", "skill"} {
+			imp.ids[table] = make(map[string]uuid.UUID)
+		}
+		for _, row := range catalog.Organizations {
+			index("organization", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.EducationalPrograms {
+			index("educational program", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Disciplines {
+			index("discipline", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Courses {
+			index("course", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Technologies {
+			index("technology", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Knowledge {
+			index("knowledge", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Competencies {
+			index("competency", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Professions {
+			index("profession", row.Id, row.Title, row)
+		}
+		for _, row := range catalog.Teachers {
+			index("teacher", row.Id, row.FullName, row)
+		}
+		for _, row := range catalog.Skills {
+			index("skill", row.Id, row.Title, row)
+		}
+		for table, links := range map[string][]store.CatalogLink{
+			"knowledge_competency":  catalog.KnowledgeCompetencies,
+			"competency_profession": catalog.CompetencyProfessions,
+			"course_competency":     catalog.CourseCompetencies,
+			"course_teacher":        catalog.CourseTeachers,
+			"skill_competency":      catalog.SkillCompetencies,
+		} {
+			imp.links[table] = make(map[store.CatalogLink]uint8)
+			for _, link := range links {
+				imp.links[table][store.CatalogLink{FromId: link.FromId, ToId: link.ToId}] = link.Level
+			}
+		}
+		unchanged := func(uuid.UUID) bool { return false }
+		// parents are saved once all the entities exist, so the ones of existing rows are compared by title
+		withParents := bundle.Version >= 3
+		titles := make(map[uuid.UUID]string)
+		parents := make(map[uuid.UUID]uuid.UUID)
+		for _, row := range catalog.Knowledge {
+			titles[row.Id], parents[row.Id] = row.Title, row.ParentId
+		}
+		for _, row := range catalog.Competencies {
+			titles[row.Id], parents[row.Id] = row.Title, row.ParentId
+		}
+		parentChanged := func(id uuid.UUID, parent string) bool { return withParents && titles[parents[id]] != parent }
+
+		for _, item := range organizations {
+			if err = imp.save("organization", item.Title, unchanged, func() (uuid.UUID, error) {
+				return tx.CreateOrganization(item.Title)
+			}); err != nil {
+				return err
+			}
+		}
+		for _, item := range technologies {
+			if err = imp.save("technology", item.Title, unchanged, func() (uuid.UUID, error) {
+				return tx.CreateTechnology(item.Title)
+			}); err != nil {
+				return err
+			}
+		}
+		for _, item := range knowledge {
+			if err = imp.save("knowledge", item.Title, func(id uuid.UUID) bool {
+				return parentChanged(id, item.Parent)
+			}, func() (uuid.UUID, error) { return tx.CreateKnowledge(item.Title) }); err != nil {
+				return err
+			}
+		}
+		for _, item := range competencies {
+			for _, title := range bundleSkills(item) {
+				if err = imp.save("skill", title, unchanged, func() (uuid.UUID, error) {
+					return tx.CreateSkill(title)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, item := range educationalPrograms {
+			row := store.EducationalProgram{Title: item.Title, Description: item.Description,
+				MinSemesterCredits: item.MinCredits, MaxSemesterCredits: item.MaxCredits}
+			if item.MaxCredits != 0 && item.MinCredits > item.MaxCredits {
+				return fmt.Errorf("%w: educational program %q: %s", ErrWrongBundle, item.Title, ErrWrongCredits)
+			}
+			if row.OrganizationId, err = imp.ref("organization", item.Organization, "educational program "+item.Title); err != nil {
+				return err
+			}
+			if err = imp.save("educational program", item.Title, func(id uuid.UUID) bool {
+				row.Id = id
+				return existing[id] != row
+			}, func() (uuid.UUID, error) { return tx.SaveEducationalProgram(row) }); err != nil {
+				return err
+			}
+		}
+		for _, item := range disciplines {
+			row := store.Discipline{Title: item.Title, Description: item.Description}
+			if row.EducationalProgramId, err = imp.ref("educational program", item.EducationalProgram, "discipline "+item.Title); err != nil {
+				return err
+			}
+			if err = imp.save("discipline", item.Title, func(id uuid.UUID) bool {
+				row.Id = id
+				return existing[id] != row
+			}, func() (uuid.UUID, error) { return tx.SaveDiscipline(row) }); err != nil {
+				return err
+			}
+		}
+		for _, item := range courses {
+			row := store.Course{Title: item.Title, Description: item.Description,
+				Credits: item.Credits, Hours: item.Hours, Alternative: item.Alternative}
+			if row.DisciplineId, err = imp.ref("discipline", item.Discipline, "course "+item.Title); err != nil {
+				return err
+			}
+			if err = imp.save("course", item.Title, func(id uuid.UUID) bool {
+				// the capacity is not a part of the catalog, SaveCourse keeps it
+				row.Id = id
+				if course, ok := existing[id].(store.Course); ok {
+					row.Capacity = course.Capacity
+				}
+				return existing[id] != row
+			}, func() (uuid.UUID, error) { return tx.SaveCourse(row) }); err != nil {
+				return err
+			}
+		}
+		// teachers are known by full name only, the missing ones are created without an organization
+		for _, item := range courses {
+			for _, name := range bundleTeachers(item) {
+				if err = imp.save("teacher", name, unchanged, func() (uuid.UUID, error) {
+					return tx.CreateTeacher(store.Teacher{FullName: name})
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		competencyRows := make(map[uuid.UUID]store.Competency)
+		for _, item := range competencies {
+			row := store.Competency{Title: item.Title}
+			if item.MainTechnology != "" {
+				if row.MainTechnologyId, err = imp.ref("technology", item.MainTechnology, "competency "+item.Title); err != nil {
+					return err
+				}
+			}
+			if err = imp.save("competency", item.Title, func(id uuid.UUID) bool {
+				// the parent is kept here and replaced below
+				row.Id = id
+				if competency, ok := existing[id].(store.Competency); ok {
+					row.ParentId = competency.ParentId
+				}
+				return existing[id] != row || parentChanged(id, item.Parent)
+			}, func() (uuid.UUID, error) { return tx.SaveCompetency(row) }); err != nil {
+				return err
+			}
+			row.Id = imp.ids["competency"][item.Title]
+			competencyRows[row.Id] = row
+		}
+		if withParents {
+			var knowledgeParents, competencyParents []bundleParent
+			for _, item := range knowledge {
+				knowledgeParents = append(knowledgeParents, bundleParent{item.Title, item.Parent})
+			}
+			for _, item := range competencies {
+				competencyParents = append(competencyParents, bundleParent{item.Title, item.Parent})
+			}
+			if err = imp.reparent("knowledge", knowledgeParents, parents, knowledgeParent(tx), tx.UpdateKnowledgeParent); err != nil {
+				return err
+			}
+			if err = imp.reparent("competency", competencyParents, parents, competencyParent(tx),
+				func(id uuid.UUID, parentId uuid.UUID) error {
+					row := competencyRows[id]
+					row.ParentId = parentId
+					_, err := tx.SaveCompetency(row)
+					return err
+				}); err != nil {
+				return err
+			}
+		}
+		for _, item := range professions {
+			row := store.Profession{Title: item.Title, Description: item.Description}
+			if err = imp.save("profession", item.Title, func(id uuid.UUID) bool {
+				row.Id = id
+				return existing[id] != row
+			}, func() (uuid.UUID, error) { return tx.SaveProfession(row) }); err != nil {
+				return err
+			}
+		}
+
+		for _, item := range competencies {
+			owner := "competency " + item.Title
+			links, err := imp.refs("knowledge", item.Knowledge, imp.ids["competency"][item.Title], owner, true)
+			if err != nil {
+				return err
+			}
+			if err = imp.link("knowledge_competency", owner, links, func(link store.CatalogLink, _ bool) error {
+				return tx.CreateKnowledgeCompetency(link.FromId, link.ToId)
+			}); err != nil {
+				return err
+			}
+
+			if links, err = imp.refs("skill", bundleSkills(item), imp.ids["competency"][item.Title], owner, true); err != nil {
+				return err
+			}
+			if err = imp.link("skill_competency", owner, links, func(link store.CatalogLink, _ bool) error {
+				return tx.CreateSkillCompetency(link.FromId, link.ToId)
+			}); err != nil {
+				return err
+			}
+		}
+		for _, item := range professions {
+			owner := "profession " + item.Title
+			links, err := imp.refs("competency", item.Competencies, imp.ids["profession"][item.Title], owner, true)
+			if err != nil {
+				return err
+			}
+			if err = imp.levels(links, item.Competencies, item.CompetencyLevels, owner); err != nil {
+				return err
+			}
+			if err = imp.link("competency_profession", owner, links, func(link store.CatalogLink, exists bool) error {
+				if exists {
+					return tx.UpdateCompetencyProfession(link.FromId, link.ToId, link.Level)
+				}
+				return tx.CreateCompetencyProfession(link.FromId, link.ToId, link.Level)
+			}); err != nil {
+				return err
+			}
+		}
+		for _, item := range courses {
+			owner := "course " + item.Title
+			links, err := imp.refs("competency", item.Competencies, imp.ids["course"][item.Title], owner, false)
+			if err != nil {
+				return err
+			}
+			if err = imp.levels(links, item.Competencies, item.CompetencyLevels, owner); err != nil {
+				return err
+			}
+			if err = imp.link("course_competency", owner, links, func(link store.CatalogLink, exists bool) error {
+				if exists {
+					return tx.UpdateCourseCompetency(link.FromId, link.ToId, link.Level)
+				}
+				return tx.CreateCourseCompetency(link.FromId, link.ToId, link.Level)
+			}); err != nil {
+				return err
+			}
+
+			if links, err = imp.refs("teacher", bundleTeachers(item), imp.ids["course"][item.Title], owner, false); err != nil {
+				return err
+			}
+			if err = imp.link("course_teacher", owner, links, func(link store.CatalogLink, _ bool) error {
+				return tx.CreateCourseTeacher(link.FromId, link.ToId)
+			}); err != nil {
+				return err
+			}
+		}
+
+		resp = imp.resp
+		return nil
+	})
+
+	return resp, err
+}
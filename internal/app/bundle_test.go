@@ -0,0 +1,155 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+func exportCatalog(t *testing.T, app *App) model.CatalogBundle {
+	t.Helper()
+	bundle, err := app.ExportCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.ExportedAt.IsZero() {
+		t.Fatal("expected the time of the export")
+	}
+	// the time of the export is the only difference of bundles of the same catalog
+	bundle.ExportedAt = time.Time{}
+	return bundle
+}
+
+func TestCatalogBundle(t *testing.T) {
+	f := newFixture(t)
+	technology, err := f.app.PostTechnology("Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	competency, err := f.app.PostCompetency("Разработка сервисов", uuid.Nil, []string{"HTTP"}, technology.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"REST", "SQL"} {
+		knowledge, err := f.app.PostKnowledge(title, uuid.Nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = f.app.PostKnowledgeCompetency(knowledge.Id, competency.Id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = f.app.PostCompetencyProfession(competency.Id, f.professionId, "advanced"); err != nil {
+		t.Fatal(err)
+	}
+	f.course(t, "Go", competency.Id)
+	f.course(t, "Алгоритмы")
+
+	bundle := exportCatalog(t, f.app)
+	if bundle.Format != CatalogBundleFormat || bundle.Version != CatalogBundleVersion {
+		t.Fatalf("unexpected header of the bundle %+v", bundle)
+	}
+	wantCompetency := model.BundleCompetency{Title: "Разработка сервисов", SkillTitles: []string{"HTTP"}, MainTechnology: "Go", Knowledge: []string{"REST", "SQL"}}
+	if len(bundle.Competencies) != 1 || !reflect.DeepEqual(bundle.Competencies[0], wantCompetency) {
+		t.Fatalf("expected competencies [%+v], got %+v", wantCompetency, bundle.Competencies)
+	}
+	if len(bundle.Courses) != 2 || bundle.Courses[0].Title != "Go" || bundle.Courses[0].Discipline != "Программирование" {
+		t.Fatalf("unexpected courses %+v", bundle.Courses)
+	}
+	// only levels other than basic are listed
+	wantLevels := map[string]string{"Разработка сервисов": "advanced"}
+	if bundle.Courses[0].CompetencyLevels != nil || !reflect.DeepEqual(bundle.Professions[0].CompetencyLevels, wantLevels) {
+		t.Fatalf("expected levels %v of the profession only, got %+v, %+v", wantLevels, bundle.Courses[0], bundle.Professions[0])
+	}
+
+	// the bundle read in reversed order gives the same catalog on an empty store
+	reversed := bundle
+	reversed.Courses = slices.Clone(bundle.Courses)
+	slices.Reverse(reversed.Courses)
+	reversed.Knowledge = slices.Clone(bundle.Knowledge)
+	slices.Reverse(reversed.Knowledge)
+	restored := newTestApp()
+	result, err := restored.ImportCatalog(reversed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (model.GetCatalogImport{Created: 11, Links: 5}) {
+		t.Fatalf("unexpected result of the import %+v", result)
+	}
+	if got := exportCatalog(t, restored); !reflect.DeepEqual(got, bundle) {
+		t.Fatalf("expected the restored catalog\n%+v\ngot\n%+v", bundle, exportCatalog(t, restored))
+	}
+
+	// a populated store gets only the changes
+	bundle.Courses[1].Description = "Сортировки"
+	bundle.Professions[0].Competencies, bundle.Professions[0].CompetencyLevels = nil, nil
+	bundle.Courses[0].CompetencyLevels = map[string]string{"Разработка сервисов": "intermediate"}
+	if result, err = restored.ImportCatalog(bundle); err != nil {
+		t.Fatal(err)
+	}
+	if result != (model.GetCatalogImport{Updated: 1, Unchanged: 10, Links: 1}) {
+		t.Fatalf("unexpected result of the second import %+v", result)
+	}
+	got := exportCatalog(t, restored)
+	if got.Courses[1].Description != "Сортировки" || len(got.Professions[0].Competencies) != 1 || got.Professions[0].CompetencyLevels == nil {
+		t.Fatalf("expected updated course and kept links, got %+v", got)
+	}
+	if level := got.Courses[0].CompetencyLevels["Разработка сервисов"]; level != "intermediate" {
+		t.Fatalf("expected the level of the course link to be replaced, got %q", level)
+	}
+}
+
+func TestImportCatalogValidation(t *testing.T) {
+	app := newTestApp()
+	header := model.CatalogBundle{Format: CatalogBundleFormat, Version: CatalogBundleVersion}
+	tests := map[string]func(bundle *model.CatalogBundle){
+		"format":  func(bundle *model.CatalogBundle) { bundle.Format = "other" },
+		"version": func(bundle *model.CatalogBundle) { bundle.Version = CatalogBundleVersion + 1 },
+		"empty title": func(bundle *model.CatalogBundle) {
+			bundle.Knowledge = []model.BundleKnowledge{{Title: " "}}
+		},
+		"duplicated title": func(bundle *model.CatalogBundle) {
+			bundle.Organizations = []model.BundleOrganization{{Title: "УрФУ"}, {Title: "УрФУ"}}
+		},
+		"missing reference": func(bundle *model.CatalogBundle) {
+			bundle.EducationalPrograms = []model.BundleEducationalProgram{{Title: "Программная инженерия", Organization: "УрФУ"}}
+		},
+		"missing link": func(bundle *model.CatalogBundle) {
+			bundle.Competencies = []model.BundleCompetency{{Title: "Разработка сервисов", Knowledge: []string{"SQL"}}}
+		},
+		"wrong level": func(bundle *model.CatalogBundle) {
+			bundle.Competencies = []model.BundleCompetency{{Title: "Разработка сервисов"}}
+			bundle.Professions = []model.BundleProfession{{Title: "Backend-разработчик", Competencies: []string{"Разработка сервисов"},
+				CompetencyLevels: map[string]string{"Разработка сервисов": "expert"}}}
+		},
+		"level of unlisted competency": func(bundle *model.CatalogBundle) {
+			bundle.Professions = []model.BundleProfession{{Title: "Backend-разработчик", CompetencyLevels: map[string]string{"SQL": "basic"}}}
+		},
+	}
+
+	for name, change := range tests {
+		bundle := header
+		change(&bundle)
+		if _, err := app.ImportCatalog(bundle); !errors.Is(err, ErrWrongBundle) {
+			t.Errorf("%s: expected ErrWrongBundle, got %v", name, err)
+		}
+	}
+
+	// nothing of the failed import is kept
+	bundle := header
+	bundle.Competencies = []model.BundleCompetency{{Title: "Разработка сервисов", MainTechnology: "Go"}}
+	bundle.Technologies = []model.BundleTechnology{{Title: "Go"}}
+	bundle.Professions = []model.BundleProfession{{Title: "Backend-разработчик", Competencies: []string{"нет такой"}}}
+	if _, err := app.ImportCatalog(bundle); !errors.Is(err, ErrWrongBundle) {
+		t.Fatalf("expected ErrWrongBundle, got %v", err)
+	}
+	if catalog, err := app.store.GetCatalog(); err != nil || len(catalog.Technologies) != 0 || len(catalog.Competencies) != 0 {
+		t.Fatalf("expected the failed import to be rolled back, got %+v, %v", catalog, err)
+	}
+}
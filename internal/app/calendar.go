@@ -0,0 +1,349 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongTerm = errors.New("term must be 1 (autumn) or 2 (spring)")
+var ErrWrongDates = errors.New("start date is after end date")
+var ErrWrongPeriodKind = errors.New("period kind must be holiday or session")
+
+type calendarTerm struct {
+	year int
+	term uint8
+}
+
+// calendar answers which semester a student is in and which dates a semester covers.
+// Academic years the organization has not configured follow the default calendar:
+// the autumn semester lasts from September 1 to January 31, the spring one from February 1 to June 30.
+type calendar struct {
+	semesters map[calendarTerm]store.CalendarSemester
+	periods   []store.CalendarPeriod
+}
+
+func newCalendar(semesters []store.CalendarSemester, periods []store.CalendarPeriod) calendar {
+	c := calendar{semesters: make(map[calendarTerm]store.CalendarSemester), periods: periods}
+	for _, semester := range semesters {
+		c.semesters[calendarTerm{semester.Year, semester.Term}] = semester
+	}
+	return c
+}
+
+// termDates returns the first and the last day of the term of the academic year starting in year.
+func (c calendar) termDates(year int, term uint8) (time.Time, time.Time) {
+	if semester, ok := c.semesters[calendarTerm{year, term}]; ok {
+		return semester.Start, semester.End
+	}
+	if term == store.TermAutumn {
+		return time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, time.January, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(year+1, time.February, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, time.June, 30, 0, 0, 0, 0, time.UTC)
+}
+
+// index numbers terms one after another, the spring term follows the autumn one of the same academic year.
+func (t calendarTerm) index() int {
+	return t.year*2 + int(t.term) - 1
+}
+
+func termAt(index int) calendarTerm {
+	return calendarTerm{year: index / 2, term: uint8(index%2) + 1}
+}
+
+// startTerm returns the term studies starting on the day begin with: the term in progress on the day
+// or the next one when the day falls on a break, so a start in January may begin with the spring term.
+func (c calendar) startTerm(start time.Time) calendarTerm {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for _, term := range []calendarTerm{
+		{start.Year() - 1, store.TermAutumn}, {start.Year() - 1, store.TermSpring}, {start.Year(), store.TermAutumn},
+	} {
+		if _, end := c.termDates(term.year, term.term); !end.Before(start) {
+			return term
+		}
+	}
+	return calendarTerm{start.Year(), store.TermSpring}
+}
+
+// semesterTerm converts the semester number of a student admitted at admition to the calendar term.
+func (c calendar) semesterTerm(admition time.Time, semester uint8) calendarTerm {
+	return termAt(c.startTerm(admition).index() + int(semester) - 1)
+}
+
+// semesterOn returns the semester the student admitted at admition studies in on the day.
+// Breaks between semesters belong to the semester before them, the first semester is the minimum.
+func (c calendar) semesterOn(admition time.Time, day time.Time) uint8 {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	// the latest term that has started: autumn of this year, spring and autumn of the previous academic year
+	current := calendarTerm{year: day.Year() - 2, term: store.TermSpring}
+	for _, term := range []calendarTerm{
+		{day.Year(), store.TermAutumn}, {day.Year() - 1, store.TermSpring}, {day.Year() - 1, store.TermAutumn},
+	} {
+		if start, _ := c.termDates(term.year, term.term); !start.After(day) {
+			current = term
+			break
+		}
+	}
+
+	semester := current.index() - c.startTerm(admition).index() + 1
+	if semester < 1 {
+		return 1
+	}
+	return uint8(semester)
+}
+
+// periodsBetween returns holidays and sessions overlapping the dates.
+func (c calendar) periodsBetween(start time.Time, end time.Time) (holidays []model.GetCalendarPeriod, sessions []model.GetCalendarPeriod) {
+	for _, period := range c.periods {
+		if period.End.Before(start) || period.Start.After(end) {
+			continue
+		}
+		if period.Kind == store.PeriodHoliday {
+			holidays = append(holidays, calendarPeriod(period))
+		} else {
+			sessions = append(sessions, calendarPeriod(period))
+		}
+	}
+	return holidays, sessions
+}
+
+func calendarPeriod(period store.CalendarPeriod) model.GetCalendarPeriod {
+	return model.GetCalendarPeriod{
+		Id:    period.Id,
+		Kind:  period.Kind,
+		Title: period.Title,
+		Start: model.JsonAdmitionDate(period.Start),
+		End:   model.JsonAdmitionDate(period.End),
+	}
+}
+
+func (app *App) getCalendar(organizationId uuid.UUID) (calendar, error) {
+	semesters, err := app.store.GetCalendarSemesters(organizationId)
+	if err != nil {
+		return calendar{}, err
+	}
+	periods, err := app.store.GetCalendarPeriods(organizationId)
+	if err != nil {
+		return calendar{}, err
+	}
+	return newCalendar(semesters, periods), nil
+}
+
+// studentCalendar counts semesters of the student from the start of studies,
+// terms that start while the student is on academic leave are not counted.
+type studentCalendar struct {
+	calendar
+	admition time.Time
+	leaves   []store.AcademicLeave
+}
+
+// onLeave tells whether the term started by the day while the student was on academic leave.
+func (c studentCalendar) onLeave(term calendarTerm, day time.Time) bool {
+	start, _ := c.termDates(term.year, term.term)
+	if start.After(day) {
+		return false
+	}
+	for _, leave := range c.leaves {
+		if !start.Before(leave.Start) && (leave.End.IsZero() || !start.After(leave.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+// semester returns the semester the student studies in on the day, the first semester is the minimum.
+func (c studentCalendar) semester(day time.Time) uint8 {
+	terms := c.semesterOn(c.admition, day)
+	semester := terms
+	for i := uint8(1); i <= terms; i++ {
+		if c.onLeave(c.semesterTerm(c.admition, i), day) {
+			semester--
+		}
+	}
+	return max(semester, 1)
+}
+
+// term returns the calendar term of the semester of the student, leaves known by the day shift the terms.
+func (c studentCalendar) term(semester uint8, day time.Time) calendarTerm {
+	semester = max(semester, 1)
+	for i, n := uint8(1), uint8(0); ; i++ {
+		term := c.semesterTerm(c.admition, i)
+		if c.onLeave(term, day) {
+			continue
+		}
+		if n++; n == semester {
+			return term
+		}
+	}
+}
+
+// getStudentCalendar returns the calendar of the organization the student studies in, students without enrollments
+// and courses get the default calendar. Semesters are counted from the first enrollment of the student,
+// without enrollments from the admition.
+func (app *App) getStudentCalendar(studentId uuid.UUID, admition time.Time) (studentCalendar, error) {
+	c := studentCalendar{admition: admition}
+	organizationId, err := app.store.GetStudentOrganization(studentId)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		c.calendar = newCalendar(nil, nil)
+	case err != nil:
+		return c, err
+	default:
+		if c.calendar, err = app.getCalendar(organizationId); err != nil {
+			return c, err
+		}
+	}
+
+	enrollments, err := app.store.GetStudentEnrollments(studentId)
+	if err != nil {
+		return c, err
+	}
+	if len(enrollments) > 0 {
+		c.admition = enrollments[0].Start
+	}
+	c.leaves, err = app.store.GetStudentAcademicLeaves(studentId)
+	return c, err
+}
+
+// getStudentSemester returns the semester the student studies in on the day.
+func (app *App) getStudentSemester(studentId uuid.UUID, admition time.Time, day time.Time) (uint8, error) {
+	c, err := app.getStudentCalendar(studentId, admition)
+	if err != nil {
+		return 0, err
+	}
+	return c.semester(day), nil
+}
+
+func (app *App) GetCalendarByOrganization(organizationId uuid.UUID) (model.GetCalendar, error) {
+	resp := model.GetCalendar{OrganizationId: organizationId}
+	if _, err := app.store.GetOrganization(organizationId); err != nil {
+		return resp, err
+	}
+
+	semesters, err := app.store.GetCalendarSemesters(organizationId)
+	if err != nil {
+		return resp, err
+	}
+	for _, semester := range semesters {
+		resp.Semesters = append(resp.Semesters, model.GetCalendarSemester{
+			Year:            semester.Year,
+			Term:            semester.Term,
+			Start:           model.JsonAdmitionDate(semester.Start),
+			End:             model.JsonAdmitionDate(semester.End),
+			EnrollmentStart: optionalDate(semester.EnrollmentStart),
+			EnrollmentEnd:   optionalDate(semester.EnrollmentEnd),
+		})
+	}
+
+	periods, err := app.store.GetCalendarPeriods(organizationId)
+	if err != nil {
+		return resp, err
+	}
+	for _, period := range periods {
+		resp.Periods = append(resp.Periods, calendarPeriod(period))
+	}
+
+	return resp, nil
+}
+
+// PostCalendarSemester sets dates of the semester and the window students enroll in its courses in,
+// zero enrollment dates leave enrollment open any day. The window ends by the end of the semester.
+func (app *App) PostCalendarSemester(organizationId uuid.UUID, year int, term uint8, start time.Time, end time.Time,
+	enrollmentStart time.Time, enrollmentEnd time.Time) (model.GetCalendarSemester, error) {
+	resp := model.GetCalendarSemester{Year: year, Term: term, Start: model.JsonAdmitionDate(start), End: model.JsonAdmitionDate(end),
+		EnrollmentStart: optionalDate(enrollmentStart), EnrollmentEnd: optionalDate(enrollmentEnd)}
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if term != store.TermAutumn && term != store.TermSpring {
+		return resp, ErrWrongTerm
+	}
+	if start.IsZero() || end.IsZero() || start.After(end) {
+		return resp, ErrWrongDates
+	}
+	if enrollmentStart.IsZero() != enrollmentEnd.IsZero() || enrollmentStart.After(enrollmentEnd) || enrollmentEnd.After(end) {
+		return resp, ErrWrongDates
+	}
+
+	return resp, app.store.CreateCalendarSemester(store.CalendarSemester{
+		OrganizationId: organizationId, Year: year, Term: term, Start: start, End: end,
+		EnrollmentStart: enrollmentStart, EnrollmentEnd: enrollmentEnd,
+	})
+}
+
+func (app *App) PostCalendarPeriod(organizationId uuid.UUID, kind string, title string, start time.Time, end time.Time) (model.GetCalendarPeriod, error) {
+	var resp model.GetCalendarPeriod
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if kind != store.PeriodHoliday && kind != store.PeriodSession {
+		return resp, ErrWrongPeriodKind
+	}
+	if start.IsZero() || end.IsZero() || start.After(end) {
+		return resp, ErrWrongDates
+	}
+
+	period := store.CalendarPeriod{OrganizationId: organizationId, Kind: kind, Title: title, Start: start, End: end}
+	var err error
+	period.Id, err = app.store.CreateCalendarPeriod(period)
+	return calendarPeriod(period), err
+}
+
+// GetStudentSemesterOn returns the semester the student studies in on the day together with its dates.
+func (app *App) GetStudentSemesterOn(studentId uuid.UUID, day time.Time) (model.GetSemesterDates, error) {
+	var resp model.GetSemesterDates
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+	c, err := app.getStudentCalendar(studentId, student.Admition)
+	if err != nil {
+		return resp, err
+	}
+
+	return semesterDates(c, student.Id, c.semester(day), day), nil
+}
+
+// GetStudentSemester returns dates, holidays and sessions of the semester of the student.
+func (app *App) GetStudentSemester(studentId uuid.UUID, semester uint8) (model.GetSemesterDates, error) {
+	var resp model.GetSemesterDates
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if semester == 0 {
+		return resp, ErrWrongSemester
+	}
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+	c, err := app.getStudentCalendar(studentId, student.Admition)
+	if err != nil {
+		return resp, err
+	}
+
+	return semesterDates(c, student.Id, semester, time.Now()), nil
+}
+
+// semesterDates finds the term of the semester by the leaves the student has taken by the day.
+func semesterDates(c studentCalendar, studentId uuid.UUID, semester uint8, day time.Time) model.GetSemesterDates {
+	term := c.term(semester, day)
+	start, end := c.termDates(term.year, term.term)
+	resp := model.GetSemesterDates{
+		StudentId: studentId,
+		Semester:  semester,
+		Year:      term.year,
+		Term:      term.term,
+		Start:     model.JsonAdmitionDate(start),
+		End:       model.JsonAdmitionDate(end),
+	}
+	resp.Holidays, resp.Sessions = c.periodsBetween(start, end)
+	return resp
+}
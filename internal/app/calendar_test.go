@@ -0,0 +1,204 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSemesterOnDefaultCalendar(t *testing.T) {
+	admition := date(2022, time.September, 1)
+	tests := []struct {
+		day  time.Time
+		want uint8
+	}{
+		{date(2022, time.September, 1), 1},
+		{date(2022, time.December, 20), 1},
+		{date(2023, time.January, 15), 1},
+		{date(2023, time.February, 10), 2},
+		{date(2023, time.August, 31), 2},
+		{date(2023, time.September, 1), 3},
+		{date(2024, time.March, 1), 4},
+		{date(2026, time.June, 1), 8},
+		// admitted in the future or before the academic year started
+		{date(2022, time.July, 1), 1},
+	}
+
+	c := newCalendar(nil, nil)
+	for _, tt := range tests {
+		if got := c.semesterOn(admition, tt.day); got != tt.want {
+			t.Errorf("semester on %s: expected %d, got %d", tt.day.Format("2006-01-02"), tt.want, got)
+		}
+	}
+}
+
+func TestSemesterOnConfiguredCalendar(t *testing.T) {
+	c := newCalendar([]store.CalendarSemester{
+		{Year: 2022, Term: store.TermAutumn, Start: date(2022, time.September, 15), End: date(2023, time.January, 25)},
+		{Year: 2022, Term: store.TermSpring, Start: date(2023, time.February, 20), End: date(2023, time.July, 5)},
+	}, nil)
+	admition := date(2022, time.August, 1)
+	tests := []struct {
+		day  time.Time
+		want uint8
+	}{
+		{date(2022, time.September, 10), 1},
+		{date(2022, time.September, 15), 1},
+		{date(2023, time.February, 10), 1},
+		{date(2023, time.February, 20), 2},
+		// the next academic year is not configured
+		{date(2023, time.September, 1), 3},
+	}
+
+	for _, tt := range tests {
+		if got := c.semesterOn(admition, tt.day); got != tt.want {
+			t.Errorf("semester on %s: expected %d, got %d", tt.day.Format("2006-01-02"), tt.want, got)
+		}
+	}
+
+	term := c.semesterTerm(admition, 2)
+	if start, end := c.termDates(term.year, term.term); !start.Equal(date(2023, time.February, 20)) || !end.Equal(date(2023, time.July, 5)) {
+		t.Errorf("unexpected dates of the second semester %s - %s", start, end)
+	}
+	term = c.semesterTerm(admition, 3)
+	if start, end := c.termDates(term.year, term.term); !start.Equal(date(2023, time.September, 1)) || !end.Equal(date(2024, time.January, 31)) {
+		t.Errorf("expected default dates of the third semester, got %s - %s", start, end)
+	}
+}
+
+func TestSemesterOnJanuaryStart(t *testing.T) {
+	// the spring term starts in January, studies starting in the break before it begin with the spring term
+	c := newCalendar([]store.CalendarSemester{
+		{Year: 2023, Term: store.TermAutumn, Start: date(2023, time.September, 1), End: date(2023, time.December, 29)},
+		{Year: 2023, Term: store.TermSpring, Start: date(2024, time.January, 15), End: date(2024, time.June, 30)},
+	}, nil)
+	admition := date(2024, time.January, 10)
+	tests := []struct {
+		day  time.Time
+		want uint8
+	}{
+		{date(2024, time.January, 10), 1},
+		{date(2024, time.March, 1), 1},
+		{date(2024, time.August, 31), 1},
+		{date(2024, time.September, 1), 2},
+		{date(2025, time.March, 1), 3},
+		{date(2025, time.September, 1), 4},
+	}
+
+	for _, tt := range tests {
+		if got := c.semesterOn(admition, tt.day); got != tt.want {
+			t.Errorf("semester on %s: expected %d, got %d", tt.day.Format("2006-01-02"), tt.want, got)
+		}
+	}
+
+	if term := c.semesterTerm(admition, 1); term != (calendarTerm{2023, store.TermSpring}) {
+		t.Errorf("expected the first semester in the spring of 2023/24, got %+v", term)
+	}
+	if term := c.semesterTerm(admition, 2); term != (calendarTerm{2024, store.TermAutumn}) {
+		t.Errorf("expected the second semester in the autumn of 2024/25, got %+v", term)
+	}
+}
+
+func TestGetStudentSemester(t *testing.T) {
+	f := newFixture(t)
+	organizationId := f.organizationId
+	admition := time.Now().AddDate(-1, 0, 0)
+	year := admition.Year()
+
+	if _, err := f.app.PostCalendarSemester(organizationId, year, store.TermSpring, date(year+1, time.February, 9), date(year+1, time.July, 1),
+		time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	holiday, err := f.app.PostCalendarPeriod(organizationId, store.PeriodHoliday, "Праздник весны и труда", date(year+1, time.May, 1), date(year+1, time.May, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := f.app.PostCalendarPeriod(organizationId, store.PeriodSession, "", date(year+1, time.June, 10), date(year+1, time.July, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostCalendarPeriod(organizationId, store.PeriodHoliday, "", date(year+1, time.January, 1), date(year+1, time.January, 8)); err != nil {
+		t.Fatal(err)
+	}
+
+	student, err := f.app.PostStudent("Иванов Иван Иванович", admition, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the student gets the calendar of the organization through the course
+	if _, err = f.app.PostStudyGroup(f.course(t, "Go"), student.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	semester, err := f.app.GetStudentSemester(student.Id, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if semester.Year != year || semester.Term != store.TermSpring || semester.Start.Format("2006-01-02") != date(year+1, time.February, 9).Format("2006-01-02") {
+		t.Fatalf("unexpected semester %+v", semester)
+	}
+	if len(semester.Holidays) != 1 || semester.Holidays[0].Id != holiday.Id || len(semester.Sessions) != 1 || semester.Sessions[0].Id != session.Id {
+		t.Fatalf("unexpected periods of the semester %+v", semester)
+	}
+
+	semester, err = f.app.GetStudentSemesterOn(student.Id, date(year+1, time.February, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if semester.Semester != 1 {
+		t.Fatalf("expected the first semester before the spring one starts, got %d", semester.Semester)
+	}
+
+	if _, err = f.app.GetStudentSemester(student.Id, 0); !errors.Is(err, ErrWrongSemester) {
+		t.Fatalf("expected ErrWrongSemester, got %v", err)
+	}
+}
+
+func TestPostCalendarValidation(t *testing.T) {
+	app := newTestApp()
+	id := uuid.NewV4()
+	start, end := date(2023, time.September, 1), date(2024, time.January, 31)
+	var no time.Time // without the enrollment window
+	late := end.AddDate(0, 0, 1)
+	tests := map[string]struct {
+		post func() error
+		want error
+	}{
+		"semester without organization": {func() error { _, err := app.PostCalendarSemester(uuid.Nil, 2023, 1, start, end, no, no); return err }, ErrEmptyId},
+		"semester wrong term":           {func() error { _, err := app.PostCalendarSemester(id, 2023, 3, start, end, no, no); return err }, ErrWrongTerm},
+		"semester wrong dates":          {func() error { _, err := app.PostCalendarSemester(id, 2023, 1, end, start, no, no); return err }, ErrWrongDates},
+		"semester half of enrollment":   {func() error { _, err := app.PostCalendarSemester(id, 2023, 1, start, end, start, no); return err }, ErrWrongDates},
+		"semester late enrollment":      {func() error { _, err := app.PostCalendarSemester(id, 2023, 1, start, end, start, late); return err }, ErrWrongDates},
+		"period without organization":   {func() error { _, err := app.PostCalendarPeriod(uuid.Nil, "holiday", "", start, end); return err }, ErrEmptyId},
+		"period wrong kind":             {func() error { _, err := app.PostCalendarPeriod(id, "vacation", "", start, end); return err }, ErrWrongPeriodKind},
+		"period without dates":          {func() error { _, err := app.PostCalendarPeriod(id, "session", "", time.Time{}, end); return err }, ErrWrongDates},
+	}
+
+	for name, tt := range tests {
+		if err := tt.post(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", name, tt.want, err)
+		}
+	}
+}
+
+func TestPostTrajectoryFutureSemester(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	courseId := f.course(t, "Go")
+
+	current := newCalendar(nil, nil).semesterOn(time.Now().AddDate(-1, 0, 0), time.Now())
+	if _, err := f.app.PostTrajectory(current+1, studentId, courseId); !errors.Is(err, ErrWrongSemester) {
+		t.Fatalf("expected ErrWrongSemester for the next semester, got %v", err)
+	}
+	if _, err := f.app.PostTrajectory(current, studentId, courseId); err != nil {
+		t.Fatal(err)
+	}
+}
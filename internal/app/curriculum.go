@@ -0,0 +1,276 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongChoose = errors.New("elective pool must choose at least one discipline")
+
+// Statuses of curriculum disciplines.
+const (
+	CurriculumTaken       = "taken"
+	CurriculumCurrent     = "current"
+	CurriculumPlanned     = "planned"
+	CurriculumOutstanding = "outstanding"
+)
+
+// Kinds of outstanding requirements.
+const (
+	RequirementDiscipline   = "discipline"
+	RequirementElectivePool = "electivePool"
+)
+
+// curriculumStatuses orders statuses from the most certain one, a discipline gets the first status any of its courses has.
+var curriculumStatuses = map[string]int{CurriculumTaken: 0, CurriculumCurrent: 1, CurriculumPlanned: 2}
+
+// PostElectivePool adds a pool of disciplines the student chooses from, the pool with the same title
+// in the educational program gets the new choose.
+func (app *App) PostElectivePool(educationalProgramId uuid.UUID, title string, choose uint8) (model.GetElectivePool, error) {
+	resp := model.GetElectivePool{Title: title, Choose: choose}
+	if educationalProgramId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if title == "" {
+		return resp, ErrEmptyTitle
+	}
+	if choose == 0 {
+		return resp, ErrWrongChoose
+	}
+
+	var err error
+	resp.Id, err = app.store.CreateElectivePool(store.ElectivePool{EducationalProgramId: educationalProgramId, Title: title, Choose: choose})
+	return resp, err
+}
+
+// PostCurriculumDiscipline places the discipline into the semester of the curriculum, the discipline is required
+// unless the elective pool is given. The discipline already in the curriculum is moved.
+func (app *App) PostCurriculumDiscipline(educationalProgramId uuid.UUID, disciplineId uuid.UUID, semester uint8, electivePoolId uuid.UUID) error {
+	if educationalProgramId == uuid.Nil || disciplineId == uuid.Nil {
+		return ErrEmptyId
+	}
+	if semester == 0 {
+		return ErrWrongSemester
+	}
+
+	return app.store.CreateCurriculumDiscipline(store.CurriculumDiscipline{
+		EducationalProgramId: educationalProgramId,
+		DisciplineId:         disciplineId,
+		Semester:             semester,
+		ElectivePoolId:       electivePoolId,
+	})
+}
+
+// GetCurriculum returns required disciplines of the educational program and its elective pools
+// with their disciplines, both ordered by semester.
+func (app *App) GetCurriculum(educationalProgramId uuid.UUID) (model.GetCurriculum, error) {
+	resp := model.GetCurriculum{EducationalProgramId: educationalProgramId}
+	if educationalProgramId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	educationalProgram, err := app.store.GetEducationalProgram(educationalProgramId)
+	if err != nil {
+		return resp, err
+	}
+	resp.EducationalProgram = educationalProgram.Title
+
+	required, pools, err := app.getCurriculum(educationalProgramId)
+	if err != nil {
+		return resp, err
+	}
+	resp.Required = required
+	resp.Pools = make([]model.GetElectivePool, 0, len(pools))
+	for _, pool := range pools {
+		resp.Pools = append(resp.Pools, pool.GetElectivePool)
+	}
+	return resp, nil
+}
+
+type curriculumPool struct {
+	model.GetElectivePool
+	semester uint8 // the earliest semester of the pool disciplines
+}
+
+func (app *App) getCurriculum(educationalProgramId uuid.UUID) ([]model.GetCurriculumDiscipline, []curriculumPool, error) {
+	electivePools, err := app.store.GetElectivePools(educationalProgramId)
+	if err != nil {
+		return nil, nil, err
+	}
+	pools := make([]curriculumPool, 0, len(electivePools))
+	index := make(map[uuid.UUID]int, len(electivePools))
+	for _, pool := range electivePools {
+		index[pool.Id] = len(pools)
+		pools = append(pools, curriculumPool{GetElectivePool: model.GetElectivePool{Id: pool.Id, Title: pool.Title, Choose: pool.Choose}})
+	}
+
+	disciplines, err := app.store.GetCurriculum(educationalProgramId)
+	if err != nil {
+		return nil, nil, err
+	}
+	required := make([]model.GetCurriculumDiscipline, 0, len(disciplines))
+	for _, curriculumDiscipline := range disciplines {
+		discipline, err := app.store.GetDiscipline(curriculumDiscipline.DisciplineId)
+		if err != nil {
+			return nil, nil, err
+		}
+		item := model.GetCurriculumDiscipline{Id: discipline.Id, Title: discipline.Title, Semester: curriculumDiscipline.Semester}
+		if curriculumDiscipline.ElectivePoolId == uuid.Nil {
+			required = append(required, item)
+			continue
+		}
+		pool := &pools[index[curriculumDiscipline.ElectivePoolId]]
+		if len(pool.Disciplines) == 0 || item.Semester < pool.semester {
+			pool.semester = item.Semester
+		}
+		pool.Disciplines = append(pool.Disciplines, item)
+	}
+	return required, pools, nil
+}
+
+// curriculumCourse is the course that covers a discipline of the curriculum.
+type curriculumCourse struct {
+	course   store.Course
+	status   string
+	semester uint8
+}
+
+// CheckCurriculum compares courses the student took (trajectories), takes (study groups) and plans with the curriculum
+// of the educational program, by default the current program of the student. A discipline is covered
+// by any of its courses, an elective pool by as many of its disciplines as it chooses. Disciplines covered after
+// the semester of the curriculum are marked late. The curriculum is complete when nothing is outstanding.
+func (app *App) CheckCurriculum(studentId uuid.UUID, educationalProgramId uuid.UUID, planned []model.PostPlannedCourse) (model.GetCurriculumCheck, error) {
+	resp := model.GetCurriculumCheck{
+		StudentId:   studentId,
+		Required:    make([]model.GetCurriculumStatus, 0),
+		Pools:       make([]model.GetElectivePoolStatus, 0),
+		Outstanding: make([]model.GetOutstandingRequirement, 0),
+	}
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	for _, course := range planned {
+		if course.CourseId == uuid.Nil {
+			return resp, ErrEmptyId
+		}
+		if course.Semester == 0 {
+			return resp, ErrWrongSemester
+		}
+	}
+
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+	if educationalProgramId == uuid.Nil {
+		if educationalProgramId, err = app.store.GetStudentEducationalProgram(studentId); err != nil {
+			return resp, err
+		}
+	}
+	educationalProgram, err := app.store.GetEducationalProgram(educationalProgramId)
+	if err != nil {
+		return resp, err
+	}
+	resp.EducationalProgramId, resp.EducationalProgram = educationalProgram.Id, educationalProgram.Title
+
+	covered, err := app.getCurriculumCourses(student, planned)
+	if err != nil {
+		return resp, err
+	}
+	required, pools, err := app.getCurriculum(educationalProgramId)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, discipline := range required {
+		status := curriculumStatus(discipline, covered)
+		resp.Required = append(resp.Required, status)
+		if status.Status == CurriculumOutstanding {
+			resp.Outstanding = append(resp.Outstanding, model.GetOutstandingRequirement{
+				Kind: RequirementDiscipline, Id: discipline.Id, Title: discipline.Title, Semester: discipline.Semester, Count: 1})
+		}
+	}
+	for _, pool := range pools {
+		poolStatus := model.GetElectivePoolStatus{Id: pool.Id, Title: pool.Title, Choose: pool.Choose, Disciplines: make([]model.GetCurriculumStatus, 0)}
+		for _, discipline := range pool.Disciplines {
+			status := curriculumStatus(discipline, covered)
+			if status.Status != CurriculumOutstanding {
+				poolStatus.Chosen++
+			}
+			poolStatus.Disciplines = append(poolStatus.Disciplines, status)
+		}
+		poolStatus.Outstanding = max(int(pool.Choose)-poolStatus.Chosen, 0)
+		resp.Pools = append(resp.Pools, poolStatus)
+		if poolStatus.Outstanding > 0 {
+			resp.Outstanding = append(resp.Outstanding, model.GetOutstandingRequirement{
+				Kind: RequirementElectivePool, Id: pool.Id, Title: pool.Title, Semester: pool.semester, Count: poolStatus.Outstanding})
+		}
+	}
+	resp.Complete = len(resp.Outstanding) == 0
+	return resp, nil
+}
+
+// getCurriculumCourses returns the course covering every discipline the student took, takes or plans.
+func (app *App) getCurriculumCourses(student store.Student, planned []model.PostPlannedCourse) (map[uuid.UUID]curriculumCourse, error) {
+	covered := make(map[uuid.UUID]curriculumCourse)
+	cover := func(course store.Course, status string, semester uint8) {
+		existing, ok := covered[course.DisciplineId]
+		if !ok || curriculumStatuses[status] < curriculumStatuses[existing.status] ||
+			status == existing.status && semester < existing.semester {
+			covered[course.DisciplineId] = curriculumCourse{course: course, status: status, semester: semester}
+		}
+	}
+
+	trajectories, err := app.store.GetStudentTrajectories(student.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, trajectory := range trajectories {
+		if trajectory.Result != store.TrajectoryPassed {
+			continue
+		}
+		course, err := app.store.GetCourse(trajectory.CourseId)
+		if err != nil {
+			return nil, err
+		}
+		cover(course, CurriculumTaken, trajectory.Semester)
+	}
+
+	groups, err := app.store.GetStudyGroupCourses(student.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 {
+		current, err := app.getStudentSemester(student.Id, student.Admition, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		for _, course := range groups {
+			cover(course, CurriculumCurrent, current)
+		}
+	}
+
+	for _, plannedCourse := range planned {
+		course, err := app.store.GetCourse(plannedCourse.CourseId)
+		if err != nil {
+			return nil, err
+		}
+		cover(course, CurriculumPlanned, plannedCourse.Semester)
+	}
+	return covered, nil
+}
+
+func curriculumStatus(discipline model.GetCurriculumDiscipline, covered map[uuid.UUID]curriculumCourse) model.GetCurriculumStatus {
+	status := model.GetCurriculumStatus{DisciplineId: discipline.Id, Discipline: discipline.Title, Semester: discipline.Semester,
+		Status: CurriculumOutstanding}
+	if course, ok := covered[discipline.Id]; ok {
+		status.Status, status.CourseId, status.Course, status.CourseSemester = course.status, course.course.Id, course.course.Title, course.semester
+		status.Late = course.semester > discipline.Semester
+	}
+	return status
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestCurriculum(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	discipline, err := f.app.store.GetDiscipline(f.disciplineId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	programId := discipline.EducationalProgramId
+
+	newDiscipline := func(title string) uuid.UUID {
+		t.Helper()
+		discipline, err := f.app.PostDiscipline(title, "", programId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return discipline.Id
+	}
+	newCourse := func(title string, disciplineId uuid.UUID) uuid.UUID {
+		t.Helper()
+		course, err := f.app.PostCourse(title, "", nil, disciplineId, 0, 0, false, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return course.Id
+	}
+	databasesId, englishId, germanId, frenchId := newDiscipline("Базы данных"), newDiscipline("Английский"),
+		newDiscipline("Немецкий"), newDiscipline("Французский")
+	programmingId := f.course(t, "Go")
+	sqlId := newCourse("SQL", databasesId)
+	englishCourseId := newCourse("English B2", englishId)
+	germanCourseId := newCourse("Deutsch A2", germanId)
+
+	if _, err = f.app.PostElectivePool(programId, "Языки", 0); !errors.Is(err, ErrWrongChoose) {
+		t.Fatalf("expected ErrWrongChoose, got %v", err)
+	}
+	if err = f.app.PostCurriculumDiscipline(programId, databasesId, 0, uuid.Nil); !errors.Is(err, ErrWrongSemester) {
+		t.Fatalf("expected ErrWrongSemester, got %v", err)
+	}
+	pool, err := f.app.PostElectivePool(programId, "Языки", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []struct {
+		id       uuid.UUID
+		semester uint8
+		pool     uuid.UUID
+	}{{f.disciplineId, 1, uuid.Nil}, {databasesId, 2, uuid.Nil}, {englishId, 3, pool.Id}, {germanId, 3, pool.Id}, {frenchId, 4, pool.Id}} {
+		if err = f.app.PostCurriculumDiscipline(programId, d.id, d.semester, d.pool); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	curriculum, err := f.app.GetCurriculum(programId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRequired := []model.GetCurriculumDiscipline{{Id: f.disciplineId, Title: "Программирование", Semester: 1},
+		{Id: databasesId, Title: "Базы данных", Semester: 2}}
+	if !reflect.DeepEqual(curriculum.Required, wantRequired) {
+		t.Fatalf("expected required disciplines %+v, got %+v", wantRequired, curriculum.Required)
+	}
+	if len(curriculum.Pools) != 1 || curriculum.Pools[0].Choose != 2 || len(curriculum.Pools[0].Disciplines) != 3 {
+		t.Fatalf("expected the pool of 3 disciplines, got %+v", curriculum.Pools)
+	}
+
+	// the student has no courses and so no program yet
+	if _, err = f.app.CheckCurriculum(studentId, uuid.Nil, nil); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without the program, got %v", err)
+	}
+	f.passed(t, 3, studentId, programmingId)
+
+	check, err := f.app.CheckCurriculum(studentId, uuid.Nil, []model.PostPlannedCourse{{CourseId: englishCourseId, Semester: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.EducationalProgramId != programId || check.Complete {
+		t.Fatalf("expected incomplete curriculum of the program, got %+v", check)
+	}
+	if want := (model.GetCurriculumStatus{DisciplineId: f.disciplineId, Discipline: "Программирование", Semester: 1,
+		Status: CurriculumTaken, CourseId: programmingId, Course: "Go", CourseSemester: 3, Late: true}); check.Required[0] != want {
+		t.Fatalf("expected the late taken discipline %+v, got %+v", want, check.Required[0])
+	}
+	wantOutstanding := []model.GetOutstandingRequirement{
+		{Kind: RequirementDiscipline, Id: databasesId, Title: "Базы данных", Semester: 2, Count: 1},
+		{Kind: RequirementElectivePool, Id: pool.Id, Title: "Языки", Semester: 3, Count: 1},
+	}
+	if !reflect.DeepEqual(check.Outstanding, wantOutstanding) {
+		t.Fatalf("expected outstanding %+v, got %+v", wantOutstanding, check.Outstanding)
+	}
+	if check.Pools[0].Chosen != 1 || check.Pools[0].Outstanding != 1 {
+		t.Fatalf("expected one of two pool disciplines chosen, got %+v", check.Pools[0])
+	}
+
+	check, err = f.app.CheckCurriculum(studentId, programId, []model.PostPlannedCourse{
+		{CourseId: englishCourseId, Semester: 3}, {CourseId: germanCourseId, Semester: 4}, {CourseId: sqlId, Semester: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check.Complete || len(check.Outstanding) != 0 || check.Required[1].Status != CurriculumPlanned {
+		t.Fatalf("expected complete curriculum, got %+v", check)
+	}
+}
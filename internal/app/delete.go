@@ -0,0 +1,179 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrHasDependents = errors.New("entity has dependent rows")
+
+// DependentsError lists rows that would be removed by ON DELETE CASCADE foreign keys
+// and the restrict ones that keep the row from being deleted even by cascade.
+type DependentsError struct {
+	Dependents []model.GetDependent
+}
+
+func (e *DependentsError) Error() string {
+	var parts []string
+	for _, dependent := range e.Dependents {
+		parts = append(parts, fmt.Sprint(dependent.Table, ": ", dependent.Count))
+	}
+	return ErrHasDependents.Error() + " (" + strings.Join(parts, ", ") + ")"
+}
+
+func (e *DependentsError) Unwrap() error {
+	return ErrHasDependents
+}
+
+// deleteById removes the row and, when cascade is allowed, everything that references it.
+// Without cascade the deletion is refused with DependentsError if any dependent row exists, with restrict rows it is always refused.
+func (app *App) deleteById(table string, id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	resp := model.GetDeleteReport{Id: id}
+	if id == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	err := app.store.Transaction(func(tx store.Store) error {
+		dependents, err := tx.GetDependents(table, id)
+		if err != nil {
+			return err
+		}
+		restricted := false
+		for _, dependent := range dependents {
+			resp.Cascade = append(resp.Cascade, model.GetDependent{Table: dependent.Table, Count: dependent.Count, Restrict: dependent.Restrict})
+			restricted = restricted || dependent.Restrict
+		}
+		if restricted || len(resp.Cascade) > 0 && !cascade {
+			return &DependentsError{Dependents: resp.Cascade}
+		}
+
+		return tx.Delete(table, id)
+	})
+	return resp, err
+}
+
+func (app *App) DeleteKnowledge(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableKnowledge, id, cascade)
+}
+
+func (app *App) DeleteTechnology(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableTechnologies, id, cascade)
+}
+
+func (app *App) DeleteCompetency(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableCompetencies, id, cascade)
+}
+
+func (app *App) DeleteProfession(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableProfessions, id, cascade)
+}
+
+func (app *App) DeleteProject(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableProjects, id, cascade)
+}
+
+func (app *App) DeleteOrganization(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableOrganizations, id, cascade)
+}
+
+func (app *App) DeleteEducationalProgram(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableEducationalPrograms, id, cascade)
+}
+
+func (app *App) DeleteDiscipline(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableDisciplines, id, cascade)
+}
+
+func (app *App) DeleteCourse(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableCourses, id, cascade)
+}
+
+func (app *App) DeletePortfolio(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TablePortfolios, id, cascade)
+}
+
+// DeleteStudent frees the seats of the student, they go to the waitlists of the courses.
+// The student is deleted only together with the promotion from the waitlists.
+func (app *App) DeleteStudent(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	var report model.GetDeleteReport
+	err := app.store.Transaction(func(tx store.Store) error {
+		courses, err := tx.GetStudyGroupCourses(id)
+		if err != nil {
+			return err
+		}
+		if report, err = (&App{store: tx}).deleteById(store.TableStudents, id, cascade); err != nil {
+			return err
+		}
+		for _, course := range courses {
+			if err = fillStudyGroup(tx, course.Id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+func (app *App) DeleteTeacher(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableTeachers, id, cascade)
+}
+
+func (app *App) DeleteSkill(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableSkills, id, cascade)
+}
+
+// DeleteTrajectory deletes the trajectory, the retakes of the first attempt are deleted with it only by cascade.
+func (app *App) DeleteTrajectory(id uuid.UUID, cascade bool) (model.GetDeleteReport, error) {
+	return app.deleteById(store.TableTrajectories, id, cascade)
+}
+
+// checkIds fails with ErrEmptyId when any of the ids of a link is empty.
+func checkIds(ids ...uuid.UUID) error {
+	if slices.Contains(ids, uuid.Nil) {
+		return ErrEmptyId
+	}
+	return nil
+}
+
+func (app *App) DeleteKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
+	if err := checkIds(knowledgeId, competencyId); err != nil {
+		return err
+	}
+	return app.store.DeleteKnowledgeCompetency(knowledgeId, competencyId)
+}
+
+func (app *App) DeleteCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID) error {
+	if err := checkIds(competencyId, professionId); err != nil {
+		return err
+	}
+	return app.store.DeleteCompetencyProfession(competencyId, professionId)
+}
+
+func (app *App) DeleteCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID) error {
+	if err := checkIds(courseId, competencyId); err != nil {
+		return err
+	}
+	return app.store.DeleteCourseCompetency(courseId, competencyId)
+}
+
+// DeleteProjectPortfolio removes project from the portfolio together with competencies the student got in it.
+func (app *App) DeleteProjectPortfolio(projectId uuid.UUID, portfolioId uuid.UUID) error {
+	if err := checkIds(projectId, portfolioId); err != nil {
+		return err
+	}
+	return app.store.DeleteProjectPortfolio(projectId, portfolioId)
+}
+
+func (app *App) DeleteProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) error {
+	if err := checkIds(projectId, portfolioId, competencyId); err != nil {
+		return err
+	}
+	return app.store.DeleteProjectPortfolioCompetency(projectId, portfolioId, competencyId)
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongEnrollmentStatus = errors.New("enrollment status must be active, academicLeave, expelled or graduated")
+var ErrNotEnrolled = errors.New("student has no open enrollment")
+var ErrEnrollmentTransition = errors.New("enrollment status can not be changed this way")
+
+// dateOf drops the time of the day, enrollments and leaves keep dates only.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// EnrollStudent enrolls the student in the educational program from the start, today when it is zero.
+// The open enrollment of the student is closed as transferred on the start, an academic leave ends there too.
+func (app *App) EnrollStudent(studentId uuid.UUID, educationalProgramId uuid.UUID, start time.Time) (model.GetEnrollment, error) {
+	var resp model.GetEnrollment
+	if studentId == uuid.Nil || educationalProgramId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if start.IsZero() {
+		start = time.Now()
+	}
+	start = dateOf(start)
+
+	enrollment := store.Enrollment{StudentId: studentId, EducationalProgramId: educationalProgramId, Start: start,
+		Status: store.EnrollmentActive}
+	err := app.store.Transaction(func(tx store.Store) error {
+		open, leave, err := openEnrollment(tx, studentId)
+		if err != nil && !errors.Is(err, ErrNotEnrolled) {
+			return err
+		}
+		if err == nil {
+			if open.EducationalProgramId == educationalProgramId {
+				return fmt.Errorf("%w: the student already studies in the educational program", ErrEnrollmentTransition)
+			}
+			if start.Before(open.Start) {
+				return ErrWrongDates
+			}
+			if err = closeEnrollment(tx, open, leave, store.EnrollmentTransferred, start); err != nil {
+				return err
+			}
+		}
+
+		enrollment.Id, err = tx.CreateEnrollment(enrollment)
+		return err
+	})
+	if err != nil {
+		return resp, err
+	}
+	return app.getEnrollment(enrollment, nil)
+}
+
+// ChangeEnrollmentStatus sends the student on academic leave, returns the student from it, expels the student
+// or marks the graduation on the day, today when it is zero. Only the open enrollment changes.
+func (app *App) ChangeEnrollmentStatus(studentId uuid.UUID, status string, day time.Time) (model.GetEnrollment, error) {
+	var resp model.GetEnrollment
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	switch status {
+	case store.EnrollmentActive, store.EnrollmentLeave, store.EnrollmentExpelled, store.EnrollmentGraduated:
+	default:
+		return resp, ErrWrongEnrollmentStatus
+	}
+	if day.IsZero() {
+		day = time.Now()
+	}
+	day = dateOf(day)
+
+	var enrollment store.Enrollment
+	err := app.store.Transaction(func(tx store.Store) error {
+		if _, err := tx.GetStudent(studentId); err != nil {
+			return err
+		}
+		open, leave, err := openEnrollment(tx, studentId)
+		if err != nil {
+			return err
+		}
+		if day.Before(open.Start) || leave.Id != uuid.Nil && day.Before(leave.Start) {
+			return ErrWrongDates
+		}
+
+		enrollment = open
+		switch {
+		case status == store.EnrollmentLeave && open.Status == store.EnrollmentActive:
+			if _, err = tx.CreateAcademicLeave(store.AcademicLeave{EnrollmentId: open.Id, Start: day}); err != nil {
+				return err
+			}
+			enrollment.Status = status
+			return tx.UpdateEnrollment(enrollment)
+		case status == store.EnrollmentActive && open.Status == store.EnrollmentLeave:
+			leave.End = day
+			if err = tx.UpdateAcademicLeave(leave); err != nil {
+				return err
+			}
+			enrollment.Status = status
+			return tx.UpdateEnrollment(enrollment)
+		case status == store.EnrollmentExpelled || status == store.EnrollmentGraduated:
+			enrollment.Status, enrollment.End = status, day
+			return closeEnrollment(tx, open, leave, status, day)
+		}
+		return fmt.Errorf("%w: from %s to %s", ErrEnrollmentTransition, open.Status, status)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	leaves, err := app.store.GetStudentAcademicLeaves(studentId)
+	if err != nil {
+		return resp, err
+	}
+	return app.getEnrollment(enrollment, leaves)
+}
+
+// openEnrollment returns the open enrollment of the student and its open academic leave, the leave id is uuid.Nil
+// when the student is not on leave.
+func openEnrollment(tx store.Store, studentId uuid.UUID) (store.Enrollment, store.AcademicLeave, error) {
+	var leave store.AcademicLeave
+	enrollments, err := tx.GetStudentEnrollments(studentId)
+	if err != nil {
+		return store.Enrollment{}, leave, err
+	}
+	if len(enrollments) == 0 || !enrollments[len(enrollments)-1].End.IsZero() {
+		return store.Enrollment{}, leave, ErrNotEnrolled
+	}
+	open := enrollments[len(enrollments)-1]
+
+	leaves, err := tx.GetStudentAcademicLeaves(studentId)
+	if err != nil {
+		return open, leave, err
+	}
+	for _, l := range leaves {
+		if l.EnrollmentId == open.Id && l.End.IsZero() {
+			leave = l
+		}
+	}
+	return open, leave, nil
+}
+
+// closeEnrollment ends the enrollment and its open academic leave on the day.
+func closeEnrollment(tx store.Store, enrollment store.Enrollment, leave store.AcademicLeave, status string, day time.Time) error {
+	if leave.Id != uuid.Nil {
+		leave.End = day
+		if err := tx.UpdateAcademicLeave(leave); err != nil {
+			return err
+		}
+	}
+	enrollment.Status, enrollment.End = status, day
+	return tx.UpdateEnrollment(enrollment)
+}
+
+// getStudentProgram fills the current educational program of the student and the status of the enrollment,
+// students who are not enrolled get the program most of their courses belong to.
+func (app *App) getStudentProgram(student *model.GetStudent) error {
+	educationalProgramId, err := app.store.GetStudentEducationalProgram(student.Id)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	educationalProgram, err := app.store.GetEducationalProgram(educationalProgramId)
+	if err != nil {
+		return err
+	}
+	student.EducationalProgramId, student.EducationalProgram = educationalProgram.Id, educationalProgram.Title
+
+	enrollments, err := app.store.GetStudentEnrollments(student.Id)
+	if len(enrollments) > 0 {
+		student.EnrollmentStatus = enrollments[len(enrollments)-1].Status
+	}
+	return err
+}
+
+// GetStudentEnrollments returns the history of the student enrollments with academic leaves ordered by start.
+func (app *App) GetStudentEnrollments(studentId uuid.UUID) (model.GetEnrollments, error) {
+	resp := model.GetEnrollments{StudentId: studentId, Enrollments: []model.GetEnrollment{}}
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if _, err := app.store.GetStudent(studentId); err != nil {
+		return resp, err
+	}
+
+	enrollments, err := app.store.GetStudentEnrollments(studentId)
+	if err != nil {
+		return resp, err
+	}
+	leaves, err := app.store.GetStudentAcademicLeaves(studentId)
+	if err != nil {
+		return resp, err
+	}
+	for _, enrollment := range enrollments {
+		item, err := app.getEnrollment(enrollment, leaves)
+		if err != nil {
+			return resp, err
+		}
+		resp.Enrollments = append(resp.Enrollments, item)
+	}
+	return resp, nil
+}
+
+// getEnrollment describes the enrollment with the leaves taken during it.
+func (app *App) getEnrollment(enrollment store.Enrollment, leaves []store.AcademicLeave) (model.GetEnrollment, error) {
+	resp := model.GetEnrollment{
+		Id:                   enrollment.Id,
+		EducationalProgramId: enrollment.EducationalProgramId,
+		Start:                model.JsonAdmitionDate(enrollment.Start),
+		End:                  optionalDate(enrollment.End),
+		Status:               enrollment.Status,
+	}
+	for _, leave := range leaves {
+		if leave.EnrollmentId == enrollment.Id {
+			resp.Leaves = append(resp.Leaves, model.GetAcademicLeave{Id: leave.Id, Start: model.JsonAdmitionDate(leave.Start),
+				End: optionalDate(leave.End)})
+		}
+	}
+
+	educationalProgram, err := app.store.GetEducationalProgram(enrollment.EducationalProgramId)
+	resp.EducationalProgram = educationalProgram.Title
+	return resp, err
+}
+
+func optionalDate(t time.Time) *model.JsonAdmitionDate {
+	if t.IsZero() {
+		return nil
+	}
+	date := model.JsonAdmitionDate(t)
+	return &date
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestSemesterSkipsAcademicLeaves(t *testing.T) {
+	c := studentCalendar{calendar: newCalendar(nil, nil), admition: date(2022, time.September, 1),
+		leaves: []store.AcademicLeave{{Start: date(2023, time.January, 20), End: date(2024, time.January, 10)}}}
+	tests := []struct {
+		day  time.Time
+		want uint8
+	}{
+		{date(2022, time.December, 1), 1},
+		// spring and autumn 2023 start during the leave
+		{date(2023, time.March, 1), 1},
+		{date(2023, time.October, 1), 1},
+		{date(2024, time.March, 1), 2},
+		{date(2024, time.September, 1), 3},
+	}
+	for _, tt := range tests {
+		if got := c.semester(tt.day); got != tt.want {
+			t.Errorf("semester on %s: expected %d, got %d", tt.day.Format("2006-01-02"), tt.want, got)
+		}
+	}
+
+	if term := c.term(2, date(2024, time.March, 1)); term != (calendarTerm{2023, store.TermSpring}) {
+		t.Errorf("expected the second semester in spring 2024, got %+v", term)
+	}
+	// the open leave lasts till the day
+	c.leaves[0].End = time.Time{}
+	if got := c.semester(date(2024, time.March, 1)); got != 1 {
+		t.Errorf("expected the first semester during the open leave, got %d", got)
+	}
+}
+
+func TestEnrollment(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	discipline, err := f.app.store.GetDiscipline(f.disciplineId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	programId := discipline.EducationalProgramId
+	other, err := f.app.PostEducationalProgram("Прикладная математика", "", f.organizationId, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentLeave, time.Time{}); !errors.Is(err, ErrNotEnrolled) {
+		t.Fatalf("expected ErrNotEnrolled, got %v", err)
+	}
+	start := time.Now().AddDate(-2, 0, 0)
+	if _, err = f.app.EnrollStudent(studentId, programId, start); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.EnrollStudent(studentId, programId, time.Time{}); !errors.Is(err, ErrEnrollmentTransition) {
+		t.Fatalf("expected ErrEnrollmentTransition for the same program, got %v", err)
+	}
+
+	// semesters are counted from the enrollment, not from the admition a year ago
+	student, err := f.app.GetStudentById(studentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := newCalendar(nil, nil).semesterOn(start, time.Now()); student.Semester != want || student.EducationalProgramId != programId ||
+		student.EnrollmentStatus != store.EnrollmentActive {
+		t.Fatalf("expected semester %d of the active enrollment, got %+v", want, student)
+	}
+
+	transfer := time.Now().AddDate(-1, 0, 0)
+	if _, err = f.app.EnrollStudent(studentId, other.Id, start.AddDate(0, 0, -1)); !errors.Is(err, ErrWrongDates) {
+		t.Fatalf("expected ErrWrongDates for the transfer before the enrollment, got %v", err)
+	}
+	enrollment, err := f.app.EnrollStudent(studentId, other.Id, transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enrollment.EducationalProgram != "Прикладная математика" || enrollment.Status != store.EnrollmentActive || enrollment.End != nil {
+		t.Fatalf("unexpected enrollment %+v", enrollment)
+	}
+
+	if _, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentTransferred, time.Time{}); !errors.Is(err, ErrWrongEnrollmentStatus) {
+		t.Fatalf("expected ErrWrongEnrollmentStatus, got %v", err)
+	}
+	if _, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentActive, time.Time{}); !errors.Is(err, ErrEnrollmentTransition) {
+		t.Fatalf("expected ErrEnrollmentTransition for the active student, got %v", err)
+	}
+	if enrollment, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentLeave, time.Now().AddDate(0, -6, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if enrollment.Status != store.EnrollmentLeave || len(enrollment.Leaves) != 1 || enrollment.Leaves[0].End != nil {
+		t.Fatalf("expected the open leave, got %+v", enrollment)
+	}
+	if enrollment, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentGraduated, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if enrollment.Status != store.EnrollmentGraduated || enrollment.End == nil || enrollment.Leaves[0].End == nil {
+		t.Fatalf("expected the graduation to close the enrollment and the leave, got %+v", enrollment)
+	}
+	if _, err = f.app.ChangeEnrollmentStatus(studentId, store.EnrollmentExpelled, time.Time{}); !errors.Is(err, ErrNotEnrolled) {
+		t.Fatalf("expected ErrNotEnrolled after the graduation, got %v", err)
+	}
+
+	history, err := f.app.GetStudentEnrollments(studentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Enrollments) != 2 || history.Enrollments[0].Status != store.EnrollmentTransferred ||
+		history.Enrollments[0].EducationalProgramId != programId || history.Enrollments[1].Status != store.EnrollmentGraduated {
+		t.Fatalf("unexpected history %+v", history.Enrollments)
+	}
+	if student, err = f.app.GetStudentById(studentId); err != nil {
+		t.Fatal(err)
+	}
+	if student.EducationalProgramId != other.Id || student.EnrollmentStatus != store.EnrollmentGraduated {
+		t.Fatalf("expected the graduate of the second program, got %+v", student)
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// Ways a student can obtain a competency.
+const (
+	CoverageByPortfolioProject = store.SourcePortfolioProject
+	CoverageByTrajectoryCourse = store.SourceTrajectoryCourse
+	CoverageByStudyGroupCourse = store.SourceStudyGroupCourse
+)
+
+var ErrWrongLevel = errors.New("proficiency level must be aware, basic, intermediate or advanced")
+
+// proficiencyLevels names levels of competency links from store.LevelAware up.
+var proficiencyLevels = []string{"aware", "basic", "intermediate", "advanced"}
+
+// parseLevel returns the level by name, links without a level are basic.
+func parseLevel(name string) (uint8, error) {
+	if name == "" {
+		return store.LevelBasic, nil
+	}
+	i := slices.Index(proficiencyLevels, name)
+	if i < 0 {
+		return 0, ErrWrongLevel
+	}
+	return store.LevelAware + uint8(i), nil
+}
+
+// levelName returns the name of the level, an empty one for 0.
+func levelName(level uint8) string {
+	if level < store.LevelAware || level > store.LevelAdvanced {
+		return ""
+	}
+	return proficiencyLevels[level-store.LevelAware]
+}
+
+// CompetencyGap compares competencies required by the profession with the ones the student already has.
+// A competency is covered when a project or a course gives it at the level the profession requires or higher.
+func (app *App) CompetencyGap(studentId uuid.UUID, professionId uuid.UUID) (model.GetCompetencyGap, error) {
+	var resp model.GetCompetencyGap
+	if studentId == uuid.Nil || professionId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	student, err := app.GetStudentById(studentId)
+	if err != nil {
+		return resp, err
+	}
+
+	profession, err := app.GetProfessionById(professionId)
+	if err != nil {
+		return resp, err
+	}
+	resp.StudentId = student.Id
+	resp.ProfessionId = profession.Id
+	resp.Profession = profession.Title
+
+	required, err := app.getRequiredCompetencies(professionId)
+	if err != nil {
+		return resp, err
+	}
+
+	sources, err := app.getCompetencySources(studentId, student.Portfolio.Id)
+	if err != nil {
+		return resp, err
+	}
+
+	covered := 0
+	resp.Competencies = make([]model.GetCompetencyCoverage, 0, len(required))
+	for _, competency := range required {
+		achieved := achievedLevel(sources[competency.id])
+		coverage := model.GetCompetencyCoverage{
+			Id:            competency.id,
+			Title:         competency.title,
+			Level:         levelName(competency.level),
+			AchievedLevel: levelName(achieved),
+			Covered:       achieved >= competency.level,
+		}
+		for _, source := range sources[competency.id] {
+			if source.Level >= competency.level {
+				coverage.CoveredBy = append(coverage.CoveredBy, model.GetCoverageSource{
+					Kind:  source.Kind,
+					Id:    source.Id,
+					Title: source.Title,
+					Level: levelName(source.Level),
+				})
+			}
+		}
+		if coverage.Knowledge, err = app.getKnowledgeByCompetency(competency.id); err != nil {
+			return resp, err
+		}
+		if coverage.Covered {
+			covered++
+		}
+
+		resp.Competencies = append(resp.Competencies, coverage)
+	}
+
+	resp.Coverage = coveragePercent(covered, len(required))
+	return resp, nil
+}
+
+// getCompetencySources returns projects and courses through which the student got each competency.
+func (app *App) getCompetencySources(studentId uuid.UUID, portfolioId uuid.UUID) (map[uuid.UUID][]store.CompetencySource, error) {
+	found, err := app.store.GetCompetencySources(studentId, portfolioId)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[uuid.UUID][]store.CompetencySource)
+	for _, source := range found {
+		sources[source.CompetencyId] = append(sources[source.CompetencyId], source)
+	}
+
+	return sources, nil
+}
+
+// achievedLevel returns the highest level the sources give the competency at, 0 without sources.
+func achievedLevel(sources []store.CompetencySource) uint8 {
+	var level uint8
+	for _, source := range sources {
+		level = max(level, source.Level)
+	}
+	return level
+}
+
+func coveragePercent(covered int, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+
+	return math.Round(float64(covered)/float64(total)*10000) / 100
+}
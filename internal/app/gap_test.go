@@ -0,0 +1,161 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestCoveragePercent(t *testing.T) {
+	tests := []struct {
+		covered, total int
+		want           float64
+	}{
+		{0, 0, 100},
+		{0, 4, 0},
+		{1, 3, 33.33},
+		{2, 3, 66.67},
+		{3, 3, 100},
+	}
+
+	for _, tt := range tests {
+		if got := coveragePercent(tt.covered, tt.total); got != tt.want {
+			t.Errorf("%d of %d: expected %v, got %v", tt.covered, tt.total, tt.want, got)
+		}
+	}
+}
+
+func TestCompetencyGap(t *testing.T) {
+	f := newFixture(t)
+	covered := f.competency(t, "covered", true)
+	missing := f.competency(t, "missing", true)
+	knowledge, err := f.app.PostKnowledge("SQL", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.app.PostKnowledgeCompetency(knowledge.Id, missing); err != nil {
+		t.Fatal(err)
+	}
+
+	studentId, _ := f.student(t)
+	current := f.course(t, "current", covered)
+	if _, err = f.app.PostStudyGroup(current, studentId); err != nil {
+		t.Fatal(err)
+	}
+
+	gap, err := f.app.CompetencyGap(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []model.GetCompetencyCoverage{
+		{
+			Id:            covered,
+			Title:         "covered",
+			Level:         "basic",
+			AchievedLevel: "basic",
+			Covered:       true,
+			CoveredBy:     []model.GetCoverageSource{{Kind: CoverageByStudyGroupCourse, Id: current, Title: "current", Level: "basic"}},
+		},
+		{Id: missing, Title: "missing", Level: "basic", Knowledge: []string{"SQL"}},
+	}
+	if !reflect.DeepEqual(gap.Competencies, want) {
+		t.Fatalf("expected %+v, got %+v", want, gap.Competencies)
+	}
+	if gap.Coverage != 50 || gap.Profession != "Backend-разработчик" {
+		t.Fatalf("unexpected gap %+v", gap)
+	}
+}
+
+func TestCompetencyGapLevels(t *testing.T) {
+	f := newFixture(t)
+	sql := f.competency(t, "SQL", false)
+	if err := f.app.PostCompetencyProfession(sql, f.professionId, "advanced"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.app.PostCompetencyProfession(f.competency(t, "Git", false), f.professionId, "expert"); !errors.Is(err, ErrWrongLevel) {
+		t.Fatalf("expected ErrWrongLevel, got %v", err)
+	}
+
+	studentId, portfolioId := f.student(t)
+	basics := f.course(t, "SQL basics", sql)
+	f.passed(t, 1, studentId, basics)
+	for title, level := range map[string]string{"Intermediate SQL": "intermediate", "Advanced SQL": "advanced"} {
+		if err := f.app.PostCourseCompetency(f.course(t, title), sql, level); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the basic course does not cover the advanced level
+	gap, err := f.app.CompetencyGap(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []model.GetCompetencyCoverage{{Id: sql, Title: "SQL", Level: "advanced", AchievedLevel: "basic"}}
+	if !reflect.DeepEqual(gap.Competencies, want) || gap.Coverage != 0 {
+		t.Fatalf("expected %+v, got %+v", want, gap)
+	}
+
+	// only courses giving the required level close the gap
+	plan, err := f.app.GetStudentPlan(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Semesters) != 1 || len(plan.Semesters[0].Courses) != 1 || plan.Semesters[0].Courses[0].Title != "Advanced SQL" {
+		t.Fatalf("expected the plan with the advanced course only, got %+v", plan)
+	}
+	recommendations, err := f.app.RecommendCourses(studentId, f.professionId, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendations.Courses) != 1 || recommendations.Courses[0].Title != "Advanced SQL" {
+		t.Fatalf("expected the advanced course to be recommended only, got %+v", recommendations.Courses)
+	}
+
+	project, err := f.app.PostProject("Хранилище данных", "", "", "", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostProjectPortolio(project.Id, portfolioId, "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.app.PostProjectPortfolioCompetency(project.Id, portfolioId, sql, "advanced"); err != nil {
+		t.Fatal(err)
+	}
+	if gap, err = f.app.CompetencyGap(studentId, f.professionId); err != nil {
+		t.Fatal(err)
+	}
+	covered := gap.Competencies[0]
+	if gap.Coverage != 100 || covered.AchievedLevel != "advanced" || len(covered.CoveredBy) != 1 || covered.CoveredBy[0].Id != project.Id {
+		t.Fatalf("expected the project to cover the competency, got %+v", gap)
+	}
+
+	// the levels of the links change without relinking
+	if _, err = f.app.UpdateProjectPortfolioCompetency(project.Id, portfolioId, sql, "expert"); !errors.Is(err, ErrWrongLevel) {
+		t.Fatalf("expected ErrWrongLevel, got %v", err)
+	}
+	if _, err = f.app.UpdateCourseCompetency(f.course(t, "Git basics"), sql, "basic"); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing link, got %v", err)
+	}
+	updated, err := f.app.UpdateProjectPortfolioCompetency(project.Id, portfolioId, sql, "")
+	if err != nil || updated.Level != "basic" {
+		t.Fatalf("expected the project to confirm the basic level, got %+v, %v", updated, err)
+	}
+	if gap, err = f.app.CompetencyGap(studentId, f.professionId); err != nil || gap.Coverage != 0 {
+		t.Fatalf("expected the gap back, got %+v, %v", gap, err)
+	}
+	if _, err = f.app.UpdateCompetencyProfession(sql, f.professionId, "intermediate"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.UpdateCourseCompetency(basics, sql, "intermediate"); err != nil {
+		t.Fatal(err)
+	}
+	if gap, err = f.app.CompetencyGap(studentId, f.professionId); err != nil || gap.Coverage != 100 || gap.Competencies[0].Level != "intermediate" {
+		t.Fatalf("expected the intermediate course to cover the competency, got %+v, %v", gap, err)
+	}
+}
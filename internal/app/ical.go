@@ -0,0 +1,219 @@
+package app
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// scheduleRefreshInterval tells calendar applications how often to download the subscription again.
+const scheduleRefreshInterval = "PT12H"
+
+const icalTime = "20060102T150405Z"
+
+var sessionKindTitles = map[string]string{
+	store.SessionLecture:  "лекция",
+	store.SessionPractice: "практика",
+	store.SessionLab:      "лабораторная работа",
+}
+
+// icalWriter writes content lines of RFC 5545: CRLF line endings, lines folded at 75 octets.
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icalWriter) line(name string, value string) {
+	line := name + ":" + value
+	// continuation lines start with a space, 74 octets of the line are left for them
+	for limit := 75; len(line) > limit; limit = 74 {
+		cut := limit
+		// do not split multi-byte UTF-8 sequences
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	w.buf.WriteString(line + "\r\n")
+}
+
+// icalText escapes TEXT values.
+func icalText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
+
+// slotTime returns the moment the time of day (15:04) comes on the day in the location.
+func slotTime(day time.Time, clock string, location *time.Location) time.Time {
+	t, _ := time.Parse("15:04", clock)
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, location)
+}
+
+// isoWeekday returns 1 for Monday and 7 for Sunday as time slots do.
+func isoWeekday(day time.Time) uint8 {
+	if day.Weekday() == time.Sunday {
+		return 7
+	}
+	return uint8(day.Weekday())
+}
+
+type scheduleCalendar struct {
+	name     string
+	source   string // subscription URL, may be empty
+	start    time.Time
+	end      time.Time
+	holidays []store.CalendarPeriod
+	entries  []model.GetTimetableEntry
+	location *time.Location // time zone of time slots
+	now      time.Time
+}
+
+// writeSchedule renders the weekly timetable as VCALENDAR with an event repeated every week of the semester.
+// Occurrences on holidays are excluded, times are written in UTC.
+func writeSchedule(s scheduleCalendar) []byte {
+	var w icalWriter
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//UrFU//Smart Schedule Former//RU")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("NAME", icalText(s.name))
+	w.line("X-WR-CALNAME", icalText(s.name))
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", scheduleRefreshInterval)
+	w.line("X-PUBLISHED-TTL", scheduleRefreshInterval)
+	if s.source != "" {
+		w.line("SOURCE;VALUE=URI", s.source)
+	}
+
+	for _, entry := range s.entries {
+		first := s.start.AddDate(0, 0, (int(entry.TimeSlot.Weekday)-int(isoWeekday(s.start))+7)%7)
+		if first.After(s.end) {
+			continue
+		}
+
+		var excluded []string
+		for _, holiday := range s.holidays {
+			for day := holiday.Start; !day.After(holiday.End); day = day.AddDate(0, 0, 1) {
+				if isoWeekday(day) == entry.TimeSlot.Weekday && !day.Before(first) && !day.After(s.end) {
+					excluded = append(excluded, slotTime(day, entry.TimeSlot.Start, s.location).UTC().Format(icalTime))
+				}
+			}
+		}
+		sort.Strings(excluded)
+
+		summary := entry.Course
+		if kind, ok := sessionKindTitles[entry.Kind]; ok {
+			summary += " (" + kind + ")"
+		}
+		var description []string
+		switch len(entry.Teachers) {
+		case 0:
+		case 1:
+			description = append(description, "Преподаватель: "+entry.Teachers[0])
+		default:
+			description = append(description, "Преподаватели: "+strings.Join(entry.Teachers, ", "))
+		}
+		description = append(description, "Аудитория: "+entry.Room.Title)
+
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", entry.SessionId.String()+"-"+entry.TimeSlot.Id.String()+"@smart-schedule-former")
+		w.line("DTSTAMP", s.now.UTC().Format(icalTime))
+		w.line("DTSTART", slotTime(first, entry.TimeSlot.Start, s.location).UTC().Format(icalTime))
+		w.line("DTEND", slotTime(first, entry.TimeSlot.End, s.location).UTC().Format(icalTime))
+		w.line("RRULE", "FREQ=WEEKLY;UNTIL="+slotTime(s.end, entry.TimeSlot.End, s.location).UTC().Format(icalTime))
+		if len(excluded) > 0 {
+			w.line("EXDATE", strings.Join(excluded, ","))
+		}
+		w.line("SUMMARY", icalText(summary))
+		w.line("LOCATION", icalText(entry.Room.Title))
+		w.line("DESCRIPTION", icalText(strings.Join(description, "\n")))
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// GetStudentSchedule exports the timetable of the student for the current semester as iCalendar (RFC 5545).
+// Time slots are read in the local time zone of the server. source is the subscription URL of the file.
+func (app *App) GetStudentSchedule(studentId uuid.UUID, source string) ([]byte, error) {
+	if studentId == uuid.Nil {
+		return nil, ErrEmptyId
+	}
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return nil, err
+	}
+	timetable, err := app.GetStudentTimetable(studentId)
+	if err != nil {
+		return nil, err
+	}
+	c, err := app.getStudentCalendar(studentId, student.Admition)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	term := c.term(c.semester(now), now)
+	start, end := c.termDates(term.year, term.term)
+	var holidays []store.CalendarPeriod
+	for _, period := range c.periods {
+		if period.Kind == store.PeriodHoliday {
+			holidays = append(holidays, period)
+		}
+	}
+
+	return writeSchedule(scheduleCalendar{
+		name:     "Расписание: " + student.FullName,
+		source:   source,
+		start:    start,
+		end:      end,
+		holidays: holidays,
+		entries:  timetable.Entries,
+		location: time.Local,
+		now:      now,
+	}), nil
+}
+
+var ErrNoFeedSecret = errors.New("schedule feeds are not available, the service has no secret")
+
+// ScheduleFeedToken signs the schedule feed of the student. Calendar applications pass it in the feedToken query parameter,
+// the token opens the schedule of the student and nothing else. Changing the secret revokes every issued token.
+func ScheduleFeedToken(secret []byte, studentId uuid.UUID) string {
+	return signToken(secret, "schedule.ics "+studentId.String())
+}
+
+// CheckScheduleFeedToken reports ErrUnauthenticated when the token is not the feed token of the student.
+func CheckScheduleFeedToken(secret []byte, studentId uuid.UUID, token string) error {
+	if len(secret) == 0 || !hmac.Equal([]byte(token), []byte(ScheduleFeedToken(secret, studentId))) {
+		return ErrUnauthenticated
+	}
+	return nil
+}
+
+// GetScheduleSubscription returns links to subscribe to the schedule of the student, base is the URL of the service.
+// The links carry the feed token of the student signed with the secret.
+func (app *App) GetScheduleSubscription(studentId uuid.UUID, base string, secret []byte) (model.GetScheduleSubscription, error) {
+	var resp model.GetScheduleSubscription
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if len(secret) == 0 {
+		return resp, ErrNoFeedSecret
+	}
+	if _, err := app.store.GetStudent(studentId); err != nil {
+		return resp, err
+	}
+
+	resp.IcsUrl = base + "/api/v1/student/" + studentId.String() + "/schedule.ics?feedToken=" + ScheduleFeedToken(secret, studentId)
+	resp.WebcalUrl = "webcal://" + strings.TrimPrefix(strings.TrimPrefix(resp.IcsUrl, "https://"), "http://")
+	resp.RefreshInterval = scheduleRefreshInterval
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestWriteSchedule(t *testing.T) {
+	location := time.FixedZone("UTC+5", 5*60*60)
+	entry := model.GetTimetableEntry{
+		SessionId: uuid.NewV4(),
+		Course:    "Go, базовый курс",
+		Kind:      store.SessionLecture,
+		Teachers:  []string{"Иванов Иван Иванович"},
+		TimeSlot:  model.GetTimeSlot{Id: uuid.NewV4(), Weekday: 3, Start: "08:30", End: "10:00"},
+		Room:      model.GetRoom{Title: "Р-237"},
+	}
+	ics := string(writeSchedule(scheduleCalendar{
+		name:     "Расписание",
+		source:   "http://localhost/api/v1/student/1/schedule.ics",
+		start:    date(2023, 9, 1),
+		end:      date(2024, 1, 31),
+		holidays: []store.CalendarPeriod{{Kind: store.PeriodHoliday, Start: date(2023, 12, 30), End: date(2024, 1, 8)}},
+		entries:  []model.GetTimetableEntry{entry},
+		location: location,
+		now:      date(2023, 9, 1),
+	}))
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is not folded: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"SOURCE;VALUE=URI:http://localhost/api/v1/student/1/schedule.ics\r\n",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT12H\r\n",
+		// September 1 2023 is Friday, the first Wednesday is September 6
+		"DTSTART:20230906T033000Z\r\n",
+		"DTEND:20230906T050000Z\r\n",
+		"RRULE:FREQ=WEEKLY;UNTIL=20240131T050000Z\r\n",
+		"EXDATE:20240103T033000Z\r\n",
+		`SUMMARY:Go\, базовый курс (лекция)` + "\r\n",
+		`DESCRIPTION:Преподаватель: Иванов Иван Иванович\nАудитория: Р-237` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("expected %q in\n%s", want, unfolded)
+		}
+	}
+}
+
+func TestIcalLineFolding(t *testing.T) {
+	description := strings.Repeat("Описание занятия по курсу, ", 5)
+	var w icalWriter
+	w.line("DESCRIPTION", icalText(description))
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected at least 3 folded lines, got %q", lines)
+	}
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets long: %q", i, len(line), line)
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("continuation line %d does not start with a space: %q", i, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+		}
+	}
+	if unfolded := strings.ReplaceAll(w.buf.String(), "\r\n ", ""); unfolded != "DESCRIPTION:"+icalText(description)+"\r\n" {
+		t.Errorf("unexpected unfolded line %q", unfolded)
+	}
+}
+
+func TestGetStudentSchedule(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	if _, err := f.app.GetStudentSchedule(uuid.Nil, ""); err != ErrEmptyId {
+		t.Fatalf("expected ErrEmptyId, got %v", err)
+	}
+
+	ics, err := f.app.GetStudentSchedule(studentId, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(ics); !strings.HasPrefix(s, "BEGIN:VCALENDAR\r\n") || strings.Contains(s, "BEGIN:VEVENT") {
+		t.Fatalf("expected an empty calendar, got %s", s)
+	}
+
+	if _, err = f.app.GetScheduleSubscription(studentId, "https://example.com", nil); err != ErrNoFeedSecret {
+		t.Fatalf("expected ErrNoFeedSecret without the secret, got %v", err)
+	}
+	secret := []byte("secret")
+	subscription, err := f.app.GetScheduleSubscription(studentId, "https://example.com", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := ScheduleFeedToken(secret, studentId)
+	if want := "webcal://example.com/api/v1/student/" + studentId.String() + "/schedule.ics?feedToken=" + token; subscription.WebcalUrl != want {
+		t.Fatalf("expected %s, got %s", want, subscription.WebcalUrl)
+	}
+	if err = CheckScheduleFeedToken(secret, studentId, token); err != nil {
+		t.Fatalf("expected the feed token to be accepted, got %v", err)
+	}
+	for name, check := range map[string]error{
+		"another student": CheckScheduleFeedToken(secret, uuid.NewV4(), token),
+		"another secret":  CheckScheduleFeedToken([]byte("other"), studentId, token),
+		"no secret":       CheckScheduleFeedToken(nil, studentId, ScheduleFeedToken(nil, studentId)),
+	} {
+		if check != ErrUnauthenticated {
+			t.Errorf("%s: expected ErrUnauthenticated, got %v", name, check)
+		}
+	}
+}
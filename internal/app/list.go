@@ -0,0 +1,253 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+var ErrWrongSort = errors.New("wrong sort field")
+
+// ListParams describes offset pagination and ordering of a collection.
+type ListParams struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// Sort keys of the collections, the first key is used when no sort is requested.
+var (
+	titleSortKeys   = []string{"title", "id"}
+	courseSortKeys  = []string{"title", "id", "teacher"}
+	teacherSortKeys = []string{"fullName", "id"}
+	studentSortKeys = []string{"fullName", "id", "admition"}
+)
+
+func (params *ListParams) normalize() {
+	if params.Limit <= 0 {
+		params.Limit = DefaultListLimit
+	}
+	if params.Limit > MaxListLimit {
+		params.Limit = MaxListLimit
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+}
+
+// page normalizes the params and checks the sort key against the keys of the collection.
+func (params *ListParams) page(sortKeys []string) (store.Page, error) {
+	params.normalize()
+	if params.Sort == "" {
+		params.Sort = sortKeys[0]
+	}
+	if !slices.Contains(sortKeys, params.Sort) {
+		return store.Page{}, fmt.Errorf("%w: %s, expected one of %s", ErrWrongSort, params.Sort, strings.Join(sortKeys, ", "))
+	}
+	return store.Page{Limit: params.Limit, Offset: params.Offset, Sort: params.Sort, Desc: params.Desc}, nil
+}
+
+// listItems reads the page of rows with list and converts every row with item.
+func listItems[T any, R any](params ListParams, sortKeys []string, list func(page store.Page) ([]R, int, error),
+	item func(row R) (T, error)) (model.GetList[T], error) {
+	resp := model.GetList[T]{Items: []T{}}
+	page, err := params.page(sortKeys)
+	resp.Limit, resp.Offset = params.Limit, params.Offset
+	if err != nil {
+		return resp, err
+	}
+
+	rows, total, err := list(page)
+	if err != nil {
+		return resp, err
+	}
+	resp.Total = total
+	for _, row := range rows {
+		converted, err := item(row)
+		if err != nil {
+			return resp, err
+		}
+		resp.Items = append(resp.Items, converted)
+	}
+	return resp, nil
+}
+
+// titles caches titles of the entities referenced by the rows of a page.
+type titles map[uuid.UUID]string
+
+func (cache titles) get(id uuid.UUID, title func(id uuid.UUID) (string, error)) (string, error) {
+	if id == uuid.Nil {
+		return "", nil
+	}
+	if cached, ok := cache[id]; ok {
+		return cached, nil
+	}
+	var err error
+	cache[id], err = title(id)
+	return cache[id], err
+}
+
+func (app *App) technologyTitle(id uuid.UUID) (string, error) {
+	technology, err := app.store.GetTechnology(id)
+	return technology.Title, err
+}
+
+func (app *App) organizationTitle(id uuid.UUID) (string, error) {
+	organization, err := app.store.GetOrganization(id)
+	return organization.Title, err
+}
+
+func (app *App) educationalProgramTitle(id uuid.UUID) (string, error) {
+	educationalProgram, err := app.store.GetEducationalProgram(id)
+	return educationalProgram.Title, err
+}
+
+func (app *App) disciplineTitle(id uuid.UUID) (string, error) {
+	discipline, err := app.store.GetDiscipline(id)
+	return discipline.Title, err
+}
+
+func (app *App) ListKnowledge(params ListParams) (model.GetList[model.GetKnowledge], error) {
+	return listItems(params, titleSortKeys, app.store.ListKnowledge, func(knowledge store.Knowledge) (model.GetKnowledge, error) {
+		return model.GetKnowledge{Id: knowledge.Id, Title: knowledge.Title, ParentId: knowledge.ParentId}, nil
+	})
+}
+
+func (app *App) ListTechnologies(params ListParams) (model.GetList[model.GetTechnology], error) {
+	return listItems(params, titleSortKeys, app.store.ListTechnologies, func(technology store.Technology) (model.GetTechnology, error) {
+		return model.GetTechnology{Id: technology.Id, Title: technology.Title}, nil
+	})
+}
+
+func (app *App) ListSkills(params ListParams) (model.GetList[model.GetSkill], error) {
+	return listItems(params, titleSortKeys, app.store.ListSkills, func(skill store.Skill) (model.GetSkill, error) {
+		return model.GetSkill{Id: skill.Id, Title: skill.Title}, nil
+	})
+}
+
+func (app *App) ListCompetencies(params ListParams, mainTechnologyId uuid.UUID) (model.GetList[model.GetCompetency], error) {
+	list := func(page store.Page) ([]store.Competency, int, error) {
+		return app.store.ListCompetencies(page, mainTechnologyId)
+	}
+	return listItems(params, titleSortKeys, list, func(competency store.Competency) (model.GetCompetency, error) {
+		resp := model.GetCompetency{Id: competency.Id, Title: competency.Title, ParentId: competency.ParentId,
+			MainTechnologyId: competency.MainTechnologyId}
+		var err error
+		resp.Skills, err = app.getSkillsByCompetency(competency.Id)
+		return resp, err
+	})
+}
+
+func (app *App) ListProfessions(params ListParams) (model.GetList[model.GetProfession], error) {
+	return listItems(params, titleSortKeys, app.store.ListProfessions, func(profession store.Profession) (model.GetProfession, error) {
+		return model.GetProfession{Id: profession.Id, Title: profession.Title, Description: profession.Description}, nil
+	})
+}
+
+func (app *App) ListProjects(params ListParams, mainTechnologyId uuid.UUID) (model.GetList[model.GetProject], error) {
+	technologies := make(titles)
+	list := func(page store.Page) ([]store.Project, int, error) {
+		return app.store.ListProjects(page, mainTechnologyId)
+	}
+	return listItems(params, titleSortKeys, list, func(project store.Project) (model.GetProject, error) {
+		resp := model.GetProject{Id: project.Id, Title: project.Title, Description: project.Description, Result: project.Result,
+			LifeScenario: project.LifeScenario}
+		var err error
+		resp.MainTechnology, err = technologies.get(project.MainTechnologyId, app.technologyTitle)
+		return resp, err
+	})
+}
+
+func (app *App) ListOrganizations(params ListParams) (model.GetList[model.GetOrganization], error) {
+	return listItems(params, titleSortKeys, app.store.ListOrganizations, func(organization store.Organization) (model.GetOrganization, error) {
+		return model.GetOrganization{Id: organization.Id, Title: organization.Title}, nil
+	})
+}
+
+func (app *App) ListEducationalPrograms(params ListParams, organizationId uuid.UUID) (model.GetList[model.GetEducationalProgram], error) {
+	organizations := make(titles)
+	list := func(page store.Page) ([]store.EducationalProgram, int, error) {
+		return app.store.ListEducationalPrograms(page, organizationId)
+	}
+	return listItems(params, titleSortKeys, list, func(educationalProgram store.EducationalProgram) (model.GetEducationalProgram, error) {
+		resp := model.GetEducationalProgram{Id: educationalProgram.Id, Title: educationalProgram.Title, Description: educationalProgram.Description,
+			MinCredits: educationalProgram.MinSemesterCredits, MaxCredits: educationalProgram.MaxSemesterCredits}
+		var err error
+		resp.Organization, err = organizations.get(educationalProgram.OrganizationId, app.organizationTitle)
+		return resp, err
+	})
+}
+
+func (app *App) ListDisciplines(params ListParams, educationalProgramId uuid.UUID) (model.GetList[model.GetDiscipline], error) {
+	educationalPrograms := make(titles)
+	list := func(page store.Page) ([]store.Discipline, int, error) {
+		return app.store.ListDisciplines(page, educationalProgramId)
+	}
+	return listItems(params, titleSortKeys, list, func(discipline store.Discipline) (model.GetDiscipline, error) {
+		resp := model.GetDiscipline{Id: discipline.Id, Title: discipline.Title, Description: discipline.Description}
+		var err error
+		resp.EducationalProgram, err = educationalPrograms.get(discipline.EducationalProgramId, app.educationalProgramTitle)
+		return resp, err
+	})
+}
+
+func (app *App) ListCourses(params ListParams, disciplineId uuid.UUID) (model.GetList[model.GetCourse], error) {
+	disciplines := make(titles)
+	list := func(page store.Page) ([]store.Course, int, error) { return app.store.ListCourses(page, disciplineId) }
+	return listItems(params, courseSortKeys, list, func(course store.Course) (model.GetCourse, error) {
+		resp := model.GetCourse{Id: course.Id, Title: course.Title, Description: course.Description, Credits: course.Credits,
+			Hours: course.Hours, Alternative: course.Alternative, Capacity: course.Capacity}
+		var err error
+		if resp.Teachers, err = app.getTeacherNames(course.Id); err != nil {
+			return resp, err
+		}
+		if resp.Discipline, err = disciplines.get(course.DisciplineId, app.disciplineTitle); err != nil {
+			return resp, err
+		}
+		if resp.Enrolled, err = app.store.CountStudyGroup(course.Id); err != nil {
+			return resp, err
+		}
+		waitlist, err := app.store.GetCourseWaitlist(course.Id)
+		resp.Waitlisted = len(waitlist)
+		return resp, err
+	})
+}
+
+func (app *App) ListTeachers(params ListParams, organizationId uuid.UUID) (model.GetList[model.GetTeacher], error) {
+	organizations := make(titles)
+	list := func(page store.Page) ([]store.Teacher, int, error) {
+		return app.store.ListTeachers(page, organizationId)
+	}
+	return listItems(params, teacherSortKeys, list, func(teacher store.Teacher) (model.GetTeacher, error) {
+		resp := model.GetTeacher{Id: teacher.Id, FullName: teacher.FullName, OrganizationId: teacher.OrganizationId,
+			Email: teacher.Email, Phone: teacher.Phone}
+		var err error
+		resp.Organization, err = organizations.get(teacher.OrganizationId, app.organizationTitle)
+		return resp, err
+	})
+}
+
+func (app *App) ListStudents(params ListParams) (model.GetList[model.GetStudent], error) {
+	// calendars are looked up per student, students of a page may study in different organizations
+	now := time.Now()
+	return listItems(params, studentSortKeys, app.store.ListStudents, func(student store.Student) (model.GetStudent, error) {
+		resp := model.GetStudent{Id: student.Id, FullName: student.FullName}
+		resp.Portfolio.Id = student.PortfolioId
+		var err error
+		resp.Semester, err = app.getStudentSemester(student.Id, student.Admition, now)
+		return resp, err
+	})
+}
@@ -0,0 +1,389 @@
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongMatrix = errors.New("wrong competency matrix")
+
+// errRollback stops the import transaction without reporting a failure, e.g. for a dry run.
+var errRollback = errors.New("rollback")
+
+// Columns of the competency matrix.
+const (
+	matrixProfession = "profession"
+	matrixCompetency = "competency"
+	matrixSkills     = "skills"
+	matrixKnowledge  = "knowledge"
+	matrixTechnology = "technology"
+	matrixLevel      = "level" // the level the profession requires, basic when blank
+)
+
+// matrixHeaders maps lower case headers of the analysts' table to columns, unknown headers are ignored.
+var matrixHeaders = map[string]string{
+	"profession":          matrixProfession,
+	"профессия":           matrixProfession,
+	"competency":          matrixCompetency,
+	"компетенция":         matrixCompetency,
+	"skills":              matrixSkills,
+	"навыки":              matrixSkills,
+	"knowledge":           matrixKnowledge,
+	"знания":              matrixKnowledge,
+	"technology":          matrixTechnology,
+	"технология":          matrixTechnology,
+	"main technology":     matrixTechnology,
+	"ключевая технология": matrixTechnology,
+	"level":               matrixLevel,
+	"уровень":             matrixLevel,
+}
+
+type matrixRow struct {
+	number     int
+	profession string
+	competency string
+	skills     string
+	technology string
+	knowledge  []string
+	level      uint8
+}
+
+// ReadMatrix reads cells of the competency matrix from CSV or XLSX data, XLSX is recognized by its zip signature.
+// CSV may be separated with commas or semicolons as Excel does in Russian locale.
+func ReadMatrix(data []byte) ([][]string, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXlsx(data)
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrWrongMatrix, err.Error())
+	}
+	return records, nil
+}
+
+// splitKnowledge splits the cell listing several knowledge items with semicolons or line breaks.
+func splitKnowledge(cell string) []string {
+	var knowledge []string
+	for _, item := range strings.FieldsFunc(cell, func(r rune) bool { return r == ';' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			knowledge = append(knowledge, item)
+		}
+	}
+	return knowledge
+}
+
+// parseMatrix maps columns by the header in the first row. Blank profession and competency cells repeat
+// the value of the row above, as merged cells are exported. Skills and technology of a competency may be given
+// in any of its rows, but must not differ.
+func parseMatrix(records [][]string) ([]matrixRow, []model.GetMatrixRowError, error) {
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%w: no header", ErrWrongMatrix)
+	}
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		if column, ok := matrixHeaders[strings.ToLower(strings.TrimSpace(header))]; ok {
+			if _, duplicated := columns[column]; duplicated {
+				return nil, nil, fmt.Errorf("%w: duplicated column %s", ErrWrongMatrix, column)
+			}
+			columns[column] = i
+		}
+	}
+	if _, ok := columns[matrixCompetency]; !ok {
+		return nil, nil, fmt.Errorf("%w: no competency column", ErrWrongMatrix)
+	}
+
+	var rows []matrixRow
+	var rowErrors []model.GetMatrixRowError
+	var previous matrixRow
+	var err error
+	// row numbers of the first skills and technology given for the competency
+	skillsRows := make(map[string]int)
+	technologyRows := make(map[string]int)
+	values := make(map[string]matrixRow)
+	for i, record := range records[1:] {
+		cell := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		row := matrixRow{
+			number:     i + 2,
+			profession: cell(matrixProfession),
+			competency: cell(matrixCompetency),
+			skills:     cell(matrixSkills),
+			technology: cell(matrixTechnology),
+			knowledge:  splitKnowledge(cell(matrixKnowledge)),
+		}
+		if row.profession == "" && row.competency == "" && row.skills == "" && row.technology == "" && len(row.knowledge) == 0 {
+			continue
+		}
+		if row.profession == "" {
+			row.profession = previous.profession
+		}
+		if row.competency == "" {
+			row.competency = previous.competency
+		}
+		previous = row
+
+		if row.competency == "" {
+			rowErrors = append(rowErrors, model.GetMatrixRowError{Row: row.number, Column: matrixCompetency, Message: "competency is empty"})
+			continue
+		}
+
+		valid := true
+		if row.level, err = parseLevel(strings.ToLower(cell(matrixLevel))); err != nil {
+			rowErrors = append(rowErrors, model.GetMatrixRowError{Row: row.number, Column: matrixLevel, Message: err.Error()})
+			valid = false
+		}
+		first := values[row.competency]
+		if row.skills != "" {
+			if number, ok := skillsRows[row.competency]; ok && first.skills != row.skills {
+				rowErrors = append(rowErrors, model.GetMatrixRowError{Row: row.number, Column: matrixSkills,
+					Message: "skills of the competency differ from row " + strconv.Itoa(number)})
+				valid = false
+			} else if !ok {
+				skillsRows[row.competency] = row.number
+				first.skills = row.skills
+			}
+		}
+		if row.technology != "" {
+			if number, ok := technologyRows[row.competency]; ok && first.technology != row.technology {
+				rowErrors = append(rowErrors, model.GetMatrixRowError{Row: row.number, Column: matrixTechnology,
+					Message: "technology of the competency differs from row " + strconv.Itoa(number)})
+				valid = false
+			} else if !ok {
+				technologyRows[row.competency] = row.number
+				first.technology = row.technology
+			}
+		}
+		values[row.competency] = first
+		if valid {
+			rows = append(rows, row)
+		}
+	}
+
+	// every row of the competency creates it with the same skills and technology
+	for i := range rows {
+		rows[i].skills = values[rows[i].competency].skills
+		rows[i].technology = values[rows[i].competency].technology
+	}
+	return rows, rowErrors, nil
+}
+
+// matrixImport resolves entities of the matrix by title and creates the missing ones and links in the transaction.
+type matrixImport struct {
+	tx store.Store
+	// ids of resolved entities: column -> title -> id
+	ids map[string]map[string]uuid.UUID
+	// existing links: column of the target -> target id -> source id -> true
+	links map[string]map[uuid.UUID]map[uuid.UUID]bool
+	diff  model.GetMatrixDiff
+}
+
+// resolve returns id of the entity with the title, calling create only when find does not find it.
+func (imp *matrixImport) resolve(column string, title string, find func() (uuid.UUID, error),
+	create func() (uuid.UUID, error), created *[]string) (uuid.UUID, error) {
+	if id, ok := imp.ids[column][title]; ok {
+		return id, nil
+	}
+
+	id, err := find()
+	if errors.Is(err, store.ErrNotFound) {
+		if id, err = create(); err == nil {
+			*created = append(*created, title)
+		}
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if imp.ids[column] == nil {
+		imp.ids[column] = make(map[string]uuid.UUID)
+	}
+	imp.ids[column][title] = id
+	return id, nil
+}
+
+// link creates the link between the source and the target unless it exists, linked loads existing links of the target.
+func (imp *matrixImport) link(column string, targetId uuid.UUID, sourceId uuid.UUID,
+	linked func() ([]uuid.UUID, error), create func() error) (bool, error) {
+	if imp.links[column] == nil {
+		imp.links[column] = make(map[uuid.UUID]map[uuid.UUID]bool)
+	}
+	sources, ok := imp.links[column][targetId]
+	if !ok {
+		ids, err := linked()
+		if err != nil {
+			return false, err
+		}
+		sources = make(map[uuid.UUID]bool)
+		for _, id := range ids {
+			sources[id] = true
+		}
+		imp.links[column][targetId] = sources
+	}
+	if sources[sourceId] {
+		return false, nil
+	}
+
+	if err := create(); err != nil {
+		return false, err
+	}
+	sources[sourceId] = true
+	return true, nil
+}
+
+func (imp *matrixImport) row(row matrixRow) error {
+	tx := imp.tx
+	var technologyId uuid.UUID
+	var err error
+	if row.technology != "" {
+		technologyId, err = imp.resolve(matrixTechnology, row.technology,
+			func() (uuid.UUID, error) {
+				technology, err := tx.GetTechnologyByTitle(row.technology)
+				return technology.Id, err
+			},
+			func() (uuid.UUID, error) { return tx.CreateTechnology(row.technology) },
+			&imp.diff.Technologies)
+		if err != nil {
+			return err
+		}
+	}
+
+	competencyId, err := imp.resolve(matrixCompetency, row.competency,
+		func() (uuid.UUID, error) {
+			competency, err := tx.GetCompetencyByTitle(row.competency)
+			return competency.Id, err
+		},
+		func() (uuid.UUID, error) {
+			id, err := tx.CreateCompetency(store.Competency{Title: row.competency, MainTechnologyId: technologyId})
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return id, addSkills(tx, id, splitSkills(row.skills))
+		},
+		&imp.diff.Competencies)
+	if err != nil {
+		return err
+	}
+
+	for _, title := range row.knowledge {
+		knowledgeId, err := imp.resolve(matrixKnowledge, title,
+			func() (uuid.UUID, error) { knowledge, err := tx.GetKnowledgeByTitle(title); return knowledge.Id, err },
+			func() (uuid.UUID, error) { return tx.CreateKnowledge(title) },
+			&imp.diff.Knowledge)
+		if err != nil {
+			return err
+		}
+
+		created, err := imp.link(matrixCompetency, competencyId, knowledgeId,
+			func() ([]uuid.UUID, error) {
+				knowledge, err := tx.GetKnowledgeByCompetency(competencyId)
+				var ids []uuid.UUID
+				for _, item := range knowledge {
+					ids = append(ids, item.Id)
+				}
+				return ids, err
+			},
+			func() error { return tx.CreateKnowledgeCompetency(knowledgeId, competencyId) })
+		if err != nil {
+			return err
+		}
+		if created {
+			imp.diff.KnowledgeCompetencies = append(imp.diff.KnowledgeCompetencies, model.GetMatrixLink{From: title, To: row.competency})
+		}
+	}
+
+	if row.profession == "" {
+		return nil
+	}
+	professionId, err := imp.resolve(matrixProfession, row.profession,
+		func() (uuid.UUID, error) {
+			profession, err := tx.GetProfessionByTitle(row.profession)
+			return profession.Id, err
+		},
+		func() (uuid.UUID, error) { return tx.CreateProfession(store.Profession{Title: row.profession}) },
+		&imp.diff.Professions)
+	if err != nil {
+		return err
+	}
+
+	created, err := imp.link(matrixProfession, professionId, competencyId,
+		func() ([]uuid.UUID, error) {
+			competencies, err := tx.GetCompetenciesByProfession(professionId)
+			var ids []uuid.UUID
+			for _, competency := range competencies {
+				ids = append(ids, competency.Id)
+			}
+			return ids, err
+		},
+		func() error { return tx.CreateCompetencyProfession(competencyId, professionId, row.level) })
+	if err != nil {
+		return err
+	}
+	if created {
+		imp.diff.CompetencyProfessions = append(imp.diff.CompetencyProfessions, model.GetMatrixLink{From: row.competency, To: row.profession})
+	}
+	return nil
+}
+
+// ImportMatrix resolves professions, competencies, knowledge and technologies of the competency matrix by title,
+// creates the missing ones with their links in one transaction and returns what was created.
+// Existing entities and links are left as they are, new profession links get the level of the row.
+// Nothing is written for a dry run or when any row is wrong.
+func (app *App) ImportMatrix(records [][]string, dryRun bool) (model.GetMatrixImport, error) {
+	resp := model.GetMatrixImport{DryRun: dryRun}
+	rows, rowErrors, err := parseMatrix(records)
+	if err != nil {
+		return resp, err
+	}
+	resp.Rows = len(rows) + len(rowErrors)
+	resp.Errors = rowErrors
+
+	err = app.store.Transaction(func(tx store.Store) error {
+		imp := matrixImport{
+			tx:    tx,
+			ids:   make(map[string]map[string]uuid.UUID),
+			links: make(map[string]map[uuid.UUID]map[uuid.UUID]bool),
+		}
+		for _, row := range rows {
+			if err := imp.row(row); err != nil {
+				return fmt.Errorf("row %d: %w", row.number, err)
+			}
+		}
+
+		resp.Created = imp.diff
+		if dryRun || len(resp.Errors) > 0 {
+			return errRollback
+		}
+		return nil
+	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
+
+	resp.Applied = err == nil && !dryRun && len(resp.Errors) == 0
+	return resp, err
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestReadMatrixCsv(t *testing.T) {
+	data := "\xEF\xBB\xBFПрофессия;Компетенция;Знания\n" +
+		"Backend-разработчик;Работа с БД;\"SQL; индексы\"\n"
+	records, err := ReadMatrix([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"Профессия", "Компетенция", "Знания"}, {"Backend-разработчик", "Работа с БД", "SQL; индексы"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("expected %q, got %q", want, records)
+	}
+}
+
+func TestReadMatrixXlsx(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Матрица" sheetId="1" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId2" Target="worksheets/matrix.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>Компетенция</t></si><si><r><t>Работа </t></r><r><t>с БД</t></r></si></sst>`,
+		"xl/worksheets/matrix.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="B1" t="s"><v>0</v></c></row>` +
+			`<row r="3"><c r="B3" t="s"><v>1</v></c><c r="C3" t="inlineStr"><is><t>SQL</t></is></c><c r="D3"><v>42</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		part, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadMatrix(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"", "Компетенция"}, nil, {"", "Работа с БД", "SQL", "42"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("expected %q, got %q", want, records)
+	}
+
+	if _, err = ReadMatrix([]byte("PK\x03\x04broken")); !errors.Is(err, ErrWrongMatrix) {
+		t.Fatalf("expected ErrWrongMatrix, got %v", err)
+	}
+}
+
+func TestParseMatrix(t *testing.T) {
+	rows, rowErrors, err := parseMatrix([][]string{
+		{"Competency", "Profession", "Skills", "Knowledge", "Comment", "Level"},
+		{"", "", "", "SQL"},
+		{"Работа с БД", "Backend-разработчик", "", "SQL;\nиндексы", "ignored", "Advanced"},
+		{"", "", "проектирование схем"},
+		{},
+		{"", "Аналитик", "запросы"},
+		{"", "", "", "нормализация", "", "expert"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantErrors := []model.GetMatrixRowError{
+		{Row: 2, Column: "competency", Message: "competency is empty"},
+		{Row: 6, Column: "skills", Message: "skills of the competency differ from row 4"},
+		{Row: 7, Column: "level", Message: ErrWrongLevel.Error()},
+	}
+	if !reflect.DeepEqual(rowErrors, wantErrors) {
+		t.Fatalf("expected errors %+v, got %+v", wantErrors, rowErrors)
+	}
+	want := []matrixRow{
+		{number: 3, profession: "Backend-разработчик", competency: "Работа с БД", skills: "проектирование схем", knowledge: []string{"SQL", "индексы"},
+			level: store.LevelAdvanced},
+		{number: 4, profession: "Backend-разработчик", competency: "Работа с БД", skills: "проектирование схем", level: store.LevelBasic},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("expected rows %+v, got %+v", want, rows)
+	}
+
+	if _, _, err = parseMatrix([][]string{{"Profession", "Knowledge"}}); !errors.Is(err, ErrWrongMatrix) {
+		t.Fatalf("expected ErrWrongMatrix without competency column, got %v", err)
+	}
+}
+
+func TestImportMatrix(t *testing.T) {
+	f := newFixture(t)
+	records := [][]string{
+		{"Профессия", "Компетенция", "Навыки", "Знания", "Технология", "Уровень"},
+		{"Backend-разработчик", "Работа с БД", "проектирование схем", "SQL; индексы", "PostgreSQL", "intermediate"},
+		{"Аналитик", "Работа с БД", "", "SQL"},
+	}
+
+	dryRun, err := f.app.ImportMatrix(records, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCreated := model.GetMatrixDiff{
+		Professions:  []string{"Аналитик"},
+		Competencies: []string{"Работа с БД"},
+		Technologies: []string{"PostgreSQL"},
+		Knowledge:    []string{"SQL", "индексы"},
+		KnowledgeCompetencies: []model.GetMatrixLink{
+			{From: "SQL", To: "Работа с БД"},
+			{From: "индексы", To: "Работа с БД"},
+		},
+		CompetencyProfessions: []model.GetMatrixLink{
+			{From: "Работа с БД", To: "Backend-разработчик"},
+			{From: "Работа с БД", To: "Аналитик"},
+		},
+	}
+	if dryRun.Applied || dryRun.Rows != 2 || !reflect.DeepEqual(dryRun.Created, wantCreated) {
+		t.Fatalf("unexpected dry run %+v", dryRun)
+	}
+	if _, err = f.app.store.GetCompetencyByTitle("Работа с БД"); err == nil {
+		t.Fatal("expected dry run to write nothing")
+	}
+
+	imported, err := f.app.ImportMatrix(records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !imported.Applied || !reflect.DeepEqual(imported.Created, wantCreated) {
+		t.Fatalf("unexpected import %+v", imported)
+	}
+	competency, err := f.app.store.GetCompetencyByTitle("Работа с БД")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skills, err := f.app.getSkillsByCompetency(competency.Id); err != nil || !reflect.DeepEqual(skills, []string{"проектирование схем"}) {
+		t.Fatalf("unexpected skills %v of the competency, %v", skills, err)
+	}
+	required, err := f.app.store.GetCompetenciesByProfession(f.professionId)
+	if err != nil || len(required) != 1 || required[0].Id != competency.Id || required[0].Level != store.LevelIntermediate {
+		t.Fatalf("expected the competency required by the existing profession, got %+v, %v", required, err)
+	}
+
+	// the second import finds everything
+	again, err := f.app.ImportMatrix(records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Applied || !reflect.DeepEqual(again.Created, model.GetMatrixDiff{}) {
+		t.Fatalf("expected nothing to be created, got %+v", again.Created)
+	}
+
+	// a wrong row keeps the whole file from being written
+	records = append(records, []string{"Тестировщик", "Тестирование", "", "pytest"}, []string{"", "Работа с БД", "другое"})
+	wrong, err := f.app.ImportMatrix(records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrong.Applied || len(wrong.Errors) != 1 || wrong.Errors[0].Row != 5 {
+		t.Fatalf("unexpected import of the wrong file %+v", wrong)
+	}
+	if _, err = f.app.store.GetProfessionByTitle("Тестировщик"); err == nil {
+		t.Fatal("expected the file with errors to write nothing")
+	}
+}
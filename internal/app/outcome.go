@@ -0,0 +1,202 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongGrade = errors.New("grade is out of the grading scale of the organization")
+var ErrWrongResult = errors.New("result must be passed or failed and agree with the grade")
+var ErrWrongGradingScale = errors.New("grading scale must go up from the minimum grade through the pass grade to the maximum one")
+var ErrNotRetakable = errors.New("only a course whose last attempt is failed can be retaken")
+var ErrCourseTaken = errors.New("student already took the course, the next attempt is added as a retake")
+var ErrOutcomeRecorded = errors.New("trajectory with an outcome or retakes can not move to another student or course")
+var ErrAttemptRetaken = errors.New("outcome of an attempt can not change once the course is retaken")
+
+// defaultGradingScale is the scale of organizations that have not set their own one: 2 to 5, passed with 3.
+var defaultGradingScale = store.GradingScale{MinGrade: 2, MaxGrade: 5, PassGrade: 3}
+
+// getGradingScale returns the grading scale of the organization, the default one when it is not set.
+func (app *App) getGradingScale(organizationId uuid.UUID) (store.GradingScale, bool, error) {
+	scale, err := app.store.GetGradingScale(organizationId)
+	if errors.Is(err, store.ErrNotFound) {
+		scale = defaultGradingScale
+		scale.OrganizationId = organizationId
+		return scale, true, nil
+	}
+	return scale, false, err
+}
+
+func (app *App) GetGradingScale(organizationId uuid.UUID) (model.GetGradingScale, error) {
+	var resp model.GetGradingScale
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if _, err := app.store.GetOrganization(organizationId); err != nil {
+		return resp, err
+	}
+
+	scale, isDefault, err := app.getGradingScale(organizationId)
+	if err != nil {
+		return resp, err
+	}
+	return gradingScale(scale, isDefault), nil
+}
+
+// PostGradingScale replaces the grading scale of the organization, outcomes recorded before keep their results.
+func (app *App) PostGradingScale(organizationId uuid.UUID, minGrade uint8, maxGrade uint8, passGrade uint8) (model.GetGradingScale, error) {
+	scale := store.GradingScale{OrganizationId: organizationId, MinGrade: minGrade, MaxGrade: maxGrade, PassGrade: passGrade}
+	resp := gradingScale(scale, false)
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if minGrade == 0 || minGrade > passGrade || passGrade > maxGrade {
+		return resp, ErrWrongGradingScale
+	}
+
+	return resp, app.store.SaveGradingScale(scale)
+}
+
+func gradingScale(scale store.GradingScale, isDefault bool) model.GetGradingScale {
+	return model.GetGradingScale{
+		OrganizationId: scale.OrganizationId,
+		MinGrade:       scale.MinGrade,
+		MaxGrade:       scale.MaxGrade,
+		PassGrade:      scale.PassGrade,
+		Default:        isDefault,
+	}
+}
+
+// PostTrajectoryOutcome records the outcome of the course the student took. A grade has to fit the grading scale of
+// the organization of the course and decides the result, courses without grades are just passed or failed.
+// Only passed courses give their competencies to the student. Once the course is retaken the outcome of the attempt stays.
+func (app *App) PostTrajectoryOutcome(id uuid.UUID, grade uint8, result string, completionDate time.Time) (model.GetTrajectory, error) {
+	var resp model.GetTrajectory
+	if id == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if result != "" && result != store.TrajectoryPassed && result != store.TrajectoryFailed {
+		return resp, ErrWrongResult
+	}
+	if grade == 0 && result == "" {
+		return resp, ErrWrongResult
+	}
+	if completionDate.IsZero() {
+		completionDate = time.Now()
+	}
+
+	// the student is locked like by retakes, so the outcome does not change under a retake being added
+	err := app.store.Transaction(func(tx store.Store) error {
+		trajectory, err := tx.GetTrajectory(id)
+		if err != nil {
+			return err
+		}
+		if err = tx.Lock(store.TableStudents, trajectory.StudentId); err != nil {
+			return err
+		}
+		retaken, err := hasLaterAttempt(tx, trajectory)
+		if err != nil {
+			return err
+		}
+		if retaken {
+			return ErrAttemptRetaken
+		}
+
+		outcome := result
+		if grade != 0 {
+			checker := &App{store: tx}
+			organizationId, err := checker.getCourseOrganization(trajectory.CourseId)
+			if err != nil {
+				return err
+			}
+			scale, _, err := checker.getGradingScale(organizationId)
+			if err != nil {
+				return err
+			}
+			if grade < scale.MinGrade || grade > scale.MaxGrade {
+				return ErrWrongGrade
+			}
+
+			outcome = store.TrajectoryFailed
+			if grade >= scale.PassGrade {
+				outcome = store.TrajectoryPassed
+			}
+			if result != "" && result != outcome {
+				return ErrWrongResult
+			}
+		}
+
+		trajectory.Grade, trajectory.Result, trajectory.CompletionDate = grade, outcome, dateOf(completionDate)
+		return tx.UpdateTrajectoryOutcome(trajectory)
+	})
+	if err != nil {
+		return resp, err
+	}
+	return app.GetTrajectoryById(id)
+}
+
+// PostTrajectoryRetake adds the next attempt of the course the student failed, the attempt is linked to the first one.
+// The retake can not come before the failed attempt and keeps within the workload of the semester.
+func (app *App) PostTrajectoryRetake(id uuid.UUID, semester uint8) (model.GetTrajectory, error) {
+	var resp model.GetTrajectory
+	if id == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if semester == 0 {
+		return resp, ErrWrongSemester
+	}
+
+	// the student is locked, so concurrent retakes wait for each other and see the attempt added by the first one
+	var retakeId uuid.UUID
+	err := app.store.Transaction(func(tx store.Store) error {
+		trajectory, err := tx.GetTrajectory(id)
+		if err != nil {
+			return err
+		}
+		if err = tx.Lock(store.TableStudents, trajectory.StudentId); err != nil {
+			return err
+		}
+		first := trajectory.Id
+		if trajectory.RetakeOf != uuid.Nil {
+			first = trajectory.RetakeOf
+		}
+
+		trajectories, err := tx.GetStudentTrajectories(trajectory.StudentId)
+		if err != nil {
+			return err
+		}
+		last := trajectory
+		for _, attempt := range trajectories {
+			if (attempt.Id == first || attempt.RetakeOf == first) && attempt.Attempt > last.Attempt {
+				last = attempt
+			}
+		}
+		if last.Result != store.TrajectoryFailed {
+			return ErrNotRetakable
+		}
+		if semester < last.Semester {
+			return ErrWrongSemester
+		}
+
+		checker := &App{store: tx}
+		if err = checker.checkTrajectorySemester(trajectory.StudentId, semester); err != nil {
+			return err
+		}
+		if err = checker.checkStudentWorkload(trajectory.StudentId, trajectory.CourseId, semester, uuid.Nil); err != nil {
+			return err
+		}
+
+		retakeId, err = tx.CreateTrajectory(store.Trajectory{StudentId: trajectory.StudentId, CourseId: trajectory.CourseId,
+			Semester: semester, Attempt: last.Attempt + 1, RetakeOf: first})
+		return err
+	})
+	if err != nil {
+		return resp, err
+	}
+	return app.GetTrajectoryById(retakeId)
+}
@@ -0,0 +1,226 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestTrajectoryOutcome(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	goId := f.course(t, "Go", f.competency(t, "Go", true))
+	sqlId := f.course(t, "SQL", f.competency(t, "SQL", true))
+	goTrajectory, err := f.app.PostTrajectory(1, studentId, goId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlTrajectory, err := f.app.PostTrajectory(1, studentId, sqlId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goTrajectory.Attempt != 1 || goTrajectory.Result != "" || goTrajectory.CompletionDate != nil {
+		t.Fatalf("expected the first attempt without an outcome, got %+v", goTrajectory)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		grade  uint8
+		result string
+		want   error
+	}{
+		{"without outcome", 0, "", ErrWrongResult},
+		{"unknown result", 0, "absent", ErrWrongResult},
+		{"above the scale", 6, "", ErrWrongGrade},
+		{"below the scale", 1, "", ErrWrongGrade},
+		{"result against grade", 4, store.TrajectoryFailed, ErrWrongResult},
+	} {
+		if _, err = f.app.PostTrajectoryOutcome(goTrajectory.Id, tt.grade, tt.result, time.Time{}); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	// the default scale passes courses with 3 and higher
+	completed := time.Now().AddDate(0, -1, 0)
+	got, err := f.app.PostTrajectoryOutcome(goTrajectory.Id, 2, "", completed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Grade != 2 || got.Result != store.TrajectoryFailed || got.CompletionDate == nil ||
+		got.CompletionDate.Format("2006-01-02") != completed.Format("2006-01-02") {
+		t.Fatalf("expected the failed course, got %+v", got)
+	}
+	if got, err = f.app.PostTrajectoryOutcome(sqlTrajectory.Id, 0, store.TrajectoryPassed, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Grade != 0 || got.Result != store.TrajectoryPassed || got.CompletionDate.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+		t.Fatalf("expected the course passed today without a grade, got %+v", got)
+	}
+
+	// only passed courses cover competencies
+	gap, err := f.app.CompetencyGap(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gap.Coverage != 50 || gap.Competencies[0].Title != "Go" || gap.Competencies[0].Covered || !gap.Competencies[1].Covered {
+		t.Fatalf("expected only SQL to be covered, got %+v", gap)
+	}
+
+	// organization scales replace the default one
+	if _, err = f.app.PostGradingScale(f.organizationId, 1, 100, 60); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = f.app.PostTrajectoryOutcome(goTrajectory.Id, 59, "", time.Time{}); err != nil || got.Result != store.TrajectoryFailed {
+		t.Fatalf("expected 59 of 100 to fail, got %+v, %v", got, err)
+	}
+	if got, err = f.app.PostTrajectoryOutcome(goTrajectory.Id, 60, store.TrajectoryPassed, time.Time{}); err != nil || got.Result != store.TrajectoryPassed {
+		t.Fatalf("expected 60 of 100 to pass, got %+v, %v", got, err)
+	}
+	if gap, err = f.app.CompetencyGap(studentId, f.professionId); err != nil || gap.Coverage != 100 {
+		t.Fatalf("expected both competencies to be covered, got %+v, %v", gap, err)
+	}
+}
+
+func TestTrajectoryRetake(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	courseId := f.course(t, "Go")
+	first, err := f.app.PostTrajectory(1, studentId, courseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = f.app.PostTrajectoryRetake(first.Id, 2); !errors.Is(err, ErrNotRetakable) {
+		t.Fatalf("expected ErrNotRetakable without the outcome, got %v", err)
+	}
+	if _, err = f.app.PostTrajectoryOutcome(first.Id, 2, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectoryRetake(uuid.NewV4(), 2); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing trajectory, got %v", err)
+	}
+
+	second, err := f.app.PostTrajectoryRetake(first.Id, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Attempt != 2 || second.RetakeOf != first.Id || second.Semester != 2 || second.Course != "Go" || second.Result != "" {
+		t.Fatalf("expected the second attempt linked to the first one, got %+v", second)
+	}
+	// the last attempt decides, the first one is failed but already retaken
+	if _, err = f.app.PostTrajectoryRetake(first.Id, 2); !errors.Is(err, ErrNotRetakable) {
+		t.Fatalf("expected ErrNotRetakable while the retake has no outcome, got %v", err)
+	}
+
+	if _, err = f.app.PostTrajectoryOutcome(second.Id, 0, store.TrajectoryFailed, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectoryRetake(second.Id, 1); !errors.Is(err, ErrWrongSemester) {
+		t.Fatalf("expected ErrWrongSemester for a retake before the failed attempt, got %v", err)
+	}
+	third, err := f.app.PostTrajectoryRetake(second.Id, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.Attempt != 3 || third.RetakeOf != first.Id {
+		t.Fatalf("expected the third attempt linked to the first one, got %+v", third)
+	}
+	if _, err = f.app.PostTrajectoryOutcome(first.Id, 4, "", time.Time{}); !errors.Is(err, ErrAttemptRetaken) {
+		t.Fatalf("expected ErrAttemptRetaken for the outcome of the retaken first attempt, got %v", err)
+	}
+	if _, err = f.app.PostTrajectoryOutcome(second.Id, 4, "", time.Time{}); !errors.Is(err, ErrAttemptRetaken) {
+		t.Fatalf("expected ErrAttemptRetaken for the outcome of the retaken second attempt, got %v", err)
+	}
+
+	if _, err = f.app.PostTrajectoryOutcome(third.Id, 4, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectoryRetake(first.Id, 2); !errors.Is(err, ErrNotRetakable) {
+		t.Fatalf("expected ErrNotRetakable for the passed course, got %v", err)
+	}
+}
+
+func TestTrajectoryAttempts(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	courseId := f.course(t, "Go")
+	otherId := f.course(t, "Алгоритмы")
+	first, err := f.app.PostTrajectory(1, studentId, courseId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectory(2, studentId, courseId); !errors.Is(err, ErrCourseTaken) {
+		t.Fatalf("expected ErrCourseTaken for the second attempt without a retake, got %v", err)
+	}
+	other, err := f.app.PostTrajectory(1, studentId, otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.UpdateTrajectory(other.Id, 1, studentId, courseId); !errors.Is(err, ErrCourseTaken) {
+		t.Fatalf("expected ErrCourseTaken for the move to the taken course, got %v", err)
+	}
+
+	if _, err = f.app.PostTrajectoryOutcome(first.Id, 2, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.UpdateTrajectory(first.Id, 1, studentId, f.course(t, "Базы данных")); !errors.Is(err, ErrOutcomeRecorded) {
+		t.Fatalf("expected ErrOutcomeRecorded for the move of the graded course, got %v", err)
+	}
+	if moved, err := f.app.UpdateTrajectory(first.Id, 2, studentId, courseId); err != nil || moved.Semester != 2 || moved.Grade != 2 {
+		t.Fatalf("expected the graded course to move to another semester with its outcome, got %+v, %v", moved, err)
+	}
+	retake, err := f.app.PostTrajectoryRetake(first.Id, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.UpdateTrajectory(retake.Id, 2, studentId, otherId); !errors.Is(err, ErrOutcomeRecorded) {
+		t.Fatalf("expected ErrOutcomeRecorded for the move of the retake, got %v", err)
+	}
+
+	var dependentsErr *DependentsError
+	if _, err = f.app.DeleteTrajectory(first.Id, false); !errors.As(err, &dependentsErr) {
+		t.Fatalf("expected DependentsError for the retaken course, got %v", err)
+	}
+	if _, err = f.app.DeleteTrajectory(first.Id, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.GetTrajectoryById(retake.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected the retake to be deleted with the first attempt, got %v", err)
+	}
+	if _, err = f.app.PostTrajectory(2, studentId, courseId); err != nil {
+		t.Fatalf("expected the course to be taken again after its attempts are deleted, got %v", err)
+	}
+}
+
+func TestGradingScale(t *testing.T) {
+	f := newFixture(t)
+	scale, err := f.app.GetGradingScale(f.organizationId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scale.Default || scale.MinGrade != 2 || scale.MaxGrade != 5 || scale.PassGrade != 3 {
+		t.Fatalf("expected the default scale, got %+v", scale)
+	}
+	if _, err = f.app.GetGradingScale(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing organization, got %v", err)
+	}
+
+	for _, grades := range [][3]uint8{{0, 5, 3}, {4, 5, 3}, {2, 5, 6}} {
+		if _, err = f.app.PostGradingScale(f.organizationId, grades[0], grades[1], grades[2]); !errors.Is(err, ErrWrongGradingScale) {
+			t.Errorf("grades %v: expected ErrWrongGradingScale, got %v", grades, err)
+		}
+	}
+	if _, err = f.app.PostGradingScale(f.organizationId, 1, 10, 5); err != nil {
+		t.Fatal(err)
+	}
+	if scale, err = f.app.GetGradingScale(f.organizationId); err != nil {
+		t.Fatal(err)
+	}
+	if scale.Default || scale.MinGrade != 1 || scale.MaxGrade != 10 || scale.PassGrade != 5 {
+		t.Fatalf("expected the scale of the organization, got %+v", scale)
+	}
+}
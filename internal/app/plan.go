@@ -0,0 +1,339 @@
+package app
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// planCoursesPerSemester limits how many new courses the plan puts into a single semester.
+const planCoursesPerSemester = 5
+
+type planCompetency struct {
+	id    uuid.UUID
+	title string
+	level uint8 // the level the profession requires
+}
+
+type planCourse struct {
+	id           uuid.UUID
+	title        string
+	credits      uint8
+	discipline   uuid.UUID
+	alternative  bool
+	competencies []uuid.UUID
+}
+
+// planLimits bounds a semester of the plan by the number of new courses and by credits of the educational program,
+// maxCredits 0 means no limit. taken is credits the student already has in semesters of the plan.
+type planLimits struct {
+	courses    int
+	maxCredits int
+	taken      map[uint8]int
+}
+
+// GetStudentPlan builds a personal educational plan that leads the student to the profession.
+// Competencies the student already has (portfolio projects, past trajectories and current study groups)
+// are skipped, the rest are covered by the smallest set of courses the greedy search can find.
+func (app *App) GetStudentPlan(studentId uuid.UUID, professionId uuid.UUID) (model.GetPlan, error) {
+	var resp model.GetPlan
+	if studentId == uuid.Nil || professionId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+	c, err := app.getStudentCalendar(studentId, student.Admition)
+	if err != nil {
+		return resp, err
+	}
+
+	profession, err := app.GetProfessionById(professionId)
+	if err != nil {
+		return resp, err
+	}
+	resp.StudentId = student.Id
+	resp.ProfessionId = profession.Id
+	resp.Profession = profession.Title
+
+	required, err := app.getRequiredCompetencies(professionId)
+	if err != nil {
+		return resp, err
+	}
+
+	acquired, err := app.getCompetencySources(studentId, student.PortfolioId)
+	if err != nil {
+		return resp, err
+	}
+
+	taken, err := app.getStudentCourses(studentId)
+	if err != nil {
+		return resp, err
+	}
+
+	var gap []planCompetency
+	for _, competency := range required {
+		if achievedLevel(acquired[competency.id]) < competency.level {
+			gap = append(gap, competency)
+		}
+	}
+
+	candidates, err := app.getCoursesByCompetencies(gap, taken)
+	if err != nil {
+		return resp, err
+	}
+
+	prerequisites, err := app.store.GetCoursePrerequisites()
+	if err != nil {
+		return resp, err
+	}
+
+	now := time.Now()
+	current := c.semester(now)
+	workload, err := app.getStudentWorkload(studentId, current, uuid.Nil)
+	if err != nil {
+		return resp, err
+	}
+	// without a program the limits are zero and nothing is violated
+	educationalProgram, _, err := app.getWorkloadProgram(studentId, uuid.Nil)
+	if err != nil {
+		return resp, err
+	}
+	limits := planLimits{courses: planCoursesPerSemester, maxCredits: int(educationalProgram.MaxSemesterCredits), taken: workload.credits}
+
+	startSemester := current
+	if taken.current {
+		startSemester++
+	}
+	resp.Semesters, resp.Uncovered = buildPlan(gap, candidates, newPrerequisiteGraph(prerequisites).hard(), startSemester, limits)
+	for i := range resp.Semesters {
+		if violation, ok := checkWorkload(educationalProgram, resp.Semesters[i].Semester, resp.Semesters[i].Credits); ok {
+			resp.Workload = append(resp.Workload, violation)
+		}
+
+		term := c.term(resp.Semesters[i].Semester, now)
+		start, end := c.termDates(term.year, term.term)
+		resp.Semesters[i].Start, resp.Semesters[i].End = model.JsonAdmitionDate(start), model.JsonAdmitionDate(end)
+	}
+	return resp, nil
+}
+
+func (app *App) getRequiredCompetencies(professionId uuid.UUID) ([]planCompetency, error) {
+	required, err := app.store.GetCompetenciesByProfession(professionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var competencies []planCompetency
+	for _, competency := range required {
+		competencies = append(competencies, planCompetency{id: competency.Id, title: competency.Title, level: competency.Level})
+	}
+
+	return competencies, nil
+}
+
+type studentCourses struct {
+	ids     map[uuid.UUID]bool
+	current bool               // student has courses in the current semester
+	chosen  map[uuid.UUID]bool // disciplines one of whose alternative courses the student has taken
+}
+
+func (app *App) getStudentCourses(studentId uuid.UUID) (studentCourses, error) {
+	courses := studentCourses{ids: make(map[uuid.UUID]bool), chosen: make(map[uuid.UUID]bool)}
+	taken, err := app.store.GetStudentCourses(studentId)
+	if err != nil {
+		return courses, err
+	}
+
+	for _, course := range taken {
+		courses.ids[course.CourseId] = true
+		courses.current = courses.current || course.Current
+		if course.Alternative {
+			courses.chosen[course.DisciplineId] = true
+		}
+	}
+
+	return courses, nil
+}
+
+// getCoursesByCompetencies returns courses the student has not taken that give competencies of the gap
+// at the required level or higher.
+func (app *App) getCoursesByCompetencies(gap []planCompetency, taken studentCourses) ([]planCourse, error) {
+	if len(gap) == 0 {
+		return nil, nil
+	}
+
+	required := make(map[uuid.UUID]uint8, len(gap))
+	competencyIds := make([]uuid.UUID, 0, len(gap))
+	for _, competency := range gap {
+		required[competency.id] = competency.level
+		competencyIds = append(competencyIds, competency.id)
+	}
+	pairs, err := app.store.GetCoursesByCompetencies(competencyIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var courses []planCourse
+	index := make(map[uuid.UUID]int)
+	for _, pair := range pairs {
+		if taken.ids[pair.CourseId] || pair.CourseAlternative && taken.chosen[pair.CourseDisciplineId] || pair.Level < required[pair.CompetencyId] {
+			continue
+		}
+
+		i, ok := index[pair.CourseId]
+		if !ok {
+			i = len(courses)
+			index[pair.CourseId] = i
+			courses = append(courses, planCourse{id: pair.CourseId, title: pair.CourseTitle, credits: pair.CourseCredits,
+				discipline: pair.CourseDisciplineId, alternative: pair.CourseAlternative})
+		}
+		courses[i].competencies = append(courses[i].competencies, pair.CompetencyId)
+	}
+
+	return courses, nil
+}
+
+// buildPlan covers the competency gap with courses (greedy set cover) and spreads the chosen courses
+// over semesters starting with startSemester within the limits, a course goes after the chosen courses it requires.
+// A course over the credit limit alone gets an empty semester. Competencies no course can cover are returned separately.
+// Only one of the alternative courses of a discipline is chosen, the other ones covering the same competencies
+// are listed as its alternatives.
+func buildPlan(gap []planCompetency, candidates []planCourse, requires map[uuid.UUID][]uuid.UUID, startSemester uint8, limits planLimits) ([]model.GetPlanSemester, []string) {
+	titles := make(map[uuid.UUID]string, len(gap))
+	uncovered := make(map[uuid.UUID]bool, len(gap))
+	for _, competency := range gap {
+		titles[competency.id] = competency.title
+		uncovered[competency.id] = true
+	}
+
+	var chosen []model.GetPlanCourse
+	used := make(map[uuid.UUID]bool)
+	chosenDisciplines := make(map[uuid.UUID]bool)
+	for len(uncovered) > 0 {
+		best := -1
+		var bestCovers []uuid.UUID
+		for i, course := range candidates {
+			if used[course.id] || course.alternative && chosenDisciplines[course.discipline] {
+				continue
+			}
+
+			var covers []uuid.UUID
+			for _, competencyId := range course.competencies {
+				if uncovered[competencyId] {
+					covers = append(covers, competencyId)
+				}
+			}
+			if len(covers) == 0 {
+				continue
+			}
+			if best == -1 || len(covers) > len(bestCovers) ||
+				(len(covers) == len(bestCovers) && course.title < candidates[best].title) {
+				best, bestCovers = i, covers
+			}
+		}
+		if best == -1 {
+			break
+		}
+
+		course := model.GetPlanCourse{Id: candidates[best].id, Title: candidates[best].title, Credits: candidates[best].credits}
+		for _, competencyId := range bestCovers {
+			course.Competencies = append(course.Competencies, titles[competencyId])
+			delete(uncovered, competencyId)
+		}
+		sort.Strings(course.Competencies)
+		if candidates[best].alternative {
+			chosenDisciplines[candidates[best].discipline] = true
+			course.Alternatives = planAlternatives(candidates[best], bestCovers, candidates)
+		}
+		used[course.Id] = true
+		chosen = append(chosen, course)
+	}
+
+	if startSemester == 0 {
+		startSemester = 1
+	}
+	var semesters []model.GetPlanSemester
+	placed := make(map[uuid.UUID]int)
+	for pending := chosen; len(pending) > 0; {
+		var waiting []model.GetPlanCourse
+		for _, course := range pending {
+			i, ready := 0, true
+			for _, prerequisiteId := range requires[course.Id] {
+				if !used[prerequisiteId] {
+					continue
+				}
+				semester, ok := placed[prerequisiteId]
+				if !ok {
+					ready = false
+					break
+				}
+				i = max(i, semester+1)
+			}
+			// a cycle written past the API must not stop the plan
+			if !ready && len(waiting) < len(pending)-1 {
+				waiting = append(waiting, course)
+				continue
+			}
+
+			for ; ; i++ {
+				for len(semesters) <= i {
+					semester := startSemester + uint8(len(semesters))
+					semesters = append(semesters, model.GetPlanSemester{Semester: semester, Credits: limits.taken[semester]})
+				}
+				if limits.fit(semesters[i], course) {
+					break
+				}
+			}
+			semesters[i].Courses = append(semesters[i].Courses, course)
+			semesters[i].Credits += int(course.Credits)
+			placed[course.Id] = i
+		}
+		pending = waiting
+	}
+
+	var missing []string
+	for _, competency := range gap {
+		if uncovered[competency.id] {
+			missing = append(missing, competency.title)
+		}
+	}
+
+	return semesters, missing
+}
+
+// planAlternatives returns the other alternative courses of the discipline that give every competency the course covers.
+func planAlternatives(course planCourse, covers []uuid.UUID, candidates []planCourse) []model.GetPlanAlternative {
+	var alternatives []model.GetPlanAlternative
+	for _, candidate := range candidates {
+		if candidate.id == course.id || !candidate.alternative || candidate.discipline != course.discipline {
+			continue
+		}
+		gives := make(map[uuid.UUID]bool, len(candidate.competencies))
+		for _, competencyId := range candidate.competencies {
+			gives[competencyId] = true
+		}
+		if slices.ContainsFunc(covers, func(competencyId uuid.UUID) bool { return !gives[competencyId] }) {
+			continue
+		}
+		alternatives = append(alternatives, model.GetPlanAlternative{Id: candidate.id, Title: candidate.title, Credits: candidate.credits})
+	}
+	return alternatives
+}
+
+// fit tells whether one more course goes into the semester of the plan.
+func (l planLimits) fit(semester model.GetPlanSemester, course model.GetPlanCourse) bool {
+	if len(semester.Courses) >= l.courses {
+		return false
+	}
+	if l.maxCredits == 0 || semester.Credits+int(course.Credits) <= l.maxCredits {
+		return true
+	}
+	return len(semester.Courses) == 0 && semester.Credits == 0
+}
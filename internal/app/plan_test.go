@@ -0,0 +1,128 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+func TestBuildPlan(t *testing.T) {
+	a := planCompetency{id: uuid.NewV4(), title: "a"}
+	b := planCompetency{id: uuid.NewV4(), title: "b"}
+	c := planCompetency{id: uuid.NewV4(), title: "c"}
+	missing := planCompetency{id: uuid.NewV4(), title: "missing"}
+
+	wide := planCourse{id: uuid.NewV4(), title: "wide", competencies: []uuid.UUID{a.id, b.id}}
+	narrowA := planCourse{id: uuid.NewV4(), title: "narrow a", competencies: []uuid.UUID{a.id}}
+	secondC := planCourse{id: uuid.NewV4(), title: "second c", competencies: []uuid.UUID{c.id}}
+	firstC := planCourse{id: uuid.NewV4(), title: "first c", competencies: []uuid.UUID{c.id}}
+
+	semesters, uncovered := buildPlan([]planCompetency{a, b, c, missing}, []planCourse{narrowA, secondC, wide, firstC}, nil, 3, planLimits{courses: 1})
+
+	want := []model.GetPlanSemester{
+		{Semester: 3, Courses: []model.GetPlanCourse{{Id: wide.id, Title: "wide", Competencies: []string{"a", "b"}}}},
+		// equal coverage is resolved by title
+		{Semester: 4, Courses: []model.GetPlanCourse{{Id: firstC.id, Title: "first c", Competencies: []string{"c"}}}},
+	}
+	if !reflect.DeepEqual(semesters, want) {
+		t.Fatalf("expected %+v, got %+v", want, semesters)
+	}
+	if !reflect.DeepEqual(uncovered, []string{"missing"}) {
+		t.Fatalf("expected only missing to be uncovered, got %v", uncovered)
+	}
+}
+
+func TestBuildPlanSemesterSize(t *testing.T) {
+	var gap []planCompetency
+	var candidates []planCourse
+	for _, title := range []string{"a", "b", "c", "d", "e"} {
+		competency := planCompetency{id: uuid.NewV4(), title: title}
+		gap = append(gap, competency)
+		candidates = append(candidates, planCourse{id: uuid.NewV4(), title: title, competencies: []uuid.UUID{competency.id}})
+	}
+
+	semesters, uncovered := buildPlan(gap, candidates, nil, 0, planLimits{courses: 2})
+	if len(uncovered) != 0 {
+		t.Fatalf("expected everything to be covered, got %v", uncovered)
+	}
+	if len(semesters) != 3 || semesters[0].Semester != 1 || len(semesters[0].Courses) != 2 || len(semesters[2].Courses) != 1 {
+		t.Fatalf("expected 2, 2 and 1 courses starting from the first semester, got %+v", semesters)
+	}
+}
+
+func TestGetStudentPlan(t *testing.T) {
+	f := newFixture(t)
+	byProject := f.competency(t, "by project", true)
+	byTrajectory := f.competency(t, "by trajectory", true)
+	needed := f.competency(t, "needed", true)
+	f.competency(t, "uncoverable", true)
+	f.competency(t, "not required", false)
+
+	studentId, portfolioId := f.student(t)
+	project, err := f.app.PostProject("Проект", "", "", "", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostProjectPortolio(project.Id, portfolioId, "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.app.PostProjectPortfolioCompetency(project.Id, portfolioId, byProject, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	past := f.course(t, "past", byTrajectory)
+	f.passed(t, 1, studentId, past)
+	next := f.course(t, "next", needed, byProject)
+	f.course(t, "useless", byProject)
+
+	plan, err := f.app.GetStudentPlan(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admition := time.Now().AddDate(-1, 0, 0)
+	semester := newCalendar(nil, nil).semesterOn(admition, time.Now())
+	term := newCalendar(nil, nil).semesterTerm(admition, semester)
+	start, end := newCalendar(nil, nil).termDates(term.year, term.term)
+	want := model.GetPlan{
+		StudentId:    studentId,
+		ProfessionId: f.professionId,
+		Profession:   "Backend-разработчик",
+		Semesters: []model.GetPlanSemester{{
+			Semester: semester,
+			Start:    model.JsonAdmitionDate(start),
+			End:      model.JsonAdmitionDate(end),
+			Courses:  []model.GetPlanCourse{{Id: next, Title: "next", Competencies: []string{"needed"}}},
+		}},
+		Uncovered: []string{"uncoverable"},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Fatalf("expected %+v, got %+v", want, plan)
+	}
+}
+
+func TestGetStudentPlanStartsAfterCurrentSemester(t *testing.T) {
+	f := newFixture(t)
+	needed := f.competency(t, "needed", true)
+	current := f.course(t, "current")
+	f.course(t, "next", needed)
+
+	studentId, _ := f.student(t)
+	if _, err := f.app.PostStudyGroup(current, studentId); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := f.app.GetStudentPlan(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := newCalendar(nil, nil).semesterOn(time.Now().AddDate(-1, 0, 0), time.Now()) + 1
+	if len(plan.Semesters) != 1 || plan.Semesters[0].Semester != want {
+		t.Fatalf("expected plan to start with semester %d, got %+v", want, plan.Semesters)
+	}
+}
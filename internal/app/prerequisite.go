@@ -0,0 +1,303 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongPrerequisiteKind = errors.New("prerequisite kind must be hard or soft")
+var ErrPrerequisiteCycle = errors.New("prerequisites form a cycle")
+
+// Reasons of prerequisite violations.
+const (
+	ViolationMissing       = "missing"
+	ViolationSameSemester  = "sameSemester"
+	ViolationLaterSemester = "laterSemester"
+)
+
+// PrerequisiteCycleError lists titles of the courses the new prerequisite would close into a cycle,
+// the first course is repeated at the end.
+type PrerequisiteCycleError struct {
+	Courses []string
+}
+
+func (e *PrerequisiteCycleError) Error() string {
+	return ErrPrerequisiteCycle.Error() + " (" + strings.Join(e.Courses, " -> ") + ")"
+}
+
+func (e *PrerequisiteCycleError) Unwrap() error {
+	return ErrPrerequisiteCycle
+}
+
+// prerequisiteGraph maps a course to the courses it requires.
+type prerequisiteGraph map[uuid.UUID][]store.CoursePrerequisite
+
+func newPrerequisiteGraph(prerequisites []store.CoursePrerequisite) prerequisiteGraph {
+	graph := make(prerequisiteGraph)
+	for _, prerequisite := range prerequisites {
+		graph[prerequisite.CourseId] = append(graph[prerequisite.CourseId], prerequisite)
+	}
+	return graph
+}
+
+// path returns courses leading from the course to the target through prerequisites, both ends included,
+// or nil when the target is not required by the course even indirectly.
+func (g prerequisiteGraph) path(courseId uuid.UUID, targetId uuid.UUID) []uuid.UUID {
+	visited := make(map[uuid.UUID]bool)
+	var walk func(id uuid.UUID) []uuid.UUID
+	walk = func(id uuid.UUID) []uuid.UUID {
+		if id == targetId {
+			return []uuid.UUID{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+
+		for _, prerequisite := range g[id] {
+			if rest := walk(prerequisite.PrerequisiteId); rest != nil {
+				return append([]uuid.UUID{id}, rest...)
+			}
+		}
+		return nil
+	}
+	return walk(courseId)
+}
+
+// hard returns hard prerequisites of every course.
+func (g prerequisiteGraph) hard() map[uuid.UUID][]uuid.UUID {
+	requires := make(map[uuid.UUID][]uuid.UUID)
+	for courseId, prerequisites := range g {
+		for _, prerequisite := range prerequisites {
+			if prerequisite.Kind == store.PrerequisiteHard {
+				requires[courseId] = append(requires[courseId], prerequisite.PrerequisiteId)
+			}
+		}
+	}
+	return requires
+}
+
+// PostCoursePrerequisite makes the course require the prerequisite, the kind of the existing prerequisite is replaced.
+// Prerequisites that would make a course require itself are rejected with *PrerequisiteCycleError.
+func (app *App) PostCoursePrerequisite(courseId uuid.UUID, prerequisiteId uuid.UUID, kind string) (model.GetCoursePrerequisite, error) {
+	resp := model.GetCoursePrerequisite{CourseId: courseId, PrerequisiteId: prerequisiteId, Kind: kind}
+	if courseId == uuid.Nil || prerequisiteId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if kind != store.PrerequisiteHard && kind != store.PrerequisiteSoft {
+		return resp, ErrWrongPrerequisiteKind
+	}
+
+	err := app.store.Transaction(func(tx store.Store) error {
+		course, err := tx.GetCourse(courseId)
+		if err != nil {
+			return err
+		}
+		prerequisite, err := tx.GetCourse(prerequisiteId)
+		if err != nil {
+			return err
+		}
+		resp.Course, resp.Prerequisite = course.Title, prerequisite.Title
+
+		prerequisites, err := tx.GetCoursePrerequisites()
+		if err != nil {
+			return err
+		}
+		if cycle := newPrerequisiteGraph(prerequisites).path(prerequisiteId, courseId); cycle != nil {
+			titles := []string{course.Title}
+			for _, id := range cycle {
+				cycleCourse, err := tx.GetCourse(id)
+				if err != nil {
+					return err
+				}
+				titles = append(titles, cycleCourse.Title)
+			}
+			return &PrerequisiteCycleError{Courses: titles}
+		}
+
+		return tx.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: courseId, PrerequisiteId: prerequisiteId, Kind: kind})
+	})
+	return resp, err
+}
+
+// GetCoursePrerequisites returns courses the course requires ordered by title.
+func (app *App) GetCoursePrerequisites(courseId uuid.UUID) ([]model.GetCoursePrerequisite, error) {
+	if courseId == uuid.Nil {
+		return nil, ErrEmptyId
+	}
+
+	course, err := app.store.GetCourse(courseId)
+	if err != nil {
+		return nil, err
+	}
+	prerequisites, err := app.store.GetCoursePrerequisites()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]model.GetCoursePrerequisite, 0)
+	for _, prerequisite := range newPrerequisiteGraph(prerequisites)[courseId] {
+		required, err := app.store.GetCourse(prerequisite.PrerequisiteId)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, model.GetCoursePrerequisite{
+			CourseId:       courseId,
+			Course:         course.Title,
+			PrerequisiteId: required.Id,
+			Prerequisite:   required.Title,
+			Kind:           prerequisite.Kind,
+		})
+	}
+
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Prerequisite < resp[j].Prerequisite })
+	return resp, nil
+}
+
+func (app *App) DeleteCoursePrerequisite(courseId uuid.UUID, prerequisiteId uuid.UUID) error {
+	if err := checkIds(courseId, prerequisiteId); err != nil {
+		return err
+	}
+	return app.store.DeleteCoursePrerequisite(courseId, prerequisiteId)
+}
+
+// ValidateStudentPlan checks that every course of the student, taken in past semesters (trajectories),
+// taken now (study groups) or planned, comes after its prerequisites. Each course counts in the earliest
+// semester the student takes it. The plan is valid when no hard prerequisite is violated.
+func (app *App) ValidateStudentPlan(studentId uuid.UUID, planned []model.PostPlannedCourse) (model.GetPlanValidation, error) {
+	resp := model.GetPlanValidation{StudentId: studentId, Violations: make([]model.GetPrerequisiteViolation, 0)}
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	for _, course := range planned {
+		if course.CourseId == uuid.Nil {
+			return resp, ErrEmptyId
+		}
+		if course.Semester == 0 {
+			return resp, ErrWrongSemester
+		}
+	}
+
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+
+	semesters := make(map[uuid.UUID]uint8)
+	take := func(courseId uuid.UUID, semester uint8) {
+		if taken, ok := semesters[courseId]; !ok || semester < taken {
+			semesters[courseId] = semester
+		}
+	}
+
+	trajectories, err := app.store.GetStudentTrajectories(studentId)
+	if err != nil {
+		return resp, err
+	}
+	for _, trajectory := range trajectories {
+		take(trajectory.CourseId, trajectory.Semester)
+	}
+
+	groups, err := app.store.GetStudyGroupCourses(studentId)
+	if err != nil {
+		return resp, err
+	}
+	if len(groups) > 0 {
+		current, err := app.getStudentSemester(studentId, student.Admition, time.Now())
+		if err != nil {
+			return resp, err
+		}
+		for _, course := range groups {
+			take(course.Id, current)
+		}
+	}
+
+	for _, course := range planned {
+		if _, err = app.store.GetCourse(course.CourseId); err != nil {
+			return resp, err
+		}
+		take(course.CourseId, course.Semester)
+	}
+
+	prerequisites, err := app.store.GetCoursePrerequisites()
+	if err != nil {
+		return resp, err
+	}
+	violations := checkPrerequisites(semesters, prerequisites)
+
+	titles := make(map[uuid.UUID]string)
+	title := func(id uuid.UUID) (string, error) {
+		if _, ok := titles[id]; !ok {
+			course, err := app.store.GetCourse(id)
+			if err != nil {
+				return "", err
+			}
+			titles[id] = course.Title
+		}
+		return titles[id], nil
+	}
+
+	resp.Valid = true
+	for _, violation := range violations {
+		if violation.Course, err = title(violation.CourseId); err != nil {
+			return resp, err
+		}
+		if violation.Prerequisite, err = title(violation.PrerequisiteId); err != nil {
+			return resp, err
+		}
+		resp.Valid = resp.Valid && violation.Kind != store.PrerequisiteHard
+		resp.Violations = append(resp.Violations, violation)
+	}
+
+	sort.SliceStable(resp.Violations, func(i, j int) bool {
+		a, b := resp.Violations[i], resp.Violations[j]
+		if a.Semester != b.Semester {
+			return a.Semester < b.Semester
+		}
+		if a.Course != b.Course {
+			return a.Course < b.Course
+		}
+		return a.Prerequisite < b.Prerequisite
+	})
+	return resp, nil
+}
+
+// checkPrerequisites returns prerequisites of the taken courses that are not taken at all
+// or are taken in the same or a later semester than the course, semesters maps taken courses to semesters.
+func checkPrerequisites(semesters map[uuid.UUID]uint8, prerequisites []store.CoursePrerequisite) []model.GetPrerequisiteViolation {
+	var violations []model.GetPrerequisiteViolation
+	for _, prerequisite := range prerequisites {
+		semester, ok := semesters[prerequisite.CourseId]
+		if !ok {
+			continue
+		}
+
+		violation := model.GetPrerequisiteViolation{
+			CourseId:       prerequisite.CourseId,
+			Semester:       semester,
+			PrerequisiteId: prerequisite.PrerequisiteId,
+			Kind:           prerequisite.Kind,
+		}
+		taken, ok := semesters[prerequisite.PrerequisiteId]
+		switch {
+		case !ok:
+			violation.Reason = ViolationMissing
+		case taken == semester:
+			violation.Reason = ViolationSameSemester
+		case taken > semester:
+			violation.Reason = ViolationLaterSemester
+		default:
+			continue
+		}
+		violation.PrerequisiteSemester = taken
+		violations = append(violations, violation)
+	}
+	return violations
+}
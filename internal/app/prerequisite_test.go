@@ -0,0 +1,154 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestPostCoursePrerequisiteCycle(t *testing.T) {
+	f := newFixture(t)
+	intro := f.course(t, "Введение в программирование")
+	golang := f.course(t, "Go")
+	advanced := f.course(t, "Продвинутый Go")
+
+	if _, err := f.app.PostCoursePrerequisite(advanced, golang, "optional"); !errors.Is(err, ErrWrongPrerequisiteKind) {
+		t.Fatalf("expected ErrWrongPrerequisiteKind, got %v", err)
+	}
+	for _, edge := range [][2]uuid.UUID{{golang, intro}, {advanced, golang}} {
+		if _, err := f.app.PostCoursePrerequisite(edge[0], edge[1], store.PrerequisiteHard); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := f.app.PostCoursePrerequisite(intro, advanced, store.PrerequisiteSoft)
+	var cycleErr *PrerequisiteCycleError
+	if !errors.As(err, &cycleErr) || !errors.Is(err, ErrPrerequisiteCycle) {
+		t.Fatalf("expected *PrerequisiteCycleError, got %v", err)
+	}
+	want := []string{"Введение в программирование", "Продвинутый Go", "Go", "Введение в программирование"}
+	if !reflect.DeepEqual(cycleErr.Courses, want) {
+		t.Fatalf("expected cycle %q, got %q", want, cycleErr.Courses)
+	}
+	if _, err = f.app.PostCoursePrerequisite(intro, intro, store.PrerequisiteHard); !errors.Is(err, ErrPrerequisiteCycle) {
+		t.Fatalf("expected the course requiring itself to be a cycle, got %v", err)
+	}
+
+	// changing the kind of the existing prerequisite is not a cycle
+	if _, err = f.app.PostCoursePrerequisite(advanced, golang, store.PrerequisiteSoft); err != nil {
+		t.Fatal(err)
+	}
+	prerequisites, err := f.app.GetCoursePrerequisites(advanced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prerequisites) != 1 || prerequisites[0].Prerequisite != "Go" || prerequisites[0].Kind != store.PrerequisiteSoft {
+		t.Fatalf("unexpected prerequisites %+v", prerequisites)
+	}
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	first, second, third, missing := uuid.NewV4(), uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	semesters := map[uuid.UUID]uint8{first: 1, second: 2, third: 2}
+	prerequisites := []store.CoursePrerequisite{
+		{CourseId: second, PrerequisiteId: first, Kind: store.PrerequisiteHard},
+		{CourseId: third, PrerequisiteId: second, Kind: store.PrerequisiteHard},
+		{CourseId: first, PrerequisiteId: third, Kind: store.PrerequisiteSoft},
+		{CourseId: third, PrerequisiteId: missing, Kind: store.PrerequisiteSoft},
+		// prerequisites of courses the student does not take are not checked
+		{CourseId: missing, PrerequisiteId: first, Kind: store.PrerequisiteHard},
+	}
+
+	want := []model.GetPrerequisiteViolation{
+		{CourseId: third, Semester: 2, PrerequisiteId: second, PrerequisiteSemester: 2, Kind: store.PrerequisiteHard, Reason: ViolationSameSemester},
+		{CourseId: first, Semester: 1, PrerequisiteId: third, PrerequisiteSemester: 2, Kind: store.PrerequisiteSoft, Reason: ViolationLaterSemester},
+		{CourseId: third, Semester: 2, PrerequisiteId: missing, Kind: store.PrerequisiteSoft, Reason: ViolationMissing},
+	}
+	if got := checkPrerequisites(semesters, prerequisites); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestValidateStudentPlan(t *testing.T) {
+	f := newFixture(t)
+	intro := f.course(t, "Введение в программирование")
+	golang := f.course(t, "Go")
+	advanced := f.course(t, "Продвинутый Go")
+	if _, err := f.app.PostCoursePrerequisite(golang, intro, store.PrerequisiteHard); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.app.PostCoursePrerequisite(advanced, golang, store.PrerequisiteSoft); err != nil {
+		t.Fatal(err)
+	}
+
+	studentId, _ := f.student(t)
+	if _, err := f.app.PostTrajectory(1, studentId, intro); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.app.PostStudyGroup(golang, studentId); err != nil {
+		t.Fatal(err)
+	}
+	current := newCalendar(nil, nil).semesterOn(time.Now().AddDate(-1, 0, 0), time.Now())
+
+	valid, err := f.app.ValidateStudentPlan(studentId, []model.PostPlannedCourse{{CourseId: advanced, Semester: current + 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid.Valid || len(valid.Violations) != 0 {
+		t.Fatalf("expected the plan to be valid, got %+v", valid)
+	}
+
+	// the advanced course planned together with its soft prerequisite is only a warning
+	warned, err := f.app.ValidateStudentPlan(studentId, []model.PostPlannedCourse{{CourseId: advanced, Semester: current}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.GetPrerequisiteViolation{
+		CourseId: advanced, Course: "Продвинутый Go", Semester: current,
+		PrerequisiteId: golang, Prerequisite: "Go", PrerequisiteSemester: current,
+		Kind: store.PrerequisiteSoft, Reason: ViolationSameSemester,
+	}
+	if !warned.Valid || len(warned.Violations) != 1 || warned.Violations[0] != want {
+		t.Fatalf("expected only the soft violation %+v, got %+v", want, warned)
+	}
+
+	other, _ := f.student(t)
+	invalid, err := f.app.ValidateStudentPlan(other, []model.PostPlannedCourse{{CourseId: golang, Semester: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invalid.Valid || len(invalid.Violations) != 1 || invalid.Violations[0].Reason != ViolationMissing {
+		t.Fatalf("expected the missing hard prerequisite, got %+v", invalid)
+	}
+
+	if _, err = f.app.ValidateStudentPlan(studentId, []model.PostPlannedCourse{{CourseId: advanced}}); !errors.Is(err, ErrWrongSemester) {
+		t.Fatalf("expected ErrWrongSemester, got %v", err)
+	}
+}
+
+func TestBuildPlanPrerequisites(t *testing.T) {
+	a := planCompetency{id: uuid.NewV4(), title: "a"}
+	b := planCompetency{id: uuid.NewV4(), title: "b"}
+	c := planCompetency{id: uuid.NewV4(), title: "c"}
+	advanced := planCourse{id: uuid.NewV4(), title: "advanced", competencies: []uuid.UUID{a.id, b.id}}
+	intro := planCourse{id: uuid.NewV4(), title: "intro", competencies: []uuid.UUID{c.id}}
+	requires := map[uuid.UUID][]uuid.UUID{advanced.id: {intro.id, uuid.NewV4()}}
+
+	semesters, _ := buildPlan([]planCompetency{a, b, c}, []planCourse{advanced, intro}, requires, 2, planLimits{courses: 5})
+	if len(semesters) != 2 || semesters[0].Courses[0].Id != intro.id || semesters[1].Courses[0].Id != advanced.id || semesters[1].Semester != 3 {
+		t.Fatalf("expected intro before advanced, got %+v", semesters)
+	}
+
+	// a cycle does not lose courses
+	requires[intro.id] = []uuid.UUID{advanced.id}
+	semesters, _ = buildPlan([]planCompetency{a, b, c}, []planCourse{advanced, intro}, requires, 2, planLimits{courses: 5})
+	if len(semesters) != 2 {
+		t.Fatalf("expected both courses to be planned, got %+v", semesters)
+	}
+}
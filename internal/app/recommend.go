@@ -0,0 +1,304 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const (
+	DefaultRecommendationLimit = 10
+	MaxRecommendationLimit     = 50
+)
+
+// Factors of the course score.
+const (
+	FactorCoverage   = "coverage"
+	FactorTechnology = "technology"
+	FactorReadiness  = "readiness"
+	FactorWorkload   = "workload"
+)
+
+// recommendationWeights sum up to 1, so the score of a course is from 0 to 100.
+var recommendationWeights = map[string]float64{
+	FactorCoverage:   0.5,
+	FactorTechnology: 0.2,
+	FactorReadiness:  0.2,
+	FactorWorkload:   0.1,
+}
+
+// recommendationData is everything the score of a course depends on.
+type recommendationData struct {
+	gap           map[uuid.UUID]uint8 // competencies of the profession the student lacks -> the required level
+	known         map[uuid.UUID]bool  // main technologies of the portfolio projects
+	taken         studentCourses
+	courses       map[uuid.UUID]store.Course
+	competencies  map[uuid.UUID]store.Competency
+	technologies  map[uuid.UUID]string
+	gives         map[uuid.UUID][]store.CatalogLink // course -> links to competencies it gives
+	prerequisites prerequisiteGraph
+	program       store.EducationalProgram
+	semester      uint8 // the semester the recommended courses are taken in
+	credits       int   // credits the student already has in the semester
+}
+
+// RecommendCourses ranks courses the student has not taken for the profession. The score of a course is the weighted sum
+// of its factors: the share of the missing competencies of the profession the course gives, the share of its technologies
+// (main technologies of its competencies) the student used in portfolio projects, the share of its prerequisites
+// the student has taken (hard ones count twice) and whether its credits fit into the next semester.
+// A competency counts as missing or given only at the level the profession requires or higher.
+// Only courses giving at least one missing competency are recommended, at most limit of them. Alternative courses
+// of a discipline whose alternative the student has taken are skipped.
+func (app *App) RecommendCourses(studentId uuid.UUID, professionId uuid.UUID, limit int) (model.GetRecommendations, error) {
+	resp := model.GetRecommendations{StudentId: studentId, ProfessionId: professionId, Courses: []model.GetCourseRecommendation{}}
+	if studentId == uuid.Nil || professionId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if limit <= 0 {
+		limit = DefaultRecommendationLimit
+	}
+	if limit > MaxRecommendationLimit {
+		limit = MaxRecommendationLimit
+	}
+
+	student, err := app.store.GetStudent(studentId)
+	if err != nil {
+		return resp, err
+	}
+	profession, err := app.store.GetProfession(professionId)
+	if err != nil {
+		return resp, err
+	}
+	resp.Profession = profession.Title
+
+	data, err := app.getRecommendationData(student, professionId)
+	if err != nil {
+		return resp, err
+	}
+	resp.Semester = data.semester
+
+	for _, course := range data.courses {
+		if data.taken.ids[course.Id] || course.Alternative && data.taken.chosen[course.DisciplineId] {
+			continue
+		}
+		recommendation := data.score(course)
+		if recommendation.Factors[0].Value > 0 {
+			resp.Courses = append(resp.Courses, recommendation)
+		}
+	}
+
+	sort.Slice(resp.Courses, func(i, j int) bool {
+		if resp.Courses[i].Score != resp.Courses[j].Score {
+			return resp.Courses[i].Score > resp.Courses[j].Score
+		}
+		return resp.Courses[i].Title < resp.Courses[j].Title
+	})
+	if len(resp.Courses) > limit {
+		resp.Courses = resp.Courses[:limit]
+	}
+	return resp, nil
+}
+
+func (app *App) getRecommendationData(student store.Student, professionId uuid.UUID) (recommendationData, error) {
+	data := recommendationData{
+		gap:          make(map[uuid.UUID]uint8),
+		known:        make(map[uuid.UUID]bool),
+		courses:      make(map[uuid.UUID]store.Course),
+		competencies: make(map[uuid.UUID]store.Competency),
+		technologies: make(map[uuid.UUID]string),
+		gives:        make(map[uuid.UUID][]store.CatalogLink),
+	}
+
+	required, err := app.getRequiredCompetencies(professionId)
+	if err != nil {
+		return data, err
+	}
+	acquired, err := app.getCompetencySources(student.Id, student.PortfolioId)
+	if err != nil {
+		return data, err
+	}
+	for _, competency := range required {
+		if achievedLevel(acquired[competency.id]) < competency.level {
+			data.gap[competency.id] = competency.level
+		}
+	}
+
+	projects, err := app.store.GetProjectPortfolios(student.PortfolioId)
+	if err != nil {
+		return data, err
+	}
+	for _, projectPortfolio := range projects {
+		project, err := app.store.GetProject(projectPortfolio.ProjectId)
+		if err != nil {
+			return data, err
+		}
+		if project.MainTechnologyId != uuid.Nil {
+			data.known[project.MainTechnologyId] = true
+		}
+	}
+
+	if data.taken, err = app.getStudentCourses(student.Id); err != nil {
+		return data, err
+	}
+
+	catalog, err := app.store.GetCatalog()
+	if err != nil {
+		return data, err
+	}
+	for _, course := range catalog.Courses {
+		data.courses[course.Id] = course
+	}
+	for _, competency := range catalog.Competencies {
+		data.competencies[competency.Id] = competency
+	}
+	for _, technology := range catalog.Technologies {
+		data.technologies[technology.Id] = technology.Title
+	}
+	for _, link := range catalog.CourseCompetencies {
+		data.gives[link.FromId] = append(data.gives[link.FromId], link)
+	}
+
+	prerequisites, err := app.store.GetCoursePrerequisites()
+	if err != nil {
+		return data, err
+	}
+	data.prerequisites = newPrerequisiteGraph(prerequisites)
+
+	c, err := app.getStudentCalendar(student.Id, student.Admition)
+	if err != nil {
+		return data, err
+	}
+	current := c.semester(time.Now())
+	data.semester = current
+	if data.taken.current {
+		data.semester++
+	}
+	workload, err := app.getStudentWorkload(student.Id, current, uuid.Nil)
+	if err != nil {
+		return data, err
+	}
+	data.credits = workload.credits[data.semester]
+	// without a program there are no limits and every course fits
+	data.program, _, err = app.getWorkloadProgram(student.Id, uuid.Nil)
+	return data, err
+}
+
+// score breaks the score of the course into factors, the coverage goes first.
+func (data recommendationData) score(course store.Course) model.GetCourseRecommendation {
+	recommendation := model.GetCourseRecommendation{Id: course.Id, Title: course.Title, Credits: course.Credits}
+	factors := []model.GetRecommendationFactor{
+		data.coverage(course),
+		data.technology(course),
+		data.readiness(course),
+		data.workload(course),
+	}
+
+	for i := range factors {
+		weight := recommendationWeights[factors[i].Factor]
+		score := factors[i].Value * weight * 100
+		factors[i].Value, factors[i].Weight, factors[i].Score = round(factors[i].Value), weight, round(score)
+		recommendation.Score += score
+		sort.Strings(factors[i].Items)
+	}
+	recommendation.Score = round(recommendation.Score)
+	recommendation.Factors = factors
+	return recommendation
+}
+
+func (data recommendationData) coverage(course store.Course) model.GetRecommendationFactor {
+	factor := model.GetRecommendationFactor{Factor: FactorCoverage}
+	for _, link := range data.gives[course.Id] {
+		if level, ok := data.gap[link.ToId]; ok && link.Level >= level {
+			factor.Items = append(factor.Items, data.competencies[link.ToId].Title)
+		}
+	}
+	factor.Value = share(len(factor.Items), len(data.gap))
+	factor.Reason = fmt.Sprintf("gives %d of %d missing competencies of the profession", len(factor.Items), len(data.gap))
+	return factor
+}
+
+func (data recommendationData) technology(course store.Course) model.GetRecommendationFactor {
+	factor := model.GetRecommendationFactor{Factor: FactorTechnology}
+	technologies := make(map[uuid.UUID]bool)
+	for _, link := range data.gives[course.Id] {
+		if technologyId := data.competencies[link.ToId].MainTechnologyId; technologyId != uuid.Nil {
+			technologies[technologyId] = true
+		}
+	}
+	for technologyId := range technologies {
+		if data.known[technologyId] {
+			factor.Items = append(factor.Items, data.technologies[technologyId])
+		}
+	}
+	if len(technologies) == 0 {
+		factor.Reason = "the course names no technologies"
+		return factor
+	}
+	factor.Value = share(len(factor.Items), len(technologies))
+	factor.Reason = fmt.Sprintf("%d of %d technologies of the course are used in portfolio projects", len(factor.Items), len(technologies))
+	return factor
+}
+
+// readiness lists the prerequisites the student has not taken.
+func (data recommendationData) readiness(course store.Course) model.GetRecommendationFactor {
+	factor := model.GetRecommendationFactor{Factor: FactorReadiness}
+	prerequisites := data.prerequisites[course.Id]
+	if len(prerequisites) == 0 {
+		factor.Value = 1
+		factor.Reason = "the course has no prerequisites"
+		return factor
+	}
+
+	var total, taken int
+	for _, prerequisite := range prerequisites {
+		weight := 1
+		if prerequisite.Kind == store.PrerequisiteHard {
+			weight = 2
+		}
+		total += weight
+		if data.taken.ids[prerequisite.PrerequisiteId] {
+			taken += weight
+		} else {
+			factor.Items = append(factor.Items, data.courses[prerequisite.PrerequisiteId].Title)
+		}
+	}
+	factor.Value = share(taken, total)
+	factor.Reason = fmt.Sprintf("%d of %d prerequisites are taken", len(prerequisites)-len(factor.Items), len(prerequisites))
+	return factor
+}
+
+// workload is the share of the course credits that fit into the semester under the maximum of the educational program.
+func (data recommendationData) workload(course store.Course) model.GetRecommendationFactor {
+	factor := model.GetRecommendationFactor{Factor: FactorWorkload, Value: 1}
+	maxCredits := int(data.program.MaxSemesterCredits)
+	if maxCredits == 0 {
+		factor.Reason = fmt.Sprintf("semester %d has no credit limit", data.semester)
+		return factor
+	}
+	if course.Credits == 0 {
+		factor.Reason = "the course has no credits"
+		return factor
+	}
+
+	free := max(maxCredits-data.credits, 0)
+	factor.Value = math.Min(share(free, int(course.Credits)), 1)
+	factor.Reason = fmt.Sprintf("semester %d has %d of %d credits free, the course takes %d", data.semester, free, maxCredits, course.Credits)
+	return factor
+}
+
+func share(part int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestRecommendCourses(t *testing.T) {
+	f := newFixture(t)
+	studentId, portfolioId := f.student(t)
+	technology, err := f.app.PostTechnology("Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend, err := f.app.PostCompetency("Backend", uuid.Nil, nil, technology.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.app.PostCompetencyProfession(backend.Id, f.professionId, ""); err != nil {
+		t.Fatal(err)
+	}
+	databases := f.competency(t, "Базы данных", true)
+	testingId := f.competency(t, "Тестирование", true)
+
+	project, err := f.app.PostProject("Бот", "", "", "", technology.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostProjectPortolio(project.Id, portfolioId, "разработчик", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	goId := f.course(t, "Go", backend.Id, databases)
+	f.course(t, "SQL", databases)
+	advancedId := f.course(t, "Тестирование на Go", testingId)
+	f.course(t, "Рисование")
+	if _, err = f.app.PostCoursePrerequisite(advancedId, goId, store.PrerequisiteHard); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := f.app.RecommendCourses(studentId, f.professionId, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	var scores []float64
+	for _, course := range resp.Courses {
+		titles = append(titles, course.Title)
+		scores = append(scores, course.Score)
+	}
+	if want := []string{"Go", "SQL", "Тестирование на Go"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("expected courses %v, got %v", want, titles)
+	}
+	// 2/3 * 50 + 20 + 20 + 10, 1/3 * 50 + 0 + 20 + 10 and 1/3 * 50 + 0 + 0 + 10
+	if want := []float64{83.33, 46.67, 26.67}; !reflect.DeepEqual(scores, want) {
+		t.Fatalf("expected scores %v, got %v", want, scores)
+	}
+
+	factors := resp.Courses[0].Factors
+	if want := (model.GetRecommendationFactor{Factor: FactorCoverage, Weight: 0.5, Value: 0.67, Score: 33.33,
+		Reason: "gives 2 of 3 missing competencies of the profession", Items: []string{"Backend", "Базы данных"}}); !reflect.DeepEqual(factors[0], want) {
+		t.Fatalf("expected coverage %+v, got %+v", want, factors[0])
+	}
+	if factors[1].Factor != FactorTechnology || factors[1].Value != 1 || !reflect.DeepEqual(factors[1].Items, []string{"Go"}) {
+		t.Fatalf("unexpected technology factor %+v", factors[1])
+	}
+	if readiness := resp.Courses[2].Factors[2]; readiness.Value != 0 || !reflect.DeepEqual(readiness.Items, []string{"Go"}) {
+		t.Fatalf("expected the missing prerequisite, got %+v", readiness)
+	}
+
+	if resp, err = f.app.RecommendCourses(studentId, f.professionId, 1); err != nil || len(resp.Courses) != 1 {
+		t.Fatalf("expected one course, got %+v, %v", resp, err)
+	}
+	if _, err = f.app.RecommendCourses(studentId, uuid.NewV4(), 0); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown profession, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+	MinSearchQuery     = 2
+)
+
+var ErrShortQuery = errors.New("search query must be at least 2 characters")
+
+// Search finds catalog entities by words of their titles, descriptions and skills in any form,
+// titles are found despite typos too. Hits are grouped by kind of entity, limit applies to every group.
+// Groups go in order of their best hit.
+func (app *App) Search(query string, limit int) (model.GetSearch, error) {
+	query = strings.TrimSpace(query)
+	resp := model.GetSearch{Query: query, Groups: []model.GetSearchGroup{}}
+	if utf8.RuneCountInString(query) < MinSearchQuery {
+		return resp, ErrShortQuery
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	hits, err := app.store.Search(query, limit)
+	if err != nil {
+		return resp, err
+	}
+
+	groups := make(map[string]int)
+	for _, hit := range hits {
+		i, ok := groups[hit.Kind]
+		if !ok {
+			i = len(resp.Groups)
+			groups[hit.Kind] = i
+			resp.Groups = append(resp.Groups, model.GetSearchGroup{Kind: hit.Kind})
+		}
+		resp.Groups[i].Hits = append(resp.Groups[i].Hits, model.GetSearchHit{
+			Id:      hit.Id,
+			Title:   hit.Title,
+			Snippet: hit.Snippet,
+			Rank:    hit.Rank,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestSearch(t *testing.T) {
+	f := newFixture(t)
+	course, err := f.app.PostCourse("Машинное обучение", "Нейронные сети и градиентный бустинг", nil, f.disciplineId, 0, 0, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	competency, err := f.app.PostCompetency("Нейронные сети", uuid.Nil, []string{"обучение моделей"}, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := f.app.Search("  нейронных сетей ", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Query != "нейронных сетей" || len(resp.Groups) != 2 {
+		t.Fatalf("unexpected search %+v", resp)
+	}
+	// the title match ranks higher than the description one
+	if group := resp.Groups[0]; group.Kind != store.SearchCompetency || len(group.Hits) != 1 || group.Hits[0].Id != competency.Id {
+		t.Fatalf("expected the competency group first, got %+v", resp.Groups)
+	}
+	group := resp.Groups[1]
+	if group.Kind != store.SearchCourse || len(group.Hits) != 1 || group.Hits[0].Id != course.Id {
+		t.Fatalf("expected the course group, got %+v", group)
+	}
+	if !strings.Contains(group.Hits[0].Snippet, "<b>Нейронные</b>") {
+		t.Fatalf("expected highlighted snippet, got %q", group.Hits[0].Snippet)
+	}
+
+	if resp, err = f.app.Search("квантовая физика", 0); err != nil || len(resp.Groups) != 0 {
+		t.Fatalf("expected no groups, got %+v, %v", resp, err)
+	}
+	if _, err = f.app.Search(" я ", 0); !errors.Is(err, ErrShortQuery) {
+		t.Fatalf("expected ErrShortQuery, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrEnrollmentClosed = errors.New("enrollment in courses of the organization is closed")
+var ErrAlreadyEnrolled = errors.New("student is already enrolled in or waitlisted for the course")
+
+// checkEnrollmentWindow refuses enrollment on the day outside every enrollment window of the organization
+// the course belongs to. Organizations without enrollment windows accept students any day, unknown courses
+// are left to the foreign keys.
+func (app *App) checkEnrollmentWindow(courseId uuid.UUID, day time.Time) error {
+	organizationId, err := app.getCourseOrganization(courseId)
+	if errors.Is(err, store.ErrNotFound) || err == nil && organizationId == uuid.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	semesters, err := app.store.GetCalendarSemesters(organizationId)
+	if err != nil {
+		return err
+	}
+
+	day = dateOf(day)
+	limited := false
+	for _, semester := range semesters {
+		if semester.EnrollmentStart.IsZero() {
+			continue
+		}
+		limited = true
+		if !day.Before(semester.EnrollmentStart) && !day.After(semester.EnrollmentEnd) {
+			return nil
+		}
+	}
+	if limited {
+		return ErrEnrollmentClosed
+	}
+	return nil
+}
+
+// getCourseOrganization returns the organization whose educational program the course belongs to,
+// uuid.Nil for courses without a discipline.
+func (app *App) getCourseOrganization(courseId uuid.UUID) (uuid.UUID, error) {
+	course, err := app.store.GetCourse(courseId)
+	if err != nil || course.DisciplineId == uuid.Nil {
+		return uuid.Nil, err
+	}
+	discipline, err := app.store.GetDiscipline(course.DisciplineId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	program, err := app.store.GetEducationalProgram(discipline.EducationalProgramId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return program.OrganizationId, nil
+}
+
+// checkEnrollment refuses the course to the student who has chosen another alternative of its discipline,
+// would go over the maximum workload with it or enrolls on the day outside the enrollment windows.
+func (app *App) checkEnrollment(studentId uuid.UUID, courseId uuid.UUID, day time.Time) error {
+	if err := app.checkAlternative(studentId, courseId); err != nil {
+		return err
+	}
+	if err := app.checkStudentWorkload(studentId, courseId, 0, uuid.Nil); err != nil {
+		return err
+	}
+	return app.checkEnrollmentWindow(courseId, day)
+}
+
+// fillStudyGroup moves students from the head of the waitlist of the course to its study group while there are free seats.
+// Students who can not enroll in the course today are skipped and stay on the waitlist.
+func fillStudyGroup(tx store.Store, courseId uuid.UUID) error {
+	if err := tx.Lock(store.TableCourses, courseId); err != nil {
+		return err
+	}
+	enrolled, err := tx.CountStudyGroup(courseId)
+	if err != nil {
+		return err
+	}
+	course, err := tx.GetCourse(courseId)
+	if err != nil {
+		return err
+	}
+	waitlist, err := tx.GetCourseWaitlist(courseId)
+	if err != nil {
+		return err
+	}
+
+	checker := &App{store: tx}
+	today := time.Now()
+	for _, studentId := range waitlist {
+		if course.Capacity != 0 && enrolled >= int(course.Capacity) {
+			break
+		}
+		if err = tx.Lock(store.TableStudents, studentId); err != nil {
+			return err
+		}
+		err = checker.checkEnrollment(studentId, courseId, today)
+		var alternativeErr *AlternativeError
+		var workloadErr *WorkloadError
+		if errors.As(err, &alternativeErr) || errors.As(err, &workloadErr) || errors.Is(err, ErrEnrollmentClosed) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if err = tx.DeleteWaitlistEntry(courseId, studentId); err != nil {
+			return err
+		}
+		if err = tx.CreateStudyGroup(courseId, studentId); err != nil {
+			return err
+		}
+		enrolled++
+	}
+	return nil
+}
+
+// PostStudyGroup enrolls the student in the course while it has free seats, otherwise the student is put
+// at the end of its waitlist. Enrollment is open only within the enrollment windows of the organization.
+func (app *App) PostStudyGroup(courseId uuid.UUID, studentId uuid.UUID) (model.GetStudyGroupEntry, error) {
+	resp := model.GetStudyGroupEntry{CourseId: courseId}
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if courseId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	// the course and then the student are locked, so concurrent enrollments of the student
+	// into alternatives or over the workload wait for each other like the ones into the same seat
+	err := app.store.Transaction(func(tx store.Store) error {
+		if err := tx.Lock(store.TableCourses, courseId); err != nil {
+			return err
+		}
+		if err := tx.Lock(store.TableStudents, studentId); err != nil {
+			return err
+		}
+		if err := (&App{store: tx}).checkEnrollment(studentId, courseId, time.Now()); err != nil {
+			return err
+		}
+
+		entries, err := tx.GetStudyGroupEntries(studentId)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.CourseId == courseId && entry.Status != store.StudyGroupDropped {
+				return ErrAlreadyEnrolled
+			}
+		}
+
+		enrolled, err := tx.CountStudyGroup(courseId)
+		if err != nil {
+			return err
+		}
+		course, err := tx.GetCourse(courseId)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		resp.Course = course.Title
+
+		if course.Capacity == 0 || enrolled < int(course.Capacity) {
+			if err = tx.CreateStudyGroup(courseId, studentId); err != nil {
+				return err
+			}
+			resp.Status = store.StudyGroupEnrolled
+		} else {
+			if err = tx.CreateWaitlistEntry(courseId, studentId); err != nil {
+				return err
+			}
+			waitlist, err := tx.GetCourseWaitlist(courseId)
+			if err != nil {
+				return err
+			}
+			resp.Status, resp.Position = store.StudyGroupWaitlisted, len(waitlist)
+		}
+		return tx.DeleteStudyGroupDrop(courseId, studentId)
+	})
+	return resp, err
+}
+
+// DeleteStudyGroup drops the student from the course or from its waitlist today,
+// the freed seat goes to the first student of the waitlist who can enroll in the course.
+func (app *App) DeleteStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
+	if courseId == uuid.Nil || studentId == uuid.Nil {
+		return ErrEmptyId
+	}
+
+	return app.store.Transaction(func(tx store.Store) error {
+		err := tx.DeleteStudyGroup(courseId, studentId)
+		if errors.Is(err, store.ErrNotFound) {
+			err = tx.DeleteWaitlistEntry(courseId, studentId)
+		}
+		if err != nil {
+			return err
+		}
+		if err = tx.CreateStudyGroupDrop(courseId, studentId, dateOf(time.Now())); err != nil {
+			return err
+		}
+		return fillStudyGroup(tx, courseId)
+	})
+}
+
+// GetStudyGroupsByStudent returns titles of the courses the student studies in the current semester
+// and the status of the student on every course the student enrolled in, waits for or dropped.
+func (app *App) GetStudyGroupsByStudent(studentId uuid.UUID) (model.GetStudyGroups, error) {
+	resp := model.GetStudyGroups{Entries: []model.GetStudyGroupEntry{}}
+	courses, err := app.store.GetStudyGroupCourses(studentId)
+	if err != nil {
+		return resp, err
+	}
+	for _, course := range courses {
+		resp.Courses = append(resp.Courses, course.Title)
+	}
+
+	entries, err := app.store.GetStudyGroupEntries(studentId)
+	if err != nil {
+		return resp, err
+	}
+	for _, entry := range entries {
+		course, err := app.store.GetCourse(entry.CourseId)
+		if err != nil {
+			return resp, err
+		}
+		resp.Entries = append(resp.Entries, model.GetStudyGroupEntry{
+			CourseId: entry.CourseId,
+			Course:   course.Title,
+			Status:   entry.Status,
+			Position: entry.Position,
+			DropDate: optionalDate(entry.DropDate),
+		})
+	}
+	return resp, nil
+}
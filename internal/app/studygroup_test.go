@@ -0,0 +1,227 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestStudyGroupWaitlist(t *testing.T) {
+	f := newFixture(t)
+	course, err := f.app.PostCourse("Базы данных", "", nil, f.disciplineId, 0, 0, false, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var students []uuid.UUID
+	for _, fullName := range []string{"Первый", "Второй", "Третий", "Четвёртый"} {
+		student, err := f.app.PostStudent(fullName, time.Now().AddDate(-1, 0, 0), uuid.Nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		students = append(students, student.Id)
+	}
+
+	for i, want := range []model.GetStudyGroupEntry{
+		{Status: store.StudyGroupEnrolled},
+		{Status: store.StudyGroupEnrolled},
+		{Status: store.StudyGroupWaitlisted, Position: 1},
+		{Status: store.StudyGroupWaitlisted, Position: 2},
+	} {
+		want.CourseId, want.Course = course.Id, course.Title
+		entry, err := f.app.PostStudyGroup(course.Id, students[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry != want {
+			t.Fatalf("expected %+v for student %d, got %+v", want, i, entry)
+		}
+	}
+	if _, err = f.app.PostStudyGroup(course.Id, students[3]); !errors.Is(err, ErrAlreadyEnrolled) {
+		t.Fatalf("expected ErrAlreadyEnrolled for the waitlisted student, got %v", err)
+	}
+	if got, err := f.app.GetCourseById(course.Id); err != nil || got.Capacity != 2 || got.Enrolled != 2 || got.Waitlisted != 2 {
+		t.Fatalf("expected two enrolled and two waitlisted students, got %+v, %v", got, err)
+	}
+
+	// the freed seat goes to the head of the waitlist
+	if err = f.app.DeleteStudyGroup(course.Id, students[0]); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := f.app.GetStudyGroupsByStudent(students[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Courses) != 1 || len(groups.Entries) != 1 || groups.Entries[0].Status != store.StudyGroupEnrolled {
+		t.Fatalf("expected the first waitlisted student to be enrolled, got %+v", groups)
+	}
+	if groups, err = f.app.GetStudyGroupsByStudent(students[3]); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Courses) != 0 || len(groups.Entries) != 1 || groups.Entries[0].Position != 1 {
+		t.Fatalf("expected the student to move up the waitlist, got %+v", groups)
+	}
+	if groups, err = f.app.GetStudyGroupsByStudent(students[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Entries) != 1 || groups.Entries[0].Status != store.StudyGroupDropped || groups.Entries[0].DropDate == nil ||
+		groups.Entries[0].DropDate.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+		t.Fatalf("expected the course to be dropped today, got %+v", groups)
+	}
+
+	// the dropped student comes back to the end of the waitlist
+	entry, err := f.app.PostStudyGroup(course.Id, students[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != store.StudyGroupWaitlisted || entry.Position != 2 {
+		t.Fatalf("expected the second place in the waitlist, got %+v", entry)
+	}
+	if groups, err = f.app.GetStudyGroupsByStudent(students[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Entries) != 1 || groups.Entries[0].Status != store.StudyGroupWaitlisted {
+		t.Fatalf("expected the drop to be replaced by the waitlist, got %+v", groups)
+	}
+
+	// leaving the waitlist does not free a seat
+	if err = f.app.DeleteStudyGroup(course.Id, students[3]); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := f.app.GetCourseById(course.Id); err != nil || got.Enrolled != 2 || got.Waitlisted != 1 {
+		t.Fatalf("expected two enrolled and one waitlisted student, got %+v, %v", got, err)
+	}
+	if err = f.app.DeleteStudyGroup(course.Id, students[3]); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the dropped student, got %v", err)
+	}
+}
+
+func TestStudyGroupEnrollmentWindow(t *testing.T) {
+	f := newFixture(t)
+	courseId := f.course(t, "Базы данных")
+	studentId, _ := f.student(t)
+	today := dateOf(time.Now())
+
+	// without enrollment windows the organization accepts students any day
+	if _, err := f.app.PostCalendarSemester(f.organizationId, today.Year()-1, store.TermAutumn, today.AddDate(0, 0, -200),
+		today.AddDate(0, 0, -100), time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.app.checkEnrollmentWindow(courseId, today); err != nil {
+		t.Fatalf("expected enrollment to be open, got %v", err)
+	}
+
+	if _, err := f.app.PostCalendarSemester(f.organizationId, today.Year()-1, store.TermAutumn, today.AddDate(0, 0, -200),
+		today.AddDate(0, 0, -100), today.AddDate(0, 0, -210), today.AddDate(0, 0, -190)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.app.PostStudyGroup(courseId, studentId); !errors.Is(err, ErrEnrollmentClosed) {
+		t.Fatalf("expected ErrEnrollmentClosed, got %v", err)
+	}
+
+	if _, err := f.app.PostCalendarSemester(f.organizationId, today.Year(), store.TermSpring, today.AddDate(0, 0, -30),
+		today.AddDate(0, 0, 60), today.AddDate(0, 0, -7), today); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := f.app.PostStudyGroup(courseId, studentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != store.StudyGroupEnrolled {
+		t.Fatalf("expected the student to be enrolled, got %+v", entry)
+	}
+	if err = f.app.checkEnrollmentWindow(courseId, today.AddDate(0, 0, 1)); !errors.Is(err, ErrEnrollmentClosed) {
+		t.Fatalf("expected enrollment to close after the window, got %v", err)
+	}
+}
+
+func TestStudyGroupWaitlistSkipsIneligible(t *testing.T) {
+	f := newFixture(t)
+	course, err := f.app.PostCourse("Базы данных", "", nil, f.disciplineId, 0, 0, true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alternative, err := f.app.PostCourse("Хранилища данных", "", nil, f.disciplineId, 0, 0, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var students []uuid.UUID
+	for _, fullName := range []string{"Первый", "Второй", "Третий"} {
+		student, err := f.app.PostStudent(fullName, time.Now().AddDate(-1, 0, 0), uuid.Nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.app.PostStudyGroup(course.Id, student.Id); err != nil {
+			t.Fatal(err)
+		}
+		students = append(students, student.Id)
+	}
+	// the head of the waitlist chooses the other alternative of the discipline while waiting
+	if _, err = f.app.PostStudyGroup(alternative.Id, students[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = f.app.DeleteStudyGroup(course.Id, students[0]); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := f.app.GetStudyGroupsByStudent(students[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Entries) != 1 || groups.Entries[0].Status != store.StudyGroupEnrolled {
+		t.Fatalf("expected the seat to go to the next eligible student, got %+v", groups)
+	}
+	if groups, err = f.app.GetStudyGroupsByStudent(students[1]); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range groups.Entries {
+		if entry.CourseId == course.Id && (entry.Status != store.StudyGroupWaitlisted || entry.Position != 1) {
+			t.Fatalf("expected the ineligible student to stay at the head of the waitlist, got %+v", entry)
+		}
+	}
+	if got, err := f.app.GetCourseById(course.Id); err != nil || got.Enrolled != 1 || got.Waitlisted != 1 {
+		t.Fatalf("expected one enrolled and one waitlisted student, got %+v, %v", got, err)
+	}
+}
+
+func TestDeleteStudentPromotes(t *testing.T) {
+	f := newFixture(t)
+	course, err := f.app.PostCourse("Базы данных", "", nil, f.disciplineId, 0, 0, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var students []uuid.UUID
+	for _, fullName := range []string{"Первый", "Второй"} {
+		student, err := f.app.PostStudent(fullName, time.Now().AddDate(-1, 0, 0), uuid.Nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.app.PostStudyGroup(course.Id, student.Id); err != nil {
+			t.Fatal(err)
+		}
+		students = append(students, student.Id)
+	}
+
+	var dependentsErr *DependentsError
+	if _, err = f.app.DeleteStudent(students[0], false); !errors.As(err, &dependentsErr) {
+		t.Fatalf("expected DependentsError without cascade, got %v", err)
+	}
+	if got, err := f.app.GetCourseById(course.Id); err != nil || got.Enrolled != 1 || got.Waitlisted != 1 {
+		t.Fatalf("expected the refused deletion to keep the study group, got %+v, %v", got, err)
+	}
+
+	if _, err = f.app.DeleteStudent(students[0], true); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := f.app.GetStudyGroupsByStudent(students[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Entries) != 1 || groups.Entries[0].Status != store.StudyGroupEnrolled {
+		t.Fatalf("expected the waitlisted student to take the freed seat, got %+v", groups)
+	}
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrTaxonomyCycle = errors.New("parent can not be the entity itself or one of its descendants")
+
+// splitSkills splits the comma separated skills competencies had before skills became rows.
+func splitSkills(skills string) []string {
+	return skillTitles(strings.Split(skills, ","))
+}
+
+// skillTitles trims the titles and drops empty and repeated ones.
+func skillTitles(titles []string) []string {
+	var result []string
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" && !slices.Contains(result, title) {
+			result = append(result, title)
+		}
+	}
+	return result
+}
+
+// addSkills gives the skills to the competency, the missing skills are created.
+func addSkills(tx store.Store, competencyId uuid.UUID, titles []string) error {
+	for _, title := range titles {
+		skillId, err := tx.CreateSkill(title)
+		if err != nil {
+			return err
+		}
+		if err = tx.CreateSkillCompetency(skillId, competencyId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkParent walks up from the parent and fails with ErrTaxonomyCycle when it meets the entity,
+// parentOf returns the parent of the entity. A missing parent is left to the foreign key.
+func checkParent(id uuid.UUID, parentId uuid.UUID, parentOf func(id uuid.UUID) (uuid.UUID, error)) error {
+	for current := parentId; current != uuid.Nil; {
+		if current == id {
+			return ErrTaxonomyCycle
+		}
+		next, err := parentOf(current)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		current = next
+	}
+	return nil
+}
+
+// competencyParent locks the competency before reading its parent, so in a transaction the ancestors checkParent walks
+// through keep their parents until it ends.
+func competencyParent(s store.Store) func(id uuid.UUID) (uuid.UUID, error) {
+	return func(id uuid.UUID) (uuid.UUID, error) {
+		if err := s.Lock(store.TableCompetencies, id); err != nil {
+			return uuid.Nil, err
+		}
+		competency, err := s.GetCompetency(id)
+		return competency.ParentId, err
+	}
+}
+
+// knowledgeParent locks the knowledge before reading its parent like competencyParent.
+func knowledgeParent(s store.Store) func(id uuid.UUID) (uuid.UUID, error) {
+	return func(id uuid.UUID) (uuid.UUID, error) {
+		if err := s.Lock(store.TableKnowledge, id); err != nil {
+			return uuid.Nil, err
+		}
+		knowledge, err := s.GetKnowledge(id)
+		return knowledge.ParentId, err
+	}
+}
+
+func (app *App) getSkillsByCompetency(competencyId uuid.UUID) ([]string, error) {
+	skills, err := app.store.GetSkillsByCompetency(competencyId)
+	if err != nil {
+		return nil, err
+	}
+
+	var titles []string
+	for _, skill := range skills {
+		titles = append(titles, skill.Title)
+	}
+	return titles, nil
+}
+
+func (app *App) GetSkillById(id uuid.UUID) (model.GetSkill, error) {
+	skill, err := app.store.GetSkill(id)
+	return model.GetSkill{Id: skill.Id, Title: skill.Title}, err
+}
+
+// linkedIds groups ids of the links by the entity they belong to, links go from the owner unless reversed.
+func linkedIds(links []store.CatalogLink, reversed bool) map[uuid.UUID][]uuid.UUID {
+	result := make(map[uuid.UUID][]uuid.UUID)
+	for _, link := range links {
+		if reversed {
+			result[link.ToId] = append(result[link.ToId], link.FromId)
+		} else {
+			result[link.FromId] = append(result[link.FromId], link.ToId)
+		}
+	}
+	return result
+}
+
+// subtreeIds is what the subtree has, every id counted once.
+type subtreeIds map[uuid.UUID]bool
+
+func (ids subtreeIds) add(items []uuid.UUID) {
+	for _, id := range items {
+		ids[id] = true
+	}
+}
+
+// competencySubtree is what competencies of the subtree have.
+type competencySubtree struct {
+	skills    subtreeIds
+	knowledge subtreeIds
+	courses   subtreeIds
+}
+
+// GetCompetencyTree returns the competency with its descendants ordered by title. Counts of every node
+// are aggregated over its subtree, a skill, knowledge or course shared by several competencies counts once.
+func (app *App) GetCompetencyTree(id uuid.UUID) (model.GetCompetencyTree, error) {
+	var resp model.GetCompetencyTree
+	if id == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	catalog, err := app.store.GetCatalog()
+	if err != nil {
+		return resp, err
+	}
+
+	titles := make(map[uuid.UUID]string)
+	for _, skill := range catalog.Skills {
+		titles[skill.Id] = skill.Title
+	}
+	for _, knowledge := range catalog.Knowledge {
+		titles[knowledge.Id] = knowledge.Title
+	}
+	children := make(map[uuid.UUID][]store.Competency)
+	var root *store.Competency
+	for i, competency := range catalog.Competencies {
+		if competency.Id == id {
+			root = &catalog.Competencies[i]
+		}
+		if competency.ParentId != uuid.Nil {
+			children[competency.ParentId] = append(children[competency.ParentId], competency)
+		}
+	}
+	if root == nil {
+		return resp, store.ErrNotFound
+	}
+	skills := linkedIds(catalog.SkillCompetencies, true)
+	knowledge := linkedIds(catalog.KnowledgeCompetencies, true)
+	courses := linkedIds(catalog.CourseCompetencies, true)
+	itemTitles := func(ids []uuid.UUID) []string {
+		var result []string
+		for _, id := range ids {
+			result = append(result, titles[id])
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	var build func(competency store.Competency) (model.GetCompetencyTree, competencySubtree)
+	build = func(competency store.Competency) (model.GetCompetencyTree, competencySubtree) {
+		node := model.GetCompetencyTree{
+			Id:           competency.Id,
+			Title:        competency.Title,
+			Skills:       itemTitles(skills[competency.Id]),
+			Knowledge:    itemTitles(knowledge[competency.Id]),
+			Competencies: 1,
+		}
+		subtree := competencySubtree{skills: subtreeIds{}, knowledge: subtreeIds{}, courses: subtreeIds{}}
+		subtree.skills.add(skills[competency.Id])
+		subtree.knowledge.add(knowledge[competency.Id])
+		subtree.courses.add(courses[competency.Id])
+		for _, child := range children[competency.Id] {
+			childNode, childSubtree := build(child)
+			node.Children = append(node.Children, childNode)
+			node.Competencies += childNode.Competencies
+			maps.Copy(subtree.skills, childSubtree.skills)
+			maps.Copy(subtree.knowledge, childSubtree.knowledge)
+			maps.Copy(subtree.courses, childSubtree.courses)
+		}
+		node.SkillCount, node.KnowledgeCount, node.CourseCount = len(subtree.skills), len(subtree.knowledge), len(subtree.courses)
+		return node, subtree
+	}
+
+	resp, _ = build(*root)
+	return resp, nil
+}
+
+// GetKnowledgeTree returns the knowledge with its descendants ordered by title. Counts of every node
+// are aggregated over its subtree, a competency made of several knowledge of the subtree counts once.
+func (app *App) GetKnowledgeTree(id uuid.UUID) (model.GetKnowledgeTree, error) {
+	var resp model.GetKnowledgeTree
+	if id == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	catalog, err := app.store.GetCatalog()
+	if err != nil {
+		return resp, err
+	}
+
+	children := make(map[uuid.UUID][]store.Knowledge)
+	var root *store.Knowledge
+	for i, knowledge := range catalog.Knowledge {
+		if knowledge.Id == id {
+			root = &catalog.Knowledge[i]
+		}
+		if knowledge.ParentId != uuid.Nil {
+			children[knowledge.ParentId] = append(children[knowledge.ParentId], knowledge)
+		}
+	}
+	if root == nil {
+		return resp, store.ErrNotFound
+	}
+	competencies := linkedIds(catalog.KnowledgeCompetencies, false)
+
+	var build func(knowledge store.Knowledge) (model.GetKnowledgeTree, subtreeIds)
+	build = func(knowledge store.Knowledge) (model.GetKnowledgeTree, subtreeIds) {
+		node := model.GetKnowledgeTree{Id: knowledge.Id, Title: knowledge.Title, Knowledge: 1}
+		linked := subtreeIds{}
+		linked.add(competencies[knowledge.Id])
+		for _, child := range children[knowledge.Id] {
+			childNode, childLinked := build(child)
+			node.Children = append(node.Children, childNode)
+			node.Knowledge += childNode.Knowledge
+			maps.Copy(linked, childLinked)
+		}
+		node.CompetencyCount = len(linked)
+		return node, linked
+	}
+
+	resp, _ = build(*root)
+	return resp, nil
+}
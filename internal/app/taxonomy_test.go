@@ -0,0 +1,198 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestSplitSkills(t *testing.T) {
+	got := splitSkills(" проектирование схем,, SQL , проектирование схем")
+	if want := []string{"проектирование схем", "SQL"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestCompetencyTree(t *testing.T) {
+	f := newFixture(t)
+	backend, err := f.app.PostCompetency("Backend-разработка", uuid.Nil, []string{"HTTP"}, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := f.app.PostCompetency("REST API", backend.Id, []string{" JSON", "HTTP", "JSON"}, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, err := f.app.PostCompetency("SQL", backend.Id, []string{"SQL"}, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rest.ParentId != backend.Id || !reflect.DeepEqual(rest.Skills, []string{"HTTP", "JSON"}) {
+		t.Fatalf("unexpected competency %+v", rest)
+	}
+	// the knowledge is shared by both children
+	knowledge, err := f.app.PostKnowledge("Сетевые протоколы", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, competencyId := range []uuid.UUID{rest.Id, sql.Id} {
+		if err = f.app.PostKnowledgeCompetency(knowledge.Id, competencyId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.course(t, "Веб-сервисы", rest.Id, sql.Id)
+	f.course(t, "Базы данных", sql.Id)
+
+	tree, err := f.app.GetCompetencyTree(backend.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Competencies != 3 || tree.SkillCount != 3 || tree.KnowledgeCount != 1 || tree.CourseCount != 2 {
+		t.Fatalf("expected counts over the subtree with shared items counted once, got %+v", tree)
+	}
+	if len(tree.Children) != 2 || tree.Children[0].Title != "REST API" || tree.Children[1].Title != "SQL" {
+		t.Fatalf("expected children ordered by title, got %+v", tree.Children)
+	}
+	wantLeaf := model.GetCompetencyTree{Id: sql.Id, Title: "SQL", Skills: []string{"SQL"}, Knowledge: []string{"Сетевые протоколы"},
+		Competencies: 1, SkillCount: 1, KnowledgeCount: 1, CourseCount: 2}
+	if !reflect.DeepEqual(tree.Children[1], wantLeaf) {
+		t.Fatalf("expected the leaf %+v, got %+v", wantLeaf, tree.Children[1])
+	}
+
+	if _, err = f.app.GetCompetencyTree(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing competency, got %v", err)
+	}
+	if _, err = f.app.GetCompetencyTree(uuid.Nil); !errors.Is(err, ErrEmptyId) {
+		t.Fatalf("expected ErrEmptyId, got %v", err)
+	}
+}
+
+func TestKnowledgeTree(t *testing.T) {
+	f := newFixture(t)
+	databases, err := f.app.PostKnowledge("Базы данных", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, err := f.app.PostKnowledge("SQL", databases.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexes, err := f.app.PostKnowledge("Индексы", sql.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	competencyId := f.competency(t, "Работа с БД", false)
+	for _, knowledgeId := range []uuid.UUID{sql.Id, indexes.Id} {
+		if err = f.app.PostKnowledgeCompetency(knowledgeId, competencyId); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := f.app.GetKnowledgeTree(databases.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Knowledge != 3 || tree.CompetencyCount != 1 || len(tree.Children) != 1 || len(tree.Children[0].Children) != 1 {
+		t.Fatalf("unexpected tree %+v", tree)
+	}
+	if got, err := f.app.GetKnowledgeByIndex(indexes.Id); err != nil || got.ParentId != sql.Id {
+		t.Fatalf("expected the parent %v, got %+v, %v", sql.Id, got, err)
+	}
+
+	if _, err = f.app.UpdateKnowledge(databases.Id, databases.Title, indexes.Id); !errors.Is(err, ErrTaxonomyCycle) {
+		t.Fatalf("expected ErrTaxonomyCycle for the descendant parent, got %v", err)
+	}
+	if got, err := f.app.GetKnowledgeByIndex(databases.Id); err != nil || got.ParentId != uuid.Nil {
+		t.Fatalf("expected the root to stay a root, got %+v, %v", got, err)
+	}
+}
+
+func TestCheckParent(t *testing.T) {
+	root, child, grandchild := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	parents := map[uuid.UUID]uuid.UUID{root: uuid.Nil, child: root, grandchild: child}
+	parentOf := func(id uuid.UUID) (uuid.UUID, error) {
+		parentId, ok := parents[id]
+		if !ok {
+			return uuid.Nil, store.ErrNotFound
+		}
+		return parentId, nil
+	}
+
+	tests := map[string]struct {
+		id, parentId uuid.UUID
+		err          error
+	}{
+		"root":       {root, uuid.Nil, nil},
+		"sibling":    {grandchild, root, nil},
+		"itself":     {child, child, ErrTaxonomyCycle},
+		"descendant": {root, grandchild, ErrTaxonomyCycle},
+		"missing":    {root, uuid.NewV4(), nil},
+	}
+	for name, test := range tests {
+		if err := checkParent(test.id, test.parentId, parentOf); !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", name, test.err, err)
+		}
+	}
+}
+
+func TestCatalogBundleTaxonomy(t *testing.T) {
+	f := newFixture(t)
+	backend, err := f.app.PostCompetency("Backend-разработка", uuid.Nil, nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostCompetency("REST API", backend.Id, []string{"HTTP", "JSON"}, uuid.Nil); err != nil {
+		t.Fatal(err)
+	}
+	databases, err := f.app.PostKnowledge("Базы данных", uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostKnowledge("SQL", databases.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := exportCatalog(t, f.app)
+	restored := newTestApp()
+	if _, err = restored.ImportCatalog(bundle); err != nil {
+		t.Fatal(err)
+	}
+	if got := exportCatalog(t, restored); !reflect.DeepEqual(got.Competencies, bundle.Competencies) || !reflect.DeepEqual(got.Knowledge, bundle.Knowledge) {
+		t.Fatalf("expected parents and skills restored\n%+v %+v\ngot\n%+v %+v", bundle.Competencies, bundle.Knowledge, got.Competencies, got.Knowledge)
+	}
+
+	// swapping the parent and the child moves both, counted as updates
+	bundle.Competencies[0].Parent, bundle.Competencies[1].Parent = "REST API", ""
+	result, err := restored.ImportCatalog(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Updated != 2 || result.Created != 0 {
+		t.Fatalf("unexpected result of the import %+v", result)
+	}
+
+	// parents referring to each other make a cycle
+	bundle.Knowledge[1].Parent = "SQL"
+	if _, err = restored.ImportCatalog(bundle); !errors.Is(err, ErrWrongBundle) {
+		t.Fatalf("expected ErrWrongBundle for a cycle, got %v", err)
+	}
+
+	// version 2 bundles give skills separated by commas and keep parents
+	old := model.CatalogBundle{Format: CatalogBundleFormat, Version: 2,
+		Competencies: []model.BundleCompetency{{Title: "REST API", Skills: "HTTP, OpenAPI"}}}
+	if _, err = restored.ImportCatalog(old); err != nil {
+		t.Fatal(err)
+	}
+	competency, err := restored.store.GetCompetencyByTitle("REST API")
+	if err != nil || competency.ParentId != uuid.Nil {
+		t.Fatalf("expected the parent kept, got %+v, %v", competency, err)
+	}
+	if skills, err := restored.getSkillsByCompetency(competency.Id); err != nil || !reflect.DeepEqual(skills, []string{"HTTP", "JSON", "OpenAPI"}) {
+		t.Fatalf("expected the skills added, got %v, %v", skills, err)
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// PostTeacher adds the teacher, the teacher with the same full name gets the new organization and contacts.
+func (app *App) PostTeacher(fullName string, organizationId uuid.UUID, email string, phone string) (model.GetTeacher, error) {
+	if fullName == "" {
+		return model.GetTeacher{FullName: fullName}, ErrEmptyTitle
+	}
+	if organizationId == uuid.Nil {
+		return model.GetTeacher{FullName: fullName}, ErrEmptyId
+	}
+
+	id, err := app.store.SaveTeacher(store.Teacher{FullName: fullName, OrganizationId: organizationId, Email: email, Phone: phone})
+	if err != nil {
+		return model.GetTeacher{FullName: fullName}, err
+	}
+	return app.GetTeacherById(id)
+}
+
+func (app *App) GetTeacherById(id uuid.UUID) (model.GetTeacher, error) {
+	resp := model.GetTeacher{Id: id}
+	teacher, err := app.store.GetTeacher(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.FullName, resp.OrganizationId, resp.Email, resp.Phone = teacher.FullName, teacher.OrganizationId, teacher.Email, teacher.Phone
+
+	if teacher.OrganizationId != uuid.Nil {
+		organization, err := app.store.GetOrganization(teacher.OrganizationId)
+		if err != nil {
+			return resp, err
+		}
+		resp.Organization = organization.Title
+	}
+
+	availability, err := app.store.GetTeacherAvailability()
+	if err != nil {
+		return resp, err
+	}
+	available := make(map[uuid.UUID]bool)
+	for _, teacherAvailability := range availability {
+		if teacherAvailability.TeacherId == id {
+			available[teacherAvailability.TimeSlotId] = true
+		}
+	}
+	if len(available) == 0 {
+		return resp, nil
+	}
+	slots, err := app.store.GetTimeSlots()
+	if err != nil {
+		return resp, err
+	}
+	for _, slot := range slots {
+		if available[slot.Id] {
+			resp.Availability = append(resp.Availability, model.GetTimeSlot{Id: slot.Id, Weekday: slot.Weekday, Start: slot.Start, End: slot.End})
+		}
+	}
+	return resp, nil
+}
+
+// getTeacherNames returns full names of the course teachers ordered by full name.
+func (app *App) getTeacherNames(courseId uuid.UUID) ([]string, error) {
+	teachers, err := app.store.GetTeachersByCourse(courseId)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, teacher := range teachers {
+		names = append(names, teacher.FullName)
+	}
+	return names, nil
+}
+
+// teacherCourse is the course of the teacher with its load.
+type teacherCourse struct {
+	course      store.Course
+	weeklyHours int
+	students    []uuid.UUID // the study group of the current semester
+}
+
+// getTeacherCourses returns the teacher and the teacher courses ordered by title.
+func (app *App) getTeacherCourses(id uuid.UUID) (store.Teacher, []teacherCourse, error) {
+	teacher, err := app.store.GetTeacher(id)
+	if err != nil {
+		return teacher, nil, err
+	}
+	courses, err := app.store.GetCoursesByTeacher(id)
+	if err != nil {
+		return teacher, nil, err
+	}
+
+	index := make(map[uuid.UUID]int, len(courses))
+	result := make([]teacherCourse, 0, len(courses))
+	for _, course := range courses {
+		index[course.Id] = len(result)
+		result = append(result, teacherCourse{course: course})
+	}
+
+	sessions, err := app.store.GetCourseSessions()
+	if err != nil {
+		return teacher, nil, err
+	}
+	for _, session := range sessions {
+		if i, ok := index[session.CourseId]; ok {
+			result[i].weeklyHours += int(session.WeeklyHours)
+		}
+	}
+
+	groups, err := app.store.GetStudyGroups()
+	if err != nil {
+		return teacher, nil, err
+	}
+	for _, group := range groups {
+		if i, ok := index[group.CourseId]; ok {
+			result[i].students = append(result[i].students, group.StudentId)
+		}
+	}
+	return teacher, result, nil
+}
+
+// GetTeacherCourses returns courses of the teacher ordered by title with their weekly hours and students.
+func (app *App) GetTeacherCourses(id uuid.UUID) (model.GetTeacherCourses, error) {
+	resp := model.GetTeacherCourses{TeacherId: id, Courses: []model.GetTeacherCourse{}}
+	teacher, courses, err := app.getTeacherCourses(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.Teacher = teacher.FullName
+
+	for _, item := range courses {
+		course := model.GetTeacherCourse{
+			Id:          item.course.Id,
+			Title:       item.course.Title,
+			Credits:     item.course.Credits,
+			Hours:       item.course.Hours,
+			WeeklyHours: item.weeklyHours,
+			Students:    len(item.students),
+		}
+		if item.course.DisciplineId != uuid.Nil {
+			discipline, err := app.store.GetDiscipline(item.course.DisciplineId)
+			if err != nil {
+				return resp, err
+			}
+			course.Discipline = discipline.Title
+		}
+		resp.Courses = append(resp.Courses, course)
+	}
+	return resp, nil
+}
+
+// GetTeacherStudyGroups returns study groups of the current semester of the teacher courses ordered by course title,
+// courses nobody studies are skipped.
+func (app *App) GetTeacherStudyGroups(id uuid.UUID) (model.GetTeacherStudyGroups, error) {
+	resp := model.GetTeacherStudyGroups{TeacherId: id, StudyGroups: []model.GetCourseStudyGroup{}}
+	teacher, courses, err := app.getTeacherCourses(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.Teacher = teacher.FullName
+
+	for _, course := range courses {
+		if len(course.students) == 0 {
+			continue
+		}
+		group, err := app.GetCourseStudyGroup(course.course.Id)
+		if err != nil {
+			return resp, err
+		}
+		resp.StudyGroups = append(resp.StudyGroups, group)
+	}
+	return resp, nil
+}
+
+// GetTeacherHours sums the load of the teacher over the teacher courses.
+func (app *App) GetTeacherHours(id uuid.UUID) (model.GetTeacherHours, error) {
+	resp := model.GetTeacherHours{TeacherId: id}
+	teacher, courses, err := app.getTeacherCourses(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.Teacher = teacher.FullName
+
+	students := make(map[uuid.UUID]bool)
+	for _, course := range courses {
+		resp.Courses++
+		resp.Hours += int(course.course.Hours)
+		if len(course.students) == 0 {
+			continue
+		}
+		resp.StudiedCourses++
+		resp.WeeklyHours += course.weeklyHours
+		for _, student := range course.students {
+			students[student] = true
+		}
+	}
+	resp.Students = len(students)
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func TestTeacherLoad(t *testing.T) {
+	f := newFixture(t)
+	petrov := f.teacher(t, "Петров Пётр Петрович")
+	sidorov := f.teacher(t, "Сидоров Сидор Сидорович")
+	databases, err := f.app.PostCourse("Базы данных", "", []uuid.UUID{sidorov, petrov}, f.disciplineId, 5, 180, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(databases.Teachers, []string{"Петров Пётр Петрович", "Сидоров Сидор Сидорович"}) {
+		t.Fatalf("expected teachers ordered by full name, got %v", databases.Teachers)
+	}
+	algorithms, err := f.app.PostCourse("Алгоритмы", "", []uuid.UUID{petrov}, f.disciplineId, 3, 108, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostCourse("Сети", "", []uuid.UUID{uuid.NewV4()}, f.disciplineId, 0, 0, false, 0); err == nil {
+		t.Fatal("expected the unknown teacher to be refused")
+	}
+
+	for _, session := range []struct {
+		courseId uuid.UUID
+		kind     string
+		hours    uint8
+	}{{databases.Id, "lecture", 2}, {databases.Id, "lab", 4}, {algorithms.Id, "practice", 2}} {
+		if _, err = f.app.PostCourseSession(session.courseId, session.kind, session.hours); err != nil {
+			t.Fatal(err)
+		}
+	}
+	studentId, _ := f.student(t)
+	classmate, err := f.app.PostStudent("Алексеев Алексей Алексеевич", time.Now().AddDate(-1, 0, 0), uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, studentId := range []uuid.UUID{studentId, classmate.Id} {
+		if _, err = f.app.PostStudyGroup(databases.Id, studentId); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	courses, err := f.app.GetTeacherCourses(petrov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if courses.Teacher != "Петров Пётр Петрович" || len(courses.Courses) != 2 {
+		t.Fatalf("expected both courses of the teacher, got %+v", courses)
+	}
+	if got := courses.Courses[1]; got.Title != "Базы данных" || got.Discipline != "Программирование" || got.WeeklyHours != 6 || got.Students != 2 {
+		t.Fatalf("unexpected course %+v", got)
+	}
+	if got := courses.Courses[0]; got.Title != "Алгоритмы" || got.WeeklyHours != 2 || got.Students != 0 {
+		t.Fatalf("unexpected course %+v", got)
+	}
+
+	groups, err := f.app.GetTeacherStudyGroups(petrov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.StudyGroups) != 1 || groups.StudyGroups[0].CourseId != databases.Id || len(groups.StudyGroups[0].Students) != 2 {
+		t.Fatalf("expected the only study group of the databases, got %+v", groups)
+	}
+
+	hours, err := f.app.GetTeacherHours(petrov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.GetTeacherHours{TeacherId: petrov, Teacher: "Петров Пётр Петрович", Courses: 2, Hours: 288, StudiedCourses: 1,
+		WeeklyHours: 6, Students: 2}
+	if hours != want {
+		t.Fatalf("expected %+v, got %+v", want, hours)
+	}
+	if _, err = f.app.GetTeacherHours(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostTeacher(t *testing.T) {
+	f := newFixture(t)
+	teacherId := f.teacher(t, "Петров Пётр Петрович")
+	slot, err := f.app.PostTimeSlot(1, "08:30", "10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.app.PostTeacherAvailability(teacherId, slot.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same full name updates the contacts
+	teacher, err := f.app.PostTeacher("Петров Пётр Петрович", f.organizationId, "petrov@urfu.ru", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if teacher.Id != teacherId || teacher.Organization != "УрФУ" || teacher.Email != "petrov@urfu.ru" ||
+		len(teacher.Availability) != 1 || teacher.Availability[0].Id != slot.Id {
+		t.Fatalf("unexpected teacher %+v", teacher)
+	}
+}
+
+func TestImportCatalogTeachers(t *testing.T) {
+	f := newFixture(t)
+	f.teacher(t, "Петров Пётр Петрович")
+	// version 1 names the only teacher of the course
+	bundle := model.CatalogBundle{
+		Format:              CatalogBundleFormat,
+		Version:             1,
+		Organizations:       []model.BundleOrganization{{Title: "УрФУ"}},
+		EducationalPrograms: []model.BundleEducationalProgram{{Title: "Программная инженерия", Organization: "УрФУ"}},
+		Disciplines:         []model.BundleDiscipline{{Title: "Программирование", EducationalProgram: "Программная инженерия"}},
+		Courses: []model.BundleCourse{
+			{Title: "Базы данных", Discipline: "Программирование", Teacher: " Петров Пётр Петрович "},
+			{Title: "Алгоритмы", Discipline: "Программирование", Teacher: "Иванов Иван Иванович"},
+		},
+	}
+	result, err := f.app.ImportCatalog(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 3 || result.Links != 2 {
+		t.Fatalf("expected two courses, the new teacher and two links, got %+v", result)
+	}
+
+	exported := exportCatalog(t, f.app)
+	if exported.Version != CatalogBundleVersion || len(exported.Courses) != 2 {
+		t.Fatalf("unexpected bundle %+v", exported)
+	}
+	if got := exported.Courses[0]; got.Title != "Алгоритмы" || got.Teacher != "" || !slices.Equal(got.Teachers, []string{"Иванов Иван Иванович"}) {
+		t.Fatalf("expected the teachers of the course, got %+v", got)
+	}
+
+	// the exported bundle is imported without changes
+	if result, err = f.app.ImportCatalog(exported); err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 0 || result.Updated != 0 || result.Links != 0 {
+		t.Fatalf("expected nothing to change, got %+v", result)
+	}
+}
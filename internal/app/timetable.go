@@ -0,0 +1,541 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongWeekday = errors.New("weekday must be from 1 (Monday) to 7 (Sunday)")
+var ErrWrongTime = errors.New("time must be formatted as 15:04 and start before end")
+var ErrWrongCapacity = errors.New("capacity must be positive")
+var ErrWrongSessionKind = errors.New("session kind must be lecture, practice or lab")
+var ErrWrongWeeklyHours = errors.New("weekly hours must be positive")
+
+// academicHoursPerSlot is the load one time slot covers: a pair of academic hours.
+const academicHoursPerSlot = 2
+
+// Reasons of sessions the scheduler could not place.
+const (
+	ProblemNoRoom             = "noRoom"
+	ProblemTeacherUnavailable = "teacherUnavailable"
+	ProblemNoFreeSlot         = "noFreeSlot"
+)
+
+type scheduleSession struct {
+	id       uuid.UUID
+	kind     string
+	teachers []uuid.UUID // every teacher of the course attends the session
+	students []uuid.UUID
+	units    int // time slots the session takes every week
+}
+
+type scheduleInput struct {
+	slots    []store.TimeSlot // ordered by weekday and start
+	rooms    []store.Room     // ordered by capacity
+	sessions []scheduleSession
+	// availability limits teachers to the time slots, teachers without rows are always available
+	availability map[uuid.UUID]map[uuid.UUID]bool
+}
+
+type schedulePlacement struct {
+	session int
+	slot    int
+	room    int
+}
+
+type scheduleProblem struct {
+	session  int
+	unplaced int
+	reason   string
+}
+
+type scheduler struct {
+	scheduleInput
+	overlaps    [][]int // time slots sharing time with the slot, the slot included
+	roomBusy    map[[2]int]bool
+	teacherBusy map[uuid.UUID]map[int]bool
+	studentBusy map[uuid.UUID]map[int]bool
+	sessionDays map[int]map[uint8]int
+}
+
+// buildTimetable places every unit of the sessions into a time slot and a room so that no student, teacher or room
+// is in two places at once and the room fits all students of the session. The most constrained session is placed first,
+// units of one session are spread over the week. Units that do not fit anywhere are reported as problems.
+func buildTimetable(in scheduleInput) ([]schedulePlacement, []scheduleProblem) {
+	s := scheduler{
+		scheduleInput: in,
+		overlaps:      make([][]int, len(in.slots)),
+		roomBusy:      make(map[[2]int]bool),
+		teacherBusy:   make(map[uuid.UUID]map[int]bool),
+		studentBusy:   make(map[uuid.UUID]map[int]bool),
+		sessionDays:   make(map[int]map[uint8]int),
+	}
+	for i, a := range in.slots {
+		for j, b := range in.slots {
+			if a.Weekday == b.Weekday && a.Start < b.End && b.Start < a.End {
+				s.overlaps[i] = append(s.overlaps[i], j)
+			}
+		}
+	}
+
+	remaining := make([]int, len(in.sessions))
+	for i, session := range in.sessions {
+		remaining[i] = session.units
+	}
+
+	var placements []schedulePlacement
+	var problems []scheduleProblem
+	for {
+		next, options := -1, []schedulePlacement(nil)
+		for i := range in.sessions {
+			if remaining[i] == 0 {
+				continue
+			}
+			sessionOptions := s.options(i)
+			if next == -1 || len(sessionOptions) < len(options) ||
+				len(sessionOptions) == len(options) && len(in.sessions[i].students) > len(in.sessions[next].students) {
+				next, options = i, sessionOptions
+			}
+		}
+		if next == -1 {
+			break
+		}
+
+		if len(options) == 0 {
+			problems = append(problems, scheduleProblem{session: next, unplaced: remaining[next], reason: s.reason(next)})
+			remaining[next] = 0
+			continue
+		}
+
+		best := options[0]
+		for _, option := range options[1:] {
+			if s.sessionDays[next][in.slots[option.slot].Weekday] < s.sessionDays[next][in.slots[best.slot].Weekday] {
+				best = option
+			}
+		}
+		s.place(best)
+		placements = append(placements, best)
+		remaining[next]--
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].session < problems[j].session })
+	return placements, problems
+}
+
+func (s *scheduler) teacherAvailable(session int, slot int) bool {
+	for _, teacher := range s.sessions[session].teachers {
+		if availability, limited := s.availability[teacher]; limited && !availability[s.slots[slot].Id] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *scheduler) teacherFree(session int, slot int) bool {
+	for _, teacher := range s.sessions[session].teachers {
+		for _, overlap := range s.overlaps[slot] {
+			if s.teacherBusy[teacher][overlap] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *scheduler) studentsFree(session int, slot int) bool {
+	for _, student := range s.sessions[session].students {
+		for _, overlap := range s.overlaps[slot] {
+			if s.studentBusy[student][overlap] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// room returns the smallest free room the students of the session fit in, -1 if there is none.
+func (s *scheduler) room(session int, slot int) int {
+	for room, candidate := range s.rooms {
+		if candidate.Capacity < len(s.sessions[session].students) {
+			continue
+		}
+		free := true
+		for _, overlap := range s.overlaps[slot] {
+			free = free && !s.roomBusy[[2]int{overlap, room}]
+		}
+		if free {
+			return room
+		}
+	}
+	return -1
+}
+
+func (s *scheduler) options(session int) []schedulePlacement {
+	var options []schedulePlacement
+	for slot := range s.slots {
+		if !s.teacherAvailable(session, slot) || !s.teacherFree(session, slot) || !s.studentsFree(session, slot) {
+			continue
+		}
+		if room := s.room(session, slot); room != -1 {
+			options = append(options, schedulePlacement{session: session, slot: slot, room: room})
+		}
+	}
+	return options
+}
+
+func (s *scheduler) place(placement schedulePlacement) {
+	session := s.sessions[placement.session]
+	s.roomBusy[[2]int{placement.slot, placement.room}] = true
+	for _, teacher := range session.teachers {
+		if s.teacherBusy[teacher] == nil {
+			s.teacherBusy[teacher] = make(map[int]bool)
+		}
+		s.teacherBusy[teacher][placement.slot] = true
+	}
+	for _, student := range session.students {
+		if s.studentBusy[student] == nil {
+			s.studentBusy[student] = make(map[int]bool)
+		}
+		s.studentBusy[student][placement.slot] = true
+	}
+	if s.sessionDays[placement.session] == nil {
+		s.sessionDays[placement.session] = make(map[uint8]int)
+	}
+	s.sessionDays[placement.session][s.slots[placement.slot].Weekday]++
+}
+
+// reason explains why the session has no options left.
+func (s *scheduler) reason(session int) string {
+	fits := false
+	for _, room := range s.rooms {
+		fits = fits || room.Capacity >= len(s.sessions[session].students)
+	}
+	if !fits {
+		return ProblemNoRoom
+	}
+
+	limited := false
+	for _, teacher := range s.sessions[session].teachers {
+		_, ok := s.availability[teacher]
+		limited = limited || ok
+	}
+	if limited {
+		for slot := range s.slots {
+			if s.teacherAvailable(session, slot) && s.teacherFree(session, slot) {
+				return ProblemNoFreeSlot
+			}
+		}
+		return ProblemTeacherUnavailable
+	}
+	return ProblemNoFreeSlot
+}
+
+// BuildTimetable schedules sessions of the courses students study in the current semester (study groups)
+// and replaces the saved timetable. Sessions nobody studies are skipped, units that could not be placed
+// are returned in problems, the rest of the timetable is saved anyway.
+func (app *App) BuildTimetable() (model.GetTimetable, error) {
+	resp := model.GetTimetable{Entries: []model.GetTimetableEntry{}}
+	in, courses, err := app.getScheduleInput()
+	if err != nil {
+		return resp, err
+	}
+
+	placements, problems := buildTimetable(in)
+	var entries []store.TimetableEntry
+	for _, placement := range placements {
+		entries = append(entries, store.TimetableEntry{
+			CourseSessionId: in.sessions[placement.session].id,
+			TimeSlotId:      in.slots[placement.slot].Id,
+			RoomId:          in.rooms[placement.room].Id,
+		})
+	}
+	if err = app.store.ReplaceTimetable(entries); err != nil {
+		return resp, err
+	}
+
+	resp.Entries, err = app.timetableEntries(entries, nil)
+	if err != nil {
+		return resp, err
+	}
+	for _, problem := range problems {
+		session := in.sessions[problem.session]
+		course := courses[session.id]
+		teachers, err := app.getTeacherNames(course.Id)
+		if err != nil {
+			return resp, err
+		}
+		resp.Problems = append(resp.Problems, model.GetTimetableProblem{
+			SessionId: session.id,
+			CourseId:  course.Id,
+			Course:    course.Title,
+			Kind:      session.kind,
+			Unplaced:  problem.unplaced,
+			Reason:    problem.reason,
+			Message:   problemMessage(problem.reason, session, teachers),
+		})
+	}
+	return resp, nil
+}
+
+func problemMessage(reason string, session scheduleSession, teachers []string) string {
+	switch reason {
+	case ProblemNoRoom:
+		return fmt.Sprint("no room fits ", len(session.students), " students")
+	case ProblemTeacherUnavailable:
+		return "teachers " + strings.Join(teachers, ", ") + " have no common available time slots left"
+	default:
+		return "no time slot is free for all students, the teachers and a fitting room"
+	}
+}
+
+// getScheduleInput returns sessions of the courses with study groups and courses of the sessions.
+func (app *App) getScheduleInput() (scheduleInput, map[uuid.UUID]store.Course, error) {
+	var in scheduleInput
+	var err error
+	if in.slots, err = app.store.GetTimeSlots(); err != nil {
+		return in, nil, err
+	}
+	if in.rooms, err = app.store.GetRooms(); err != nil {
+		return in, nil, err
+	}
+
+	availability, err := app.store.GetTeacherAvailability()
+	if err != nil {
+		return in, nil, err
+	}
+	in.availability = make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, teacherAvailability := range availability {
+		if in.availability[teacherAvailability.TeacherId] == nil {
+			in.availability[teacherAvailability.TeacherId] = make(map[uuid.UUID]bool)
+		}
+		in.availability[teacherAvailability.TeacherId][teacherAvailability.TimeSlotId] = true
+	}
+
+	studyGroups, err := app.store.GetStudyGroups()
+	if err != nil {
+		return in, nil, err
+	}
+	students := make(map[uuid.UUID][]uuid.UUID)
+	for _, studyGroup := range studyGroups {
+		students[studyGroup.CourseId] = append(students[studyGroup.CourseId], studyGroup.StudentId)
+	}
+
+	sessions, err := app.store.GetCourseSessions()
+	if err != nil {
+		return in, nil, err
+	}
+	courses := make(map[uuid.UUID]store.Course)
+	for _, session := range sessions {
+		if len(students[session.CourseId]) == 0 {
+			continue
+		}
+		course, err := app.store.GetCourse(session.CourseId)
+		if err != nil {
+			return in, nil, err
+		}
+		teachers, err := app.store.GetTeachersByCourse(session.CourseId)
+		if err != nil {
+			return in, nil, err
+		}
+		teacherIds := make([]uuid.UUID, 0, len(teachers))
+		for _, teacher := range teachers {
+			teacherIds = append(teacherIds, teacher.Id)
+		}
+
+		courses[session.Id] = course
+		in.sessions = append(in.sessions, scheduleSession{
+			id:       session.Id,
+			kind:     session.Kind,
+			teachers: teacherIds,
+			students: students[session.CourseId],
+			units:    (int(session.WeeklyHours) + academicHoursPerSlot - 1) / academicHoursPerSlot,
+		})
+	}
+
+	return in, courses, nil
+}
+
+// timetableEntries describes saved entries, only entries of the courses are kept when courses is not nil.
+func (app *App) timetableEntries(entries []store.TimetableEntry, courses map[uuid.UUID]bool) ([]model.GetTimetableEntry, error) {
+	resp := []model.GetTimetableEntry{}
+	slots, err := app.store.GetTimeSlots()
+	if err != nil {
+		return resp, err
+	}
+	slotById := make(map[uuid.UUID]store.TimeSlot)
+	for _, slot := range slots {
+		slotById[slot.Id] = slot
+	}
+
+	rooms, err := app.store.GetRooms()
+	if err != nil {
+		return resp, err
+	}
+	roomById := make(map[uuid.UUID]store.Room)
+	for _, room := range rooms {
+		roomById[room.Id] = room
+	}
+
+	sessions, err := app.store.GetCourseSessions()
+	if err != nil {
+		return resp, err
+	}
+	sessionById := make(map[uuid.UUID]store.CourseSession)
+	for _, session := range sessions {
+		sessionById[session.Id] = session
+	}
+
+	for _, entry := range entries {
+		session := sessionById[entry.CourseSessionId]
+		if courses != nil && !courses[session.CourseId] {
+			continue
+		}
+		course, err := app.store.GetCourse(session.CourseId)
+		if err != nil {
+			return resp, err
+		}
+		teachers, err := app.getTeacherNames(course.Id)
+		if err != nil {
+			return resp, err
+		}
+
+		slot, room := slotById[entry.TimeSlotId], roomById[entry.RoomId]
+		resp = append(resp, model.GetTimetableEntry{
+			SessionId: session.Id,
+			CourseId:  course.Id,
+			Course:    course.Title,
+			Kind:      session.Kind,
+			Teachers:  teachers,
+			TimeSlot:  model.GetTimeSlot{Id: slot.Id, Weekday: slot.Weekday, Start: slot.Start, End: slot.End},
+			Room:      model.GetRoom{Id: room.Id, Title: room.Title, Capacity: room.Capacity},
+		})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		a, b := resp[i].TimeSlot, resp[j].TimeSlot
+		if a.Weekday != b.Weekday {
+			return a.Weekday < b.Weekday
+		}
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return resp[i].Room.Title < resp[j].Room.Title
+	})
+	return resp, nil
+}
+
+func (app *App) GetTimetable() (model.GetTimetable, error) {
+	var resp model.GetTimetable
+	entries, err := app.store.GetTimetable()
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Entries, err = app.timetableEntries(entries, nil)
+	return resp, err
+}
+
+// GetStudentTimetable returns the part of the timetable with courses the student studies in the current semester.
+func (app *App) GetStudentTimetable(studentId uuid.UUID) (model.GetTimetable, error) {
+	var resp model.GetTimetable
+	if studentId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if _, err := app.store.GetStudent(studentId); err != nil {
+		return resp, err
+	}
+
+	studyGroups, err := app.store.GetStudyGroupCourses(studentId)
+	if err != nil {
+		return resp, err
+	}
+	courses := make(map[uuid.UUID]bool)
+	for _, course := range studyGroups {
+		courses[course.Id] = true
+	}
+
+	entries, err := app.store.GetTimetable()
+	if err != nil {
+		return resp, err
+	}
+	resp.Entries, err = app.timetableEntries(entries, courses)
+	return resp, err
+}
+
+// parseClock normalizes time of the day to 15:04.
+func parseClock(value string) (string, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", false
+	}
+	return t.Format("15:04"), true
+}
+
+func (app *App) PostTimeSlot(weekday uint8, start string, end string) (model.GetTimeSlot, error) {
+	var resp model.GetTimeSlot
+	if weekday < 1 || weekday > 7 {
+		return resp, ErrWrongWeekday
+	}
+	start, startOk := parseClock(start)
+	end, endOk := parseClock(end)
+	if !startOk || !endOk || start >= end {
+		return resp, ErrWrongTime
+	}
+
+	resp = model.GetTimeSlot{Weekday: weekday, Start: start, End: end}
+	var err error
+	resp.Id, err = app.store.CreateTimeSlot(store.TimeSlot{Weekday: weekday, Start: start, End: end})
+	return resp, err
+}
+
+func (app *App) PostRoom(title string, capacity int) (model.GetRoom, error) {
+	resp := model.GetRoom{Title: title, Capacity: capacity}
+	if title == "" {
+		return resp, ErrEmptyTitle
+	}
+	if capacity <= 0 {
+		return resp, ErrWrongCapacity
+	}
+
+	var err error
+	resp.Id, err = app.store.CreateRoom(store.Room{Title: title, Capacity: capacity})
+	return resp, err
+}
+
+func (app *App) PostCourseSession(courseId uuid.UUID, kind string, weeklyHours uint8) (model.GetCourseSession, error) {
+	resp := model.GetCourseSession{CourseId: courseId, Kind: kind, WeeklyHours: weeklyHours}
+	if courseId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if kind != store.SessionLecture && kind != store.SessionPractice && kind != store.SessionLab {
+		return resp, ErrWrongSessionKind
+	}
+	if weeklyHours == 0 {
+		return resp, ErrWrongWeeklyHours
+	}
+
+	var err error
+	resp.Id, err = app.store.CreateCourseSession(store.CourseSession{CourseId: courseId, Kind: kind, WeeklyHours: weeklyHours})
+	if err != nil {
+		return resp, err
+	}
+	course, err := app.store.GetCourse(courseId)
+	resp.Course = course.Title
+	return resp, err
+}
+
+func (app *App) PostTeacherAvailability(teacherId uuid.UUID, timeSlotId uuid.UUID) error {
+	if teacherId == uuid.Nil || timeSlotId == uuid.Nil {
+		return ErrEmptyId
+	}
+
+	return app.store.CreateTeacherAvailability(store.TeacherAvailability{TeacherId: teacherId, TimeSlotId: timeSlotId})
+}
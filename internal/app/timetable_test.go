@@ -0,0 +1,233 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func slots(times ...store.TimeSlot) []store.TimeSlot {
+	for i := range times {
+		times[i].Id = uuid.NewV4()
+	}
+	return times
+}
+
+func students(n int) []uuid.UUID {
+	var ids []uuid.UUID
+	for i := 0; i < n; i++ {
+		ids = append(ids, uuid.NewV4())
+	}
+	return ids
+}
+
+func TestBuildTimetableStudentConflicts(t *testing.T) {
+	group := students(2)
+	in := scheduleInput{
+		slots: slots(store.TimeSlot{Weekday: 1, Start: "08:30", End: "10:00"}),
+		rooms: []store.Room{{Id: uuid.NewV4(), Title: "a", Capacity: 30}, {Id: uuid.NewV4(), Title: "b", Capacity: 30}},
+		sessions: []scheduleSession{
+			{id: uuid.NewV4(), teachers: []uuid.UUID{uuid.NewV4()}, students: group, units: 1},
+			{id: uuid.NewV4(), teachers: []uuid.UUID{uuid.NewV4()}, students: group[:1], units: 1},
+		},
+	}
+
+	placements, problems := buildTimetable(in)
+	if len(placements) != 1 || placements[0].session != 0 {
+		t.Fatalf("expected the larger group to be placed, got %+v", placements)
+	}
+	if len(problems) != 1 || problems[0] != (scheduleProblem{session: 1, unplaced: 1, reason: ProblemNoFreeSlot}) {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+
+	// overlapping time slots conflict as well
+	in.slots = append(in.slots, slots(store.TimeSlot{Weekday: 1, Start: "09:00", End: "10:30"})...)
+	if _, problems = buildTimetable(in); len(problems) != 1 {
+		t.Fatalf("expected overlapping slot to be refused, got %+v", problems)
+	}
+	in.slots = append(in.slots, slots(store.TimeSlot{Weekday: 2, Start: "09:00", End: "10:30"})...)
+	if placements, problems = buildTimetable(in); len(problems) != 0 || len(placements) != 2 {
+		t.Fatalf("expected both sessions placed, got %+v and problems %+v", placements, problems)
+	}
+}
+
+func TestBuildTimetableRooms(t *testing.T) {
+	in := scheduleInput{
+		slots: slots(store.TimeSlot{Weekday: 1, Start: "08:30", End: "10:00"}),
+		rooms: []store.Room{{Id: uuid.NewV4(), Title: "small", Capacity: 2}, {Id: uuid.NewV4(), Title: "large", Capacity: 3}},
+		sessions: []scheduleSession{
+			{id: uuid.NewV4(), students: students(4), units: 1},
+			{id: uuid.NewV4(), students: students(2), units: 1},
+			{id: uuid.NewV4(), students: students(2), units: 1},
+		},
+	}
+
+	placements, problems := buildTimetable(in)
+	// small groups take the smallest fitting rooms, so both fit into the slot
+	if len(placements) != 2 || placements[0].room == placements[1].room {
+		t.Fatalf("expected two sessions in different rooms, got %+v", placements)
+	}
+	if len(problems) != 1 || problems[0] != (scheduleProblem{session: 0, unplaced: 1, reason: ProblemNoRoom}) {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+}
+
+func TestBuildTimetableTeacherAvailability(t *testing.T) {
+	in := scheduleInput{
+		slots: slots(
+			store.TimeSlot{Weekday: 1, Start: "08:30", End: "10:00"},
+			store.TimeSlot{Weekday: 1, Start: "10:15", End: "11:45"},
+		),
+		rooms: []store.Room{{Id: uuid.NewV4(), Title: "a", Capacity: 30}, {Id: uuid.NewV4(), Title: "b", Capacity: 30}},
+	}
+	teacher := uuid.NewV4()
+	in.availability = map[uuid.UUID]map[uuid.UUID]bool{teacher: {in.slots[1].Id: true}}
+	in.sessions = []scheduleSession{
+		{id: uuid.NewV4(), teachers: []uuid.UUID{teacher}, students: students(1), units: 1},
+		{id: uuid.NewV4(), teachers: []uuid.UUID{teacher}, students: students(1), units: 1},
+	}
+
+	placements, problems := buildTimetable(in)
+	if len(placements) != 1 || placements[0].slot != 1 {
+		t.Fatalf("expected the session in the available slot, got %+v", placements)
+	}
+	if len(problems) != 1 || problems[0].reason != ProblemTeacherUnavailable {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+}
+
+func TestBuildTimetableCoTeachers(t *testing.T) {
+	in := scheduleInput{
+		slots: slots(
+			store.TimeSlot{Weekday: 1, Start: "08:30", End: "10:00"},
+			store.TimeSlot{Weekday: 1, Start: "10:15", End: "11:45"},
+		),
+		rooms: []store.Room{{Id: uuid.NewV4(), Title: "a", Capacity: 30}, {Id: uuid.NewV4(), Title: "b", Capacity: 30}},
+	}
+	first, second := uuid.NewV4(), uuid.NewV4()
+	// the second teacher works only in the morning, the shared session goes there
+	in.availability = map[uuid.UUID]map[uuid.UUID]bool{second: {in.slots[0].Id: true}}
+	in.sessions = []scheduleSession{
+		{id: uuid.NewV4(), teachers: []uuid.UUID{first, second}, students: students(2), units: 1},
+		{id: uuid.NewV4(), teachers: []uuid.UUID{first}, students: students(1), units: 1},
+	}
+
+	placements, problems := buildTimetable(in)
+	if len(problems) != 0 || len(placements) != 2 {
+		t.Fatalf("expected both sessions placed, got %+v and problems %+v", placements, problems)
+	}
+	for _, placement := range placements {
+		if want := placement.session; placement.slot != want {
+			t.Fatalf("expected session %d in slot %d, got %+v", want, want, placements)
+		}
+	}
+}
+
+func TestBuildTimetableSpreadsUnits(t *testing.T) {
+	in := scheduleInput{
+		slots: slots(
+			store.TimeSlot{Weekday: 1, Start: "08:30", End: "10:00"},
+			store.TimeSlot{Weekday: 1, Start: "10:15", End: "11:45"},
+			store.TimeSlot{Weekday: 3, Start: "08:30", End: "10:00"},
+		),
+		rooms:    []store.Room{{Id: uuid.NewV4(), Title: "a", Capacity: 30}},
+		sessions: []scheduleSession{{id: uuid.NewV4(), students: students(10), units: 2}},
+	}
+
+	placements, problems := buildTimetable(in)
+	if len(problems) != 0 || len(placements) != 2 || placements[0].slot != 0 || placements[1].slot != 2 {
+		t.Fatalf("expected units on Monday and Wednesday, got %+v and problems %+v", placements, problems)
+	}
+}
+
+func TestBuildTimetable(t *testing.T) {
+	f := newFixture(t)
+	studentId, _ := f.student(t)
+	goId := f.course(t, "Go")
+	f.course(t, "nobody studies")
+	if _, err := f.app.PostStudyGroup(goId, studentId); err != nil {
+		t.Fatal(err)
+	}
+
+	monday, err := f.app.PostTimeSlot(1, "8:30", "10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if monday.Start != "08:30" {
+		t.Fatalf("expected normalized start, got %s", monday.Start)
+	}
+	if _, err = f.app.PostTimeSlot(2, "08:30", "10:00"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostRoom("Р-237", 30); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostCourseSession(goId, store.SessionLecture, 2); err != nil {
+		t.Fatal(err)
+	}
+	// three academic hours take two time slots
+	if _, err = f.app.PostCourseSession(goId, store.SessionLab, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	timetable, err := f.app.BuildTimetable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timetable.Entries) != 2 || len(timetable.Problems) != 1 {
+		t.Fatalf("expected two entries and one problem, got %+v", timetable)
+	}
+	problem := timetable.Problems[0]
+	if problem.Course != "Go" || problem.Unplaced != 1 || problem.Reason != ProblemNoFreeSlot {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if entry := timetable.Entries[0]; entry.Course != "Go" || entry.TimeSlot.Weekday != 1 || entry.Room.Title != "Р-237" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	saved, err := f.app.GetStudentTimetable(studentId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Entries) != 2 {
+		t.Fatalf("expected the saved timetable of the student, got %+v", saved)
+	}
+	other, err := f.app.PostStudent("Петров Петр Петрович", time.Now(), uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved, err = f.app.GetStudentTimetable(other.Id); err != nil || len(saved.Entries) != 0 {
+		t.Fatalf("expected empty timetable of the student without study groups, got %+v, %v", saved, err)
+	}
+}
+
+func TestPostTimetableValidation(t *testing.T) {
+	app := newTestApp()
+	id := uuid.NewV4()
+	tests := map[string]struct {
+		post func() error
+		want error
+	}{
+		"time slot weekday":       {func() error { _, err := app.PostTimeSlot(0, "08:30", "10:00"); return err }, ErrWrongWeekday},
+		"time slot format":        {func() error { _, err := app.PostTimeSlot(1, "8.30", "10:00"); return err }, ErrWrongTime},
+		"time slot order":         {func() error { _, err := app.PostTimeSlot(1, "10:00", "08:30"); return err }, ErrWrongTime},
+		"room title":              {func() error { _, err := app.PostRoom("", 30); return err }, ErrEmptyTitle},
+		"room capacity":           {func() error { _, err := app.PostRoom("Р-237", 0); return err }, ErrWrongCapacity},
+		"session course":          {func() error { _, err := app.PostCourseSession(uuid.Nil, "lecture", 2); return err }, ErrEmptyId},
+		"session kind":            {func() error { _, err := app.PostCourseSession(id, "seminar", 2); return err }, ErrWrongSessionKind},
+		"session hours":           {func() error { _, err := app.PostCourseSession(id, "lab", 0); return err }, ErrWrongWeeklyHours},
+		"availability teacher":    {func() error { return app.PostTeacherAvailability(uuid.Nil, id) }, ErrEmptyId},
+		"availability time slot":  {func() error { return app.PostTeacherAvailability(id, uuid.Nil) }, ErrEmptyId},
+		"timetable of no student": {func() error { _, err := app.GetStudentTimetable(uuid.Nil); return err }, ErrEmptyId},
+	}
+
+	for name, tt := range tests {
+		if err := tt.post(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", name, tt.want, err)
+		}
+	}
+}
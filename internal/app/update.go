@@ -0,0 +1,539 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongSemester = errors.New("semester must be positive")
+
+// Get*ForUpdate methods return current editable state of the entity in the same shape as the request body,
+// so partial updates can be decoded on top of it.
+
+func (app *App) GetKnowledgeForUpdate(id uuid.UUID) (model.PostKnowledge, error) {
+	knowledge, err := app.store.GetKnowledge(id)
+	return model.PostKnowledge{Title: knowledge.Title, ParentId: knowledge.ParentId}, err
+}
+
+// UpdateKnowledge replaces the title and the parent of the knowledge, the parent can not be one of its descendants.
+func (app *App) UpdateKnowledge(id uuid.UUID, knowledge string, parentId uuid.UUID) (model.GetKnowledge, error) {
+	var resp model.GetKnowledge
+	if knowledge == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	// the knowledge and then its new ancestors are locked, so concurrent updates can not close a cycle unseen
+	err := app.store.Transaction(func(tx store.Store) error {
+		if err := tx.Lock(store.TableKnowledge, id); err != nil {
+			return err
+		}
+		if err := checkParent(id, parentId, knowledgeParent(tx)); err != nil {
+			return err
+		}
+		return tx.UpdateKnowledge(store.Knowledge{Id: id, Title: knowledge, ParentId: parentId})
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return model.GetKnowledge{Id: id, Title: knowledge, ParentId: parentId}, nil
+}
+
+func (app *App) GetTechnologyForUpdate(id uuid.UUID) (model.PostTechnology, error) {
+	technology, err := app.store.GetTechnology(id)
+	return model.PostTechnology{Title: technology.Title}, err
+}
+
+func (app *App) UpdateTechnology(id uuid.UUID, technology string) (model.GetTechnology, error) {
+	var resp model.GetTechnology
+	if technology == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	if err := app.store.UpdateTechnology(store.Technology{Id: id, Title: technology}); err != nil {
+		return resp, err
+	}
+
+	return model.GetTechnology{Id: id, Title: technology}, nil
+}
+
+func (app *App) GetCompetencyForUpdate(id uuid.UUID) (model.PostCompetency, error) {
+	var resp model.PostCompetency
+	competency, err := app.store.GetCompetency(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.Title, resp.ParentId, resp.MainTechnologyId = competency.Title, competency.ParentId, competency.MainTechnologyId
+
+	resp.Skills, err = app.getSkillsByCompetency(id)
+	return resp, err
+}
+
+// UpdateCompetency replaces the columns, the parent and the skills of the competency, the missing skills are created.
+// The parent can not be one of the descendants of the competency.
+func (app *App) UpdateCompetency(id uuid.UUID, competency string, parentId uuid.UUID, skills []string, technologyId uuid.UUID) (model.GetCompetency, error) {
+	var resp model.GetCompetency
+	if competency == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	// the competency and then its new ancestors are locked like in UpdateKnowledge
+	titles := skillTitles(skills)
+	err := app.store.Transaction(func(tx store.Store) error {
+		if err := tx.Lock(store.TableCompetencies, id); err != nil {
+			return err
+		}
+		if err := checkParent(id, parentId, competencyParent(tx)); err != nil {
+			return err
+		}
+		err := tx.UpdateCompetency(store.Competency{Id: id, Title: competency, ParentId: parentId, MainTechnologyId: technologyId})
+		if err != nil {
+			return err
+		}
+
+		existing, err := tx.GetSkillsByCompetency(id)
+		if err != nil {
+			return err
+		}
+		var kept []string
+		for _, skill := range existing {
+			if slices.Contains(titles, skill.Title) {
+				kept = append(kept, skill.Title)
+			} else if err = tx.DeleteSkillCompetency(skill.Id, id); err != nil {
+				return err
+			}
+		}
+		return addSkills(tx, id, slices.DeleteFunc(titles, func(title string) bool { return slices.Contains(kept, title) }))
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetCompetencyById(id)
+}
+
+func (app *App) GetProfessionForUpdate(id uuid.UUID) (model.PostProfession, error) {
+	profession, err := app.store.GetProfession(id)
+	return model.PostProfession{Title: profession.Title, Description: profession.Description}, err
+}
+
+func (app *App) UpdateProfession(id uuid.UUID, profession string, description string) (model.GetProfession, error) {
+	var resp model.GetProfession
+	if profession == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	err := app.store.UpdateProfession(store.Profession{Id: id, Title: profession, Description: description})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetProfessionById(id)
+}
+
+func (app *App) GetProjectForUpdate(id uuid.UUID) (model.PostProject, error) {
+	project, err := app.store.GetProject(id)
+	return model.PostProject{Title: project.Title, Description: project.Description, Result: project.Result,
+		LifeScenario: project.LifeScenario, MainTechnologyId: project.MainTechnologyId}, err
+}
+
+func (app *App) UpdateProject(id uuid.UUID, project string, description string, result string, lifeScenario string, technologyId uuid.UUID) (model.GetProject, error) {
+	var resp model.GetProject
+	if project == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	err := app.store.UpdateProject(store.Project{Id: id, Title: project, Description: description, Result: result,
+		LifeScenario: lifeScenario, MainTechnologyId: technologyId})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetProjectById(id)
+}
+
+func (app *App) GetOrganizationForUpdate(id uuid.UUID) (model.PostOrganization, error) {
+	organization, err := app.store.GetOrganization(id)
+	return model.PostOrganization{Title: organization.Title}, err
+}
+
+func (app *App) UpdateOrganization(id uuid.UUID, organization string) (model.GetOrganization, error) {
+	var resp model.GetOrganization
+	if organization == "" {
+		return resp, ErrEmptyTitle
+	}
+
+	if err := app.store.UpdateOrganization(store.Organization{Id: id, Title: organization}); err != nil {
+		return resp, err
+	}
+
+	return model.GetOrganization{Id: id, Title: organization}, nil
+}
+
+func (app *App) GetEducationalProgramForUpdate(id uuid.UUID) (model.PostEducationalProgram, error) {
+	educationalProgram, err := app.store.GetEducationalProgram(id)
+	return model.PostEducationalProgram{Title: educationalProgram.Title, Description: educationalProgram.Description,
+		OrganizationId: educationalProgram.OrganizationId, MinCredits: educationalProgram.MinSemesterCredits,
+		MaxCredits: educationalProgram.MaxSemesterCredits}, err
+}
+
+func (app *App) UpdateEducationalProgram(id uuid.UUID, educationalProgram string, description string, organizationId uuid.UUID,
+	minCredits uint8, maxCredits uint8) (model.GetEducationalProgram, error) {
+	var resp model.GetEducationalProgram
+	if educationalProgram == "" {
+		return resp, ErrEmptyTitle
+	}
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if maxCredits != 0 && minCredits > maxCredits {
+		return resp, ErrWrongCredits
+	}
+
+	err := app.store.UpdateEducationalProgram(store.EducationalProgram{Id: id, Title: educationalProgram, Description: description,
+		OrganizationId: organizationId, MinSemesterCredits: minCredits, MaxSemesterCredits: maxCredits})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetEducationalProgramById(id)
+}
+
+func (app *App) GetDisciplineForUpdate(id uuid.UUID) (model.PostDiscipline, error) {
+	discipline, err := app.store.GetDiscipline(id)
+	return model.PostDiscipline{Title: discipline.Title, Description: discipline.Description,
+		EducationalProgramId: discipline.EducationalProgramId}, err
+}
+
+func (app *App) UpdateDiscipline(id uuid.UUID, discipline string, description string, educationalProgramId uuid.UUID) (model.GetDiscipline, error) {
+	var resp model.GetDiscipline
+	if discipline == "" {
+		return resp, ErrEmptyTitle
+	}
+	if educationalProgramId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	err := app.store.UpdateDiscipline(store.Discipline{Id: id, Title: discipline, Description: description,
+		EducationalProgramId: educationalProgramId})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetDisciplineById(id)
+}
+
+func (app *App) GetCourseForUpdate(id uuid.UUID) (model.PostCourse, error) {
+	var resp model.PostCourse
+	course, err := app.store.GetCourse(id)
+	if err != nil {
+		return resp, err
+	}
+	resp.Title, resp.Description, resp.DisciplineId = course.Title, course.Description, course.DisciplineId
+	resp.Credits, resp.Hours, resp.Alternative, resp.Capacity = course.Credits, course.Hours, course.Alternative, course.Capacity
+
+	teachers, err := app.store.GetTeachersByCourse(id)
+	if err != nil {
+		return resp, err
+	}
+	for _, teacher := range teachers {
+		resp.TeacherIds = append(resp.TeacherIds, teacher.Id)
+	}
+	return resp, nil
+}
+
+// UpdateCourse replaces the columns and the teachers of the course, seats added to the course go to its waitlist.
+func (app *App) UpdateCourse(id uuid.UUID, course string, description string, teacherIds []uuid.UUID, disciplineId uuid.UUID,
+	credits uint8, hours uint16, alternative bool, capacity uint16) (model.GetCourse, error) {
+	var resp model.GetCourse
+	if course == "" {
+		return resp, ErrEmptyTitle
+	}
+	if disciplineId == uuid.Nil || slices.Contains(teacherIds, uuid.Nil) {
+		return resp, ErrEmptyId
+	}
+
+	err := app.store.Transaction(func(tx store.Store) error {
+		err := tx.UpdateCourse(store.Course{Id: id, Title: course, Description: description, DisciplineId: disciplineId,
+			Credits: credits, Hours: hours, Alternative: alternative, Capacity: capacity})
+		if err != nil {
+			return err
+		}
+
+		teachers, err := tx.GetTeachersByCourse(id)
+		if err != nil {
+			return err
+		}
+		linked := make(map[uuid.UUID]bool)
+		for _, teacher := range teachers {
+			if slices.Contains(teacherIds, teacher.Id) {
+				linked[teacher.Id] = true
+			} else if err = tx.DeleteCourseTeacher(id, teacher.Id); err != nil {
+				return err
+			}
+		}
+		for _, teacherId := range teacherIds {
+			if linked[teacherId] {
+				continue
+			}
+			if err = tx.CreateCourseTeacher(id, teacherId); err != nil {
+				return err
+			}
+			linked[teacherId] = true
+		}
+		return fillStudyGroup(tx, id)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetCourseById(id)
+}
+
+func (app *App) GetStudentForUpdate(id uuid.UUID) (model.PostStudent, error) {
+	student, err := app.store.GetStudent(id)
+	return model.PostStudent{FullName: student.FullName, Admition: model.JsonAdmitionDate(student.Admition),
+		PortfolioId: student.PortfolioId}, err
+}
+
+func (app *App) UpdateStudent(id uuid.UUID, fullName string, admition time.Time, portfolioId uuid.UUID) (model.GetStudent, error) {
+	var resp model.GetStudent
+	if fullName == "" {
+		return resp, ErrEmptyTitle
+	}
+	if portfolioId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if admition.IsZero() {
+		admition = time.Now()
+	}
+
+	err := app.store.UpdateStudent(store.Student{Id: id, FullName: fullName, Admition: admition, PortfolioId: portfolioId})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetStudentById(id)
+}
+
+func (app *App) GetTeacherForUpdate(id uuid.UUID) (model.PostTeacher, error) {
+	teacher, err := app.store.GetTeacher(id)
+	return model.PostTeacher{FullName: teacher.FullName, OrganizationId: teacher.OrganizationId, Email: teacher.Email,
+		Phone: teacher.Phone}, err
+}
+
+func (app *App) UpdateTeacher(id uuid.UUID, fullName string, organizationId uuid.UUID, email string, phone string) (model.GetTeacher, error) {
+	var resp model.GetTeacher
+	if fullName == "" {
+		return resp, ErrEmptyTitle
+	}
+	if organizationId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+
+	err := app.store.UpdateTeacher(store.Teacher{Id: id, FullName: fullName, OrganizationId: organizationId, Email: email, Phone: phone})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetTeacherById(id)
+}
+
+func (app *App) GetTrajectoryForUpdate(id uuid.UUID) (model.PostTrajectory, error) {
+	trajectory, err := app.store.GetTrajectory(id)
+	return model.PostTrajectory{StudentId: trajectory.StudentId, Semester: trajectory.Semester, CourseId: trajectory.CourseId}, err
+}
+
+func (app *App) UpdateTrajectory(id uuid.UUID, semester uint8, studentId uuid.UUID, courseId uuid.UUID) (model.GetTrajectory, error) {
+	var resp model.GetTrajectory
+	if semester == 0 {
+		return resp, ErrWrongSemester
+	}
+	if studentId == uuid.Nil || courseId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if err := app.checkTrajectorySemester(studentId, semester); err != nil {
+		return resp, err
+	}
+	if err := app.checkStudentWorkload(studentId, courseId, semester, id); err != nil {
+		return resp, err
+	}
+
+	err := app.store.Transaction(func(tx store.Store) error {
+		trajectory, err := tx.GetTrajectory(id)
+		if err != nil {
+			return err
+		}
+		if trajectory.StudentId != studentId || trajectory.CourseId != courseId {
+			if err = checkOutcomeRecorded(tx, trajectory); err != nil {
+				return err
+			}
+			if err = checkCourseTaken(tx, studentId, courseId, id); err != nil {
+				return err
+			}
+		}
+		return tx.UpdateTrajectory(store.Trajectory{Id: id, StudentId: studentId, CourseId: courseId, Semester: semester})
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return app.GetTrajectoryById(id)
+}
+
+// checkOutcomeRecorded refuses to move the trajectory to another student or course once its outcome is recorded
+// or it is a retake or retaken, the outcome and the attempts belong to the course the student took.
+func checkOutcomeRecorded(s store.Store, trajectory store.Trajectory) error {
+	if trajectory.Result != "" || trajectory.Grade != 0 || trajectory.RetakeOf != uuid.Nil {
+		return ErrOutcomeRecorded
+	}
+	retaken, err := hasLaterAttempt(s, trajectory)
+	if err != nil {
+		return err
+	}
+	if retaken {
+		return ErrOutcomeRecorded
+	}
+	return nil
+}
+
+// hasLaterAttempt reports whether the student retook the course after the attempt of the trajectory.
+func hasLaterAttempt(s store.Store, trajectory store.Trajectory) (bool, error) {
+	first := trajectory.Id
+	if trajectory.RetakeOf != uuid.Nil {
+		first = trajectory.RetakeOf
+	}
+	trajectories, err := s.GetStudentTrajectories(trajectory.StudentId)
+	if err != nil {
+		return false, err
+	}
+	for _, attempt := range trajectories {
+		if attempt.RetakeOf == first && attempt.Attempt > trajectory.Attempt {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (app *App) GetProjectPortfolioForUpdate(projectId uuid.UUID, portfolioId uuid.UUID) (model.PutProjectPortfolio, error) {
+	var resp model.PutProjectPortfolio
+	projects, err := app.store.GetProjectPortfolios(portfolioId)
+	if err != nil {
+		return resp, err
+	}
+	for _, project := range projects {
+		if project.ProjectId == projectId {
+			return model.PutProjectPortfolio{TeamRole: project.TeamRole, Semester: project.Semester}, nil
+		}
+	}
+	return resp, store.ErrNotFound
+}
+
+func (app *App) GetCompetencyProfessionForUpdate(competencyId uuid.UUID, professionId uuid.UUID) (model.PutCompetencyLevel, error) {
+	competencies, err := app.store.GetCompetenciesByProfession(professionId)
+	return competencyLevel(competencies, competencyId, err)
+}
+
+func (app *App) GetCourseCompetencyForUpdate(courseId uuid.UUID, competencyId uuid.UUID) (model.PutCompetencyLevel, error) {
+	competencies, err := app.store.GetCompetenciesByCourse(courseId)
+	return competencyLevel(competencies, competencyId, err)
+}
+
+func (app *App) GetProjectPortfolioCompetencyForUpdate(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) (model.PutCompetencyLevel, error) {
+	competencies, err := app.store.GetCompetenciesByPersonalProject(portfolioId, projectId)
+	return competencyLevel(competencies, competencyId, err)
+}
+
+// competencyLevel returns the level of the link to the competency among the linked competencies.
+func competencyLevel(competencies []store.Competency, competencyId uuid.UUID, err error) (model.PutCompetencyLevel, error) {
+	var resp model.PutCompetencyLevel
+	if err != nil {
+		return resp, err
+	}
+	for _, competency := range competencies {
+		if competency.Id == competencyId {
+			return model.PutCompetencyLevel{Level: levelName(competency.Level)}, nil
+		}
+	}
+	return resp, store.ErrNotFound
+}
+
+func (app *App) UpdateProjectPortfolio(projectId uuid.UUID, portfolioId uuid.UUID, teamRole string, semester uint8) (model.PostProjectPortfolio, error) {
+	resp := model.PostProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId}
+	if projectId == uuid.Nil || portfolioId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	if semester == 0 {
+		return resp, ErrWrongSemester
+	}
+
+	err := app.store.UpdateProjectPortfolio(store.ProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, TeamRole: teamRole,
+		Semester: semester})
+	if err != nil {
+		return resp, err
+	}
+
+	resp.TeamRole, resp.Semester = teamRole, semester
+	return resp, nil
+}
+
+// UpdateCompetencyProfession replaces the level the profession requires the competency at, basic by default.
+func (app *App) UpdateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level string) (model.PostCompetencyProfession, error) {
+	resp := model.PostCompetencyProfession{CompetencyId: competencyId, ProfessionId: professionId}
+	if competencyId == uuid.Nil || professionId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = app.store.UpdateCompetencyProfession(competencyId, professionId, proficiency); err != nil {
+		return resp, err
+	}
+	resp.Level = levelName(proficiency)
+	return resp, nil
+}
+
+// UpdateCourseCompetency replaces the level the course gives the competency at, basic by default.
+func (app *App) UpdateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level string) (model.PostCourseCompetency, error) {
+	resp := model.PostCourseCompetency{CourseId: courseId, CompetencyId: competencyId}
+	if courseId == uuid.Nil || competencyId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = app.store.UpdateCourseCompetency(courseId, competencyId, proficiency); err != nil {
+		return resp, err
+	}
+	resp.Level = levelName(proficiency)
+	return resp, nil
+}
+
+// UpdateProjectPortfolioCompetency replaces the level the project of the portfolio confirms the competency at,
+// basic by default.
+func (app *App) UpdateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level string) (model.PostProjectPortfolioCompetency, error) {
+	resp := model.PostProjectPortfolioCompetency{ProjectId: projectId, PortfolioId: portfolioId, CompetencyId: competencyId}
+	if projectId == uuid.Nil || portfolioId == uuid.Nil || competencyId == uuid.Nil {
+		return resp, ErrEmptyId
+	}
+	proficiency, err := parseLevel(level)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = app.store.UpdateProjectPortfolioCompetency(projectId, portfolioId, competencyId, proficiency); err != nil {
+		return resp, err
+	}
+	resp.Level = levelName(proficiency)
+	return resp, nil
+}
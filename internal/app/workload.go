@@ -0,0 +1,177 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+var ErrWrongCredits = errors.New("minimum semester credits must not exceed the maximum")
+var ErrWorkload = errors.New("semester workload is out of the limits of the educational program")
+
+// Reasons of workload violations.
+const (
+	WorkloadAboveMaximum = "aboveMaximum"
+	WorkloadBelowMinimum = "belowMinimum"
+)
+
+// WorkloadError names the semester whose credits would go out of the limits of the educational program.
+type WorkloadError struct {
+	Violation model.GetWorkloadViolation
+}
+
+func (e *WorkloadError) Error() string {
+	v := e.Violation
+	if v.Reason == WorkloadBelowMinimum {
+		return fmt.Sprintf("%s: semester %d has %d credits, educational program %q requires at least %d",
+			ErrWorkload, v.Semester, v.Credits, v.EducationalProgram, v.MinCredits)
+	}
+	return fmt.Sprintf("%s: semester %d would have %d credits, educational program %q allows at most %d",
+		ErrWorkload, v.Semester, v.Credits, v.EducationalProgram, v.MaxCredits)
+}
+
+func (e *WorkloadError) Unwrap() error {
+	return ErrWorkload
+}
+
+// checkWorkload compares credits of the semester with the limits of the educational program,
+// ok is false when the credits fit.
+func checkWorkload(educationalProgram store.EducationalProgram, semester uint8, credits int) (violation model.GetWorkloadViolation, ok bool) {
+	violation = model.GetWorkloadViolation{
+		EducationalProgram: educationalProgram.Title,
+		Semester:           semester,
+		Credits:            credits,
+		MinCredits:         educationalProgram.MinSemesterCredits,
+		MaxCredits:         educationalProgram.MaxSemesterCredits,
+	}
+	switch {
+	case educationalProgram.MaxSemesterCredits != 0 && credits > int(educationalProgram.MaxSemesterCredits):
+		violation.Reason = WorkloadAboveMaximum
+	case credits < int(educationalProgram.MinSemesterCredits):
+		violation.Reason = WorkloadBelowMinimum
+	default:
+		return violation, false
+	}
+	return violation, true
+}
+
+// getWorkloadProgram returns the educational program whose limits apply to the student: the program most of the student
+// courses belong to, for students without courses the program of the course. ok is false when there is no such program.
+func (app *App) getWorkloadProgram(studentId uuid.UUID, courseId uuid.UUID) (educationalProgram store.EducationalProgram, ok bool, err error) {
+	educationalProgramId, err := app.store.GetStudentEducationalProgram(studentId)
+	if errors.Is(err, store.ErrNotFound) && courseId != uuid.Nil {
+		var course store.Course
+		var discipline store.Discipline
+		if course, err = app.store.GetCourse(courseId); err == nil {
+			if discipline, err = app.store.GetDiscipline(course.DisciplineId); err == nil {
+				educationalProgramId = discipline.EducationalProgramId
+			}
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		return educationalProgram, false, nil
+	}
+	if err != nil {
+		return educationalProgram, false, err
+	}
+
+	educationalProgram, err = app.store.GetEducationalProgram(educationalProgramId)
+	return educationalProgram, err == nil, err
+}
+
+// studentWorkload is credits of the courses the student takes in every semester, a course counts once per semester.
+type studentWorkload struct {
+	credits map[uint8]int
+	courses map[uint8]map[uuid.UUID]bool
+}
+
+func (w studentWorkload) add(course store.Course, semester uint8) {
+	if w.courses[semester] == nil {
+		w.courses[semester] = make(map[uuid.UUID]bool)
+	}
+	if !w.courses[semester][course.Id] {
+		w.courses[semester][course.Id] = true
+		w.credits[semester] += int(course.Credits)
+	}
+}
+
+// getStudentWorkload counts past trajectories in their semesters and study groups in the current semester,
+// the replaced trajectory is skipped.
+func (app *App) getStudentWorkload(studentId uuid.UUID, current uint8, replaced uuid.UUID) (studentWorkload, error) {
+	workload := studentWorkload{credits: make(map[uint8]int), courses: make(map[uint8]map[uuid.UUID]bool)}
+	trajectories, err := app.store.GetStudentTrajectories(studentId)
+	if err != nil {
+		return workload, err
+	}
+	for _, trajectory := range trajectories {
+		if trajectory.Id == replaced {
+			continue
+		}
+		course, err := app.store.GetCourse(trajectory.CourseId)
+		if err != nil {
+			return workload, err
+		}
+		workload.add(course, trajectory.Semester)
+	}
+
+	groups, err := app.store.GetStudyGroupCourses(studentId)
+	if err != nil {
+		return workload, err
+	}
+	for _, course := range groups {
+		workload.add(course, current)
+	}
+
+	return workload, nil
+}
+
+// checkStudentWorkload refuses to add the course to the semester of the student when the semester would go over
+// the maximum credits of the educational program, semester 0 is the current one, the replaced trajectory is not counted.
+// The minimum can not be checked while courses are added one by one, it is reported by plans.
+// Unknown students and courses are left to the foreign keys.
+func (app *App) checkStudentWorkload(studentId uuid.UUID, courseId uuid.UUID, semester uint8, replaced uuid.UUID) error {
+	educationalProgram, ok, err := app.getWorkloadProgram(studentId, courseId)
+	if err != nil || !ok || educationalProgram.MaxSemesterCredits == 0 {
+		return err
+	}
+
+	student, err := app.store.GetStudent(studentId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	course, err := app.store.GetCourse(courseId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	current, err := app.getStudentSemester(studentId, student.Admition, time.Now())
+	if err != nil {
+		return err
+	}
+	if semester == 0 {
+		semester = current
+	}
+
+	workload, err := app.getStudentWorkload(studentId, current, replaced)
+	if err != nil {
+		return err
+	}
+	workload.add(course, semester)
+
+	violation, ok := checkWorkload(educationalProgram, semester, workload.credits[semester])
+	if ok && violation.Reason == WorkloadAboveMaximum {
+		return &WorkloadError{Violation: violation}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// limitedProgram creates an educational program with the credit limits and a course of it per credits value.
+func limitedProgram(t *testing.T, f fixture, minCredits uint8, maxCredits uint8, credits ...uint8) []uuid.UUID {
+	t.Helper()
+	program, err := f.app.PostEducationalProgram("Бизнес-информатика", "", f.organizationId, minCredits, maxCredits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	discipline, err := f.app.PostDiscipline("Экономика", "", program.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []uuid.UUID
+	for i, value := range credits {
+		course, err := f.app.PostCourse(string(rune('A'+i))+" курс", "", nil, discipline.Id, value, uint16(value)*36, false, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, course.Id)
+	}
+	return ids
+}
+
+func TestWrongCredits(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.app.PostEducationalProgram("Бизнес-информатика", "", f.organizationId, 40, 30); !errors.Is(err, ErrWrongCredits) {
+		t.Fatalf("expected ErrWrongCredits, got %v", err)
+	}
+	program, err := f.app.PostEducationalProgram("Бизнес-информатика", "", f.organizationId, 40, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if program.MinCredits != 40 || program.MaxCredits != 0 {
+		t.Fatalf("unexpected educational program %+v", program)
+	}
+}
+
+func TestStudentWorkload(t *testing.T) {
+	f := newFixture(t)
+	courses := limitedProgram(t, f, 10, 12, 6, 5, 2)
+	studentId, _ := f.student(t)
+	current := newCalendar(nil, nil).semesterOn(time.Now().AddDate(-1, 0, 0), time.Now())
+
+	// the first course of the student brings the rules of its educational program
+	if _, err := f.app.PostStudyGroup(courses[0], studentId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.app.PostStudyGroup(courses[1], studentId); err != nil {
+		t.Fatal(err)
+	}
+	_, err := f.app.PostStudyGroup(courses[2], studentId)
+	var workloadErr *WorkloadError
+	if !errors.As(err, &workloadErr) || !errors.Is(err, ErrWorkload) {
+		t.Fatalf("expected *WorkloadError, got %v", err)
+	}
+	want := model.GetWorkloadViolation{EducationalProgram: "Бизнес-информатика", Semester: current, Credits: 13,
+		MinCredits: 10, MaxCredits: 12, Reason: WorkloadAboveMaximum}
+	if workloadErr.Violation != want {
+		t.Fatalf("expected %+v, got %+v", want, workloadErr.Violation)
+	}
+
+	// past semesters are limited the same way, the replaced trajectory is not counted
+	trajectory, err := f.app.PostTrajectory(1, studentId, courses[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectory(1, studentId, courses[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.app.PostTrajectory(1, studentId, courses[2]); !errors.As(err, &workloadErr) || workloadErr.Violation.Semester != 1 {
+		t.Fatalf("expected *WorkloadError of the first semester, got %v", err)
+	}
+	if err = f.app.checkStudentWorkload(studentId, courses[2], 1, trajectory.Id); err != nil {
+		t.Fatalf("expected the replaced trajectory to free its credits, got %v", err)
+	}
+}
+
+func TestBuildPlanCredits(t *testing.T) {
+	var gap []planCompetency
+	var candidates []planCourse
+	for _, credits := range []uint8{6, 5, 20, 4} {
+		competency := planCompetency{id: uuid.NewV4(), title: string(rune('a' + len(gap)))}
+		gap = append(gap, competency)
+		candidates = append(candidates, planCourse{id: uuid.NewV4(), title: competency.title, credits: credits, competencies: []uuid.UUID{competency.id}})
+	}
+
+	semesters, _ := buildPlan(gap, candidates, nil, 3, planLimits{courses: 5, maxCredits: 12, taken: map[uint8]int{3: 4}})
+	credits := make([]int, 0, len(semesters))
+	for _, semester := range semesters {
+		credits = append(credits, semester.Credits)
+	}
+	// a (6) and the taken 4 fill the third semester, the course over the limit gets a semester alone
+	want := []int{10, 9, 20}
+	if len(semesters) != 3 || credits[0] != want[0] || credits[1] != want[1] || credits[2] != want[2] {
+		t.Fatalf("expected credits %v, got %v in %+v", want, credits, semesters)
+	}
+}
+
+func TestGetStudentPlanWorkload(t *testing.T) {
+	f := newFixture(t)
+	courses := limitedProgram(t, f, 8, 10, 6, 6, 2)
+	for _, courseId := range courses[1:] {
+		competencyId := f.competency(t, "для "+courseId.String(), true)
+		if err := f.app.PostCourseCompetency(courseId, competencyId, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	studentId, _ := f.student(t)
+	if _, err := f.app.PostTrajectory(1, studentId, courses[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := f.app.GetStudentPlan(studentId, f.professionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Semesters) != 1 || plan.Semesters[0].Credits != 8 {
+		t.Fatalf("expected both courses in one semester of 8 credits, got %+v", plan.Semesters)
+	}
+	if len(plan.Workload) != 0 {
+		t.Fatalf("expected no violations, got %+v", plan.Workload)
+	}
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// The part of Office Open XML spreadsheets the competency matrix is read from.
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	text := t.Text
+	for _, run := range t.Runs {
+		text += run.Text
+	}
+	return text
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationId string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Items []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXlsxPart(files map[string]*zip.File, name string, v any) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: no %s in xlsx", ErrWrongMatrix, name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err = xml.NewDecoder(reader).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("%w: %s: %s", ErrWrongMatrix, name, err.Error())
+	}
+	return nil
+}
+
+// xlsxColumn returns the zero-based column of the cell reference like AB12.
+func xlsxColumn(ref string) int {
+	column := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+	}
+	return column - 1
+}
+
+// readXlsx returns cell values of the first worksheet, row i of the result is row i+1 of the sheet.
+func readXlsx(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrWrongMatrix, err.Error())
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetName := "xl/worksheets/sheet1.xml"
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	if readXlsxPart(files, "xl/workbook.xml", &workbook) == nil && len(workbook.Sheets) > 0 &&
+		readXlsxPart(files, "xl/_rels/workbook.xml.rels", &relationships) == nil {
+		for _, relationship := range relationships.Items {
+			if relationship.Id != workbook.Sheets[0].RelationId {
+				continue
+			}
+			if strings.HasPrefix(relationship.Target, "/") {
+				sheetName = strings.TrimPrefix(relationship.Target, "/")
+			} else {
+				sheetName = path.Join("xl", relationship.Target)
+			}
+		}
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err = readXlsxPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	var sheet xlsxWorksheet
+	if err = readXlsxPart(files, sheetName, &sheet); err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for i, row := range sheet.Rows {
+		number := row.Number
+		if number == 0 {
+			number = i + 1
+		}
+		for len(records) < number {
+			records = append(records, nil)
+		}
+
+		var record []string
+		for j, cell := range row.Cells {
+			column := j
+			if cell.Ref != "" {
+				column = xlsxColumn(cell.Ref)
+			}
+			for len(record) <= column {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("%w: wrong shared string in cell %s", ErrWrongMatrix, cell.Ref)
+				}
+				record[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				record[column] = cell.Inline.String()
+			default:
+				record[column] = cell.Value
+			}
+		}
+		records[number-1] = record
+	}
+
+	return records, nil
+}
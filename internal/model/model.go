@@ -32,8 +32,9 @@ func (j JsonAdmitionDate) Format(s string) string {
 }
 
 type GetKnowledge struct {
-	Id    uuid.UUID `json:"knowledgeId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	Title string    `json:"knowledgeTitle" example:"Название знания"`
+	Id       uuid.UUID `json:"knowledgeId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title    string    `json:"knowledgeTitle" example:"Название знания"`
+	ParentId uuid.UUID `json:"knowledgeParentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 }
 
 type GetTechnology struct {
@@ -44,27 +45,59 @@ type GetTechnology struct {
 type GetCompetency struct {
 	Id               uuid.UUID `json:"competencyId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 	Title            string    `json:"competencyTitle" example:"Название компетенции"`
-	Skills           string    `json:"competencySkills,omitempty" example:"навык 1, навык 2..."`
+	ParentId         uuid.UUID `json:"competencyParentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Skills           []string  `json:"competencySkills,omitempty" example:"навык 1,навык 2"`
 	MainTechnologyId uuid.UUID `json:"competencyMainTechnology,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 	Knowledge        []string  `json:"competencyKnowledge,omitempty" example:"знание 1, знание 2..."`
 }
 
+// PostCompetency lists skills by title, the missing ones are created.
 type PostCompetency struct {
-	Title            string    `json:"competencyTitle" example:"Название компетенции"`
-	Skills           string    `json:"competencySkills,omitempty" example:"навык 1, навык 2..."`
+	Title            string    `json:"competencyTitle" example:"Название компетенции" validate:"required,max=255"`
+	ParentId         uuid.UUID `json:"competencyParentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Skills           []string  `json:"competencySkills,omitempty" example:"навык 1,навык 2"`
 	MainTechnologyId uuid.UUID `json:"competencyMainTechnology,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 }
 
+type GetSkill struct {
+	Id    uuid.UUID `json:"skillId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title string    `json:"skillTitle" example:"Название навыка"`
+}
+
+// GetCompetencyTree is the competency with its subtree, counts are aggregated over the whole subtree
+// and count every skill, knowledge and course once.
+type GetCompetencyTree struct {
+	Id             uuid.UUID           `json:"treeCompetencyId" example:"00000000-0000-0000-0000-000000000000"`
+	Title          string              `json:"treeCompetencyTitle" example:"Название компетенции"`
+	Skills         []string            `json:"treeCompetencySkills,omitempty" example:"навык 1,навык 2"`
+	Knowledge      []string            `json:"treeCompetencyKnowledge,omitempty" example:"знание 1,знание 2"`
+	Competencies   int                 `json:"treeCompetencies" example:"3"` // the competency and its descendants
+	SkillCount     int                 `json:"treeSkillCount" example:"7"`
+	KnowledgeCount int                 `json:"treeKnowledgeCount" example:"5"`
+	CourseCount    int                 `json:"treeCourseCount" example:"2"` // courses giving any competency of the subtree
+	Children       []GetCompetencyTree `json:"treeChildren,omitempty"`
+}
+
+// GetKnowledgeTree is the knowledge with its subtree, counts are aggregated over the whole subtree.
+type GetKnowledgeTree struct {
+	Id              uuid.UUID          `json:"treeKnowledgeId" example:"00000000-0000-0000-0000-000000000000"`
+	Title           string             `json:"treeKnowledgeTitle" example:"Название знания"`
+	Knowledge       int                `json:"treeKnowledge" example:"4"` // the knowledge and its descendants
+	CompetencyCount int                `json:"treeCompetencyCount" example:"6"`
+	Children        []GetKnowledgeTree `json:"treeKnowledgeChildren,omitempty"`
+}
+
 type GetProfession struct {
-	Id           uuid.UUID `json:"professionId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	Title        string    `json:"professionTitle" example:"Название профессии"`
-	Description  string    `json:"professionDescription,omitempty" example:"Описание профессии"`
-	Competencies []string  `json:"professionCompetencies,omitempty" example:"компетенция 1, компетенция 2..."`
+	Id               uuid.UUID         `json:"professionId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title            string            `json:"professionTitle" example:"Название профессии"`
+	Description      string            `json:"professionDescription,omitempty" example:"Описание профессии"`
+	Competencies     []string          `json:"professionCompetencies,omitempty" example:"компетенция 1, компетенция 2..."`
+	CompetencyLevels map[string]string `json:"professionCompetencyLevels,omitempty"` // required levels by competency title
 }
 
 type PostProfession struct {
-	Title       string `json:"professionTitle" example:"Название профессии"`
-	Description string `json:"professionDescription,omitempty" example:"Описание профессии"`
+	Title       string `json:"professionTitle" example:"Название профессии" validate:"required,max=255"`
+	Description string `json:"professionDescription,omitempty" example:"Описание профессии" validate:"max=5000"`
 }
 
 type GetProject struct {
@@ -82,7 +115,7 @@ type GetOrganization struct {
 }
 
 type PostOrganization struct {
-	Title string `json:"organizationTitle" example:"Название организации"`
+	Title string `json:"organizationTitle" example:"Название организации" validate:"required,max=255"`
 }
 
 type GetEducationalProgram struct {
@@ -90,6 +123,8 @@ type GetEducationalProgram struct {
 	Title        string    `json:"educationalProgramTitle" example:"Название образовательной программы"`
 	Description  string    `json:"educationalProgramDexcription,omitempty" example:"Описание образовательной программы"`
 	Organization string    `json:"educationalProgramOrganization,omitempty" example:"организация, отвечающая за образовательную программу"`
+	MinCredits   uint8     `json:"educationalProgramMinSemesterCredits,omitempty" example:"20"`
+	MaxCredits   uint8     `json:"educationalProgramMaxSemesterCredits,omitempty" example:"35"`
 }
 
 type GetDiscipline struct {
@@ -100,24 +135,32 @@ type GetDiscipline struct {
 }
 
 type GetCourse struct {
-	Id           uuid.UUID `json:"courseId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	Title        string    `json:"courseTitle" example:"Название курса"`
-	Description  string    `json:"courseDescription,omitempty"  example:"Описание курса"`
-	Teacher      string    `json:"courseTeacher,omitempty" example:"Преподаватель"`
-	Discipline   string    `json:"courseDiscipline,omitempty" example:"Дисциплина, к которой отностися курс"`
-	Competencies []string  `json:"courseCompetencies,omitempty" example:"компетенция 1, компетенция 2..."`
+	Id               uuid.UUID         `json:"courseId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title            string            `json:"courseTitle" example:"Название курса"`
+	Description      string            `json:"courseDescription,omitempty"  example:"Описание курса"`
+	Teachers         []string          `json:"courseTeachers,omitempty" example:"Фамилия Имя Отчество 1, Фамилия Имя Отчество 2..."`
+	Discipline       string            `json:"courseDiscipline,omitempty" example:"Дисциплина, к которой отностися курс"`
+	Credits          uint8             `json:"courseCredits,omitempty" example:"5"`
+	Hours            uint16            `json:"courseHours,omitempty" example:"180"`
+	Alternative      bool              `json:"courseAlternative,omitempty" example:"true"`
+	Capacity         uint16            `json:"courseCapacity,omitempty" example:"30"`
+	Enrolled         int               `json:"courseEnrolled" example:"30"`
+	Waitlisted       int               `json:"courseWaitlisted" example:"2"`
+	Competencies     []string          `json:"courseCompetencies,omitempty" example:"компетенция 1, компетенция 2..."`
+	CompetencyLevels map[string]string `json:"courseCompetencyLevels,omitempty"` // given levels by competency title
 }
 
 type GetPersonalProject struct {
-	Id             uuid.UUID `json:"projectId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	Title          string    `json:"projectTitle" example:"Исследование зивисимости длины шерстки капибар от продолжительности их жизни"`
-	Description    string    `json:"projectDescription,omitempty" example:"Курс направлен на изучение поведения капибар в дикой природе..."`
-	Result         string    `json:"projectResult,omitempty" example:"Данные о зависимости длины шерстки капибар от продолжительности их жизни"`
-	LifeScenario   string    `json:"projectLifeScenarion,omitempty" example:"Проект позволит подобрать идеальную длину шерстки для ваших капибар"`
-	MainTechnology string    `json:"personalProjectMainTechnology,omitempty" example:"Основная технология проекта"`
-	TeamRole       string    `json:"personalProjectTeamRole" example:"Роль участника в команде"`
-	Semester       string    `json:"personalProjectSemester" example:"Семестр, в котором участик работал над проектом"`
-	Competencies   []string  `json:"personalProjectCompetencies,omitempty" example:"Компетенции участика в этом проекте"`
+	Id               uuid.UUID         `json:"projectId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title            string            `json:"projectTitle" example:"Исследование зивисимости длины шерстки капибар от продолжительности их жизни"`
+	Description      string            `json:"projectDescription,omitempty" example:"Курс направлен на изучение поведения капибар в дикой природе..."`
+	Result           string            `json:"projectResult,omitempty" example:"Данные о зависимости длины шерстки капибар от продолжительности их жизни"`
+	LifeScenario     string            `json:"projectLifeScenarion,omitempty" example:"Проект позволит подобрать идеальную длину шерстки для ваших капибар"`
+	MainTechnology   string            `json:"personalProjectMainTechnology,omitempty" example:"Основная технология проекта"`
+	TeamRole         string            `json:"personalProjectTeamRole" example:"Роль участника в команде"`
+	Semester         string            `json:"personalProjectSemester" example:"Семестр, в котором участик работал над проектом"`
+	Competencies     []string          `json:"personalProjectCompetencies,omitempty" example:"Компетенции участика в этом проекте"`
+	CompetencyLevels map[string]string `json:"personalProjectCompetencyLevels,omitempty"` // confirmed levels by competency title
 }
 
 type GetPortfolio struct {
@@ -126,10 +169,13 @@ type GetPortfolio struct {
 }
 
 type GetStudent struct {
-	Id        uuid.UUID    `json:"studentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	FullName  string       `json:"studentFullName" example:"Фамилия Имя Отчество"`
-	Portfolio GetPortfolio `json:"studentPortfolio,omitempty"`
-	Semester  uint8        `json:"studentSemester" example:"3"`
+	Id                   uuid.UUID    `json:"studentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	FullName             string       `json:"studentFullName" example:"Фамилия Имя Отчество"`
+	Portfolio            GetPortfolio `json:"studentPortfolio,omitempty"`
+	Semester             uint8        `json:"studentSemester" example:"3"`
+	EducationalProgramId uuid.UUID    `json:"studentEducationalProgramId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgram   string       `json:"studentEducationalProgram,omitempty" example:"Название образовательной программы"`
+	EnrollmentStatus     string       `json:"studentEnrollmentStatus,omitempty" example:"active" enums:"active,academicLeave,expelled,graduated,transferred"`
 }
 
 type GetStudyGroups struct {
@@ -138,48 +184,71 @@ type GetStudyGroups struct {
 	// Semester        uint8    `json:"studyGroupSemester"`
 	Courses []string `json:"studyGroupCourse" example:"Курсы, которые студент изучает"`
 	// StudentFullName string   `json:"studyGroupStudent"`
+	Entries []GetStudyGroupEntry `json:"studyGroupEntries"`
+}
+
+// GetStudyGroupEntry is the status of the student on the course: enrolled, waitlisted with the position
+// counted from 1 or dropped on the date.
+type GetStudyGroupEntry struct {
+	CourseId uuid.UUID         `json:"studyGroupCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course   string            `json:"studyGroupCourseTitle" example:"Название курса"`
+	Status   string            `json:"studyGroupStatus" example:"waitlisted" enums:"enrolled,waitlisted,dropped"`
+	Position int               `json:"studyGroupPosition,omitempty" example:"2"`
+	DropDate *JsonAdmitionDate `json:"studyGroupDropDate,omitempty" example:"2024-02-01"`
 }
 
 type GetTrajectory struct {
-	Id       uuid.UUID `json:"trajectoryId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
-	Student  string    `json:"trajectoryStudent" example:"Фамилия Имя Отчество"`
-	Semester uint8     `json:"trajectorySemester" example:"3"`
-	Course   string    `json:"trajectoryCourse" example:"Название курса"`
+	Id             uuid.UUID         `json:"trajectoryId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Student        string            `json:"trajectoryStudent" example:"Фамилия Имя Отчество"`
+	Semester       uint8             `json:"trajectorySemester" example:"3"`
+	Course         string            `json:"trajectoryCourse" example:"Название курса"`
+	Grade          uint8             `json:"trajectoryGrade,omitempty" example:"4"`
+	Result         string            `json:"trajectoryResult,omitempty" example:"passed" enums:"passed,failed"` // omitted until the outcome is recorded
+	Attempt        uint8             `json:"trajectoryAttempt" example:"1"`
+	CompletionDate *JsonAdmitionDate `json:"trajectoryCompletionDate,omitempty" example:"2024-01-25"`
+	RetakeOf       uuid.UUID         `json:"trajectoryRetakeOf,omitempty" example:"00000000-0000-0000-0000-000000000000"` // the first attempt of the course
 }
 
 type PostKnowledge struct {
-	Title string `json:"knowledgeTitle" example:"Название знания"`
+	Title    string    `json:"knowledgeTitle" example:"Название знания" validate:"required,max=255"`
+	ParentId uuid.UUID `json:"knowledgeParentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 }
 
 type PostTechnology struct {
-	Title string `json:"technologyTitle" example:"Название технологии"`
+	Title string `json:"technologyTitle" example:"Название технологии" validate:"required,max=255"`
 }
 
 type PostProject struct {
-	Title            string    `json:"projectTitle" example:"Исследование зивисимости длины шерстки капибар от продолжительности их жизни"`
-	Description      string    `json:"projectDescription,omitempty" example:"Курс направлен на изучение поведения капибар в дикой природе..."`
-	Result           string    `json:"projectResult,omitempty" example:"Данные о зависимости длины шерстки капибар от продолжительности их жизни"`
-	LifeScenario     string    `json:"projectLifeScenarion,omitempty" example:"Проект позволит подобрать идеальную длину шерстки для ваших капибар"`
+	Title            string    `json:"projectTitle" example:"Исследование зивисимости длины шерстки капибар от продолжительности их жизни" validate:"required,max=255"`
+	Description      string    `json:"projectDescription,omitempty" example:"Курс направлен на изучение поведения капибар в дикой природе..." validate:"max=5000"`
+	Result           string    `json:"projectResult,omitempty" example:"Данные о зависимости длины шерстки капибар от продолжительности их жизни" validate:"max=5000"`
+	LifeScenario     string    `json:"projectLifeScenarion,omitempty" example:"Проект позволит подобрать идеальную длину шерстки для ваших капибар" validate:"max=5000"`
 	MainTechnologyId uuid.UUID `json:"projectMainTechnologyId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 }
 
 type PostEducationalProgram struct {
-	Title          string    `json:"educationalProgramTitle" example:"Название образовательной программы"`
-	Description    string    `json:"educationalProgramDescription,omitempty" example:"Описание образовательной программы"`
-	OrganizationId uuid.UUID `json:"educationalProgramOrganizationId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title          string    `json:"educationalProgramTitle" example:"Название образовательной программы" validate:"required,max=255"`
+	Description    string    `json:"educationalProgramDescription,omitempty" example:"Описание образовательной программы" validate:"max=5000"`
+	OrganizationId uuid.UUID `json:"educationalProgramOrganizationId,omitempty" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	MinCredits     uint8     `json:"educationalProgramMinSemesterCredits,omitempty" example:"20" validate:"max=60"`
+	MaxCredits     uint8     `json:"educationalProgramMaxSemesterCredits,omitempty" example:"35" validate:"max=60"`
 }
 
 type PostDiscipline struct {
-	Title                string    `json:"disciplineTitle" example:"Название дисциплины"`
-	Description          string    `json:"disciplineDescription,omitempty" example:"Описание дисциплины"`
-	EducationalProgramId uuid.UUID `json:"disciplineEducationalProgramId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title                string    `json:"disciplineTitle" example:"Название дисциплины" validate:"required,max=255"`
+	Description          string    `json:"disciplineDescription,omitempty" example:"Описание дисциплины" validate:"max=5000"`
+	EducationalProgramId uuid.UUID `json:"disciplineEducationalProgramId,omitempty" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
 }
 
 type PostCourse struct {
-	Title        string    `json:"courseTitle" example:"Название курса"`
-	Description  string    `json:"courseDescription,omitempty" example:"Описание курса"`
-	Teacher      string    `json:"courseTeacher,omitempty" example:"Фамилия Имя Отчество"`
-	DisciplineId uuid.UUID `json:"courseDisciplineId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title        string      `json:"courseTitle" example:"Название курса" validate:"required,max=255"`
+	Description  string      `json:"courseDescription,omitempty" example:"Описание курса" validate:"max=5000"`
+	TeacherIds   []uuid.UUID `json:"courseTeacherIds,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	DisciplineId uuid.UUID   `json:"courseDisciplineId,omitempty" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Credits      uint8       `json:"courseCredits,omitempty" example:"5" validate:"max=30"`
+	Hours        uint16      `json:"courseHours,omitempty" example:"180" validate:"max=1080"`
+	Alternative  bool        `json:"courseAlternative,omitempty" example:"true"`                // one of interchangeable courses of the discipline, a student takes one of them
+	Capacity     uint16      `json:"courseCapacity,omitempty" example:"30" validate:"max=1000"` // seats in the study group, 0 means no limit
 }
 
 type PostPortfolio struct {
@@ -187,46 +256,728 @@ type PostPortfolio struct {
 }
 
 type PostProjectPortfolio struct {
-	ProjectId   uuid.UUID `json:"ProjectId" example:"00000000-0000-0000-0000-000000000000"`
-	PortfolioId uuid.UUID `json:"PortfolioId" example:"00000000-0000-0000-0000-000000000000"`
-	TeamRole    string    `json:"TeamRole,omitempty" example:"Роль в команде"`
-	Semester    uint8     `json:"projectSemester,omitempty" example:"3"`
+	ProjectId   uuid.UUID `json:"ProjectId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	PortfolioId uuid.UUID `json:"PortfolioId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	TeamRole    string    `json:"TeamRole,omitempty" example:"Роль в команде" validate:"max=255"`
+	Semester    uint8     `json:"projectSemester,omitempty" example:"3" validate:"required,min=1,max=12"`
 }
 
 type PostProjectPortfolioCompetency struct {
-	ProjectId    uuid.UUID `json:"projectId" example:"00000000-0000-0000-0000-000000000000"`
-	PortfolioId  uuid.UUID `json:"PortfolioId" example:"00000000-0000-0000-0000-000000000000"`
-	CompetencyId uuid.UUID `json:"CompetencyId" example:"00000000-0000-0000-0000-000000000000"`
+	ProjectId    uuid.UUID `json:"projectId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	PortfolioId  uuid.UUID `json:"PortfolioId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	CompetencyId uuid.UUID `json:"CompetencyId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Level        string    `json:"competencyLevel,omitempty" example:"intermediate" validate:"oneof=aware basic intermediate advanced"` // basic when omitted
 }
 
 type PostStudent struct {
-	FullName    string           `json:"studentFullName" example:"Фамилия Имя Отчество"`
-	Admition    JsonAdmitionDate `json:"studentAdmitionDate" example:"2024-01-19"`
+	FullName    string           `json:"studentFullName" example:"Фамилия Имя Отчество" validate:"required,max=255"`
+	Admition    JsonAdmitionDate `json:"studentAdmitionDate" example:"2024-01-19" validate:"notfuture"` // can not be in the future, today when omitted
 	PortfolioId uuid.UUID        `json:"studentPortfolioId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
 }
 
 type PostTrajectory struct {
-	StudentId uuid.UUID `json:"trajectoryStudentId" example:"00000000-0000-0000-0000-000000000000"`
-	Semester  uint8     `json:"trajectorySemester" example:"3"`
-	CourseId  uuid.UUID `json:"trajectoryCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	StudentId uuid.UUID `json:"trajectoryStudentId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Semester  uint8     `json:"trajectorySemester" example:"3" validate:"required,min=1,max=12"`
+	CourseId  uuid.UUID `json:"trajectoryCourseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+}
+
+// PostTrajectoryOutcome needs the grade or the result, the result of a graded course follows from the grading scale.
+type PostTrajectoryOutcome struct {
+	Grade          uint8            `json:"trajectoryGrade,omitempty" example:"4" validate:"max=100"`
+	Result         string           `json:"trajectoryResult,omitempty" example:"passed" validate:"oneof=passed failed"`
+	CompletionDate JsonAdmitionDate `json:"trajectoryCompletionDate,omitempty" example:"2024-01-25" validate:"notfuture"` // today when omitted
+}
+
+type PostTrajectoryRetake struct {
+	Semester uint8 `json:"trajectorySemester" example:"4" validate:"required,min=1,max=12"`
+}
+
+type PostGradingScale struct {
+	OrganizationId uuid.UUID `json:"gradingOrganizationId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	MinGrade       uint8     `json:"gradingMinGrade" example:"2" validate:"required,max=100"`
+	MaxGrade       uint8     `json:"gradingMaxGrade" example:"5" validate:"required,max=100"`
+	PassGrade      uint8     `json:"gradingPassGrade" example:"3" validate:"required,max=100"` // the lowest grade a course is passed with
+}
+
+type GetGradingScale struct {
+	OrganizationId uuid.UUID `json:"gradingOrganizationId" example:"00000000-0000-0000-0000-000000000000"`
+	MinGrade       uint8     `json:"gradingMinGrade" example:"2"`
+	MaxGrade       uint8     `json:"gradingMaxGrade" example:"5"`
+	PassGrade      uint8     `json:"gradingPassGrade" example:"3"`
+	Default        bool      `json:"gradingDefault,omitempty" example:"true"` // the organization has not set its own scale
 }
 
 type PostKnowledgeCompetency struct {
-	KnowledgeId  uuid.UUID `json:"knowledgeId" example:"00000000-0000-0000-0000-000000000000"`
-	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000"`
+	KnowledgeId  uuid.UUID `json:"knowledgeId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
 }
 
 type PostCompetencyProfession struct {
-	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000"`
-	ProfessionId uuid.UUID `json:"professionId" example:"00000000-0000-0000-0000-000000000000"`
+	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	ProfessionId uuid.UUID `json:"professionId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Level        string    `json:"competencyLevel,omitempty" example:"advanced" validate:"oneof=aware basic intermediate advanced"` // basic when omitted
 }
 
 type PostCourseCompetency struct {
-	CourseId     uuid.UUID `json:"courseId" example:"00000000-0000-0000-0000-000000000000"`
-	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000"`
+	CourseId     uuid.UUID `json:"courseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	CompetencyId uuid.UUID `json:"competencyId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Level        string    `json:"competencyLevel,omitempty" example:"basic" validate:"oneof=aware basic intermediate advanced"` // basic when omitted
 }
 
 type PostStudyGroup struct {
-	CourseId  uuid.UUID `json:"courseId" example:"00000000-0000-0000-0000-000000000000"`
-	StudentId uuid.UUID `json:"studentId" example:"00000000-0000-0000-0000-000000000000"`
+	CourseId  uuid.UUID `json:"courseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	StudentId uuid.UUID `json:"studentId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+}
+
+type GetPlanAlternative struct {
+	Id      uuid.UUID `json:"planAlternativeId" example:"00000000-0000-0000-0000-000000000000"`
+	Title   string    `json:"planAlternativeTitle" example:"Название курса"`
+	Credits uint8     `json:"planAlternativeCredits,omitempty" example:"5"`
+}
+
+type GetPlanCourse struct {
+	Id           uuid.UUID            `json:"planCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Title        string               `json:"planCourseTitle" example:"Название курса"`
+	Credits      uint8                `json:"planCourseCredits,omitempty" example:"5"`
+	Competencies []string             `json:"planCourseCompetencies" example:"Компетенции из профессии, которые закрывает курс"`
+	Alternatives []GetPlanAlternative `json:"planCourseAlternatives,omitempty"` // courses of the same discipline the course may be swapped for
+}
+
+type GetPlanSemester struct {
+	Semester uint8            `json:"planSemester" example:"3"`
+	Start    JsonAdmitionDate `json:"planSemesterStartDate" example:"2024-09-01"`
+	End      JsonAdmitionDate `json:"planSemesterEndDate" example:"2025-01-31"`
+	Credits  int              `json:"planSemesterCredits" example:"30"`
+	Courses  []GetPlanCourse  `json:"planSemesterCourses"`
+}
+
+type GetPlan struct {
+	StudentId    uuid.UUID              `json:"planStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	ProfessionId uuid.UUID              `json:"planProfessionId" example:"00000000-0000-0000-0000-000000000000"`
+	Profession   string                 `json:"planProfession" example:"Название профессии"`
+	Semesters    []GetPlanSemester      `json:"planSemesters"`
+	Uncovered    []string               `json:"planUncoveredCompetencies,omitempty" example:"Компетенции, которые не закрывает ни один курс"`
+	Workload     []GetWorkloadViolation `json:"planWorkloadViolations,omitempty"`
+}
+
+type GetCoverageSource struct {
+	Kind  string    `json:"coverageKind" example:"portfolioProject"`
+	Id    uuid.UUID `json:"coverageId" example:"00000000-0000-0000-0000-000000000000"`
+	Title string    `json:"coverageTitle" example:"Название проекта или курса"`
+	Level string    `json:"coverageLevel" example:"intermediate"`
+}
+
+type GetCompetencyCoverage struct {
+	Id            uuid.UUID           `json:"competencyId" example:"00000000-0000-0000-0000-000000000000"`
+	Title         string              `json:"competencyTitle" example:"Название компетенции"`
+	Level         string              `json:"competencyLevel" example:"advanced"`                       // the level the profession requires
+	AchievedLevel string              `json:"competencyAchievedLevel,omitempty" example:"intermediate"` // the highest level the student has
+	Covered       bool                `json:"competencyCovered" example:"true"`
+	CoveredBy     []GetCoverageSource `json:"competencyCoveredBy,omitempty"` // sources reaching the required level
+	Knowledge     []string            `json:"competencyKnowledge,omitempty" example:"знание 1, знание 2..."`
+}
+
+type GetCompetencyGap struct {
+	StudentId    uuid.UUID               `json:"gapStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	ProfessionId uuid.UUID               `json:"gapProfessionId" example:"00000000-0000-0000-0000-000000000000"`
+	Profession   string                  `json:"gapProfession" example:"Название профессии"`
+	Competencies []GetCompetencyCoverage `json:"gapCompetencies"`
+	Coverage     float64                 `json:"gapCoverage" example:"66.67"`
+}
+
+type GetSearchHit struct {
+	Id      uuid.UUID `json:"hitId" example:"00000000-0000-0000-0000-000000000000"`
+	Title   string    `json:"hitTitle" example:"Машинное обучение"`
+	Snippet string    `json:"hitSnippet" example:"<b>Нейронные</b> <b>сети</b> и градиентный бустинг"`
+	Rank    float64   `json:"hitRank" example:"1.35"`
+}
+
+type GetSearchGroup struct {
+	Kind string         `json:"groupKind" example:"course" enums:"knowledge,technology,competency,profession,project,organization,educationalProgram,discipline,course"`
+	Hits []GetSearchHit `json:"groupHits"`
+}
+
+type GetSearch struct {
+	Query  string           `json:"searchQuery" example:"нейронные сети"`
+	Groups []GetSearchGroup `json:"searchGroups"`
+}
+
+type GetAlternativeCourse struct {
+	Id           uuid.UUID `json:"alternativeCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Title        string    `json:"alternativeCourseTitle" example:"Go от geekbrains"`
+	Teachers     []string  `json:"alternativeCourseTeachers,omitempty" example:"Фамилия Имя Отчество"`
+	Credits      uint8     `json:"alternativeCourseCredits,omitempty" example:"5"`
+	Chosen       bool      `json:"alternativeCourseChosen" example:"false"`
+	Competencies []string  `json:"alternativeCourseCompetencies,omitempty" example:"компетенция 1,компетенция 2"`
+	Extra        []string  `json:"alternativeCourseExtraCompetencies,omitempty" example:"Компетенции, которые дают не все варианты"`
+	Missing      []string  `json:"alternativeCourseMissingCompetencies,omitempty" example:"Компетенции, которые дают только другие варианты"`
+}
+
+type GetDisciplineAlternatives struct {
+	DisciplineId uuid.UUID              `json:"alternativesDisciplineId" example:"00000000-0000-0000-0000-000000000000"`
+	Discipline   string                 `json:"alternativesDiscipline" example:"Название дисциплины"`
+	Common       []string               `json:"alternativesCommonCompetencies,omitempty" example:"Компетенции, которые дают все варианты"`
+	Courses      []GetAlternativeCourse `json:"alternativesCourses"`
+}
+
+type GetRecommendationFactor struct {
+	Factor string   `json:"factor" example:"coverage" enums:"coverage,technology,readiness,workload"`
+	Weight float64  `json:"factorWeight" example:"0.5"`
+	Value  float64  `json:"factorValue" example:"0.67"`  // from 0 to 1
+	Score  float64  `json:"factorScore" example:"33.33"` // the part of the course score, value * weight * 100
+	Reason string   `json:"factorReason" example:"gives 2 of 3 missing competencies of the profession"`
+	Items  []string `json:"factorItems,omitempty" example:"given competencies, known technologies or prerequisites not taken"`
+}
+
+type GetCourseRecommendation struct {
+	Id      uuid.UUID                 `json:"recommendationCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Title   string                    `json:"recommendationCourseTitle" example:"Название курса"`
+	Credits uint8                     `json:"recommendationCourseCredits,omitempty" example:"5"`
+	Score   float64                   `json:"recommendationScore" example:"78.33"` // from 0 to 100
+	Factors []GetRecommendationFactor `json:"recommendationFactors"`
+}
+
+type GetRecommendations struct {
+	StudentId    uuid.UUID                 `json:"recommendationStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	ProfessionId uuid.UUID                 `json:"recommendationProfessionId" example:"00000000-0000-0000-0000-000000000000"`
+	Profession   string                    `json:"recommendationProfession" example:"Название профессии"`
+	Semester     uint8                     `json:"recommendationSemester" example:"3"`
+	Courses      []GetCourseRecommendation `json:"recommendationCourses"`
+}
+
+type GetList[T any] struct {
+	Items  []T `json:"listItems"`
+	Total  int `json:"listTotal" example:"42"`
+	Limit  int `json:"listLimit" example:"20"`
+	Offset int `json:"listOffset" example:"0"`
+}
+
+type PutProjectPortfolio struct {
+	TeamRole string `json:"TeamRole,omitempty" example:"Роль в команде" validate:"max=255"`
+	Semester uint8  `json:"projectSemester,omitempty" example:"3" validate:"required,min=1,max=12"`
+}
+
+// PutCompetencyLevel is the proficiency level of a competency link of a profession, a course or a personal project.
+type PutCompetencyLevel struct {
+	Level string `json:"competencyLevel,omitempty" example:"intermediate" validate:"oneof=aware basic intermediate advanced"` // basic when omitted
+}
+
+// GetDependent is the number of rows deleted by cascade, restrict rows are not deleted and keep the row from being deleted.
+type GetDependent struct {
+	Table    string `json:"dependentTable" example:"competencies"`
+	Count    int    `json:"dependentCount" example:"3"`
+	Restrict bool   `json:"dependentRestrict,omitempty" example:"false"`
+}
+
+type GetDeleteReport struct {
+	Id      uuid.UUID      `json:"deletedId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Cascade []GetDependent `json:"deletedCascade,omitempty"`
+}
+
+type PostCalendarSemester struct {
+	OrganizationId uuid.UUID        `json:"calendarOrganizationId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Year           int              `json:"calendarYear" example:"2023" validate:"required,min=1900,max=2100"`
+	Term           uint8            `json:"calendarTerm" example:"1" validate:"required,oneof=1 2"`
+	Start          JsonAdmitionDate `json:"calendarStartDate" example:"2023-09-01" validate:"required"`
+	End            JsonAdmitionDate `json:"calendarEndDate" example:"2024-01-31" validate:"required"`
+	// students enroll in courses of the semester between the dates, both are omitted when enrollment is not limited
+	EnrollmentStart JsonAdmitionDate `json:"calendarEnrollmentStartDate" example:"2023-08-20"`
+	EnrollmentEnd   JsonAdmitionDate `json:"calendarEnrollmentEndDate" example:"2023-09-15"`
+}
+
+type GetCalendarSemester struct {
+	Year            int               `json:"calendarYear" example:"2023"`
+	Term            uint8             `json:"calendarTerm" example:"1"`
+	Start           JsonAdmitionDate  `json:"calendarStartDate" example:"2023-09-01"`
+	End             JsonAdmitionDate  `json:"calendarEndDate" example:"2024-01-31"`
+	EnrollmentStart *JsonAdmitionDate `json:"calendarEnrollmentStartDate,omitempty" example:"2023-08-20"`
+	EnrollmentEnd   *JsonAdmitionDate `json:"calendarEnrollmentEndDate,omitempty" example:"2023-09-15"`
+}
+
+type PostCalendarPeriod struct {
+	OrganizationId uuid.UUID        `json:"periodOrganizationId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Kind           string           `json:"periodKind" example:"holiday" validate:"required,oneof=holiday session"`
+	Title          string           `json:"periodTitle,omitempty" example:"Новогодние каникулы" validate:"max=255"`
+	Start          JsonAdmitionDate `json:"periodStartDate" example:"2023-12-30" validate:"required"`
+	End            JsonAdmitionDate `json:"periodEndDate" example:"2024-01-08" validate:"required"`
+}
+
+type GetCalendarPeriod struct {
+	Id    uuid.UUID        `json:"periodId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Kind  string           `json:"periodKind" example:"holiday"`
+	Title string           `json:"periodTitle,omitempty" example:"Новогодние каникулы"`
+	Start JsonAdmitionDate `json:"periodStartDate" example:"2023-12-30"`
+	End   JsonAdmitionDate `json:"periodEndDate" example:"2024-01-08"`
+}
+
+type GetCalendar struct {
+	OrganizationId uuid.UUID             `json:"calendarOrganizationId" example:"00000000-0000-0000-0000-000000000000"`
+	Semesters      []GetCalendarSemester `json:"calendarSemesters"`
+	Periods        []GetCalendarPeriod   `json:"calendarPeriods"`
+}
+
+type GetSemesterDates struct {
+	StudentId uuid.UUID           `json:"semesterStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	Semester  uint8               `json:"semesterNumber" example:"3"`
+	Year      int                 `json:"semesterYear" example:"2023"`
+	Term      uint8               `json:"semesterTerm" example:"1"`
+	Start     JsonAdmitionDate    `json:"semesterStartDate" example:"2023-09-01"`
+	End       JsonAdmitionDate    `json:"semesterEndDate" example:"2024-01-31"`
+	Holidays  []GetCalendarPeriod `json:"semesterHolidays,omitempty"`
+	Sessions  []GetCalendarPeriod `json:"semesterSessions,omitempty"`
+}
+
+type PostTimeSlot struct {
+	Weekday uint8  `json:"timeSlotWeekday" example:"1" validate:"required,min=1,max=7"`
+	Start   string `json:"timeSlotStart" example:"08:30" validate:"required"`
+	End     string `json:"timeSlotEnd" example:"10:00" validate:"required"`
+}
+
+type GetTimeSlot struct {
+	Id      uuid.UUID `json:"timeSlotId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Weekday uint8     `json:"timeSlotWeekday" example:"1"`
+	Start   string    `json:"timeSlotStart" example:"08:30"`
+	End     string    `json:"timeSlotEnd" example:"10:00"`
+}
+
+type PostRoom struct {
+	Title    string `json:"roomTitle" example:"Р-237" validate:"required,max=64"`
+	Capacity int    `json:"roomCapacity" example:"30" validate:"required,min=1,max=10000"`
+}
+
+type GetRoom struct {
+	Id       uuid.UUID `json:"roomId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Title    string    `json:"roomTitle" example:"Р-237"`
+	Capacity int       `json:"roomCapacity" example:"30"`
+}
+
+type PostCourseSession struct {
+	CourseId    uuid.UUID `json:"sessionCourseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Kind        string    `json:"sessionKind" example:"lecture" validate:"required,oneof=lecture practice lab"`
+	WeeklyHours uint8     `json:"sessionWeeklyHours" example:"4" validate:"required,min=1,max=40"`
+}
+
+type GetCourseSession struct {
+	Id          uuid.UUID `json:"sessionId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	CourseId    uuid.UUID `json:"sessionCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course      string    `json:"sessionCourse" example:"Название курса"`
+	Kind        string    `json:"sessionKind" example:"lecture"`
+	WeeklyHours uint8     `json:"sessionWeeklyHours" example:"4"`
+}
+
+type PostTeacherAvailability struct {
+	TeacherId  uuid.UUID `json:"availabilityTeacherId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	TimeSlotId uuid.UUID `json:"availabilityTimeSlotId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+}
+
+type GetTimetableEntry struct {
+	SessionId uuid.UUID   `json:"entrySessionId" example:"00000000-0000-0000-0000-000000000000"`
+	CourseId  uuid.UUID   `json:"entryCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course    string      `json:"entryCourse" example:"Название курса"`
+	Kind      string      `json:"entryKind" example:"lecture"`
+	Teachers  []string    `json:"entryTeachers,omitempty" example:"Фамилия Имя Отчество"`
+	TimeSlot  GetTimeSlot `json:"entryTimeSlot"`
+	Room      GetRoom     `json:"entryRoom"`
+}
+
+type GetTimetableProblem struct {
+	SessionId uuid.UUID `json:"problemSessionId" example:"00000000-0000-0000-0000-000000000000"`
+	CourseId  uuid.UUID `json:"problemCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course    string    `json:"problemCourse" example:"Название курса"`
+	Kind      string    `json:"problemKind" example:"lecture"`
+	Unplaced  int       `json:"problemUnplaced" example:"2"`
+	Reason    string    `json:"problemReason" example:"noRoom"`
+	Message   string    `json:"problemMessage" example:"no room fits 120 students"`
+}
+
+type GetTimetable struct {
+	Entries  []GetTimetableEntry   `json:"timetableEntries"`
+	Problems []GetTimetableProblem `json:"timetableProblems,omitempty"`
+}
+
+type GetScheduleSubscription struct {
+	IcsUrl          string `json:"scheduleIcsUrl" example:"https://example.com/api/v1/student/00000000-0000-0000-0000-000000000000/schedule.ics?feedToken=token"`
+	WebcalUrl       string `json:"scheduleWebcalUrl" example:"webcal://example.com/api/v1/student/00000000-0000-0000-0000-000000000000/schedule.ics?feedToken=token"`
+	RefreshInterval string `json:"scheduleRefreshInterval" example:"PT12H"`
+}
+
+type GetMatrixLink struct {
+	From string `json:"linkFrom" example:"Название знания"`
+	To   string `json:"linkTo" example:"Название компетенции"`
+}
+
+type GetMatrixDiff struct {
+	Professions           []string        `json:"diffProfessions"`
+	Competencies          []string        `json:"diffCompetencies"`
+	Technologies          []string        `json:"diffTechnologies"`
+	Knowledge             []string        `json:"diffKnowledge"`
+	KnowledgeCompetencies []GetMatrixLink `json:"diffKnowledgeCompetencies"`
+	CompetencyProfessions []GetMatrixLink `json:"diffCompetencyProfessions"`
+}
+
+type GetMatrixRowError struct {
+	Row     int    `json:"rowNumber" example:"2"`
+	Column  string `json:"rowColumn,omitempty" example:"competency"`
+	Message string `json:"rowMessage" example:"competency is empty"`
+}
+
+type GetMatrixImport struct {
+	DryRun  bool                `json:"importDryRun" example:"true"`
+	Applied bool                `json:"importApplied" example:"false"`
+	Rows    int                 `json:"importRows" example:"42"`
+	Created GetMatrixDiff       `json:"importCreated"`
+	Errors  []GetMatrixRowError `json:"importErrors"`
+}
+
+// CatalogBundle is the whole catalog with references by title, so it can be applied to any database.
+type CatalogBundle struct {
+	Format              string                     `json:"bundleFormat" example:"smart-schedule-former/catalog"`
+	Version             int                        `json:"bundleVersion" example:"1"`
+	ExportedAt          time.Time                  `json:"bundleExportedAt" example:"2024-02-01T12:00:00Z"`
+	Organizations       []BundleOrganization       `json:"bundleOrganizations"`
+	EducationalPrograms []BundleEducationalProgram `json:"bundleEducationalPrograms"`
+	Disciplines         []BundleDiscipline         `json:"bundleDisciplines"`
+	Courses             []BundleCourse             `json:"bundleCourses"`
+	Technologies        []BundleTechnology         `json:"bundleTechnologies"`
+	Knowledge           []BundleKnowledge          `json:"bundleKnowledge"`
+	Competencies        []BundleCompetency         `json:"bundleCompetencies"`
+	Professions         []BundleProfession         `json:"bundleProfessions"`
+}
+
+type BundleOrganization struct {
+	Title string `json:"organizationTitle" example:"Название организации"`
+}
+
+type BundleEducationalProgram struct {
+	Title        string `json:"educationalProgramTitle" example:"Название образовательной программы"`
+	Description  string `json:"educationalProgramDescription,omitempty" example:"Описание образовательной программы"`
+	Organization string `json:"educationalProgramOrganization" example:"Название организации"`
+	MinCredits   uint8  `json:"educationalProgramMinSemesterCredits,omitempty" example:"20"`
+	MaxCredits   uint8  `json:"educationalProgramMaxSemesterCredits,omitempty" example:"35"`
+}
+
+type BundleDiscipline struct {
+	Title              string `json:"disciplineTitle" example:"Название дисциплины"`
+	Description        string `json:"disciplineDescription,omitempty" example:"Описание дисциплины"`
+	EducationalProgram string `json:"disciplineEducationalProgram" example:"Название образовательной программы"`
+}
+
+type BundleCourse struct {
+	Title        string   `json:"courseTitle" example:"Название курса"`
+	Description  string   `json:"courseDescription,omitempty" example:"Описание курса"`
+	Teacher      string   `json:"courseTeacher,omitempty" example:"Фамилия Имя Отчество"` // the only teacher of version 1 bundles
+	Teachers     []string `json:"courseTeachers,omitempty" example:"Фамилия Имя Отчество"`
+	Discipline   string   `json:"courseDiscipline" example:"Название дисциплины"`
+	Credits      uint8    `json:"courseCredits,omitempty" example:"5"`
+	Hours        uint16   `json:"courseHours,omitempty" example:"180"`
+	Alternative  bool     `json:"courseAlternative,omitempty" example:"true"`
+	Competencies []string `json:"courseCompetencies,omitempty" example:"компетенция 1,компетенция 2"`
+	// levels of the competencies by title, the ones not listed are basic
+	CompetencyLevels map[string]string `json:"courseCompetencyLevels,omitempty"`
+}
+
+type BundleTechnology struct {
+	Title string `json:"technologyTitle" example:"Название технологии"`
+}
+
+type BundleKnowledge struct {
+	Title  string `json:"knowledgeTitle" example:"Название знания"`
+	Parent string `json:"knowledgeParent,omitempty" example:"Название родительского знания"`
+}
+
+type BundleCompetency struct {
+	Title          string   `json:"competencyTitle" example:"Название компетенции"`
+	Parent         string   `json:"competencyParent,omitempty" example:"Название родительской компетенции"`
+	Skills         string   `json:"competencySkills,omitempty" example:"навык 1, навык 2"` // skills of version 1 and 2 bundles separated by commas
+	SkillTitles    []string `json:"competencySkillTitles,omitempty" example:"навык 1,навык 2"`
+	MainTechnology string   `json:"competencyMainTechnology,omitempty" example:"Название технологии"`
+	Knowledge      []string `json:"competencyKnowledge,omitempty" example:"знание 1,знание 2"`
+}
+
+type BundleProfession struct {
+	Title        string   `json:"professionTitle" example:"Название профессии"`
+	Description  string   `json:"professionDescription,omitempty" example:"Описание профессии"`
+	Competencies []string `json:"professionCompetencies,omitempty" example:"компетенция 1,компетенция 2"`
+	// levels of the competencies by title, the ones not listed are basic
+	CompetencyLevels map[string]string `json:"professionCompetencyLevels,omitempty"`
+}
+
+type GetCatalogImport struct {
+	Created   int `json:"catalogCreated" example:"10"`
+	Updated   int `json:"catalogUpdated" example:"2"`
+	Unchanged int `json:"catalogUnchanged" example:"30"`
+	Links     int `json:"catalogLinks" example:"15"`
+}
+
+type PostCoursePrerequisite struct {
+	CourseId       uuid.UUID `json:"prerequisiteCourseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	PrerequisiteId uuid.UUID `json:"prerequisiteId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Kind           string    `json:"prerequisiteKind" example:"hard" validate:"required,oneof=hard soft"`
+}
+
+type GetCoursePrerequisite struct {
+	CourseId       uuid.UUID `json:"prerequisiteCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course         string    `json:"prerequisiteCourse" example:"Название курса"`
+	PrerequisiteId uuid.UUID `json:"prerequisiteId" example:"00000000-0000-0000-0000-000000000000"`
+	Prerequisite   string    `json:"prerequisiteTitle" example:"Название курса, который нужно пройти раньше"`
+	Kind           string    `json:"prerequisiteKind" example:"hard"`
+}
+
+type PostPlannedCourse struct {
+	CourseId uuid.UUID `json:"plannedCourseId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Semester uint8     `json:"plannedSemester" example:"4" validate:"required,min=1,max=12"`
+}
+
+type PostPlanValidation struct {
+	Courses []PostPlannedCourse `json:"validationPlannedCourses"`
+}
+
+type GetPrerequisiteViolation struct {
+	CourseId             uuid.UUID `json:"violationCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course               string    `json:"violationCourse" example:"Название курса"`
+	Semester             uint8     `json:"violationSemester" example:"3"`
+	PrerequisiteId       uuid.UUID `json:"violationPrerequisiteId" example:"00000000-0000-0000-0000-000000000000"`
+	Prerequisite         string    `json:"violationPrerequisite" example:"Название пререквизита"`
+	PrerequisiteSemester uint8     `json:"violationPrerequisiteSemester,omitempty" example:"4"`
+	Kind                 string    `json:"violationKind" example:"hard"`
+	Reason               string    `json:"violationReason" example:"laterSemester"`
+}
+
+type GetPlanValidation struct {
+	StudentId  uuid.UUID                  `json:"validationStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	Valid      bool                       `json:"validationValid" example:"false"`
+	Violations []GetPrerequisiteViolation `json:"validationViolations"`
+}
+
+// GetWorkloadViolation names the semester whose workload is out of the limits of the educational program.
+type GetWorkloadViolation struct {
+	EducationalProgram string `json:"workloadEducationalProgram" example:"Название образовательной программы"`
+	Semester           uint8  `json:"workloadSemester" example:"3"`
+	Credits            int    `json:"workloadCredits" example:"40"`
+	MinCredits         uint8  `json:"workloadMinCredits" example:"20"`
+	MaxCredits         uint8  `json:"workloadMaxCredits,omitempty" example:"35"`
+	Reason             string `json:"workloadReason" example:"aboveMaximum"`
+}
+
+type PostApiKey struct {
+	Title     string    `json:"apiKeyTitle" example:"Ключ куратора образовательной программы" validate:"required,max=255"`
+	Role      string    `json:"apiKeyRole" example:"analyst" validate:"required,oneof=admin analyst teacher student"`
+	StudentId uuid.UUID `json:"apiKeyStudentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	TeacherId uuid.UUID `json:"apiKeyTeacherId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+// GetApiKey is the created key, the key itself is not stored and is shown only once.
+type GetApiKey struct {
+	Id        uuid.UUID `json:"apiKeyId" example:"00000000-0000-0000-0000-000000000000"`
+	Key       string    `json:"apiKey" example:"3q2-7wAAAAC9eXh9b2tlbi1rZXktZXhhbXBsZQ"`
+	Title     string    `json:"apiKeyTitle" example:"Ключ куратора образовательной программы"`
+	Role      string    `json:"apiKeyRole" example:"analyst"`
+	StudentId uuid.UUID `json:"apiKeyStudentId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	TeacherId uuid.UUID `json:"apiKeyTeacherId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetToken struct {
+	Token     string    `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Type      string    `json:"tokenType" example:"Bearer"`
+	ExpiresAt time.Time `json:"tokenExpiresAt" example:"2024-02-10T13:00:00Z"`
+}
+
+type GetStudyGroupStudent struct {
+	Id       uuid.UUID `json:"studyGroupStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	FullName string    `json:"studyGroupStudentFullName" example:"Фамилия Имя Отчество"`
+}
+
+// GetCourseStudyGroup is the students of the current semester studying the course.
+type GetCourseStudyGroup struct {
+	CourseId uuid.UUID              `json:"courseStudyGroupCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Course   string                 `json:"courseStudyGroupCourse" example:"Название курса"`
+	Teachers []string               `json:"courseStudyGroupTeachers,omitempty" example:"Фамилия Имя Отчество"`
+	Students []GetStudyGroupStudent `json:"courseStudyGroupStudents"`
+}
+
+type PostTeacher struct {
+	FullName       string    `json:"teacherFullName" example:"Фамилия Имя Отчество" validate:"required,max=255"`
+	OrganizationId uuid.UUID `json:"teacherOrganizationId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Email          string    `json:"teacherEmail,omitempty" example:"teacher@urfu.ru" validate:"email,max=255"`
+	Phone          string    `json:"teacherPhone,omitempty" example:"+7 343 000-00-00" validate:"max=32"`
+}
+
+// GetTeacher is the teacher with the time slots the teacher is available in, no time slots means always available.
+type GetTeacher struct {
+	Id             uuid.UUID     `json:"teacherId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	FullName       string        `json:"teacherFullName" example:"Фамилия Имя Отчество"`
+	OrganizationId uuid.UUID     `json:"teacherOrganizationId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Organization   string        `json:"teacherOrganization,omitempty" example:"Название организации"`
+	Email          string        `json:"teacherEmail,omitempty" example:"teacher@urfu.ru"`
+	Phone          string        `json:"teacherPhone,omitempty" example:"+7 343 000-00-00"`
+	Availability   []GetTimeSlot `json:"teacherAvailability,omitempty"`
+}
+
+type GetTeacherCourse struct {
+	Id          uuid.UUID `json:"teacherCourseId" example:"00000000-0000-0000-0000-000000000000"`
+	Title       string    `json:"teacherCourseTitle" example:"Название курса"`
+	Discipline  string    `json:"teacherCourseDiscipline,omitempty" example:"Название дисциплины"`
+	Credits     uint8     `json:"teacherCourseCredits,omitempty" example:"5"`
+	Hours       uint16    `json:"teacherCourseHours,omitempty" example:"180"`
+	WeeklyHours int       `json:"teacherCourseWeeklyHours" example:"6"`
+	Students    int       `json:"teacherCourseStudents" example:"25"` // students of the current semester
+}
+
+type GetTeacherCourses struct {
+	TeacherId uuid.UUID          `json:"teacherCoursesTeacherId" example:"00000000-0000-0000-0000-000000000000"`
+	Teacher   string             `json:"teacherCoursesTeacher" example:"Фамилия Имя Отчество"`
+	Courses   []GetTeacherCourse `json:"teacherCourses"`
+}
+
+// GetTeacherStudyGroups is the students of the current semester studying courses of the teacher.
+type GetTeacherStudyGroups struct {
+	TeacherId   uuid.UUID             `json:"teacherStudyGroupsTeacherId" example:"00000000-0000-0000-0000-000000000000"`
+	Teacher     string                `json:"teacherStudyGroupsTeacher" example:"Фамилия Имя Отчество"`
+	StudyGroups []GetCourseStudyGroup `json:"teacherStudyGroups"`
+}
+
+// GetTeacherHours is the load of the teacher. Hours are the academic hours of all courses of the teacher,
+// weekly hours are the sessions of the courses studied in the current semester.
+type GetTeacherHours struct {
+	TeacherId      uuid.UUID `json:"teacherHoursTeacherId" example:"00000000-0000-0000-0000-000000000000"`
+	Teacher        string    `json:"teacherHoursTeacher" example:"Фамилия Имя Отчество"`
+	Courses        int       `json:"teacherHoursCourses" example:"3"`
+	Hours          int       `json:"teacherHours" example:"540"`
+	StudiedCourses int       `json:"teacherHoursStudiedCourses" example:"2"`
+	WeeklyHours    int       `json:"teacherHoursWeekly" example:"12"`
+	Students       int       `json:"teacherHoursStudents" example:"40"`
+}
+
+// GetProblem is the body of every error response, it is sent as application/problem+json (RFC 7807).
+// Code is stable for the frontend, field names the request field or parameter the error is about.
+type GetProblem struct {
+	Type       string                `json:"type" example:"about:blank"`
+	Title      string                `json:"title" example:"Bad Request"`
+	Status     int                   `json:"status" example:"400"`
+	Code       string                `json:"code" example:"disciplineNotFound"`
+	Message    string                `json:"message" example:"discipline does not exist"`
+	Field      string                `json:"field,omitempty" example:"courseDisciplineId"`
+	RequestId  string                `json:"requestId" example:"00000000-0000-0000-0000-000000000000"`
+	Errors     []GetFieldError       `json:"errors,omitempty"`
+	Workload   *GetWorkloadViolation `json:"workload,omitempty"`
+	Dependents []GetDependent        `json:"dependents,omitempty"`
+	Import     *GetMatrixImport      `json:"import,omitempty"`
+}
+
+// GetFieldError is the broken validation rule of the request field.
+type GetFieldError struct {
+	Field   string `json:"field" example:"courseTitle"`
+	Code    string `json:"code" example:"tooLong"`
+	Message string `json:"message" example:"must be at most 255 characters"`
+}
+
+type PostElectivePool struct {
+	EducationalProgramId uuid.UUID `json:"poolEducationalProgramId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Title                string    `json:"poolTitle" example:"Иностранный язык" validate:"required,max=255"`
+	Choose               uint8     `json:"poolChoose" example:"1" validate:"required,min=1"`
+}
+
+type GetElectivePool struct {
+	Id          uuid.UUID                 `json:"poolId" example:"00000000-0000-0000-0000-000000000000"`
+	Title       string                    `json:"poolTitle" example:"Иностранный язык"`
+	Choose      uint8                     `json:"poolChoose" example:"1"`
+	Disciplines []GetCurriculumDiscipline `json:"poolDisciplines,omitempty"`
+}
+
+type PostCurriculumDiscipline struct {
+	EducationalProgramId uuid.UUID `json:"curriculumEducationalProgramId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	DisciplineId         uuid.UUID `json:"curriculumDisciplineId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Semester             uint8     `json:"curriculumSemester" example:"3" validate:"required,min=1,max=12"`
+	ElectivePoolId       uuid.UUID `json:"curriculumElectivePoolId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetCurriculumDiscipline struct {
+	Id       uuid.UUID `json:"curriculumDisciplineId" example:"00000000-0000-0000-0000-000000000000"`
+	Title    string    `json:"curriculumDiscipline" example:"Название дисциплины"`
+	Semester uint8     `json:"curriculumSemester" example:"3"`
+}
+
+type GetCurriculum struct {
+	EducationalProgramId uuid.UUID                 `json:"curriculumEducationalProgramId" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgram   string                    `json:"curriculumEducationalProgram" example:"Название образовательной программы"`
+	Required             []GetCurriculumDiscipline `json:"curriculumRequired"`
+	Pools                []GetElectivePool         `json:"curriculumElectivePools"`
+}
+
+type PostCurriculumCheck struct {
+	EducationalProgramId uuid.UUID           `json:"checkEducationalProgramId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Courses              []PostPlannedCourse `json:"checkPlannedCourses"`
+}
+
+// GetCurriculumStatus tells whether the discipline of the curriculum is covered by a course the student took, takes or plans.
+type GetCurriculumStatus struct {
+	DisciplineId   uuid.UUID `json:"statusDisciplineId" example:"00000000-0000-0000-0000-000000000000"`
+	Discipline     string    `json:"statusDiscipline" example:"Название дисциплины"`
+	Semester       uint8     `json:"statusSemester" example:"3"`
+	Status         string    `json:"status" example:"taken" enums:"taken,current,planned,outstanding"`
+	CourseId       uuid.UUID `json:"statusCourseId,omitempty" example:"00000000-0000-0000-0000-000000000000"`
+	Course         string    `json:"statusCourse,omitempty" example:"Название курса"`
+	CourseSemester uint8     `json:"statusCourseSemester,omitempty" example:"4"`
+	Late           bool      `json:"statusLate,omitempty" example:"true"`
+}
+
+type GetElectivePoolStatus struct {
+	Id          uuid.UUID             `json:"poolId" example:"00000000-0000-0000-0000-000000000000"`
+	Title       string                `json:"poolTitle" example:"Иностранный язык"`
+	Choose      uint8                 `json:"poolChoose" example:"2"`
+	Chosen      int                   `json:"poolChosen" example:"1"`
+	Outstanding int                   `json:"poolOutstanding" example:"1"`
+	Disciplines []GetCurriculumStatus `json:"poolDisciplines"`
+}
+
+// GetOutstandingRequirement is a required discipline or the disciplines of an elective pool the student still has to take.
+type GetOutstandingRequirement struct {
+	Kind     string    `json:"outstandingKind" example:"discipline" enums:"discipline,electivePool"`
+	Id       uuid.UUID `json:"outstandingId" example:"00000000-0000-0000-0000-000000000000"`
+	Title    string    `json:"outstandingTitle" example:"Название дисциплины или группы по выбору"`
+	Semester uint8     `json:"outstandingSemester" example:"3"`
+	Count    int       `json:"outstandingCount" example:"1"`
+}
+
+type GetCurriculumCheck struct {
+	StudentId            uuid.UUID                   `json:"checkStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgramId uuid.UUID                   `json:"checkEducationalProgramId" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgram   string                      `json:"checkEducationalProgram" example:"Название образовательной программы"`
+	Complete             bool                        `json:"checkComplete" example:"false"`
+	Required             []GetCurriculumStatus       `json:"checkRequired"`
+	Pools                []GetElectivePoolStatus     `json:"checkElectivePools"`
+	Outstanding          []GetOutstandingRequirement `json:"checkOutstanding"`
+}
+
+type PostEnrollment struct {
+	StudentId            uuid.UUID        `json:"enrollmentStudentId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	EducationalProgramId uuid.UUID        `json:"enrollmentEducationalProgramId" example:"00000000-0000-0000-0000-000000000000" validate:"required"`
+	Start                JsonAdmitionDate `json:"enrollmentStartDate" example:"2024-09-01"` // today when omitted
+}
+
+type PostEnrollmentStatus struct {
+	Status string           `json:"enrollmentStatus" example:"academicLeave" validate:"required,oneof=active academicLeave expelled graduated"`
+	Date   JsonAdmitionDate `json:"enrollmentStatusDate" example:"2024-02-01"` // today when omitted
+}
+
+type GetAcademicLeave struct {
+	Id    uuid.UUID         `json:"leaveId" example:"00000000-0000-0000-0000-000000000000"`
+	Start JsonAdmitionDate  `json:"leaveStartDate" example:"2024-02-01"`
+	End   *JsonAdmitionDate `json:"leaveEndDate,omitempty" example:"2025-01-31"`
+}
+
+type GetEnrollment struct {
+	Id                   uuid.UUID          `json:"enrollmentId" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgramId uuid.UUID          `json:"enrollmentEducationalProgramId" example:"00000000-0000-0000-0000-000000000000"`
+	EducationalProgram   string             `json:"enrollmentEducationalProgram" example:"Название образовательной программы"`
+	Start                JsonAdmitionDate   `json:"enrollmentStartDate" example:"2023-09-01"`
+	End                  *JsonAdmitionDate  `json:"enrollmentEndDate,omitempty" example:"2024-02-01"`
+	Status               string             `json:"enrollmentStatus" example:"active" enums:"active,academicLeave,expelled,graduated,transferred"`
+	Leaves               []GetAcademicLeave `json:"enrollmentLeaves,omitempty"`
+}
+
+// GetEnrollments is the history of the student studies, transfers between programs go in order, the last one is current.
+type GetEnrollments struct {
+	StudentId   uuid.UUID       `json:"enrollmentsStudentId" example:"00000000-0000-0000-0000-000000000000"`
+	Enrollments []GetEnrollment `json:"enrollments"`
 }
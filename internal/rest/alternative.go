@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GetDisciplineAlternatives
+//
+// @Summary      Compare alternative courses of discipline
+// @Description  get interchangeable courses of the discipline, a student takes one of them. Competencies every course gives
+// @Description  are common, for each course the competencies it gives besides them and the ones only other courses give are listed
+// @Tags         discipline
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Discipline ID"
+// @Success      200  {object}  model.GetDisciplineAlternatives
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/discipline/{id}/alternatives [get]
+func (h *Handler) GetDisciplineAlternatives(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetDisciplineAlternatives(id, uuid.Nil)
+	writeResponse(w, resp, err)
+}
+
+// GetStudentAlternatives
+//
+// @Summary      Compare alternative courses of discipline for student
+// @Description  get interchangeable courses of the discipline compared by competencies, the course the student takes or took is chosen
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id             path      string  true  "Student ID"
+// @Param        disciplineId   path      string  true  "Discipline ID"
+// @Success      200  {object}  model.GetDisciplineAlternatives
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/alternatives/{disciplineId} [get]
+func (h *Handler) GetStudentAlternatives(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	studentId, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	disciplineId, ok := parseId(w, params, "disciplineId")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetDisciplineAlternatives(disciplineId, studentId)
+	writeResponse(w, resp, err)
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostApiKey
+//
+// @Summary      Post API key
+// @Description  create a key to call the API with the role: admin, analyst, teacher or student. A student key belongs to the student,
+// @Description  a teacher key to the teacher. The key is shown only in this response. Admins only
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostApiKey  true  "API key data"
+// @Success      200  {object}  model.GetApiKey
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/apiKey/ [post]
+func (h *Handler) PostApiKey(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostApiKey](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostApiKey(req.Title, req.Role, req.StudentId, req.TeacherId)
+	writeResponse(w, resp, err)
+}
+
+// DeleteApiKey
+//
+// @Summary      Delete API key
+// @Description  revoke the key together with the tokens issued for it. Admins only
+// @Tags         auth
+// @Produce      json
+// @Param        id   path      string  true  "API key ID"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/apiKey/{id} [delete]
+func (h *Handler) DeleteApiKey(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, func(id uuid.UUID, _ bool) (model.GetDeleteReport, error) {
+		return h.App.DeleteApiKey(id)
+	})
+}
+
+// PostToken
+//
+// @Summary      Issue access token
+// @Description  exchange the API key given in the X-API-Key header for a JWT to send as "Authorization: Bearer <token>".
+// @Description  The token carries the role of the key and expires in an hour, a token can not be exchanged for another one
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  model.GetToken
+// @Failure      401  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Router       /api/v1/token/ [post]
+func (h *Handler) PostToken(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	principal := principalOf(r)
+	if principal.FromToken {
+		writeAuthError(w, app.ErrUnauthenticated)
+		return
+	}
+	if len(h.secret) == 0 {
+		writeProblem(w, problem{http.StatusNotImplemented, "tokensDisabled", ""}, "tokens are not issued, the service has no secret", nil)
+		return
+	}
+
+	resp, err := app.IssueToken(h.secret, principal, time.Now())
+	writeResponse(w, resp, err)
+}
+
+// GetCourseStudyGroup
+//
+// @Summary      Show course study group
+// @Description  get students studying the course in the current semester ordered by full name. Available to analysts and the teacher of the course
+// @Tags         studyGroup
+// @Produce      json
+// @Param        id   path      string  true  "Course ID"
+// @Success      200  {object}  model.GetCourseStudyGroup
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/course/{id}/studyGroup [get]
+func (h *Handler) GetCourseStudyGroup(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetCourseStudyGroup(id)
+	writeResponse(w, resp, err)
+}
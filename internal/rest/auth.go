@@ -0,0 +1,306 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+)
+
+// access is the rule of a route. Admins pass every rule, other roles pass when they are listed in roles.
+// Besides them a student passes when every resolved student is the student themselves,
+// a teacher passes when the teacher themselves is among the resolved teachers.
+type access struct {
+	roles    []string
+	students []studentResolver
+	teacher  teacherResolver
+	feed     studentResolver // the student whose feed token is accepted instead of a key, nil when feeds are not served
+}
+
+// studentResolver finds the student the request is about, errNoClaim means the request does not name one.
+type studentResolver func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error)
+
+// teacherResolver finds the teachers the request is about, any of them may make it.
+type teacherResolver func(h *Handler, r *http.Request, params httprouter.Params) ([]uuid.UUID, error)
+
+var errNoClaim = errors.New("the request does not name the owner")
+var errWrongId = errors.New("wrong id")
+
+var (
+	// authenticated lets any caller with a valid key or token in.
+	authenticated = access{roles: app.Roles}
+	// curriculum is the catalog of competencies, professions, programs and courses kept by analysts.
+	curriculum = access{roles: []string{app.RoleAnalyst}}
+	// administration is for admins only: students, enrollment, calendar, rooms and keys.
+	administration = access{}
+	// projectAuthors are analysts and students adding projects to their portfolios.
+	projectAuthors = access{roles: []string{app.RoleAnalyst, app.RoleStudent}}
+)
+
+// studentData lets analysts read data of the students and every student read their own.
+func studentData(resolvers ...studentResolver) access {
+	return access{roles: []string{app.RoleAnalyst}, students: resolvers}
+}
+
+// scheduleFeed is studentData that also lets calendar applications in with the feed token of the student.
+func scheduleFeed(resolver studentResolver) access {
+	rule := studentData(resolver)
+	rule.feed = resolver
+	return rule
+}
+
+// studentOwned lets only the student themselves change their data besides admins.
+func studentOwned(resolvers ...studentResolver) access {
+	return access{students: resolvers}
+}
+
+// teacherOwned lets analysts and the teacher themselves in.
+func teacherOwned(resolver teacherResolver) access {
+	return access{roles: []string{app.RoleAnalyst}, teacher: resolver}
+}
+
+// courseTeaching lets only the teachers of the course in besides admins.
+func courseTeaching(resolver teacherResolver) access {
+	return access{teacher: resolver}
+}
+
+func pathStudent(name string) studentResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error) {
+		return pathUuid(params, name)
+	}
+}
+
+func pathTrajectoryStudent(name string) studentResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error) {
+		id, err := pathUuid(params, name)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return h.App.GetTrajectoryStudent(id)
+	}
+}
+
+func pathPortfolioStudent(name string) studentResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error) {
+		id, err := pathUuid(params, name)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return h.App.GetPortfolioStudent(id)
+	}
+}
+
+func bodyStudent(field string) studentResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error) {
+		return bodyUuid(r, field)
+	}
+}
+
+func bodyPortfolioStudent(field string) studentResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) (uuid.UUID, error) {
+		id, err := bodyUuid(r, field)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return h.App.GetPortfolioStudent(id)
+	}
+}
+
+func pathTeacher(name string) teacherResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) ([]uuid.UUID, error) {
+		id, err := pathUuid(params, name)
+		if err != nil {
+			return nil, err
+		}
+		return []uuid.UUID{id}, nil
+	}
+}
+
+func pathCourseTeacher(name string) teacherResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) ([]uuid.UUID, error) {
+		id, err := pathUuid(params, name)
+		if err != nil {
+			return nil, err
+		}
+		return h.App.GetCourseTeachers(id)
+	}
+}
+
+func pathTrajectoryTeacher(name string) teacherResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) ([]uuid.UUID, error) {
+		id, err := pathUuid(params, name)
+		if err != nil {
+			return nil, err
+		}
+		return h.App.GetTrajectoryTeachers(id)
+	}
+}
+
+func bodyTeacher(field string) teacherResolver {
+	return func(h *Handler, r *http.Request, params httprouter.Params) ([]uuid.UUID, error) {
+		id, err := bodyUuid(r, field)
+		if err != nil {
+			return nil, err
+		}
+		return []uuid.UUID{id}, nil
+	}
+}
+
+func pathUuid(params httprouter.Params, name string) (uuid.UUID, error) {
+	id, err := uuid.FromString(params.ByName(name))
+	if err != nil {
+		return uuid.Nil, errWrongId
+	}
+	return id, nil
+}
+
+func bodyUuid(r *http.Request, field string) (uuid.UUID, error) {
+	raw, err := bodyField(r, field)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	var id uuid.UUID
+	if err = json.Unmarshal(raw, &id); err != nil {
+		return uuid.Nil, errWrongId
+	}
+	return id, nil
+}
+
+// bodyField reads the field of the JSON body and puts the body back for the handler. The field is decoded like decodeInto
+// decodes the request: keys match it regardless of case and the last matching key wins, so the owner checked by guard
+// is the one the handler gets. A body that is not a JSON object is left to the handler to report.
+func bodyField(r *http.Request, field string) (json.RawMessage, error) {
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	holder := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Field",
+		Type: reflect.TypeOf(json.RawMessage{}),
+		Tag:  reflect.StructTag(`json:"` + field + `"`),
+	}}))
+	if json.Unmarshal(body, holder.Interface()) != nil {
+		return nil, errNoClaim
+	}
+	raw := holder.Elem().Field(0).Interface().(json.RawMessage)
+	if raw == nil {
+		return nil, errNoClaim
+	}
+	return raw, nil
+}
+
+type principalKey struct{}
+
+// principalOf returns the caller authenticated by guard.
+func principalOf(r *http.Request) app.Principal {
+	principal, _ := r.Context().Value(principalKey{}).(app.Principal)
+	return principal
+}
+
+// guard is the only place the access to the API is checked. It authenticates the caller
+// with the X-API-Key header or with the Bearer token, checks the rule and only then calls the handler.
+// The apiKey and feedToken query parameters are accepted only for GET requests of schedule feeds, calendar applications
+// can not send headers. Other routes never take credentials from the URL, where they end up in logs and browser history.
+func (h *Handler) guard(rule access, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		principal, err := h.authenticate(r, rule, params)
+		if err != nil {
+			writeAuthError(w, err)
+			return
+		}
+		if err = h.authorize(principal, rule, r, params); err != nil {
+			writeAuthError(w, err)
+			return
+		}
+
+		handle(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)), params)
+	}
+}
+
+func (h *Handler) authenticate(r *http.Request, rule access, params httprouter.Params) (app.Principal, error) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return h.App.Authenticate(key)
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if len(h.secret) == 0 {
+			return app.Principal{}, fmt.Errorf("%w: tokens are not accepted, the service has no secret", app.ErrUnauthenticated)
+		}
+		return h.App.AuthenticateToken(h.secret, token, time.Now())
+	}
+	if key := r.URL.Query().Get("apiKey"); key != "" && r.Method == http.MethodGet && rule.feed != nil {
+		return h.App.Authenticate(key)
+	}
+	if token := r.URL.Query().Get("feedToken"); token != "" && r.Method == http.MethodGet && rule.feed != nil {
+		studentId, err := rule.feed(h, r, params)
+		if err != nil {
+			return app.Principal{}, err
+		}
+		if err = app.CheckScheduleFeedToken(h.secret, studentId, token); err != nil {
+			return app.Principal{}, err
+		}
+		return app.Principal{Role: app.RoleStudent, StudentId: studentId, FromToken: true}, nil
+	}
+	return app.Principal{}, app.ErrUnauthenticated
+}
+
+func (h *Handler) authorize(principal app.Principal, rule access, r *http.Request, params httprouter.Params) error {
+	if principal.Role == app.RoleAdmin || slices.Contains(rule.roles, principal.Role) {
+		return nil
+	}
+
+	switch {
+	case principal.Role == app.RoleStudent && len(rule.students) > 0:
+		claimed := false
+		for _, resolve := range rule.students {
+			studentId, err := resolve(h, r, params)
+			if errors.Is(err, errNoClaim) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if studentId != principal.StudentId {
+				return app.ErrForbidden
+			}
+			claimed = true
+		}
+		if claimed {
+			return nil
+		}
+	case principal.Role == app.RoleTeacher && rule.teacher != nil:
+		teachers, err := rule.teacher(h, r, params)
+		if err != nil && !errors.Is(err, errNoClaim) {
+			return err
+		}
+		if err == nil && slices.Contains(teachers, principal.TeacherId) {
+			return nil
+		}
+	}
+	return app.ErrForbidden
+}
+
+// writeAuthError reports a request that names no owner as forbidden, a wrong id in it is reported as it is.
+func writeAuthError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errNoClaim):
+		writeError(w, app.ErrForbidden)
+	case errors.Is(err, errWrongId):
+		writeError(w, badRequest("wrongId", "", err.Error()))
+	default:
+		writeError(w, err)
+	}
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+)
+
+// TestBodyOwnerCase checks that the owner named in the body is read the way the handler decodes it:
+// keys differing in case only match the same field and the last of them wins.
+func TestBodyOwnerCase(t *testing.T) {
+	f := newFixture(t)
+	ownId := f.student(t, "Иванов Иван Иванович")
+	victimId := f.student(t, "Петров Пётр Петрович")
+	key := f.key(t, app.RoleStudent, ownId, uuid.Nil)
+
+	tests := map[string]struct {
+		body string
+		want int
+	}{
+		"victim in a case variant after own": {
+			fmt.Sprintf(`{"trajectoryStudentId": %q, "TRAJECTORYSTUDENTID": %q, "trajectorySemester": 1, "trajectoryCourseId": %q}`,
+				ownId, victimId, f.courseId),
+			http.StatusForbidden,
+		},
+		"victim in a case variant only": {
+			fmt.Sprintf(`{"TrajectoryStudentId": %q, "trajectorySemester": 1, "trajectoryCourseId": %q}`, victimId, f.courseId),
+			http.StatusForbidden,
+		},
+		"victim duplicated after own": {
+			fmt.Sprintf(`{"trajectoryStudentId": %q, "trajectoryStudentId": %q, "trajectorySemester": 1, "trajectoryCourseId": %q}`,
+				ownId, victimId, f.courseId),
+			http.StatusForbidden,
+		},
+		"own in a case variant after victim": {
+			fmt.Sprintf(`{"trajectoryStudentId": %q, "TRAJECTORYSTUDENTID": %q, "trajectorySemester": 1, "trajectoryCourseId": %q}`,
+				victimId, ownId, f.courseId),
+			http.StatusOK,
+		},
+	}
+	for name, tt := range tests {
+		w := f.serve(http.MethodPost, "/api/v1/trajectory/", key, tt.body)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d %s", name, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	trajectories, err := f.store.GetStudentTrajectories(victimId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trajectories) != 0 {
+		t.Fatalf("expected no trajectory of the victim, got %+v", trajectories)
+	}
+}
+
+func TestBodyTeacherCase(t *testing.T) {
+	f := newFixture(t)
+	own, err := f.h.App.PostTeacher("Иванов Иван Иванович", f.organizationId, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := f.h.App.PostTeacher("Петров Пётр Петрович", f.organizationId, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slot, err := f.h.App.PostTimeSlot(1, "08:30", "10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := f.key(t, app.RoleTeacher, uuid.Nil, own.Id)
+
+	body := fmt.Sprintf(`{"availabilityTeacherId": %q, "AvailabilityTeacherID": %q, "availabilityTimeSlotId": %q}`, own.Id, other.Id, slot.Id)
+	if w := f.serve(http.MethodPost, "/api/v1/teacherAvailability/", key, body); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for the availability of another teacher, got %d %s", w.Code, w.Body.String())
+	}
+}
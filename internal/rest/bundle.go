@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// GetCatalogBundle
+//
+// @Summary      Export catalog
+// @Description  get organizations, educational programs, disciplines, courses, technologies, knowledge, competencies,
+// @Description  professions and their links as a versioned JSON bundle with references by title
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  model.CatalogBundle
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/catalog/ [get]
+func (h *Handler) GetCatalogBundle(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	resp, err := h.App.ExportCatalog()
+	if err == nil {
+		w.Header().Set("Content-Disposition", `attachment; filename="catalog.json"`)
+	}
+	writeResponse(w, resp, err)
+}
+
+// PostCatalogBundle
+//
+// @Summary      Import catalog
+// @Description  upsert the exported bundle by titles in one transaction: missing entities and links are created,
+// @Description  columns of existing entities and levels of existing competency links are replaced, nothing is deleted
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.CatalogBundle  true  "Catalog bundle"
+// @Success      200  {object}  model.GetCatalogImport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/catalog/ [post]
+func (h *Handler) PostCatalogBundle(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.CatalogBundle](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.ImportCatalog(req)
+	writeResponse(w, resp, err)
+}
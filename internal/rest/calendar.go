@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// GetCalendar
+//
+// @Summary      Show organization calendar
+// @Description  get configured semesters, holidays and sessions of the organization
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  model.GetCalendar
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/organization/{id}/calendar [get]
+func (h *Handler) GetCalendar(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetCalendarByOrganization(id)
+	writeResponse(w, resp, err)
+}
+
+// PostCalendarSemester
+//
+// @Summary      Set semester dates
+// @Description  set dates of the autumn (1) or spring (2) semester of the academic year and the window students enroll in courses in, previous dates are replaced.
+// @Description  Once an organization sets enrollment windows, students enroll in its courses only within them
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostCalendarSemester  true  "Calendar semester request"
+// @Success      200  {object}  model.GetCalendarSemester
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/calendarSemester/ [post]
+func (h *Handler) PostCalendarSemester(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostCalendarSemester](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostCalendarSemester(req.OrganizationId, req.Year, req.Term, time.Time(req.Start), time.Time(req.End),
+		time.Time(req.EnrollmentStart), time.Time(req.EnrollmentEnd))
+	writeResponse(w, resp, err)
+}
+
+// PostCalendarPeriod
+//
+// @Summary      Add holiday or session
+// @Description  add holiday or examination session to the organization calendar, both dates are inclusive
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostCalendarPeriod  true  "Calendar period request"
+// @Success      200  {object}  model.GetCalendarPeriod
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/calendarPeriod/ [post]
+func (h *Handler) PostCalendarPeriod(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostCalendarPeriod](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostCalendarPeriod(req.OrganizationId, req.Kind, req.Title, time.Time(req.Start), time.Time(req.End))
+	writeResponse(w, resp, err)
+}
+
+// GetStudentSemesterOn
+//
+// @Summary      Show student semester on date
+// @Description  get the semester the student studies in on the date (today by default) with its dates, holidays and sessions. Semesters are counted from the first enrollment, terms started during academic leaves are skipped
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "Student ID"
+// @Param        date   query     string  false  "Date in format 2006-01-02"
+// @Success      200  {object}  model.GetSemesterDates
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/semester [get]
+func (h *Handler) GetStudentSemesterOn(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	day := time.Now()
+	if value := r.URL.Query().Get("date"); value != "" {
+		var err error
+		if day, err = time.Parse("2006-01-02", value); err != nil {
+			writeError(w, badRequest("wrongDate", "date", "wrong date, expected 2006-01-02"))
+			return
+		}
+	}
+
+	resp, err := h.App.GetStudentSemesterOn(id, day)
+	writeResponse(w, resp, err)
+}
+
+// GetStudentSemester
+//
+// @Summary      Show student semester dates
+// @Description  get dates, holidays and sessions of the semester of the student
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "Student ID"
+// @Param        semester   path      int     true  "Semester number"
+// @Success      200  {object}  model.GetSemesterDates
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/semester/{semester} [get]
+func (h *Handler) GetStudentSemester(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	semester, err := strconv.ParseUint(params.ByName("semester"), 10, 8)
+	if err != nil {
+		writeError(w, badRequest("wrongSemester", "semester", "wrong semester"))
+		return
+	}
+
+	resp, err := h.App.GetStudentSemester(id, uint8(semester))
+	writeResponse(w, resp, err)
+}
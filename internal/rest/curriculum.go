@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostElectivePool
+//
+// @Summary      Post elective pool
+// @Description  post a pool of disciplines of the educational program the student chooses from, the student takes choose of them. The choose of the pool with the same title is updated
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostElectivePool  true  "Elective pool data"
+// @Success      200  {object}  model.GetElectivePool
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/electivePool/ [post]
+func (h *Handler) PostElectivePool(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostElectivePool](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostElectivePool(req.EducationalProgramId, req.Title, req.Choose)
+	writeResponse(w, resp, err)
+}
+
+// PostCurriculumDiscipline
+//
+// @Summary      Post curriculum discipline
+// @Description  place the discipline into the semester of the curriculum of the educational program, the discipline is required unless the elective pool of the same program is given. The discipline already in the curriculum is moved
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostCurriculumDiscipline  true  "Curriculum discipline data"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/curriculum/ [post]
+func (h *Handler) PostCurriculumDiscipline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostCurriculumDiscipline](w, r)
+	if !ok {
+		return
+	}
+
+	err := h.App.PostCurriculumDiscipline(req.EducationalProgramId, req.DisciplineId, req.Semester, req.ElectivePoolId)
+	writeDone(w, err)
+}
+
+// GetCurriculum
+//
+// @Summary      Show curriculum
+// @Description  get required disciplines of the educational program and its elective pools ordered by semester
+// @Tags         curriculum
+// @Produce      json
+// @Param        id   path      string  true  "Educational program ID"
+// @Success      200  {object}  model.GetCurriculum
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/educationalProgram/{id}/curriculum [get]
+func (h *Handler) GetCurriculum(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetCurriculum(id)
+	writeResponse(w, resp, err)
+}
+
+// PostStudentCurriculumCheck
+//
+// @Summary      Check student curriculum
+// @Description  compare passed courses of past trajectories, current study groups and planned courses of the student with the curriculum of the educational program, by default the one the student is enrolled in or most of the student courses belong to. A discipline is covered by any of its courses, an elective pool by as many disciplines as it chooses. Required disciplines and pools still outstanding before graduation are listed, disciplines covered after their semester are marked late
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                     true  "Student ID"
+// @Param        input   body      model.PostCurriculumCheck  true  "Planned courses"
+// @Success      200  {object}  model.GetCurriculumCheck
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/curriculumCheck [post]
+func (h *Handler) PostStudentCurriculumCheck(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	req, ok := decodeRequest[model.PostCurriculumCheck](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.CheckCurriculum(id, req.EducationalProgramId, req.Courses)
+	writeResponse(w, resp, err)
+}
@@ -0,0 +1,474 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// writeDelete deletes entity by id. Deletion that would cascade to other rows is refused with 409
+// and the list of dependent rows unless cascade=true query parameter is given.
+func writeDelete(w http.ResponseWriter, r *http.Request, params httprouter.Params, remove func(id uuid.UUID, cascade bool) (model.GetDeleteReport, error)) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	cascade := r.URL.Query().Get("cascade") == "true"
+	resp, err := remove(id, cascade)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == store.CodeForeignKeyViolation {
+		writeProblem(w, problem{http.StatusConflict, "referenced", ""}, "entity is referenced by "+pqErr.Table, nil)
+		return
+	}
+	writeResponse(w, resp, err)
+}
+
+// DeleteKnowledge
+//
+// @Summary      Delete knowledge
+// @Description  delete single knowledge, cascade deletion has to be confirmed
+// @Tags         knowledge
+// @Produce      json
+// @Param        id        path      string  true   "Knowledge ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/knowledge/{id} [delete]
+func (h *Handler) DeleteKnowledge(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteKnowledge)
+}
+
+// DeleteTechnology
+//
+// @Summary      Delete technology
+// @Description  delete single technology, cascade deletion of competencies has to be confirmed
+// @Tags         technology
+// @Produce      json
+// @Param        id        path      string  true   "Technology ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/technology/{id} [delete]
+func (h *Handler) DeleteTechnology(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteTechnology)
+}
+
+// DeleteCompetency
+//
+// @Summary      Delete competency
+// @Description  delete single competency, cascade deletion has to be confirmed
+// @Tags         competency
+// @Produce      json
+// @Param        id        path      string  true   "Competency ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competency/{id} [delete]
+func (h *Handler) DeleteCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteCompetency)
+}
+
+// DeleteProfession
+//
+// @Summary      Delete profession
+// @Description  delete single profession, cascade deletion has to be confirmed
+// @Tags         profession
+// @Produce      json
+// @Param        id        path      string  true   "Profession ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/profession/{id} [delete]
+func (h *Handler) DeleteProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteProfession)
+}
+
+// DeleteProject
+//
+// @Summary      Delete project
+// @Description  delete single project, cascade deletion has to be confirmed
+// @Tags         project
+// @Produce      json
+// @Param        id        path      string  true   "Project ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/project/{id} [delete]
+func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteProject)
+}
+
+// DeleteOrganization
+//
+// @Summary      Delete organization
+// @Description  delete single organization, cascade deletion has to be confirmed
+// @Tags         organization
+// @Produce      json
+// @Param        id        path      string  true   "Organization ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/organization/{id} [delete]
+func (h *Handler) DeleteOrganization(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteOrganization)
+}
+
+// DeleteEducationalProgram
+//
+// @Summary      Delete educational program
+// @Description  delete single educational program, cascade deletion has to be confirmed
+// @Tags         educational program
+// @Produce      json
+// @Param        id        path      string  true   "Educational program ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/educationalProgram/{id} [delete]
+func (h *Handler) DeleteEducationalProgram(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteEducationalProgram)
+}
+
+// DeleteDiscipline
+//
+// @Summary      Delete discipline
+// @Description  delete single discipline, cascade deletion has to be confirmed
+// @Tags         discipline
+// @Produce      json
+// @Param        id        path      string  true   "Discipline ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/discipline/{id} [delete]
+func (h *Handler) DeleteDiscipline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteDiscipline)
+}
+
+// DeleteCourse
+//
+// @Summary      Delete course
+// @Description  delete single course, cascade deletion has to be confirmed
+// @Tags         course
+// @Produce      json
+// @Param        id        path      string  true   "Course ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/course/{id} [delete]
+func (h *Handler) DeleteCourse(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteCourse)
+}
+
+// DeletePortfolio
+//
+// @Summary      Delete portfolio
+// @Description  delete single portfolio, cascade deletion (including students) has to be confirmed
+// @Tags         portfolio
+// @Produce      json
+// @Param        id        path      string  true   "Portfolio ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/portfolio/{id} [delete]
+func (h *Handler) DeletePortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeletePortfolio)
+}
+
+// DeleteStudent
+//
+// @Summary      Delete student
+// @Description  delete single student, cascade deletion has to be confirmed
+// @Tags         student
+// @Produce      json
+// @Param        id        path      string  true   "Student ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id} [delete]
+func (h *Handler) DeleteStudent(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteStudent)
+}
+
+// DeleteTeacher
+//
+// @Summary      Delete teacher
+// @Description  delete single teacher with the course links, availability and API keys, cascade deletion has to be confirmed. Admins only
+// @Tags         teacher
+// @Produce      json
+// @Param        id        path      string  true   "Teacher ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id} [delete]
+func (h *Handler) DeleteTeacher(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteTeacher)
+}
+
+// DeleteSkill
+//
+// @Summary      Delete skill
+// @Description  delete single skill with the competency links, cascade deletion has to be confirmed
+// @Tags         skill
+// @Produce      json
+// @Param        id        path      string  true   "Skill ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/skill/{id} [delete]
+func (h *Handler) DeleteSkill(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteSkill)
+}
+
+// DeleteTrajectory
+//
+// @Summary      Delete student`s archive course
+// @Description  delete single student`s archive course, deletion of the first attempt of a retaken course with its retakes has to be confirmed
+// @Tags         trajectory
+// @Produce      json
+// @Param        id        path      string  true   "Trajectory ID"
+// @Param        cascade   query     bool    false  "Delete dependent rows too"
+// @Success      200  {object}  model.GetDeleteReport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/trajectory/{id} [delete]
+func (h *Handler) DeleteTrajectory(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	writeDelete(w, r, params, h.App.DeleteTrajectory)
+}
+
+// DeleteKnowledgeCompetency
+//
+// @Summary      Delete knowledge-competency connection
+// @Tags         knowledgeCompetency
+// @Param        knowledgeId    path      string  true  "Knowledge ID"
+// @Param        competencyId   path      string  true  "Competency ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/knowledgeCompetency/{knowledgeId}/{competencyId} [delete]
+func (h *Handler) DeleteKnowledgeCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	knowledgeId, ok := parseId(w, params, "knowledgeId")
+	if !ok {
+		return
+	}
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteKnowledgeCompetency(knowledgeId, competencyId))
+}
+
+// DeleteCompetencyProfession
+//
+// @Summary      Delete competency-profession connection
+// @Tags         competencyProfession
+// @Param        competencyId   path      string  true  "Competency ID"
+// @Param        professionId   path      string  true  "Profession ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competencyProfession/{competencyId}/{professionId} [delete]
+func (h *Handler) DeleteCompetencyProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+	professionId, ok := parseId(w, params, "professionId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteCompetencyProfession(competencyId, professionId))
+}
+
+// DeleteCourseCompetency
+//
+// @Summary      Delete course-competency connection
+// @Tags         courseCompetency
+// @Param        courseId       path      string  true  "Course ID"
+// @Param        competencyId   path      string  true  "Competency ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/courseCompetency/{courseId}/{competencyId} [delete]
+func (h *Handler) DeleteCourseCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	courseId, ok := parseId(w, params, "courseId")
+	if !ok {
+		return
+	}
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteCourseCompetency(courseId, competencyId))
+}
+
+// DeleteProjectPortfolio
+//
+// @Summary      Delete project-portfolio connection
+// @Description  remove project from the portfolio together with competencies confirmed by it
+// @Tags         projectPortfolio
+// @Param        projectId     path      string  true  "Project ID"
+// @Param        portfolioId   path      string  true  "Portfolio ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/projectPortfolio/{projectId}/{portfolioId} [delete]
+func (h *Handler) DeleteProjectPortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	projectId, ok := parseId(w, params, "projectId")
+	if !ok {
+		return
+	}
+	portfolioId, ok := parseId(w, params, "portfolioId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteProjectPortfolio(projectId, portfolioId))
+}
+
+// DeleteProjectPortfolioCompetency
+//
+// @Summary      Delete project-portfolio-competency connection
+// @Tags         projectPortfolioCompetency
+// @Param        projectId      path      string  true  "Project ID"
+// @Param        portfolioId    path      string  true  "Portfolio ID"
+// @Param        competencyId   path      string  true  "Competency ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/projectPortfolioCompetency/{projectId}/{portfolioId}/{competencyId} [delete]
+func (h *Handler) DeleteProjectPortfolioCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	projectId, ok := parseId(w, params, "projectId")
+	if !ok {
+		return
+	}
+	portfolioId, ok := parseId(w, params, "portfolioId")
+	if !ok {
+		return
+	}
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteProjectPortfolioCompetency(projectId, portfolioId, competencyId))
+}
+
+// DeleteStudyGroup
+//
+// @Summary      Delete student`s course in current semester
+// @Description  drop the student from the course or from its waitlist, the freed seat goes to the first student of the waitlist who can enroll in the course
+// @Tags         studyGroup
+// @Param        courseId    path      string  true  "Course ID"
+// @Param        studentId   path      string  true  "Student ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/studyGroup/{courseId}/{studentId} [delete]
+func (h *Handler) DeleteStudyGroup(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	courseId, ok := parseId(w, params, "courseId")
+	if !ok {
+		return
+	}
+	studentId, ok := parseId(w, params, "studentId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteStudyGroup(courseId, studentId))
+}
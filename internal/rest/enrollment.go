@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostEnrollment
+//
+// @Summary      Post enrollment
+// @Description  enroll the student in the educational program from the start date (today by default). The open enrollment of the student in another program is closed as transferred on that date
+// @Tags         enrollment
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostEnrollment  true  "Enrollment data"
+// @Success      200  {object}  model.GetEnrollment
+// @Failure      400  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/enrollment/ [post]
+func (h *Handler) PostEnrollment(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostEnrollment](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.EnrollStudent(req.StudentId, req.EducationalProgramId, time.Time(req.Start))
+	writeResponse(w, resp, err)
+}
+
+// PostEnrollmentStatus
+//
+// @Summary      Change enrollment status
+// @Description  send the student on academic leave, return the student from it, expel the student or mark the graduation on the date (today by default). Only the open enrollment changes
+// @Tags         enrollment
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                      true  "Student ID"
+// @Param        input   body      model.PostEnrollmentStatus  true  "New status"
+// @Success      200  {object}  model.GetEnrollment
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/enrollmentStatus [post]
+func (h *Handler) PostEnrollmentStatus(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	req, ok := decodeRequest[model.PostEnrollmentStatus](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.ChangeEnrollmentStatus(id, req.Status, time.Time(req.Date))
+	writeResponse(w, resp, err)
+}
+
+// GetStudentEnrollments
+//
+// @Summary      Show student enrollments
+// @Description  get enrollments of the student with academic leaves ordered by start, transfers between programs close enrollments as transferred and the last enrollment is the current one
+// @Tags         enrollment
+// @Produce      json
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  model.GetEnrollments
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/enrollments [get]
+func (h *Handler) GetStudentEnrollments(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetStudentEnrollments(id)
+	writeResponse(w, resp, err)
+}
@@ -1,64 +1,187 @@
 package rest
 
 import (
-	"database/sql"
-	"encoding/json"
-	"errors"
-	"log/slog"
+	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	_ "github.com/M-Koscheev/urfu-project-smart-schedule-former/docs"
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
-	"github.com/lib/pq"
 
 	"github.com/julienschmidt/httprouter"
-	uuid "github.com/satori/go.uuid"
 )
 
 type Handler struct {
 	App    *app.App
 	Router *httprouter.Router
+	secret []byte // signs access tokens, tokens are not accepted without it
 }
 
-func New(app *app.App) *Handler {
+// New registers every route behind guard, secret signs access tokens issued for API keys.
+func New(app *app.App, secret []byte) *Handler {
 	router := httprouter.New()
-	h := &Handler{App: app, Router: router}
+	h := &Handler{App: app, Router: router, secret: secret}
 	router.ServeFiles("/docs/*filepath", http.Dir("docs"))
-
-	router.GET("/api/v1/knowledge/:id", h.GetKnowledge)
-	router.GET("/api/v1/technology/:id", h.GetTechnology)
-	router.GET("/api/v1/competency/:id", h.GetCompetency)
-	router.GET("/api/v1/profession/:id", h.GetProfession)
-	router.GET("/api/v1/project/:id", h.GetProject)
-	router.GET("/api/v1/organization/:id", h.GetOrganization)
-	router.GET("/api/v1/educationalProgram/:id", h.GetEducationalProgram)
-	router.GET("/api/v1/discipline/:id", h.GetDiscipline)
-	router.GET("/api/v1/course/:id", h.GetCourse)
-	router.GET("/api/v1/portfolio/:id", h.GetPortfolio)
-	router.GET("/api/v1/student/:id", h.GetStudent)
-	router.GET("/api/v1/trajectory/:id", h.GetTrajectory)
-
-	router.POST("/api/v1/knowledge/", h.PostKnowledge)
-	router.POST("/api/v1/technology/", h.PostTechnology)
-	router.POST("/api/v1/competency/", h.PostCompetency)
-	router.POST("/api/v1/knowledgeCompetency/", h.PostKnowledgeCompetency)
-	router.POST("/api/v1/profession/", h.PostProfession)
-	router.POST("/api/v1/competencyProfession/", h.PostCompetencyProfession)
-	router.POST("/api/v1/project/", h.PostProject)
-	router.POST("/api/v1/organization/", h.PostOrganization)
-	router.POST("/api/v1/educationalProgram/", h.PostEducationalProgram)
-	router.POST("/api/v1/discipline/", h.PostDiscipline)
-	router.POST("/api/v1/course/", h.PostCourse)
-	router.POST("/api/v1/courseCompetency/", h.PostCourseCompetency)
-	router.POST("/api/v1/portfolio/", h.PostPortfolio)
-	router.POST("/api/v1/projectPortfolio/", h.PostProjectPortfolio)
-	router.POST("/api/v1/projectPortfolioCompetency/", h.PostProjectPortfolioCompetency)
-	router.POST("/api/v1/studyGroup/", h.PostStudyGroup)
-	router.POST("/api/v1/student/", h.PostStudent)
-	router.POST("/api/v1/trajectory/", h.PostTrajectory)
+	router.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, problem{http.StatusNotFound, "routeNotFound", ""}, "no such route", nil)
+	})
+	router.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, problem{http.StatusMethodNotAllowed, "methodNotAllowed", ""}, r.Method+" is not allowed here", nil)
+	})
+	router.PanicHandler = func(w http.ResponseWriter, r *http.Request, recovered any) {
+		writeError(w, fmt.Errorf("panic: %v", recovered))
+	}
+
+	router.GET("/api/v1/knowledge/:id", h.guard(authenticated, h.GetKnowledge))
+	router.GET("/api/v1/technology/:id", h.guard(authenticated, h.GetTechnology))
+	router.GET("/api/v1/competency/:id", h.guard(authenticated, h.GetCompetency))
+	router.GET("/api/v1/competency/:id/tree", h.guard(authenticated, h.GetCompetencyTree))
+	router.GET("/api/v1/knowledge/:id/tree", h.guard(authenticated, h.GetKnowledgeTree))
+	router.GET("/api/v1/skill/:id", h.guard(authenticated, h.GetSkill))
+	router.GET("/api/v1/profession/:id", h.guard(authenticated, h.GetProfession))
+	router.GET("/api/v1/project/:id", h.guard(authenticated, h.GetProject))
+	router.GET("/api/v1/organization/:id", h.guard(authenticated, h.GetOrganization))
+	router.GET("/api/v1/educationalProgram/:id", h.guard(authenticated, h.GetEducationalProgram))
+	router.GET("/api/v1/educationalProgram/:id/curriculum", h.guard(authenticated, h.GetCurriculum))
+	router.GET("/api/v1/discipline/:id", h.guard(authenticated, h.GetDiscipline))
+	router.GET("/api/v1/discipline/:id/alternatives", h.guard(authenticated, h.GetDisciplineAlternatives))
+	router.GET("/api/v1/course/:id", h.guard(authenticated, h.GetCourse))
+	router.GET("/api/v1/course/:id/prerequisites", h.guard(authenticated, h.GetCoursePrerequisites))
+	router.GET("/api/v1/course/:id/studyGroup", h.guard(teacherOwned(pathCourseTeacher("id")), h.GetCourseStudyGroup))
+	router.GET("/api/v1/teacher/:id", h.guard(authenticated, h.GetTeacher))
+	router.GET("/api/v1/teacher/:id/courses", h.guard(teacherOwned(pathTeacher("id")), h.GetTeacherCourses))
+	router.GET("/api/v1/teacher/:id/studyGroups", h.guard(teacherOwned(pathTeacher("id")), h.GetTeacherStudyGroups))
+	router.GET("/api/v1/teacher/:id/hours", h.guard(teacherOwned(pathTeacher("id")), h.GetTeacherHours))
+	router.GET("/api/v1/portfolio/:id", h.guard(studentData(pathPortfolioStudent("id")), h.GetPortfolio))
+	router.GET("/api/v1/student/:id", h.guard(studentData(pathStudent("id")), h.GetStudent))
+	router.GET("/api/v1/trajectory/:id", h.guard(studentData(pathTrajectoryStudent("id")), h.GetTrajectory))
+	router.GET("/api/v1/student/:id/competencyGap", h.guard(studentData(pathStudent("id")), h.GetCompetencyGap))
+	router.GET("/api/v1/student/:id/recommendations", h.guard(studentData(pathStudent("id")), h.GetCourseRecommendations))
+	router.GET("/api/v1/student/:id/alternatives/:disciplineId", h.guard(studentData(pathStudent("id")), h.GetStudentAlternatives))
+	router.GET("/api/v1/student/:id/semester", h.guard(studentData(pathStudent("id")), h.GetStudentSemesterOn))
+	router.GET("/api/v1/student/:id/semester/:semester", h.guard(studentData(pathStudent("id")), h.GetStudentSemester))
+	router.GET("/api/v1/student/:id/enrollments", h.guard(studentData(pathStudent("id")), h.GetStudentEnrollments))
+	router.GET("/api/v1/organization/:id/calendar", h.guard(authenticated, h.GetCalendar))
+	router.GET("/api/v1/organization/:id/gradingScale", h.guard(authenticated, h.GetGradingScale))
+	router.GET("/api/v1/student/:id/timetable", h.guard(studentData(pathStudent("id")), h.GetStudentTimetable))
+	router.GET("/api/v1/student/:id/schedule.ics", h.guard(scheduleFeed(pathStudent("id")), h.GetStudentSchedule))
+	router.GET("/api/v1/student/:id/scheduleSubscription", h.guard(studentData(pathStudent("id")), h.GetScheduleSubscription))
+	router.GET("/api/v1/timetable/", h.guard(authenticated, h.GetTimetable))
+	router.GET("/api/v1/catalog/", h.guard(authenticated, h.GetCatalogBundle))
+	router.GET("/api/v1/search/", h.guard(authenticated, h.GetSearch))
+
+	router.GET("/api/v1/knowledge/", h.guard(authenticated, h.ListKnowledge))
+	router.GET("/api/v1/technology/", h.guard(authenticated, h.ListTechnologies))
+	router.GET("/api/v1/competency/", h.guard(authenticated, h.ListCompetencies))
+	router.GET("/api/v1/profession/", h.guard(authenticated, h.ListProfessions))
+	router.GET("/api/v1/project/", h.guard(authenticated, h.ListProjects))
+	router.GET("/api/v1/organization/", h.guard(authenticated, h.ListOrganizations))
+	router.GET("/api/v1/educationalProgram/", h.guard(authenticated, h.ListEducationalPrograms))
+	router.GET("/api/v1/discipline/", h.guard(authenticated, h.ListDisciplines))
+	router.GET("/api/v1/course/", h.guard(authenticated, h.ListCourses))
+	router.GET("/api/v1/teacher/", h.guard(authenticated, h.ListTeachers))
+	router.GET("/api/v1/skill/", h.guard(authenticated, h.ListSkills))
+	router.GET("/api/v1/student/", h.guard(studentData(), h.ListStudents))
+
+	router.POST("/api/v1/knowledge/", h.guard(curriculum, h.PostKnowledge))
+	router.POST("/api/v1/technology/", h.guard(curriculum, h.PostTechnology))
+	router.POST("/api/v1/competency/", h.guard(curriculum, h.PostCompetency))
+	router.POST("/api/v1/knowledgeCompetency/", h.guard(curriculum, h.PostKnowledgeCompetency))
+	router.POST("/api/v1/profession/", h.guard(curriculum, h.PostProfession))
+	router.POST("/api/v1/competencyProfession/", h.guard(curriculum, h.PostCompetencyProfession))
+	router.POST("/api/v1/project/", h.guard(projectAuthors, h.PostProject))
+	router.POST("/api/v1/organization/", h.guard(curriculum, h.PostOrganization))
+	router.POST("/api/v1/educationalProgram/", h.guard(curriculum, h.PostEducationalProgram))
+	router.POST("/api/v1/discipline/", h.guard(curriculum, h.PostDiscipline))
+	router.POST("/api/v1/course/", h.guard(curriculum, h.PostCourse))
+	router.POST("/api/v1/courseCompetency/", h.guard(curriculum, h.PostCourseCompetency))
+	router.POST("/api/v1/coursePrerequisite/", h.guard(curriculum, h.PostCoursePrerequisite))
+	router.POST("/api/v1/electivePool/", h.guard(curriculum, h.PostElectivePool))
+	router.POST("/api/v1/curriculum/", h.guard(curriculum, h.PostCurriculumDiscipline))
+	router.POST("/api/v1/portfolio/", h.guard(administration, h.PostPortfolio))
+	router.POST("/api/v1/projectPortfolio/", h.guard(studentOwned(bodyPortfolioStudent("PortfolioId")), h.PostProjectPortfolio))
+	router.POST("/api/v1/projectPortfolioCompetency/", h.guard(studentOwned(bodyPortfolioStudent("PortfolioId")), h.PostProjectPortfolioCompetency))
+	router.POST("/api/v1/studyGroup/", h.guard(administration, h.PostStudyGroup))
+	router.POST("/api/v1/enrollment/", h.guard(administration, h.PostEnrollment))
+	router.POST("/api/v1/student/:id/enrollmentStatus", h.guard(administration, h.PostEnrollmentStatus))
+	router.POST("/api/v1/student/", h.guard(administration, h.PostStudent))
+	router.POST("/api/v1/trajectory/", h.guard(studentOwned(bodyStudent("trajectoryStudentId")), h.PostTrajectory))
+	router.POST("/api/v1/student/:id/plan", h.guard(studentData(pathStudent("id")), h.PostStudentPlan))
+	router.POST("/api/v1/student/:id/planValidation", h.guard(studentData(pathStudent("id")), h.PostStudentPlanValidation))
+	router.POST("/api/v1/student/:id/curriculumCheck", h.guard(studentData(pathStudent("id")), h.PostStudentCurriculumCheck))
+	router.POST("/api/v1/calendarSemester/", h.guard(administration, h.PostCalendarSemester))
+	router.POST("/api/v1/calendarPeriod/", h.guard(administration, h.PostCalendarPeriod))
+	router.POST("/api/v1/gradingScale/", h.guard(administration, h.PostGradingScale))
+	router.POST("/api/v1/trajectory/:id/outcome", h.guard(courseTeaching(pathTrajectoryTeacher("id")), h.PostTrajectoryOutcome))
+	router.POST("/api/v1/trajectory/:id/retake", h.guard(studentOwned(pathTrajectoryStudent("id")), h.PostTrajectoryRetake))
+	router.POST("/api/v1/timeSlot/", h.guard(administration, h.PostTimeSlot))
+	router.POST("/api/v1/room/", h.guard(administration, h.PostRoom))
+	router.POST("/api/v1/courseSession/", h.guard(curriculum, h.PostCourseSession))
+	router.POST("/api/v1/teacher/", h.guard(administration, h.PostTeacher))
+	router.POST("/api/v1/teacherAvailability/", h.guard(access{teacher: bodyTeacher("availabilityTeacherId")}, h.PostTeacherAvailability))
+	router.POST("/api/v1/timetable/", h.guard(administration, h.PostTimetable))
+	router.POST("/api/v1/competencyMatrix/", h.guard(curriculum, h.PostCompetencyMatrix))
+	router.POST("/api/v1/catalog/", h.guard(curriculum, h.PostCatalogBundle))
+	router.POST("/api/v1/apiKey/", h.guard(administration, h.PostApiKey))
+	router.POST("/api/v1/token/", h.guard(authenticated, h.PostToken))
+
+	router.PUT("/api/v1/knowledge/:id", h.guard(curriculum, h.PutKnowledge))
+	router.PUT("/api/v1/technology/:id", h.guard(curriculum, h.PutTechnology))
+	router.PUT("/api/v1/competency/:id", h.guard(curriculum, h.PutCompetency))
+	router.PUT("/api/v1/profession/:id", h.guard(curriculum, h.PutProfession))
+	router.PUT("/api/v1/project/:id", h.guard(curriculum, h.PutProject))
+	router.PUT("/api/v1/organization/:id", h.guard(curriculum, h.PutOrganization))
+	router.PUT("/api/v1/educationalProgram/:id", h.guard(curriculum, h.PutEducationalProgram))
+	router.PUT("/api/v1/discipline/:id", h.guard(curriculum, h.PutDiscipline))
+	router.PUT("/api/v1/course/:id", h.guard(curriculum, h.PutCourse))
+	router.PUT("/api/v1/student/:id", h.guard(administration, h.PutStudent))
+	router.PUT("/api/v1/teacher/:id", h.guard(administration, h.PutTeacher))
+	router.PUT("/api/v1/trajectory/:id", h.guard(studentOwned(pathTrajectoryStudent("id"), bodyStudent("trajectoryStudentId")), h.PutTrajectory))
+	router.PUT("/api/v1/projectPortfolio/:projectId/:portfolioId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.PutProjectPortfolio))
+	router.PUT("/api/v1/competencyProfession/:competencyId/:professionId", h.guard(curriculum, h.PutCompetencyProfession))
+	router.PUT("/api/v1/courseCompetency/:courseId/:competencyId", h.guard(curriculum, h.PutCourseCompetency))
+	router.PUT("/api/v1/projectPortfolioCompetency/:projectId/:portfolioId/:competencyId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.PutProjectPortfolioCompetency))
+
+	router.PATCH("/api/v1/knowledge/:id", h.guard(curriculum, h.PutKnowledge))
+	router.PATCH("/api/v1/technology/:id", h.guard(curriculum, h.PutTechnology))
+	router.PATCH("/api/v1/competency/:id", h.guard(curriculum, h.PutCompetency))
+	router.PATCH("/api/v1/profession/:id", h.guard(curriculum, h.PutProfession))
+	router.PATCH("/api/v1/project/:id", h.guard(curriculum, h.PutProject))
+	router.PATCH("/api/v1/organization/:id", h.guard(curriculum, h.PutOrganization))
+	router.PATCH("/api/v1/educationalProgram/:id", h.guard(curriculum, h.PutEducationalProgram))
+	router.PATCH("/api/v1/discipline/:id", h.guard(curriculum, h.PutDiscipline))
+	router.PATCH("/api/v1/course/:id", h.guard(curriculum, h.PutCourse))
+	router.PATCH("/api/v1/student/:id", h.guard(administration, h.PutStudent))
+	router.PATCH("/api/v1/teacher/:id", h.guard(administration, h.PutTeacher))
+	router.PATCH("/api/v1/trajectory/:id", h.guard(studentOwned(pathTrajectoryStudent("id"), bodyStudent("trajectoryStudentId")), h.PutTrajectory))
+	router.PATCH("/api/v1/projectPortfolio/:projectId/:portfolioId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.PutProjectPortfolio))
+	router.PATCH("/api/v1/competencyProfession/:competencyId/:professionId", h.guard(curriculum, h.PutCompetencyProfession))
+	router.PATCH("/api/v1/courseCompetency/:courseId/:competencyId", h.guard(curriculum, h.PutCourseCompetency))
+	router.PATCH("/api/v1/projectPortfolioCompetency/:projectId/:portfolioId/:competencyId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.PutProjectPortfolioCompetency))
+
+	router.DELETE("/api/v1/knowledge/:id", h.guard(curriculum, h.DeleteKnowledge))
+	router.DELETE("/api/v1/technology/:id", h.guard(curriculum, h.DeleteTechnology))
+	router.DELETE("/api/v1/competency/:id", h.guard(curriculum, h.DeleteCompetency))
+	router.DELETE("/api/v1/skill/:id", h.guard(curriculum, h.DeleteSkill))
+	router.DELETE("/api/v1/profession/:id", h.guard(curriculum, h.DeleteProfession))
+	router.DELETE("/api/v1/project/:id", h.guard(curriculum, h.DeleteProject))
+	router.DELETE("/api/v1/organization/:id", h.guard(curriculum, h.DeleteOrganization))
+	router.DELETE("/api/v1/educationalProgram/:id", h.guard(curriculum, h.DeleteEducationalProgram))
+	router.DELETE("/api/v1/discipline/:id", h.guard(curriculum, h.DeleteDiscipline))
+	router.DELETE("/api/v1/course/:id", h.guard(curriculum, h.DeleteCourse))
+	router.DELETE("/api/v1/portfolio/:id", h.guard(studentOwned(pathPortfolioStudent("id")), h.DeletePortfolio))
+	router.DELETE("/api/v1/student/:id", h.guard(administration, h.DeleteStudent))
+	router.DELETE("/api/v1/teacher/:id", h.guard(administration, h.DeleteTeacher))
+	router.DELETE("/api/v1/trajectory/:id", h.guard(studentOwned(pathTrajectoryStudent("id")), h.DeleteTrajectory))
+	router.DELETE("/api/v1/knowledgeCompetency/:knowledgeId/:competencyId", h.guard(curriculum, h.DeleteKnowledgeCompetency))
+	router.DELETE("/api/v1/competencyProfession/:competencyId/:professionId", h.guard(curriculum, h.DeleteCompetencyProfession))
+	router.DELETE("/api/v1/courseCompetency/:courseId/:competencyId", h.guard(curriculum, h.DeleteCourseCompetency))
+	router.DELETE("/api/v1/coursePrerequisite/:courseId/:prerequisiteId", h.guard(curriculum, h.DeleteCoursePrerequisite))
+	router.DELETE("/api/v1/projectPortfolio/:projectId/:portfolioId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.DeleteProjectPortfolio))
+	router.DELETE("/api/v1/projectPortfolioCompetency/:projectId/:portfolioId/:competencyId", h.guard(studentOwned(pathPortfolioStudent("portfolioId")), h.DeleteProjectPortfolioCompetency))
+	router.DELETE("/api/v1/studyGroup/:courseId/:studentId", h.guard(administration, h.DeleteStudyGroup))
+	router.DELETE("/api/v1/apiKey/:id", h.guard(administration, h.DeleteApiKey))
 
 	return h
 }
@@ -72,37 +195,20 @@ func New(app *app.App) *Handler {
 // @Produce      json
 // @Param        id   path      string  true  "Knowledge ID"
 // @Success      200  {object}  model.GetKnowledge
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/knowledge/{id} [get]
 func (h *Handler) GetKnowledge(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetKnowledgeByIndex(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting knowledge by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetTechnology return technology by it`s id
@@ -114,37 +220,20 @@ func (h *Handler) GetKnowledge(w http.ResponseWriter, r *http.Request, params ht
 // @Produce      json
 // @Param        id   path      string  true  "Technology ID"
 // @Success      200  {object}  model.GetTechnology
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/technology/{id} [get]
 func (h *Handler) GetTechnology(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetTechnolgyById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting technology by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetCompetency return competency by it`s id
@@ -156,37 +245,20 @@ func (h *Handler) GetTechnology(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        id   path      string  true  "Competency ID"
 // @Success      200  {object}  model.GetCompetency
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/competency/{id} [get]
 func (h *Handler) GetCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetCompetencyById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting competency by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetProfession return profession by it`s id
@@ -198,37 +270,20 @@ func (h *Handler) GetCompetency(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        id   path      string  true  "Profession ID"
 // @Success      200  {object}  model.GetProfession
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/profession/{id} [get]
 func (h *Handler) GetProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetProfessionById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting profession by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetProject return project by it`s id
@@ -240,37 +295,20 @@ func (h *Handler) GetProfession(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        id   path      string  true  "Project ID"
 // @Success      200  {object}  model.GetProject
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/project/{id} [get]
 func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetProjectById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetOrganization return organization by it`s id
@@ -282,37 +320,20 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request, params http
 // @Produce      json
 // @Param        id   path      string  true  "Organization ID"
 // @Success      200  {object}  model.GetOrganization
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/organization/{id} [get]
 func (h *Handler) GetOrganization(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetOrganizationById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetEducationalProgram return educational program by it`s id
@@ -324,37 +345,20 @@ func (h *Handler) GetOrganization(w http.ResponseWriter, r *http.Request, params
 // @Produce      json
 // @Param        id   path      string  true  "Educational program ID"
 // @Success      200  {object}  model.GetEducationalProgram
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/educationalProgram/{id} [get]
 func (h *Handler) GetEducationalProgram(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetEducationalProgramById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetDiscipline return discipline by it`s id
@@ -366,37 +370,20 @@ func (h *Handler) GetEducationalProgram(w http.ResponseWriter, r *http.Request,
 // @Produce      json
 // @Param        id   path      string  true  "Discipline ID"
 // @Success      200  {object}  model.GetDiscipline
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/discipline/{id} [get]
 func (h *Handler) GetDiscipline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetDisciplineById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetCourse return course by it`s id
@@ -408,37 +395,20 @@ func (h *Handler) GetDiscipline(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        id   path      string  true  "Course ID"
 // @Success      200  {object}  model.GetCourse
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/course/{id} [get]
 func (h *Handler) GetCourse(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetCourseById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetPortfolio return portfolio by it`s id
@@ -450,79 +420,45 @@ func (h *Handler) GetCourse(w http.ResponseWriter, r *http.Request, params httpr
 // @Produce      json
 // @Param        id   path      string  true  "Portfolio ID"
 // @Success      200  {object}  model.GetPortfolio
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/portfolio/{id} [get]
 func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetPortfolioById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetStudent return student by it`s id
 //
 // @Summary      Show student
-// @Description  get single student by ID
+// @Description  get single student by ID with the current educational program, the semester is counted from the first enrollment without academic leaves
 // @Tags         student
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Student ID"
 // @Success      200  {object}  model.GetStudent
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/student/{id} [get]
 func (h *Handler) GetStudent(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetStudentById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // GetTrajectory return trajectory by it`s id
@@ -534,37 +470,20 @@ func (h *Handler) GetStudent(w http.ResponseWriter, r *http.Request, params http
 // @Produce      json
 // @Param        id   path      string  true  "Trajectory ID"
 // @Success      200  {object}  model.GetTrajectory
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/trajectory/{id} [get]
 func (h *Handler) GetTrajectory(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	id, err := uuid.FromString(params.ByName("id"))
-	if err != nil {
-		slog.Error("wrong id format", err)
-		w.WriteHeader(http.StatusBadRequest)
+	id, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.GetTrajectoryById(id)
-	if errors.Is(err, sql.ErrNoRows) {
-		slog.Info("no row with such id was found", err)
-		w.WriteHeader(http.StatusNotFound)
-		return
-	} else if err != nil {
-		slog.Info("error getting project by id", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostKnowledge
@@ -576,62 +495,19 @@ func (h *Handler) GetTrajectory(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        input   body      model.PostKnowledge  true  "Knowledge request"
 // @Success      200  {object}  model.GetKnowledge
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/knowledge/ [post]
 func (h *Handler) PostKnowledge(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostKnowledge
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostKnowledge](w, r)
+	if !ok {
 		return
 	}
 
-	resp, err := h.App.PostKnowledge(req.Title)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusBadGateway)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostKnowledge(req.Title, req.ParentId)
+	writeResponse(w, resp, err)
 }
 
 // PostTechnology
@@ -643,62 +519,19 @@ func (h *Handler) PostKnowledge(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        input   body      model.PostTechnology  true  "Technology request"
 // @Success      200  {object}  model.GetTechnology
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/technology/ [post]
 func (h *Handler) PostTechnology(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostTechnology
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostTechnology](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostTechnology(req.Title)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostCompetency
@@ -710,62 +543,19 @@ func (h *Handler) PostTechnology(w http.ResponseWriter, r *http.Request, params
 // @Produce      json
 // @Param        input   body      model.PostCompetency  true  "Competency request"
 // @Success      200  {object}  model.GetCompetency
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/competency/ [post]
 func (h *Handler) PostCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostCompetency
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	resp, err := h.App.PostCompetency(req.Title, req.Skills, req.MainTechnologyId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+	req, ok := decodeRequest[model.PostCompetency](w, r)
+	if !ok {
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostCompetency(req.Title, req.ParentId, req.Skills, req.MainTechnologyId)
+	writeResponse(w, resp, err)
 }
 
 // PostKnowledgeCompetency
@@ -777,56 +567,19 @@ func (h *Handler) PostCompetency(w http.ResponseWriter, r *http.Request, params
 // @Produce      json
 // @Param        input   body      model.PostKnowledgeCompetency  true  "Knowledge-competency request"
 // @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/knowledgeCompetency/ [post]
 func (h *Handler) PostKnowledgeCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostKnowledgeCompetency
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostKnowledgeCompetency](w, r)
+	if !ok {
 		return
 	}
 
-	err = h.App.PostKnowledgeCompetency(req.KnowledgeId, req.CompetencyId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	w.Header().Set("content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	err := h.App.PostKnowledgeCompetency(req.KnowledgeId, req.CompetencyId)
+	writeDone(w, err)
 }
 
 // PostProfession
@@ -838,123 +591,43 @@ func (h *Handler) PostKnowledgeCompetency(w http.ResponseWriter, r *http.Request
 // @Produce      json
 // @Param        input   body      model.PostProfession  true  "Profession data"
 // @Success      200  {object}  model.GetProfession
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/profession/ [post]
 func (h *Handler) PostProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostProfession
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostProfession](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostProfession(req.Title, req.Description)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostCompetencyProfession
 //
 // @Summary      Post competency-profession connection
-// @Description  post single competency-profession connection
+// @Description  post single competency-profession connection with the level (aware, basic, intermediate or advanced) the profession requires, basic by default
 // @Tags         competencyProfession
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostCompetencyProfession  true  "CompetencyProfession data"
 // @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/competencyProfession/ [post]
 func (h *Handler) PostCompetencyProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostCompetencyProfession
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostCompetencyProfession](w, r)
+	if !ok {
 		return
 	}
 
-	err = h.App.PostCompetencyProfession(req.CompetencyId, req.ProfessionId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	w.Header().Set("content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	err := h.App.PostCompetencyProfession(req.CompetencyId, req.ProfessionId, req.Level)
+	writeDone(w, err)
 }
 
 // PostProject
@@ -966,62 +639,19 @@ func (h *Handler) PostCompetencyProfession(w http.ResponseWriter, r *http.Reques
 // @Produce      json
 // @Param        input   body      model.PostProject  true  "Project data"
 // @Success      200  {object}  model.GetProject
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/project/ [post]
 func (h *Handler) PostProject(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostProject
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostProject](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostProject(req.Title, req.Description, req.Result, req.LifeScenario, req.MainTechnologyId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostOrganization
@@ -1033,129 +663,43 @@ func (h *Handler) PostProject(w http.ResponseWriter, r *http.Request, params htt
 // @Produce      json
 // @Param        input   body      model.PostOrganization  true  "Organization data"
 // @Success      200  {object}  model.GetOrganization
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/organization/ [post]
 func (h *Handler) PostOrganization(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.GetOrganization
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostOrganization](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostOrganization(req.Title)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostEducationalProgram
 //
 // @Summary      Post educational program
-// @Description  post single educational program
+// @Description  post single educational program, min and max semester credits limit the workload of its students, max 0 means no limit
 // @Tags         educational program
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostEducationalProgram  true  "Educational program data"
 // @Success      200  {object}  model.GetEducationalProgram
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/educationalProgram/ [post]
 func (h *Handler) PostEducationalProgram(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostEducationalProgram
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	resp, err := h.App.PostEducationalProgram(req.Title, req.Description, req.OrganizationId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+	req, ok := decodeRequest[model.PostEducationalProgram](w, r)
+	if !ok {
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostEducationalProgram(req.Title, req.Description, req.OrganizationId, req.MinCredits, req.MaxCredits)
+	writeResponse(w, resp, err)
 }
 
 // PostDiscipline
@@ -1167,189 +711,68 @@ func (h *Handler) PostEducationalProgram(w http.ResponseWriter, r *http.Request,
 // @Produce      json
 // @Param        input   body      model.PostDiscipline  true  "Discipline data"
 // @Success      200  {object}  model.GetDiscipline
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/discipline/ [post]
 func (h *Handler) PostDiscipline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostDiscipline
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostDiscipline](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostDiscipline(req.Title, req.Description, req.EducationalProgramId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostCourse
 //
 // @Summary      Post course
-// @Description  post single course
+// @Description  post single course with its teachers and workload in credits and academic hours
 // @Tags         course
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostCourse  true  "Course data"
 // @Success      200  {object}  model.GetCourse
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/course/ [post]
 func (h *Handler) PostCourse(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostCourse
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	resp, err := h.App.PostCourse(req.Title, req.Description, req.Teacher, req.DisciplineId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty title"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+	req, ok := decodeRequest[model.PostCourse](w, r)
+	if !ok {
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostCourse(req.Title, req.Description, req.TeacherIds, req.DisciplineId, req.Credits, req.Hours, req.Alternative,
+		req.Capacity)
+	writeResponse(w, resp, err)
 }
 
 // PostCourseCompetency
 //
 // @Summary      Post course-competency connection
-// @Description  post single course-competency connection
+// @Description  post single course-competency connection with the level (aware, basic, intermediate or advanced) the course gives, basic by default
 // @Tags         courseCompetency
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostCourseCompetency  true  "Course-competency data"
 // @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/courseCompetency/ [post]
 func (h *Handler) PostCourseCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostCourseCompetency
-	var unmarshalErr *json.UnmarshalTypeError
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostCourseCompetency](w, r)
+	if !ok {
 		return
 	}
 
-	err = h.App.PostCourseCompetency(req.CourseId, req.CompetencyId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	w.Header().Set("content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	err := h.App.PostCourseCompetency(req.CourseId, req.CompetencyId, req.Level)
+	writeDone(w, err)
 }
 
 // PostPortfolio
@@ -1360,39 +783,15 @@ func (h *Handler) PostCourseCompetency(w http.ResponseWriter, r *http.Request, p
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  model.PostPortfolio
-// @Failure      500
-// @Failure      502
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/portfolio/ [post]
 func (h *Handler) PostPortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	defer r.Body.Close()
-	resp, err := h.App.PostPortfolio()
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostPortfolio()
+	writeResponse(w, resp, err)
 }
 
 // PostProjectPortolio
@@ -1404,184 +803,70 @@ func (h *Handler) PostPortfolio(w http.ResponseWriter, r *http.Request, params h
 // @Produce      json
 // @Param        input   body      model.PostProjectPortfolio  true  "Personal project data"
 // @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/projectPortfolio/ [post]
 func (h *Handler) PostProjectPortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostProjectPortfolio
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostProjectPortfolio](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostProjectPortolio(req.ProjectId, req.PortfolioId, req.TeamRole, req.Semester)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	writeResponse(w, resp, err)
 }
 
 // PostProjectPortfolioCompetency
 //
 // @Summary      Post project-portfolio-competency connection
-// @Description  post single project-portfolio-competency connection
+// @Description  post single project-portfolio-competency connection with the level (aware, basic, intermediate or advanced) the project confirms, basic by default
 // @Tags         projectPortfolioCompetency
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostProjectPortfolioCompetency  true  "Personal project competency"
 // @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/projectPortfolioCompetency/ [post]
 func (h *Handler) PostProjectPortfolioCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostProjectPortfolioCompetency
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	err = h.App.PostProjectPortfolioCompetency(req.ProjectId, req.PortfolioId, req.CompetencyId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+	req, ok := decodeRequest[model.PostProjectPortfolioCompetency](w, r)
+	if !ok {
 		return
 	}
 
-	w.Header().Set("content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	err := h.App.PostProjectPortfolioCompetency(req.ProjectId, req.PortfolioId, req.CompetencyId, req.Level)
+	writeDone(w, err)
 }
 
 // PostStudyGroup
 //
 // @Summary      Post student`s course in current semester
-// @Description  Student`s course in current semester
+// @Description  Student`s course in current semester. Courses over the maximum semester credits of the educational program are rejected with the violation,
+// @Description  an alternative course is rejected when the student studies another alternative course of the discipline.
+// @Description  When the course has no free seats the student is put at the end of its waitlist. Outside the enrollment windows of the organization enrollment is closed
 // @Tags         studyGroup
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostStudyGroup  true  "Personal current student`s project"
-// @Success      200
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Success      200  {object}  model.GetStudyGroupEntry
+// @Failure      400  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/studyGroup/ [post]
 func (h *Handler) PostStudyGroup(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostStudyGroup
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostStudyGroup](w, r)
+	if !ok {
 		return
 	}
 
-	err = h.App.PostStudyGroup(req.CourseId, req.StudentId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	w.Header().Set("content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	resp, err := h.App.PostStudyGroup(req.CourseId, req.StudentId)
+	writeResponse(w, resp, err)
 }
 
 // PostStudent
@@ -1593,145 +878,142 @@ func (h *Handler) PostStudyGroup(w http.ResponseWriter, r *http.Request, params
 // @Produce      json
 // @Param        input   body      model.PostStudent  true  "Student`s data"
 // @Success      200 {object} model.GetStudent
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/student/ [post]
 func (h *Handler) PostStudent(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostStudent
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	bytes, err := req.Admition.MarshalJSON()
-	if err != nil {
-		slog.Error("date marshalling error " + err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(err.Error()))
+	req, ok := decodeRequest[model.PostStudent](w, r)
+	if !ok {
 		return
 	}
 
-	dateString := strings.Trim(string(bytes), "\"")
-	date, err := time.Parse("2006-01-02", dateString)
-	if err != nil {
-		slog.Error("date parsing error " + err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(err.Error()))
-		return
-	}
-
-	resp, err := h.App.PostStudent(req.FullName, date, req.PortfolioId)
-	if errors.Is(err, app.ErrEmptyTitle) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty student`s name"))
-		return
-	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		return
-	}
-
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	resp, err := h.App.PostStudent(req.FullName, time.Time(req.Admition), req.PortfolioId)
+	writeResponse(w, resp, err)
 }
 
 // PostTrajectory
 //
 // @Summary      Post student`s archive course
-// @Description  post single student`s archive course
+// @Description  post single student`s archive course, its outcome is recorded separately. Courses over the maximum semester credits of the educational program are rejected with the violation.
+// @Description  A course the student already took is rejected, the next attempt at it is added as a retake
 // @Tags         trajectory
 // @Accept       json
 // @Produce      json
 // @Param        input   body      model.PostTrajectory  true  "Trajectory`s data"
 // @Success      200 {object} model.GetTrajectory
-// @Failure      400
-// @Failure      500
-// @Failure      502
+// @Failure      400  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /api/v1/trajectory/ [post]
 func (h *Handler) PostTrajectory(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
-	defer r.Body.Close()
-
-	var req model.PostTrajectory
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&req)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			slog.Error("Bad request. Wrong Type provided for field "+unmarshalErr.Field, err)
-		} else {
-			slog.Error("Bad request "+err.Error(), err)
-		}
-		w.WriteHeader(http.StatusBadRequest)
+	req, ok := decodeRequest[model.PostTrajectory](w, r)
+	if !ok {
 		return
 	}
 
 	resp, err := h.App.PostTrajectory(req.Semester, req.StudentId, req.CourseId)
-	if errors.Is(err, app.ErrEmptyId) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("empty id"))
+	writeResponse(w, resp, err)
+}
+
+// PostStudentPlan
+//
+// @Summary      Build student`s educational plan
+// @Description  build semester-by-semester course list that covers competencies required by the profession and not given by passed or current courses, semesters keep within the maximum credits of the student`s educational program and semesters out of its limits are reported
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id             path      string  true  "Student ID"
+// @Param        professionId   query     string  true  "Desired profession ID"
+// @Success      200  {object}  model.GetPlan
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/plan [post]
+func (h *Handler) PostStudentPlan(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	studentId, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
-	if err != nil {
-		switch e := err.(type) {
-		case *pq.Error:
-			w.WriteHeader(http.StatusBadRequest)
-			switch e.Code {
-			case "23503":
-				w.Write([]byte("foreign key violation"))
-			case "23505":
-				w.Write([]byte("duplicate value"))
-			default:
-				w.Write([]byte(e.Message))
-			}
-		default:
-			slog.Error("unknown error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(err.Error()))
-		}
+	professionId, ok := parseQueryId(w, r, "professionId")
+	if !ok {
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		slog.Error("error converting data to JSON format", err)
-		w.WriteHeader(http.StatusInternalServerError)
+	resp, err := h.App.GetStudentPlan(studentId, professionId)
+	writeResponse(w, resp, err)
+}
+
+// GetCompetencyGap
+//
+// @Summary      Show student`s competency gap
+// @Description  compare competencies required by the profession with the ones the student already has through portfolio projects, passed courses and current study groups
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id             path      string  true  "Student ID"
+// @Param        professionId   query     string  true  "Desired profession ID"
+// @Success      200  {object}  model.GetCompetencyGap
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/competencyGap [get]
+func (h *Handler) GetCompetencyGap(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	studentId, ok := parseId(w, params, "id")
+	if !ok {
 		return
 	}
-	w.Header().Set("content-Type", "application/json")
-	w.Write(respJSON)
+	professionId, ok := parseQueryId(w, r, "professionId")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.CompetencyGap(studentId, professionId)
+	writeResponse(w, resp, err)
+}
+
+// GetCourseRecommendations
+//
+// @Summary      Recommend courses to student
+// @Description  rank courses the student has not taken for the desired profession. The score from 0 to 100 is the weighted sum
+// @Description  of factors: coverage (share of the missing competencies of the profession the course gives, weight 0.5),
+// @Description  technology (share of technologies of the course used in portfolio projects, 0.2), readiness (share
+// @Description  of prerequisites taken, hard ones count twice, 0.2) and workload (share of the course credits fitting into
+// @Description  the next semester, 0.1). Every factor is explained. Only courses giving a missing competency are returned
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id             path      string  true   "Student ID"
+// @Param        professionId   query     string  true   "Desired profession ID"
+// @Param        limit          query     int     false  "Number of courses"  default(10)  maximum(50)
+// @Success      200  {object}  model.GetRecommendations
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/recommendations [get]
+func (h *Handler) GetCourseRecommendations(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	studentId, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	professionId, ok := parseQueryId(w, r, "professionId")
+	if !ok {
+		return
+	}
+	limit, ok := parseQueryLimit(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.RecommendCourses(studentId, professionId, limit)
+	writeResponse(w, resp, err)
 }
@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store/memory"
+)
+
+// fixture is the handler over an in-memory store with a course to take and the admin key.
+type fixture struct {
+	h              *Handler
+	store          store.Store
+	organizationId uuid.UUID
+	courseId       uuid.UUID
+	adminKey       string
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	f := fixture{store: memory.New()}
+	f.h = New(app.NewWithStore(f.store), []byte("secret"))
+	organization, err := f.h.App.PostOrganization("УрФУ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, err := f.h.App.PostEducationalProgram("Программная инженерия", "", organization.Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	discipline, err := f.h.App.PostDiscipline("Программирование", "", program.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	course, err := f.h.App.PostCourse("Go", "", nil, discipline.Id, 0, 0, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.organizationId = organization.Id
+	f.courseId = course.Id
+	f.adminKey = f.key(t, app.RoleAdmin, uuid.Nil, uuid.Nil)
+	return f
+}
+
+func (f fixture) key(t *testing.T, role string, studentId uuid.UUID, teacherId uuid.UUID) string {
+	t.Helper()
+	apiKey, err := f.h.App.PostApiKey(role, role, studentId, teacherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return apiKey.Key
+}
+
+func (f fixture) student(t *testing.T, fullName string) uuid.UUID {
+	t.Helper()
+	student, err := f.h.App.PostStudent(fullName, time.Now().AddDate(-1, 0, 0), uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return student.Id
+}
+
+// serve makes the request with the key and returns the response.
+func (f fixture) serve(method string, target string, key string, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		r.Header.Set("X-API-Key", key)
+	}
+	w := httptest.NewRecorder()
+	f.h.Router.ServeHTTP(w, r)
+	return w
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) model.GetProblem {
+	t.Helper()
+	var problem model.GetProblem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("expected problem+json, got %q: %v", w.Body.String(), err)
+	}
+	return problem
+}
+
+func TestUnauthenticated(t *testing.T) {
+	f := newFixture(t)
+	if w := f.serve(http.MethodGet, "/api/v1/course/"+f.courseId.String(), "", ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the key, got %d %s", w.Code, w.Body.String())
+	}
+	if w := f.serve(http.MethodGet, "/api/v1/course/"+f.courseId.String(), f.adminKey, ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with the key, got %d %s", w.Code, w.Body.String())
+	}
+	if w := f.serve(http.MethodGet, "/api/v1/course/"+f.courseId.String()+"?apiKey="+f.adminKey, "", ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for the key in the query of a route other than feeds, got %d %s", w.Code, w.Body.String())
+	}
+	studentId := f.student(t, "Иванов Иван")
+	if w := f.serve(http.MethodGet, "/api/v1/student/"+studentId.String()+"/schedule.ics?apiKey="+f.adminKey, "", ""); w.Code == http.StatusUnauthorized {
+		t.Fatalf("expected the key in the query of the schedule feed to be accepted, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCompetencyLinkLevel(t *testing.T) {
+	f := newFixture(t)
+	competency, err := f.h.App.PostCompetency("SQL", uuid.Nil, nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.h.App.PostCourseCompetency(f.courseId, competency.Id, "advanced"); err != nil {
+		t.Fatal(err)
+	}
+	target := "/api/v1/courseCompetency/" + f.courseId.String() + "/" + competency.Id.String()
+
+	// PATCH keeps the level that is not sent, PUT puts the default one
+	for _, tc := range []struct {
+		method, body, level string
+	}{
+		{http.MethodPatch, `{}`, "advanced"},
+		{http.MethodPatch, `{"competencyLevel":"intermediate"}`, "intermediate"},
+		{http.MethodPut, `{}`, "basic"},
+	} {
+		w := f.serve(tc.method, target, f.adminKey, tc.body)
+		var link model.PostCourseCompetency
+		if err = json.Unmarshal(w.Body.Bytes(), &link); w.Code != http.StatusOK || err != nil || link.Level != tc.level {
+			t.Fatalf("%s %s: expected the %s level, got %d %s", tc.method, tc.body, tc.level, w.Code, w.Body.String())
+		}
+	}
+
+	w := f.serve(http.MethodPut, target, f.adminKey, `{"competencyLevel":"expert"}`)
+	if problem := decodeProblem(t, w); w.Code != http.StatusBadRequest || len(problem.Errors) != 1 || problem.Errors[0].Field != "competencyLevel" {
+		t.Fatalf("expected 400 for the wrong level, got %d %+v", w.Code, problem)
+	}
+	w = f.serve(http.MethodPatch, "/api/v1/competencyProfession/"+competency.Id.String()+"/"+uuid.NewV4().String(), f.adminKey, `{}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a missing link, got %d %s", w.Code, w.Body.String())
+	}
+}
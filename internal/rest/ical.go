@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// baseUrl returns the URL the service was requested with, taking a reverse proxy into account.
+func baseUrl(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+// GetStudentSchedule
+//
+// @Summary      Export student schedule
+// @Description  get the timetable of the student for the current semester as iCalendar (RFC 5545) file with weekly events
+// @Description  bounded by semester dates, holidays are excluded. Calendar applications may subscribe to the URL to refresh it,
+// @Description  they can not send headers and pass the feed token from the subscription links in the feedToken query parameter.
+// @Description  The SOURCE of the calendar is the URL without query parameters, credentials are never written into the file
+// @Tags         student
+// @Produce      text/calendar
+// @Param        id         path      string  true   "Student ID"
+// @Param        feedToken  query     string  false  "Feed token of the student from the subscription links"
+// @Param        apiKey     query     string  false  "API key allowed to read the schedule, for calendar applications that can not send headers"
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/schedule.ics [get]
+func (h *Handler) GetStudentSchedule(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	// the query may carry credentials of the caller
+	resp, err := h.App.GetStudentSchedule(id, baseUrl(r)+r.URL.EscapedPath())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="schedule.ics"`)
+	w.Write(resp)
+}
+
+// GetScheduleSubscription
+//
+// @Summary      Show schedule subscription links
+// @Description  get links to subscribe to the schedule of the student in a calendar application. The links carry the feed token
+// @Description  of the student, it opens the schedule and nothing else. Feeds need the JWT secret of the service
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  model.GetScheduleSubscription
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Failure      503  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/scheduleSubscription [get]
+func (h *Handler) GetScheduleSubscription(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetScheduleSubscription(id, baseUrl(r), h.secret)
+	writeResponse(w, resp, err)
+}
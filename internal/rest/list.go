@@ -0,0 +1,367 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// parseListParams reads limit, offset, sort and order query parameters.
+func parseListParams(r *http.Request) (app.ListParams, error) {
+	var params app.ListParams
+	var err error
+	query := r.URL.Query()
+	if limit := query.Get("limit"); limit != "" {
+		if params.Limit, err = strconv.Atoi(limit); err != nil {
+			return params, badRequest("wrongLimit", "limit", "wrong limit")
+		}
+	}
+	if offset := query.Get("offset"); offset != "" {
+		if params.Offset, err = strconv.Atoi(offset); err != nil {
+			return params, badRequest("wrongOffset", "offset", "wrong offset")
+		}
+	}
+
+	params.Sort = query.Get("sort")
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		params.Desc = true
+	default:
+		return params, badRequest("wrongOrder", "order", "wrong order, expected asc or desc")
+	}
+
+	return params, nil
+}
+
+// parseFilterId reads optional parent id query parameter. Empty parameter means no filter.
+func parseFilterId(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return uuid.Nil, true
+	}
+
+	id, err := uuid.FromString(value)
+	if err != nil {
+		writeError(w, badRequest("wrongId", name, "wrong "+name))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func writeList[T any](w http.ResponseWriter, r *http.Request, list func(params app.ListParams) (model.GetList[T], error)) {
+	params, err := parseListParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := list(params)
+	writeResponse(w, resp, err)
+}
+
+// ListKnowledge
+//
+// @Summary      List knowledge
+// @Description  get page of knowledge
+// @Tags         knowledge
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetKnowledge]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/knowledge/ [get]
+func (h *Handler) ListKnowledge(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListKnowledge)
+}
+
+// ListSkills
+//
+// @Summary      List skills
+// @Description  get page of skills
+// @Tags         skill
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetSkill]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/skill/ [get]
+func (h *Handler) ListSkills(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListSkills)
+}
+
+// ListTechnologies
+//
+// @Summary      List technologies
+// @Description  get page of technologies
+// @Tags         technology
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetTechnology]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/technology/ [get]
+func (h *Handler) ListTechnologies(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListTechnologies)
+}
+
+// ListCompetencies
+//
+// @Summary      List competencies
+// @Description  get page of competencies, optionally filtered by main technology
+// @Tags         competency
+// @Accept       json
+// @Produce      json
+// @Param        mainTechnologyId  query     string  false  "Main technology ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetCompetency]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competency/ [get]
+func (h *Handler) ListCompetencies(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	mainTechnologyId, ok := parseFilterId(w, r, "mainTechnologyId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetCompetency], error) {
+		return h.App.ListCompetencies(params, mainTechnologyId)
+	})
+}
+
+// ListProfessions
+//
+// @Summary      List professions
+// @Description  get page of professions
+// @Tags         profession
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetProfession]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/profession/ [get]
+func (h *Handler) ListProfessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListProfessions)
+}
+
+// ListProjects
+//
+// @Summary      List projects
+// @Description  get page of projects, optionally filtered by main technology
+// @Tags         project
+// @Accept       json
+// @Produce      json
+// @Param        mainTechnologyId  query     string  false  "Main technology ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetProject]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/project/ [get]
+func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	mainTechnologyId, ok := parseFilterId(w, r, "mainTechnologyId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetProject], error) {
+		return h.App.ListProjects(params, mainTechnologyId)
+	})
+}
+
+// ListOrganizations
+//
+// @Summary      List organizations
+// @Description  get page of organizations
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetOrganization]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/organization/ [get]
+func (h *Handler) ListOrganizations(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListOrganizations)
+}
+
+// ListEducationalPrograms
+//
+// @Summary      List educational programs
+// @Description  get page of educational programs, optionally filtered by organization
+// @Tags         educational program
+// @Accept       json
+// @Produce      json
+// @Param        organizationId  query     string  false  "Organization ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetEducationalProgram]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/educationalProgram/ [get]
+func (h *Handler) ListEducationalPrograms(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	organizationId, ok := parseFilterId(w, r, "organizationId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetEducationalProgram], error) {
+		return h.App.ListEducationalPrograms(params, organizationId)
+	})
+}
+
+// ListDisciplines
+//
+// @Summary      List disciplines
+// @Description  get page of disciplines, optionally filtered by educational program
+// @Tags         discipline
+// @Accept       json
+// @Produce      json
+// @Param        educationalProgramId  query     string  false  "Educational program ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetDiscipline]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/discipline/ [get]
+func (h *Handler) ListDisciplines(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	educationalProgramId, ok := parseFilterId(w, r, "educationalProgramId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetDiscipline], error) {
+		return h.App.ListDisciplines(params, educationalProgramId)
+	})
+}
+
+// ListCourses
+//
+// @Summary      List courses
+// @Description  get page of courses, optionally filtered by discipline
+// @Tags         course
+// @Accept       json
+// @Produce      json
+// @Param        disciplineId  query     string  false  "Discipline ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(title, id, teacher)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetCourse]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/course/ [get]
+func (h *Handler) ListCourses(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	disciplineId, ok := parseFilterId(w, r, "disciplineId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetCourse], error) {
+		return h.App.ListCourses(params, disciplineId)
+	})
+}
+
+// ListTeachers
+//
+// @Summary      List teachers
+// @Description  get page of teachers, optionally filtered by organization
+// @Tags         teacher
+// @Accept       json
+// @Produce      json
+// @Param        organizationId  query     string  false  "Organization ID"
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(fullName, id)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetTeacher]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/ [get]
+func (h *Handler) ListTeachers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	organizationId, ok := parseFilterId(w, r, "organizationId")
+	if !ok {
+		return
+	}
+
+	writeList(w, r, func(params app.ListParams) (model.GetList[model.GetTeacher], error) {
+		return h.App.ListTeachers(params, organizationId)
+	})
+}
+
+// ListStudents
+//
+// @Summary      List students
+// @Description  get page of students
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "Page size"  default(20)
+// @Param        offset   query     int     false  "Number of skipped items"  default(0)
+// @Param        sort     query     string  false  "Sort field"  Enums(fullName, id, admition)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  model.GetList[model.GetStudent]
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/ [get]
+func (h *Handler) ListStudents(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeList(w, r, h.App.ListStudents)
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// maxMatrixSize limits the size of the uploaded competency matrix.
+const maxMatrixSize = 10 << 20
+
+// PostCompetencyMatrix
+//
+// @Summary      Import competency matrix
+// @Description  import the analysts' table with profession, competency, skills, knowledge and technology columns from CSV or XLSX.
+// @Description  Entities are found by title or created, with their links, in one transaction. Blank profession and competency cells
+// @Description  repeat the row above, knowledge cells may list several items separated with semicolons, skills cells with commas. An optional level column
+// @Description  gives the level (aware, basic, intermediate or advanced) the profession requires, basic when blank, existing links keep their levels.
+// @Description  A dry run and a file with wrong rows write nothing, the response shows what would be created and errors of the rows
+// @Tags         import
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        file     formData  file  false  "CSV or XLSX file, the request body is read when there is no form"
+// @Param        dryRun   query     bool  false  "Only show what would be created"
+// @Success      200  {object}  model.GetMatrixImport
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competencyMatrix/ [post]
+func (h *Handler) PostCompetencyMatrix(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	defer r.Body.Close()
+	r.Body = http.MaxBytesReader(w, r.Body, maxMatrixSize)
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	var body io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, badRequest("noFile", "file", "no file"))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		writeError(w, badRequest("wrongFile", "file", "wrong file"))
+		return
+	}
+	records, err := app.ReadMatrix(data)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := h.App.ImportMatrix(records, dryRun)
+	if err != nil || len(resp.Errors) == 0 {
+		writeResponse(w, resp, err)
+		return
+	}
+
+	writeProblem(w, problem{http.StatusBadRequest, "wrongMatrix", "file"}, app.ErrWrongMatrix.Error(), func(extended *model.GetProblem) {
+		extended.Import = &resp
+	})
+}
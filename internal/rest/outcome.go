@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostTrajectoryOutcome
+//
+// @Summary      Record course outcome
+// @Description  record the grade, the result and the completion date (today by default) of the course the student took, a previous outcome is replaced.
+// @Description  A grade has to fit the grading scale of the organization of the course and decides whether the course is passed, courses without grades are marked passed or failed. Only passed courses cover competencies and count as taken. The outcome of an attempt stays once the course is retaken
+// @Tags         trajectory
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                       true  "Trajectory ID"
+// @Param        input   body      model.PostTrajectoryOutcome  true  "Outcome of the course"
+// @Success      200  {object}  model.GetTrajectory
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/trajectory/{id}/outcome [post]
+func (h *Handler) PostTrajectoryOutcome(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	req, ok := decodeRequest[model.PostTrajectoryOutcome](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostTrajectoryOutcome(id, req.Grade, req.Result, time.Time(req.CompletionDate))
+	writeResponse(w, resp, err)
+}
+
+// PostTrajectoryRetake
+//
+// @Summary      Retake failed course
+// @Description  add the next attempt of the course whose last attempt the student failed, the attempt is linked to the first one. The retake can not come before the failed attempt and keeps within the maximum semester credits
+// @Tags         trajectory
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                      true  "ID of any attempt of the course"
+// @Param        input   body      model.PostTrajectoryRetake  true  "Semester of the retake"
+// @Success      200  {object}  model.GetTrajectory
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/trajectory/{id}/retake [post]
+func (h *Handler) PostTrajectoryRetake(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	req, ok := decodeRequest[model.PostTrajectoryRetake](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostTrajectoryRetake(id, req.Semester)
+	writeResponse(w, resp, err)
+}
+
+// GetGradingScale
+//
+// @Summary      Show organization grading scale
+// @Description  get grades the organization gives and the lowest passing one, organizations without their own scale grade from 2 to 5 and pass with 3
+// @Tags         trajectory
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  model.GetGradingScale
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/organization/{id}/gradingScale [get]
+func (h *Handler) GetGradingScale(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetGradingScale(id)
+	writeResponse(w, resp, err)
+}
+
+// PostGradingScale
+//
+// @Summary      Set organization grading scale
+// @Description  set grades the organization gives and the lowest passing one, the previous scale is replaced. Outcomes recorded before keep their results
+// @Tags         trajectory
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostGradingScale  true  "Grading scale"
+// @Success      200  {object}  model.GetGradingScale
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/gradingScale/ [post]
+func (h *Handler) PostGradingScale(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostGradingScale](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostGradingScale(req.OrganizationId, req.MinGrade, req.MaxGrade, req.PassGrade)
+	writeResponse(w, resp, err)
+}
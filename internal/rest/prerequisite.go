@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostCoursePrerequisite
+//
+// @Summary      Post course prerequisite
+// @Description  make the course require the prerequisite course, hard prerequisites are mandatory and soft ones are recommended. The kind of the existing prerequisite is updated, prerequisites forming a cycle are rejected
+// @Tags         coursePrerequisite
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostCoursePrerequisite  true  "Course prerequisite data"
+// @Success      200  {object}  model.GetCoursePrerequisite
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/coursePrerequisite/ [post]
+func (h *Handler) PostCoursePrerequisite(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostCoursePrerequisite](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostCoursePrerequisite(req.CourseId, req.PrerequisiteId, req.Kind)
+	writeResponse(w, resp, err)
+}
+
+// GetCoursePrerequisites
+//
+// @Summary      Show course prerequisites
+// @Description  get courses the course requires ordered by title
+// @Tags         coursePrerequisite
+// @Produce      json
+// @Param        id   path      string  true  "Course ID"
+// @Success      200  {array}   model.GetCoursePrerequisite
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/course/{id}/prerequisites [get]
+func (h *Handler) GetCoursePrerequisites(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetCoursePrerequisites(id)
+	writeResponse(w, resp, err)
+}
+
+// DeleteCoursePrerequisite
+//
+// @Summary      Delete course prerequisite
+// @Tags         coursePrerequisite
+// @Param        courseId         path      string  true  "Course ID"
+// @Param        prerequisiteId   path      string  true  "Prerequisite course ID"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/coursePrerequisite/{courseId}/{prerequisiteId} [delete]
+func (h *Handler) DeleteCoursePrerequisite(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	courseId, ok := parseId(w, params, "courseId")
+	if !ok {
+		return
+	}
+	prerequisiteId, ok := parseId(w, params, "prerequisiteId")
+	if !ok {
+		return
+	}
+
+	writeDone(w, h.App.DeleteCoursePrerequisite(courseId, prerequisiteId))
+}
+
+// PostStudentPlanValidation
+//
+// @Summary      Validate student plan
+// @Description  check that past trajectories, current study groups and planned courses of the student come after their prerequisites. Prerequisites that are missing or taken in the same or a later semester are reported, the plan is valid without hard violations
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                    true  "Student ID"
+// @Param        input   body      model.PostPlanValidation  true  "Planned courses"
+// @Success      200  {object}  model.GetPlanValidation
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/planValidation [post]
+func (h *Handler) PostStudentPlanValidation(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+	req, ok := decodeRequest[model.PostPlanValidation](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.ValidateStudentPlan(id, req.Courses)
+	writeResponse(w, resp, err)
+}
@@ -0,0 +1,332 @@
+package rest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/app"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// requestIdHeader carries the id of the request, it is taken from the client or generated and is sent back with every response.
+const requestIdHeader = "X-Request-Id"
+
+// problem is how an error is reported: the status, the machine-readable code and the request field the error is about.
+type problem struct {
+	status int
+	code   string
+	field  string
+}
+
+// appProblems are sentinel errors of the app, they are reported with their message.
+// Wrapping errors go first, so an error wrapped into the bundle is reported as the bundle error.
+var appProblems = []struct {
+	err error
+	problem
+}{
+	{app.ErrWrongBundle, problem{http.StatusBadRequest, "wrongBundle", ""}},
+	{app.ErrWrongMatrix, problem{http.StatusBadRequest, "wrongMatrix", ""}},
+	{app.ErrEmptyTitle, problem{http.StatusBadRequest, "emptyTitle", ""}},
+	{app.ErrEmptyId, problem{http.StatusBadRequest, "emptyId", ""}},
+	{app.ErrWrongSort, problem{http.StatusBadRequest, "wrongSort", "sort"}},
+	{app.ErrShortQuery, problem{http.StatusBadRequest, "shortQuery", "q"}},
+	{app.ErrWrongSemester, problem{http.StatusBadRequest, "wrongSemester", ""}},
+	{app.ErrWrongCredits, problem{http.StatusBadRequest, "wrongCredits", "educationalProgramMinSemesterCredits"}},
+	{app.ErrWrongTerm, problem{http.StatusBadRequest, "wrongTerm", "calendarTerm"}},
+	{app.ErrWrongDates, problem{http.StatusBadRequest, "wrongDates", ""}},
+	{app.ErrWrongPeriodKind, problem{http.StatusBadRequest, "wrongPeriodKind", "periodKind"}},
+	{app.ErrWrongWeekday, problem{http.StatusBadRequest, "wrongWeekday", "timeSlotWeekday"}},
+	{app.ErrWrongTime, problem{http.StatusBadRequest, "wrongTime", ""}},
+	{app.ErrWrongCapacity, problem{http.StatusBadRequest, "wrongCapacity", "roomCapacity"}},
+	{app.ErrWrongSessionKind, problem{http.StatusBadRequest, "wrongSessionKind", "sessionKind"}},
+	{app.ErrWrongWeeklyHours, problem{http.StatusBadRequest, "wrongWeeklyHours", "sessionWeeklyHours"}},
+	{app.ErrWrongChoose, problem{http.StatusBadRequest, "wrongChoose", "poolChoose"}},
+	{app.ErrWrongPrerequisiteKind, problem{http.StatusBadRequest, "wrongPrerequisiteKind", "prerequisiteKind"}},
+	{app.ErrPrerequisiteCycle, problem{http.StatusBadRequest, "prerequisiteCycle", "prerequisiteId"}},
+	{app.ErrTaxonomyCycle, problem{http.StatusBadRequest, "taxonomyCycle", ""}},
+	{app.ErrWrongEnrollmentStatus, problem{http.StatusBadRequest, "wrongEnrollmentStatus", "enrollmentStatus"}},
+	{app.ErrNotEnrolled, problem{http.StatusConflict, "notEnrolled", ""}},
+	{app.ErrEnrollmentTransition, problem{http.StatusConflict, "enrollmentTransition", "enrollmentStatus"}},
+	{app.ErrAlternativeChosen, problem{http.StatusConflict, "alternativeChosen", "courseId"}},
+	{app.ErrEnrollmentClosed, problem{http.StatusConflict, "enrollmentClosed", "courseId"}},
+	{app.ErrAlreadyEnrolled, problem{http.StatusConflict, "alreadyEnrolled", "courseId"}},
+	{app.ErrWrongGrade, problem{http.StatusBadRequest, "wrongGrade", "trajectoryGrade"}},
+	{app.ErrWrongResult, problem{http.StatusBadRequest, "wrongResult", "trajectoryResult"}},
+	{app.ErrWrongGradingScale, problem{http.StatusBadRequest, "wrongGradingScale", "gradingPassGrade"}},
+	{app.ErrNotRetakable, problem{http.StatusConflict, "notRetakable", ""}},
+	{app.ErrCourseTaken, problem{http.StatusConflict, "courseTaken", "trajectoryCourseId"}},
+	{app.ErrOutcomeRecorded, problem{http.StatusConflict, "outcomeRecorded", ""}},
+	{app.ErrAttemptRetaken, problem{http.StatusConflict, "attemptRetaken", ""}},
+	{app.ErrWrongLevel, problem{http.StatusBadRequest, "wrongLevel", "competencyLevel"}},
+	{app.ErrWrongRole, problem{http.StatusBadRequest, "wrongRole", "apiKeyRole"}},
+	{app.ErrUnauthenticated, problem{http.StatusUnauthorized, "unauthenticated", ""}},
+	{app.ErrForbidden, problem{http.StatusForbidden, "forbidden", ""}},
+	{app.ErrNoFeedSecret, problem{http.StatusServiceUnavailable, "feedUnavailable", ""}},
+}
+
+// constraintProblem names what a violated constraint means for the client.
+type constraintProblem struct {
+	problem
+	message string
+}
+
+func missing(entity string, field string) constraintProblem {
+	return constraintProblem{problem{http.StatusBadRequest, entity + "NotFound", field}, entity + " does not exist"}
+}
+
+func duplicate(entity string, field string) constraintProblem {
+	return constraintProblem{problem{http.StatusConflict, entity + "Exists", field}, entity + " already exists"}
+}
+
+func invalid(code string, field string, message string) constraintProblem {
+	return constraintProblem{problem{http.StatusBadRequest, code, field}, message}
+}
+
+// constraintProblems maps Postgres constraints to codes, a foreign key names the referenced entity that was not found.
+var constraintProblems = map[string]constraintProblem{
+	"competencies_main_technology_id_fkey":               missing("technology", "competencyMainTechnology"),
+	"projects_main_technology_id_fkey":                   missing("technology", "projectMainTechnologyId"),
+	"knowledge_competency_knowledge_id_fkey":             missing("knowledge", "knowledgeId"),
+	"knowledge_parent_id_fkey":                           missing("knowledge", "knowledgeParentId"),
+	"competencies_parent_id_fkey":                        missing("competency", "competencyParentId"),
+	"skill_competency_skill_id_fkey":                     missing("skill", "skillId"),
+	"skill_competency_competency_id_fkey":                missing("competency", "competencyId"),
+	"knowledge_competency_competency_id_fkey":            missing("competency", "competencyId"),
+	"competency_profession_competency_id_fkey":           missing("competency", "competencyId"),
+	"competency_profession_profession_id_fkey":           missing("profession", "professionId"),
+	"educational_programs_organizations_id_fkey":         missing("organization", "educationalProgramOrganizationId"),
+	"disciplines_educational_program_id_fkey":            missing("educationalProgram", "disciplineEducationalProgramId"),
+	"courses_discipline_id_fkey":                         missing("discipline", "courseDisciplineId"),
+	"course_competency_course_id_fkey":                   missing("course", "courseId"),
+	"course_competency_competency_id_fkey":               missing("competency", "competencyId"),
+	"course_prerequisite_course_id_fkey":                 missing("course", "prerequisiteCourseId"),
+	"course_prerequisite_prerequisite_id_fkey":           missing("course", "prerequisiteId"),
+	"project_portfolio_project_id_fkey":                  missing("project", "ProjectId"),
+	"project_portfolio_portfolio_id_fkey":                missing("portfolio", "PortfolioId"),
+	"project_portfolio_competency_competency_id_fkey":    missing("competency", "CompetencyId"),
+	"project_portfolio_competency_project_id_fkey":       missing("project", "projectId"),
+	"project_portfolio_competency_portfolio_id_fkey":     missing("portfolio", "PortfolioId"),
+	"students_portfolio_id_fkey":                         missing("portfolio", "studentPortfolioId"),
+	"study_groups_course_id_fkey":                        missing("course", "courseId"),
+	"study_groups_student_id_fkey":                       missing("student", "studentId"),
+	"course_waitlists_course_id_fkey":                    missing("course", "courseId"),
+	"course_waitlists_student_id_fkey":                   missing("student", "studentId"),
+	"study_group_drops_course_id_fkey":                   missing("course", "courseId"),
+	"study_group_drops_student_id_fkey":                  missing("student", "studentId"),
+	"trajectories_student_id_fkey":                       missing("student", "trajectoryStudentId"),
+	"trajectories_course_id_fkey":                        missing("course", "trajectoryCourseId"),
+	"calendar_semesters_organization_id_fkey":            missing("organization", "calendarOrganizationId"),
+	"calendar_periods_organization_id_fkey":              missing("organization", "periodOrganizationId"),
+	"grading_scales_organization_id_fkey":                missing("organization", "gradingOrganizationId"),
+	"course_sessions_course_id_fkey":                     missing("course", "sessionCourseId"),
+	"teacher_availability_time_slot_id_fkey":             missing("timeSlot", "availabilityTimeSlotId"),
+	"teacher_availability_teacher_id_fkey":               missing("teacher", "availabilityTeacherId"),
+	"teachers_organization_id_fkey":                      missing("organization", "teacherOrganizationId"),
+	"course_teacher_course_id_fkey":                      missing("course", "courseId"),
+	"course_teacher_teacher_id_fkey":                     missing("teacher", "courseTeacherIds"),
+	"api_keys_teacher_id_fkey":                           missing("teacher", "apiKeyTeacherId"),
+	"api_keys_student_id_fkey":                           missing("student", "apiKeyStudentId"),
+	"enrollments_student_id_fkey":                        missing("student", "enrollmentStudentId"),
+	"enrollments_educational_program_id_fkey":            missing("educationalProgram", "enrollmentEducationalProgramId"),
+	"academic_leaves_enrollment_id_fkey":                 missing("enrollment", ""),
+	"elective_pools_educational_program_id_fkey":         missing("educationalProgram", "poolEducationalProgramId"),
+	"curriculum_disciplines_educational_program_id_fkey": missing("educationalProgram", "curriculumEducationalProgramId"),
+	"curriculum_disciplines_discipline_id_fkey":          missing("discipline", "curriculumDisciplineId"),
+	"curriculum_disciplines_elective_pool_id_fkey":       missing("electivePool", "curriculumElectivePoolId"),
+
+	"knowledge_title_key":                duplicate("knowledge", "knowledgeTitle"),
+	"technologies_title_key":             duplicate("technology", "technologyTitle"),
+	"competencies_title_key":             duplicate("competency", "competencyTitle"),
+	"professions_title_key":              duplicate("profession", "professionTitle"),
+	"projects_title_key":                 duplicate("project", "projectTitle"),
+	"organizations_title_key":            duplicate("organization", "organizationTitle"),
+	"educational_programs_title_key":     duplicate("educationalProgram", "educationalProgramTitle"),
+	"disciplines_title_key":              duplicate("discipline", "disciplineTitle"),
+	"courses_title_key":                  duplicate("course", "courseTitle"),
+	"teachers_full_name_key":             duplicate("teacher", "teacherFullName"),
+	"rooms_title_key":                    duplicate("room", "roomTitle"),
+	"time_slots_weekday_start_time_key":  duplicate("timeSlot", "timeSlotStart"),
+	"course_sessions_course_id_kind_key": duplicate("courseSession", "sessionKind"),
+	"knowledge_competency_pkey":          duplicate("knowledgeCompetency", ""),
+	"competency_profession_pkey":         duplicate("competencyProfession", ""),
+	"course_competency_pkey":             duplicate("courseCompetency", ""),
+	"project_portfolio_pkey":             duplicate("projectPortfolio", ""),
+	"project_portfolio_competency_pkey":  duplicate("projectPortfolioCompetency", ""),
+	"study_groups_pkey":                  duplicate("studyGroup", ""),
+	"course_waitlists_pkey":              duplicate("studyGroup", ""),
+	"teacher_availability_pkey":          duplicate("teacherAvailability", ""),
+	"enrollments_student_id_key":         duplicate("enrollment", "enrollmentStudentId"),
+	"trajectories_retake_of_attempt_key": duplicate("trajectoryAttempt", ""),
+
+	"trajectories_semester_check":                 invalid("wrongSemester", "trajectorySemester", app.ErrWrongSemester.Error()),
+	"project_portfolio_semester_check":            invalid("wrongSemester", "projectSemester", app.ErrWrongSemester.Error()),
+	"educational_programs_semester_credits_check": invalid("wrongCredits", "educationalProgramMinSemesterCredits", app.ErrWrongCredits.Error()),
+	"course_prerequisite_self_check":              invalid("prerequisiteCycle", "prerequisiteId", "a course can not be its own prerequisite"),
+	"competencies_parent_check":                   invalid("taxonomyCycle", "competencyParentId", "a competency can not be its own parent"),
+	"knowledge_parent_check":                      invalid("taxonomyCycle", "knowledgeParentId", "knowledge can not be its own parent"),
+	"api_keys_api_key_role_check":                 invalid("wrongRole", "apiKeyRole", app.ErrWrongRole.Error()),
+	"elective_pools_choose_check":                 invalid("wrongChoose", "poolChoose", app.ErrWrongChoose.Error()),
+	"curriculum_disciplines_semester_check":       invalid("wrongSemester", "curriculumSemester", "semester must be from 1 to 12"),
+	"enrollments_status_check":                    invalid("wrongEnrollmentStatus", "enrollmentStatus", app.ErrWrongEnrollmentStatus.Error()),
+	"enrollments_dates_check":                     invalid("wrongDates", "enrollmentStatusDate", app.ErrWrongDates.Error()),
+	"enrollments_end_check":                       invalid("wrongEnrollmentStatus", "enrollmentStatus", "only closed enrollments have the end date"),
+	"academic_leaves_dates_check":                 invalid("wrongDates", "enrollmentStatusDate", app.ErrWrongDates.Error()),
+	"calendar_semesters_enrollment_check":         invalid("wrongDates", "calendarEnrollmentEndDate", "enrollment window must have both dates and end by the end of the semester"),
+	"grading_scales_grades_check":                 invalid("wrongGradingScale", "gradingPassGrade", app.ErrWrongGradingScale.Error()),
+	"grading_scales_min_grade_check":              invalid("wrongGradingScale", "gradingMinGrade", app.ErrWrongGradingScale.Error()),
+	"competency_profession_level_check":           invalid("wrongLevel", "competencyLevel", app.ErrWrongLevel.Error()),
+	"course_competency_level_check":               invalid("wrongLevel", "competencyLevel", app.ErrWrongLevel.Error()),
+	"project_portfolio_competency_level_check":    invalid("wrongLevel", "competencyLevel", app.ErrWrongLevel.Error()),
+	"api_keys_subject_check":                      invalid("wrongRole", "apiKeyRole", "the student id is given for the student role only, the teacher id for the teacher role, both are required there"),
+}
+
+// requestError is a wrong request found before the app is called: a malformed id, parameter or body.
+type requestError struct {
+	problem
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func badRequest(code string, field string, message string) error {
+	return &requestError{problem{http.StatusBadRequest, code, field}, message}
+}
+
+// writeProblem writes the error body as application/problem+json, extend adds the details of the error.
+func writeProblem(w http.ResponseWriter, p problem, message string, extend func(resp *model.GetProblem)) {
+	resp := model.GetProblem{
+		Type:      "about:blank",
+		Title:     http.StatusText(p.status),
+		Status:    p.status,
+		Code:      p.code,
+		Message:   message,
+		Field:     p.field,
+		RequestId: w.Header().Get(requestIdHeader),
+	}
+	if extend != nil {
+		extend(&resp)
+	}
+
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		slog.Error("error converting problem to JSON format "+err.Error(), "requestId", resp.RequestId)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if p.status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.status)
+	w.Write(respJSON)
+}
+
+// writeError is the only place errors are turned into responses. Errors the client can not fix are logged and hidden.
+func writeError(w http.ResponseWriter, err error) {
+	var reqErr *requestError
+	var validationErr *validationError
+	var workloadErr *app.WorkloadError
+	var dependentsErr *app.DependentsError
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &reqErr):
+		writeProblem(w, reqErr.problem, reqErr.message, nil)
+		return
+	case errors.As(err, &validationErr):
+		writeProblem(w, problem{http.StatusBadRequest, "validationFailed", validationErr.errors[0].Field}, err.Error(), func(resp *model.GetProblem) {
+			resp.Errors = validationErr.errors
+		})
+		return
+	case errors.As(err, &workloadErr):
+		writeProblem(w, problem{http.StatusBadRequest, "workloadExceeded", ""}, err.Error(), func(resp *model.GetProblem) {
+			resp.Workload = &workloadErr.Violation
+		})
+		return
+	case errors.As(err, &dependentsErr):
+		writeProblem(w, problem{http.StatusConflict, "hasDependents", "cascade"}, err.Error(), func(resp *model.GetProblem) {
+			resp.Dependents = dependentsErr.Dependents
+		})
+		return
+	case errors.Is(err, sql.ErrNoRows):
+		writeProblem(w, problem{http.StatusNotFound, "notFound", ""}, "entity not found", nil)
+		return
+	case errors.As(err, &pqErr):
+		writeConstraintError(w, pqErr)
+		return
+	}
+
+	for _, appProblem := range appProblems {
+		if errors.Is(err, appProblem.err) {
+			writeProblem(w, appProblem.problem, err.Error(), nil)
+			return
+		}
+	}
+
+	slog.Error("unknown error "+err.Error(), "requestId", w.Header().Get(requestIdHeader))
+	writeProblem(w, problem{http.StatusInternalServerError, "internal", ""}, "internal error", nil)
+}
+
+// writeConstraintError reports violations of constraints, the ones missing from constraintProblems are reported by their class.
+func writeConstraintError(w http.ResponseWriter, pqErr *pq.Error) {
+	if constraint, ok := constraintProblems[pqErr.Constraint]; ok {
+		writeProblem(w, constraint.problem, constraint.message, nil)
+		return
+	}
+
+	switch pqErr.Code {
+	case store.CodeForeignKeyViolation:
+		writeProblem(w, problem{http.StatusBadRequest, "referenceNotFound", ""}, "referenced entity does not exist", nil)
+	case store.CodeUniqueViolation:
+		writeProblem(w, problem{http.StatusConflict, "alreadyExists", ""}, "entity already exists", nil)
+	case store.CodeCheckViolation:
+		slog.Warn("unmapped check constraint "+pqErr.Constraint, "requestId", w.Header().Get(requestIdHeader))
+		writeProblem(w, problem{http.StatusBadRequest, "invalidValue", ""}, "value breaks the "+pqErr.Constraint+" check", nil)
+	default:
+		slog.Error("database error "+pqErr.Error(), "requestId", w.Header().Get(requestIdHeader))
+		writeProblem(w, problem{http.StatusInternalServerError, "internal", ""}, "internal error", nil)
+	}
+}
+
+// writeResponse writes the response of the app as JSON or the error.
+func writeResponse(w http.ResponseWriter, resp any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("content-Type", "application/json")
+	w.Write(respJSON)
+}
+
+// writeDone answers requests without a response body.
+func writeDone(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ServeHTTP gives every request an id and routes it. The id of the client is kept when it is a UUID, so logs can be matched.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestId, err := uuid.FromString(r.Header.Get(requestIdHeader))
+	if err != nil {
+		requestId = uuid.NewV4()
+	}
+	w.Header().Set(requestIdHeader, requestId.String())
+	h.Router.ServeHTTP(w, r)
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// GetSearch
+//
+// @Summary      Search the catalog
+// @Description  find knowledge, technologies, competencies, professions, projects, organizations, educational programs,
+// @Description  disciplines and courses by words of their titles, descriptions and skills in Russian. Words are found
+// @Description  in any form and titles despite typos. Hits are grouped by kind of entity, groups go in order of their best hit,
+// @Description  matched words of the snippet are wrapped in <b></b>
+// @Tags         search
+// @Accept       json
+// @Produce      json
+// @Param        q       query     string  true   "Search query"  minlength(2)
+// @Param        limit   query     int     false  "Maximum number of hits of every kind"  default(10)  maximum(50)
+// @Success      200  {object}  model.GetSearch
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/search/ [get]
+func (h *Handler) GetSearch(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	limit, ok := parseQueryLimit(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.Search(r.URL.Query().Get("q"), limit)
+	writeResponse(w, resp, err)
+}
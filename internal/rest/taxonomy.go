@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// GetSkill
+//
+// @Summary      Show skill
+// @Description  get single skill by ID
+// @Tags         skill
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Skill ID"
+// @Success      200  {object}  model.GetSkill
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/skill/{id} [get]
+func (h *Handler) GetSkill(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetSkillById(id)
+	writeResponse(w, resp, err)
+}
+
+// GetCompetencyTree
+//
+// @Summary      Show competency tree
+// @Description  get the competency with its child competencies ordered by title. Counts of every node are aggregated
+// @Description  over its subtree, a skill, knowledge or course shared by several competencies is counted once
+// @Tags         competency
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Competency ID"
+// @Success      200  {object}  model.GetCompetencyTree
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competency/{id}/tree [get]
+func (h *Handler) GetCompetencyTree(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetCompetencyTree(id)
+	writeResponse(w, resp, err)
+}
+
+// GetKnowledgeTree
+//
+// @Summary      Show knowledge tree
+// @Description  get the knowledge with its child knowledge ordered by title. Counts of every node are aggregated
+// @Description  over its subtree, a competency made of several knowledge of the subtree is counted once
+// @Tags         knowledge
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Knowledge ID"
+// @Success      200  {object}  model.GetKnowledgeTree
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/knowledge/{id}/tree [get]
+func (h *Handler) GetKnowledgeTree(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetKnowledgeTree(id)
+	writeResponse(w, resp, err)
+}
@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostTeacher
+//
+// @Summary      Post teacher
+// @Description  post single teacher of the organization with contacts, the teacher with the same full name is updated. Admins only
+// @Tags         teacher
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostTeacher  true  "Teacher data"
+// @Success      200  {object}  model.GetTeacher
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/ [post]
+func (h *Handler) PostTeacher(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostTeacher](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostTeacher(req.FullName, req.OrganizationId, req.Email, req.Phone)
+	writeResponse(w, resp, err)
+}
+
+// GetTeacher
+//
+// @Summary      Show teacher
+// @Description  get single teacher by ID with the time slots the teacher is available in
+// @Tags         teacher
+// @Produce      json
+// @Param        id   path      string  true  "Teacher ID"
+// @Success      200  {object}  model.GetTeacher
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id} [get]
+func (h *Handler) GetTeacher(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetTeacherById(id)
+	writeResponse(w, resp, err)
+}
+
+// GetTeacherCourses
+//
+// @Summary      Show teacher courses
+// @Description  get courses of the teacher ordered by title with weekly hours and students of the current semester. Available to analysts and the teacher
+// @Tags         teacher
+// @Produce      json
+// @Param        id   path      string  true  "Teacher ID"
+// @Success      200  {object}  model.GetTeacherCourses
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id}/courses [get]
+func (h *Handler) GetTeacherCourses(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetTeacherCourses(id)
+	writeResponse(w, resp, err)
+}
+
+// GetTeacherStudyGroups
+//
+// @Summary      Show teacher study groups
+// @Description  get students of the current semester studying courses of the teacher, grouped by course. Available to analysts and the teacher
+// @Tags         teacher
+// @Produce      json
+// @Param        id   path      string  true  "Teacher ID"
+// @Success      200  {object}  model.GetTeacherStudyGroups
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id}/studyGroups [get]
+func (h *Handler) GetTeacherStudyGroups(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetTeacherStudyGroups(id)
+	writeResponse(w, resp, err)
+}
+
+// GetTeacherHours
+//
+// @Summary      Show teacher hours
+// @Description  get total academic hours of the teacher courses and weekly hours of the courses studied in the current semester. Available to analysts and the teacher
+// @Tags         teacher
+// @Produce      json
+// @Param        id   path      string  true  "Teacher ID"
+// @Success      200  {object}  model.GetTeacherHours
+// @Failure      400  {object}  model.GetProblem
+// @Failure      401  {object}  model.GetProblem
+// @Failure      403  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id}/hours [get]
+func (h *Handler) GetTeacherHours(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetTeacherHours(id)
+	writeResponse(w, resp, err)
+}
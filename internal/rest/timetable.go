@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// PostTimeSlot
+//
+// @Summary      Post time slot
+// @Description  post weekly time slot, weekday 1 is Monday. The slot starting at the same time of the weekday is updated
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostTimeSlot  true  "Time slot data"
+// @Success      200  {object}  model.GetTimeSlot
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/timeSlot/ [post]
+func (h *Handler) PostTimeSlot(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostTimeSlot](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostTimeSlot(req.Weekday, req.Start, req.End)
+	writeResponse(w, resp, err)
+}
+
+// PostRoom
+//
+// @Summary      Post room
+// @Description  post single room, capacity of the existing room with the title is updated
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostRoom  true  "Room data"
+// @Success      200  {object}  model.GetRoom
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/room/ [post]
+func (h *Handler) PostRoom(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostRoom](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostRoom(req.Title, req.Capacity)
+	writeResponse(w, resp, err)
+}
+
+// PostCourseSession
+//
+// @Summary      Post course session
+// @Description  post lecture, practice or lab of the course with weekly hours, hours of the existing session of the kind are updated
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostCourseSession  true  "Course session data"
+// @Success      200  {object}  model.GetCourseSession
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/courseSession/ [post]
+func (h *Handler) PostCourseSession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostCourseSession](w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.PostCourseSession(req.CourseId, req.Kind, req.WeeklyHours)
+	writeResponse(w, resp, err)
+}
+
+// PostTeacherAvailability
+//
+// @Summary      Post teacher availability
+// @Description  post time slot the teacher can work in, teachers without available slots can work any time
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Param        input   body      model.PostTeacherAvailability  true  "Teacher availability data"
+// @Success      200
+// @Failure      400  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacherAvailability/ [post]
+func (h *Handler) PostTeacherAvailability(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	req, ok := decodeRequest[model.PostTeacherAvailability](w, r)
+	if !ok {
+		return
+	}
+
+	err := h.App.PostTeacherAvailability(req.TeacherId, req.TimeSlotId)
+	writeDone(w, err)
+}
+
+// GetTimetable
+//
+// @Summary      Show timetable
+// @Description  get the saved weekly timetable
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  model.GetTimetable
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/timetable/ [get]
+func (h *Handler) GetTimetable(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	resp, err := h.App.GetTimetable()
+	writeResponse(w, resp, err)
+}
+
+// PostTimetable
+//
+// @Summary      Build timetable
+// @Description  place sessions of the courses students study in into time slots and rooms without conflicts and save the timetable.
+// @Description  Sessions that could not be placed are listed in problems with the reason.
+// @Tags         timetable
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  model.GetTimetable
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/timetable/ [post]
+func (h *Handler) PostTimetable(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	resp, err := h.App.BuildTimetable()
+	writeResponse(w, resp, err)
+}
+
+// GetStudentTimetable
+//
+// @Summary      Show student timetable
+// @Description  get the part of the saved timetable with courses the student studies in the current semester
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  model.GetTimetable
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id}/timetable [get]
+func (h *Handler) GetStudentTimetable(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.GetStudentTimetable(id)
+	writeResponse(w, resp, err)
+}
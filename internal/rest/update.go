@@ -0,0 +1,651 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+func parseId(w http.ResponseWriter, params httprouter.Params, name string) (uuid.UUID, bool) {
+	id, err := uuid.FromString(params.ByName(name))
+	if err != nil {
+		writeError(w, badRequest("wrongId", name, "wrong "+name))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// parseQueryId reads required id query parameter.
+func parseQueryId(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.FromString(r.URL.Query().Get(name))
+	if err != nil {
+		writeError(w, badRequest("wrongId", name, "wrong "+name))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// parseQueryLimit reads the optional limit query parameter, 0 when it is omitted.
+func parseQueryLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return 0, true
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		writeError(w, badRequest("wrongLimit", "limit", "wrong limit"))
+		return 0, false
+	}
+
+	return limit, true
+}
+
+// decodeUpdate decodes request body. PUT replaces the whole entity, so the body is decoded into the empty request,
+// PATCH body is decoded on top of the current state returned by load.
+func decodeUpdate[T any](w http.ResponseWriter, r *http.Request, load func() (T, error)) (T, bool) {
+	defer r.Body.Close()
+
+	var req T
+	if r.Method == http.MethodPatch {
+		var err error
+		if req, err = load(); err != nil {
+			writeError(w, err)
+			return req, false
+		}
+	}
+
+	return req, decodeInto(w, r, &req)
+}
+
+// decodeRequest decodes body of a creating request.
+func decodeRequest[T any](w http.ResponseWriter, r *http.Request) (T, bool) {
+	defer r.Body.Close()
+
+	var req T
+	return req, decodeInto(w, r, &req)
+}
+
+// decodeInto reports the field of the wrong type and unknown fields of the body, then validates the request.
+func decodeInto(w http.ResponseWriter, r *http.Request, req any) bool {
+	var unmarshalErr *json.UnmarshalTypeError
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(req)
+	if err == nil {
+		err = validate(req)
+	}
+	var validationErr *validationError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &validationErr), errors.Is(err, errWrongRule):
+		writeError(w, err)
+	case errors.As(err, &unmarshalErr):
+		writeError(w, badRequest("wrongType", unmarshalErr.Field, "wrong type provided for field "+unmarshalErr.Field))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		writeError(w, badRequest("unknownField", field, "unknown field "+field))
+	default:
+		writeError(w, badRequest("malformedBody", "", "malformed body: "+err.Error()))
+	}
+	return false
+}
+
+// PutKnowledge
+//
+// @Summary      Update knowledge
+// @Description  replace (PUT) or partially update (PATCH) single knowledge
+// @Tags         knowledge
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string               true  "Knowledge ID"
+// @Param        input   body      model.PostKnowledge  true  "Knowledge request"
+// @Success      200  {object}  model.GetKnowledge
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/knowledge/{id} [put]
+// @Router       /api/v1/knowledge/{id} [patch]
+func (h *Handler) PutKnowledge(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostKnowledge, error) { return h.App.GetKnowledgeForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateKnowledge(id, req.Title, req.ParentId)
+	writeResponse(w, resp, err)
+}
+
+// PutTechnology
+//
+// @Summary      Update technology
+// @Description  replace (PUT) or partially update (PATCH) single technology
+// @Tags         technology
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                true  "Technology ID"
+// @Param        input   body      model.PostTechnology  true  "Technology request"
+// @Success      200  {object}  model.GetTechnology
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/technology/{id} [put]
+// @Router       /api/v1/technology/{id} [patch]
+func (h *Handler) PutTechnology(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostTechnology, error) { return h.App.GetTechnologyForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateTechnology(id, req.Title)
+	writeResponse(w, resp, err)
+}
+
+// PutCompetency
+//
+// @Summary      Update competency
+// @Description  replace (PUT) or partially update (PATCH) single competency
+// @Tags         competency
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                true  "Competency ID"
+// @Param        input   body      model.PostCompetency  true  "Competency request"
+// @Success      200  {object}  model.GetCompetency
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competency/{id} [put]
+// @Router       /api/v1/competency/{id} [patch]
+func (h *Handler) PutCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostCompetency, error) { return h.App.GetCompetencyForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateCompetency(id, req.Title, req.ParentId, req.Skills, req.MainTechnologyId)
+	writeResponse(w, resp, err)
+}
+
+// PutProfession
+//
+// @Summary      Update profession
+// @Description  replace (PUT) or partially update (PATCH) single profession
+// @Tags         profession
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                true  "Profession ID"
+// @Param        input   body      model.PostProfession  true  "Profession request"
+// @Success      200  {object}  model.GetProfession
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/profession/{id} [put]
+// @Router       /api/v1/profession/{id} [patch]
+func (h *Handler) PutProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostProfession, error) { return h.App.GetProfessionForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateProfession(id, req.Title, req.Description)
+	writeResponse(w, resp, err)
+}
+
+// PutProject
+//
+// @Summary      Update project
+// @Description  replace (PUT) or partially update (PATCH) single project
+// @Tags         project
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string             true  "Project ID"
+// @Param        input   body      model.PostProject  true  "Project request"
+// @Success      200  {object}  model.GetProject
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/project/{id} [put]
+// @Router       /api/v1/project/{id} [patch]
+func (h *Handler) PutProject(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostProject, error) { return h.App.GetProjectForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateProject(id, req.Title, req.Description, req.Result, req.LifeScenario, req.MainTechnologyId)
+	writeResponse(w, resp, err)
+}
+
+// PutOrganization
+//
+// @Summary      Update organization
+// @Description  replace (PUT) or partially update (PATCH) single organization
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                  true  "Organization ID"
+// @Param        input   body      model.PostOrganization  true  "Organization request"
+// @Success      200  {object}  model.GetOrganization
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/organization/{id} [put]
+// @Router       /api/v1/organization/{id} [patch]
+func (h *Handler) PutOrganization(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostOrganization, error) { return h.App.GetOrganizationForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateOrganization(id, req.Title)
+	writeResponse(w, resp, err)
+}
+
+// PutEducationalProgram
+//
+// @Summary      Update educational program
+// @Description  replace (PUT) or partially update (PATCH) single educational program
+// @Tags         educational program
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                        true  "Educational program ID"
+// @Param        input   body      model.PostEducationalProgram  true  "Educational program data"
+// @Success      200  {object}  model.GetEducationalProgram
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/educationalProgram/{id} [put]
+// @Router       /api/v1/educationalProgram/{id} [patch]
+func (h *Handler) PutEducationalProgram(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostEducationalProgram, error) { return h.App.GetEducationalProgramForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateEducationalProgram(id, req.Title, req.Description, req.OrganizationId, req.MinCredits, req.MaxCredits)
+	writeResponse(w, resp, err)
+}
+
+// PutDiscipline
+//
+// @Summary      Update discipline
+// @Description  replace (PUT) or partially update (PATCH) single discipline
+// @Tags         discipline
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                true  "Discipline ID"
+// @Param        input   body      model.PostDiscipline  true  "Discipline data"
+// @Success      200  {object}  model.GetDiscipline
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/discipline/{id} [put]
+// @Router       /api/v1/discipline/{id} [patch]
+func (h *Handler) PutDiscipline(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostDiscipline, error) { return h.App.GetDisciplineForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateDiscipline(id, req.Title, req.Description, req.EducationalProgramId)
+	writeResponse(w, resp, err)
+}
+
+// PutCourse
+//
+// @Summary      Update course
+// @Description  replace (PUT) or partially update (PATCH) single course
+// @Tags         course
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string            true  "Course ID"
+// @Param        input   body      model.PostCourse  true  "Course data"
+// @Success      200  {object}  model.GetCourse
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/course/{id} [put]
+// @Router       /api/v1/course/{id} [patch]
+func (h *Handler) PutCourse(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostCourse, error) { return h.App.GetCourseForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateCourse(id, req.Title, req.Description, req.TeacherIds, req.DisciplineId, req.Credits, req.Hours, req.Alternative,
+		req.Capacity)
+	writeResponse(w, resp, err)
+}
+
+// PutStudent
+//
+// @Summary      Update student
+// @Description  replace (PUT) or partially update (PATCH) single student
+// @Tags         student
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string             true  "Student ID"
+// @Param        input   body      model.PostStudent  true  "Student`s data"
+// @Success      200  {object}  model.GetStudent
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/student/{id} [put]
+// @Router       /api/v1/student/{id} [patch]
+func (h *Handler) PutStudent(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostStudent, error) { return h.App.GetStudentForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateStudent(id, req.FullName, time.Time(req.Admition), req.PortfolioId)
+	writeResponse(w, resp, err)
+}
+
+// PutTeacher
+//
+// @Summary      Update teacher
+// @Description  replace (PUT) or partially update (PATCH) single teacher. Admins only
+// @Tags         teacher
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string             true  "Teacher ID"
+// @Param        input   body      model.PostTeacher  true  "Teacher data"
+// @Success      200  {object}  model.GetTeacher
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/teacher/{id} [put]
+// @Router       /api/v1/teacher/{id} [patch]
+func (h *Handler) PutTeacher(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostTeacher, error) { return h.App.GetTeacherForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateTeacher(id, req.FullName, req.OrganizationId, req.Email, req.Phone)
+	writeResponse(w, resp, err)
+}
+
+// PutTrajectory
+//
+// @Summary      Update student`s archive course
+// @Description  replace (PUT) or partially update (PATCH) single student`s archive course. The student and the course
+// @Description  can not be changed once the outcome is recorded or the course is retaken, nor to a course the student already took
+// @Tags         trajectory
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                true  "Trajectory ID"
+// @Param        input   body      model.PostTrajectory  true  "Trajectory`s data"
+// @Success      200  {object}  model.GetTrajectory
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      409  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/trajectory/{id} [put]
+// @Router       /api/v1/trajectory/{id} [patch]
+func (h *Handler) PutTrajectory(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id, ok := parseId(w, params, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PostTrajectory, error) { return h.App.GetTrajectoryForUpdate(id) })
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateTrajectory(id, req.Semester, req.StudentId, req.CourseId)
+	writeResponse(w, resp, err)
+}
+
+// PutProjectPortfolio
+//
+// @Summary      Update project-portfolio connection
+// @Description  replace (PUT) or partially update (PATCH) student`s role and semester in the project
+// @Tags         projectPortfolio
+// @Accept       json
+// @Produce      json
+// @Param        projectId     path      string                     true  "Project ID"
+// @Param        portfolioId   path      string                     true  "Portfolio ID"
+// @Param        input         body      model.PutProjectPortfolio  true  "Personal project data"
+// @Success      200  {object}  model.PostProjectPortfolio
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/projectPortfolio/{projectId}/{portfolioId} [put]
+// @Router       /api/v1/projectPortfolio/{projectId}/{portfolioId} [patch]
+func (h *Handler) PutProjectPortfolio(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	projectId, ok := parseId(w, params, "projectId")
+	if !ok {
+		return
+	}
+	portfolioId, ok := parseId(w, params, "portfolioId")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PutProjectPortfolio, error) {
+		return h.App.GetProjectPortfolioForUpdate(projectId, portfolioId)
+	})
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateProjectPortfolio(projectId, portfolioId, req.TeamRole, req.Semester)
+	writeResponse(w, resp, err)
+}
+
+// PutCompetencyProfession
+//
+// @Summary      Update competency-profession connection
+// @Description  replace (PUT) or partially update (PATCH) the level the profession requires the competency at, basic when omitted on PUT
+// @Tags         competencyProfession
+// @Accept       json
+// @Produce      json
+// @Param        competencyId  path      string                    true  "Competency ID"
+// @Param        professionId  path      string                    true  "Profession ID"
+// @Param        input         body      model.PutCompetencyLevel  true  "Required level"
+// @Success      200  {object}  model.PostCompetencyProfession
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/competencyProfession/{competencyId}/{professionId} [put]
+// @Router       /api/v1/competencyProfession/{competencyId}/{professionId} [patch]
+func (h *Handler) PutCompetencyProfession(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+	professionId, ok := parseId(w, params, "professionId")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PutCompetencyLevel, error) {
+		return h.App.GetCompetencyProfessionForUpdate(competencyId, professionId)
+	})
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateCompetencyProfession(competencyId, professionId, req.Level)
+	writeResponse(w, resp, err)
+}
+
+// PutCourseCompetency
+//
+// @Summary      Update course-competency connection
+// @Description  replace (PUT) or partially update (PATCH) the level the course gives the competency at, basic when omitted on PUT
+// @Tags         courseCompetency
+// @Accept       json
+// @Produce      json
+// @Param        courseId      path      string                    true  "Course ID"
+// @Param        competencyId  path      string                    true  "Competency ID"
+// @Param        input         body      model.PutCompetencyLevel  true  "Given level"
+// @Success      200  {object}  model.PostCourseCompetency
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/courseCompetency/{courseId}/{competencyId} [put]
+// @Router       /api/v1/courseCompetency/{courseId}/{competencyId} [patch]
+func (h *Handler) PutCourseCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	courseId, ok := parseId(w, params, "courseId")
+	if !ok {
+		return
+	}
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PutCompetencyLevel, error) {
+		return h.App.GetCourseCompetencyForUpdate(courseId, competencyId)
+	})
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateCourseCompetency(courseId, competencyId, req.Level)
+	writeResponse(w, resp, err)
+}
+
+// PutProjectPortfolioCompetency
+//
+// @Summary      Update personal project competency
+// @Description  replace (PUT) or partially update (PATCH) the level the project of the portfolio confirms the competency at, basic when omitted on PUT
+// @Tags         projectPortfolioCompetency
+// @Accept       json
+// @Produce      json
+// @Param        projectId     path      string                    true  "Project ID"
+// @Param        portfolioId   path      string                    true  "Portfolio ID"
+// @Param        competencyId  path      string                    true  "Competency ID"
+// @Param        input         body      model.PutCompetencyLevel  true  "Confirmed level"
+// @Success      200  {object}  model.PostProjectPortfolioCompetency
+// @Failure      400  {object}  model.GetProblem
+// @Failure      404  {object}  model.GetProblem
+// @Failure      500  {object}  model.GetProblem
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api/v1/projectPortfolioCompetency/{projectId}/{portfolioId}/{competencyId} [put]
+// @Router       /api/v1/projectPortfolioCompetency/{projectId}/{portfolioId}/{competencyId} [patch]
+func (h *Handler) PutProjectPortfolioCompetency(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	projectId, ok := parseId(w, params, "projectId")
+	if !ok {
+		return
+	}
+	portfolioId, ok := parseId(w, params, "portfolioId")
+	if !ok {
+		return
+	}
+	competencyId, ok := parseId(w, params, "competencyId")
+	if !ok {
+		return
+	}
+
+	req, ok := decodeUpdate(w, r, func() (model.PutCompetencyLevel, error) {
+		return h.App.GetProjectPortfolioCompetencyForUpdate(projectId, portfolioId, competencyId)
+	})
+	if !ok {
+		return
+	}
+
+	resp, err := h.App.UpdateProjectPortfolioCompetency(projectId, portfolioId, competencyId, req.Level)
+	writeResponse(w, resp, err)
+}
@@ -0,0 +1,243 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// validationError lists every wrong field of the request.
+type validationError struct {
+	errors []model.GetFieldError
+}
+
+func (e *validationError) Error() string {
+	fields := make([]string, 0, len(e.errors))
+	for _, fieldErr := range e.errors {
+		fields = append(fields, fieldErr.Field+" "+fieldErr.Message)
+	}
+	return "wrong request: " + strings.Join(fields, "; ")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// errWrongRule means the validate tag of the model can not be applied, it is a mistake of the model and not of the request.
+var errWrongRule = errors.New("wrong validation rule")
+
+// rule is a rule of the validate tag, bound is the parsed parameter of min and max.
+type rule struct {
+	name  string
+	param string
+	bound float64
+}
+
+// validate checks the decoded request by the validate tags of its fields, the same tags describe the fields in Swagger.
+// The rules are required, min and max (the length of strings, the value of numbers), oneof (space-separated values)
+// notfuture for dates and email for addresses. Zero values are checked by required only, so optional fields may be left out.
+// Nested structs and slices of structs are checked too, their fields are named by the path, e.g. items[2].title.
+func validate(req any) error {
+	var errs []model.GetFieldError
+	if err := validateValue(reflect.Indirect(reflect.ValueOf(req)), "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return &validationError{errors: errs}
+	}
+	return nil
+}
+
+func validateValue(value reflect.Value, path string, errs *[]model.GetFieldError) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		typ := value.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := jsonName(field)
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			if tag := field.Tag.Get("validate"); tag != "" {
+				rules, err := parseRules(field, tag)
+				if err != nil {
+					return fmt.Errorf("%w of %s.%s: %w", errWrongRule, typ.Name(), field.Name, err)
+				}
+				if fieldErr, ok := checkRules(value.Field(i), rules); !ok {
+					fieldErr.Field = name
+					*errs = append(*errs, fieldErr)
+				}
+			}
+			if err := validateValue(value.Field(i), name, errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := validateValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkTags checks the validate tags of the type and of the types nested in it without a value, so the mistakes in them
+// are found before a request runs into them.
+func checkTags(typ reflect.Type) error {
+	switch typ.Kind() {
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() || jsonName(field) == "-" {
+				continue
+			}
+			if tag := field.Tag.Get("validate"); tag != "" {
+				if _, err := parseRules(field, tag); err != nil {
+					return fmt.Errorf("%w of %s.%s: %w", errWrongRule, typ.Name(), field.Name, err)
+				}
+			}
+			if err := checkTags(field.Type); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Struct {
+			return checkTags(typ.Elem())
+		}
+	}
+	return nil
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// parseRules reads the rules of the tag and checks that each of them is known and applies to the type of the field.
+func parseRules(field reflect.StructField, tag string) ([]rule, error) {
+	var rules []rule
+	for _, text := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(text, "=")
+		r := rule{name: name, param: param}
+		switch name {
+		case "required":
+		case "min", "max":
+			bound, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return nil, fmt.Errorf("wrong bound %q of %s", param, name)
+			}
+			if !isNumber(field.Type) && field.Type.Kind() != reflect.String {
+				return nil, fmt.Errorf("%s is not supported for %s", name, field.Type)
+			}
+			r.bound = bound
+		case "oneof":
+			if len(strings.Fields(param)) == 0 {
+				return nil, errors.New("oneof has no values")
+			}
+		case "notfuture":
+			if !field.Type.ConvertibleTo(timeType) {
+				return nil, fmt.Errorf("notfuture is not supported for %s", field.Type)
+			}
+		case "email":
+			if field.Type.Kind() != reflect.String {
+				return nil, fmt.Errorf("email is not supported for %s", field.Type)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", text)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func isNumber(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// checkRules reports the first broken rule of the value, the rules are already checked to apply to it by parseRules.
+func checkRules(value reflect.Value, rules []rule) (model.GetFieldError, bool) {
+	zero := value.IsZero() || value.Kind() == reflect.String && strings.TrimSpace(value.String()) == ""
+	for _, r := range rules {
+		if r.name == "required" {
+			if zero {
+				return model.GetFieldError{Code: "required", Message: "is required"}, false
+			}
+			continue
+		}
+		if zero {
+			continue
+		}
+
+		switch r.name {
+		case "min", "max":
+			if fieldErr, ok := checkBound(value, r); !ok {
+				return fieldErr, false
+			}
+		case "oneof":
+			options := strings.Fields(r.param)
+			if !slices.Contains(options, fmt.Sprint(value.Interface())) {
+				return model.GetFieldError{Code: "notOneOf", Message: "must be one of " + strings.Join(options, ", ")}, false
+			}
+		case "notfuture":
+			if value.Convert(timeType).Interface().(time.Time).After(time.Now()) {
+				return model.GetFieldError{Code: "inFuture", Message: "must not be in the future"}, false
+			}
+		case "email":
+			if address, err := mail.ParseAddress(value.String()); err != nil || address.Address != value.String() {
+				return model.GetFieldError{Code: "wrongEmail", Message: "must be an email address"}, false
+			}
+		}
+	}
+	return model.GetFieldError{}, true
+}
+
+// checkBound compares the length of strings and the value of numbers with the bound.
+func checkBound(value reflect.Value, r rule) (model.GetFieldError, bool) {
+	var actual float64
+	unit := ""
+	switch value.Kind() {
+	case reflect.String:
+		actual, unit = float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	}
+
+	switch {
+	case r.name == "min" && actual < r.bound && unit != "":
+		return model.GetFieldError{Code: "tooShort", Message: fmt.Sprintf("must be at least %s%s", r.param, unit)}, false
+	case r.name == "min" && actual < r.bound:
+		return model.GetFieldError{Code: "tooSmall", Message: "must be at least " + r.param}, false
+	case r.name == "max" && actual > r.bound && unit != "":
+		return model.GetFieldError{Code: "tooLong", Message: fmt.Sprintf("must be at most %s%s", r.param, unit)}, false
+	case r.name == "max" && actual > r.bound:
+		return model.GetFieldError{Code: "tooLarge", Message: "must be at most " + r.param}, false
+	}
+	return model.GetFieldError{}, true
+}
@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/model"
+)
+
+// modelRequests are the models decoded from request bodies, TestModelTags makes sure none of the tagged ones is missing.
+var modelRequests = []any{
+	model.PostApiKey{}, model.PostCalendarPeriod{}, model.PostCalendarSemester{}, model.PostCompetency{},
+	model.PostCompetencyProfession{}, model.PostCourse{}, model.PostCourseCompetency{}, model.PostCoursePrerequisite{},
+	model.PostCourseSession{}, model.PostCurriculumDiscipline{}, model.PostDiscipline{}, model.PostEducationalProgram{},
+	model.PostElectivePool{}, model.PostEnrollment{}, model.PostEnrollmentStatus{}, model.PostGradingScale{},
+	model.PostKnowledge{}, model.PostKnowledgeCompetency{}, model.PostOrganization{}, model.PostPlannedCourse{},
+	model.PostProfession{}, model.PostProject{}, model.PostProjectPortfolio{}, model.PostProjectPortfolioCompetency{},
+	model.PostRoom{}, model.PostStudent{}, model.PostStudyGroup{}, model.PostTeacher{}, model.PostTeacherAvailability{},
+	model.PostTechnology{}, model.PostTimeSlot{}, model.PostTrajectory{}, model.PostTrajectoryOutcome{},
+	model.PostTrajectoryRetake{}, model.PutCompetencyLevel{}, model.PutProjectPortfolio{},
+}
+
+func TestModelTags(t *testing.T) {
+	checked := map[string]bool{}
+	for _, req := range modelRequests {
+		typ := reflect.TypeOf(req)
+		checked[typ.Name()] = true
+		if err := checkTags(typ); err != nil {
+			t.Error(err)
+		}
+	}
+
+	files, err := parser.ParseDir(token.NewFileSet(), "../model", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files["model"].Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if fields, ok := spec.Type.(*ast.StructType); ok && !checked[spec.Name.Name] {
+				for _, field := range fields.Fields.List {
+					if field.Tag != nil && strings.Contains(field.Tag.Value, `validate:"`) {
+						t.Errorf("%s has validate tags, add it to modelRequests", spec.Name.Name)
+						break
+					}
+				}
+			}
+			return false
+		})
+	}
+}
+
+func TestWrongRule(t *testing.T) {
+	tests := map[string]any{
+		"unknown rule": struct {
+			A string `validate:"requried"`
+		}{"a"},
+		"wrong bound": struct {
+			A string `validate:"max=ten"`
+		}{"a"},
+		"bound of bool": struct {
+			A bool `validate:"min=1"`
+		}{true},
+		"notfuture of text": struct {
+			A string `validate:"notfuture"`
+		}{"a"},
+		"email of number": struct {
+			A int `validate:"email"`
+		}{1},
+		"oneof of nothing": struct {
+			A string `validate:"oneof="`
+		}{"a"},
+		"nested rule": struct {
+			B []struct {
+				A string `validate:"maximum=1"`
+			}
+		}{},
+	}
+	for name, req := range tests {
+		if err := checkTags(reflect.TypeOf(req)); !errors.Is(err, errWrongRule) {
+			t.Errorf("%s: expected errWrongRule from checkTags, got %v", name, err)
+		}
+		if name == "nested rule" {
+			continue // there is no value to run into the rule
+		}
+		if err := validate(req); !errors.Is(err, errWrongRule) {
+			t.Errorf("%s: expected errWrongRule from validate, got %v", name, err)
+		}
+	}
+}
+
+func TestValidationProblem(t *testing.T) {
+	f := newFixture(t)
+	organizationId := f.organizationId.String()
+	tests := map[string]struct {
+		target string
+		body   string
+		want   []model.GetFieldError
+	}{
+		"required": {
+			"/api/v1/calendarSemester/",
+			`{"calendarYear": 2023, "calendarTerm": 1, "calendarStartDate": "2023-09-01", "calendarEndDate": "2024-01-31"}`,
+			[]model.GetFieldError{{Field: "calendarOrganizationId", Code: "required", Message: "is required"}},
+		},
+		"blank required": {
+			"/api/v1/teacher/",
+			fmt.Sprintf(`{"teacherFullName": "  ", "teacherOrganizationId": %q}`, organizationId),
+			[]model.GetFieldError{{Field: "teacherFullName", Code: "required", Message: "is required"}},
+		},
+		"min": {
+			"/api/v1/calendarSemester/",
+			fmt.Sprintf(`{"calendarOrganizationId": %q, "calendarYear": 1800, "calendarTerm": 1, "calendarStartDate": "2023-09-01", "calendarEndDate": "2024-01-31"}`, organizationId),
+			[]model.GetFieldError{{Field: "calendarYear", Code: "tooSmall", Message: "must be at least 1900"}},
+		},
+		"max": {
+			"/api/v1/teacher/",
+			fmt.Sprintf(`{"teacherFullName": %q, "teacherOrganizationId": %q}`, strings.Repeat("я", 256), organizationId),
+			[]model.GetFieldError{{Field: "teacherFullName", Code: "tooLong", Message: "must be at most 255 characters"}},
+		},
+		"oneof": {
+			"/api/v1/calendarSemester/",
+			fmt.Sprintf(`{"calendarOrganizationId": %q, "calendarYear": 2023, "calendarTerm": 3, "calendarStartDate": "2023-09-01", "calendarEndDate": "2024-01-31"}`, organizationId),
+			[]model.GetFieldError{{Field: "calendarTerm", Code: "notOneOf", Message: "must be one of 1, 2"}},
+		},
+		"notfuture": {
+			"/api/v1/student/",
+			fmt.Sprintf(`{"studentFullName": "Иванов Иван Иванович", "studentAdmitionDate": %q}`, time.Now().AddDate(1, 0, 0).Format(time.DateOnly)),
+			[]model.GetFieldError{{Field: "studentAdmitionDate", Code: "inFuture", Message: "must not be in the future"}},
+		},
+		"email": {
+			"/api/v1/teacher/",
+			fmt.Sprintf(`{"teacherFullName": "Петров Пётр Петрович", "teacherOrganizationId": %q, "teacherEmail": "Петров <teacher@urfu.ru>"}`, organizationId),
+			[]model.GetFieldError{{Field: "teacherEmail", Code: "wrongEmail", Message: "must be an email address"}},
+		},
+		"every wrong field": {
+			"/api/v1/calendarSemester/",
+			`{"calendarYear": 2200, "calendarTerm": 3, "calendarStartDate": "2023-09-01", "calendarEndDate": "2024-01-31"}`,
+			[]model.GetFieldError{
+				{Field: "calendarOrganizationId", Code: "required", Message: "is required"},
+				{Field: "calendarYear", Code: "tooLarge", Message: "must be at most 2100"},
+				{Field: "calendarTerm", Code: "notOneOf", Message: "must be one of 1, 2"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		w := f.serve(http.MethodPost, tt.target, f.adminKey, tt.body)
+		if w.Code != http.StatusBadRequest || w.Header().Get("Content-Type") != "application/problem+json" {
+			t.Errorf("%s: expected 400 problem+json, got %d %s %s", name, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+			continue
+		}
+		problem := decodeProblem(t, w)
+		if problem.Code != "validationFailed" || problem.Field != tt.want[0].Field || !reflect.DeepEqual(problem.Errors, tt.want) {
+			t.Errorf("%s: expected the errors %+v, got %+v", name, tt.want, problem)
+		}
+	}
+
+	body := fmt.Sprintf(`{"teacherFullName": "Петров Пётр Петрович", "teacherOrganizationId": %q, "teacherEmail": "teacher@urfu.ru"}`, organizationId)
+	if w := f.serve(http.MethodPost, "/api/v1/teacher/", f.adminKey, body); w.Code != http.StatusOK {
+		t.Fatalf("expected the valid teacher to be created, got %d %s", w.Code, w.Body.String())
+	}
+}
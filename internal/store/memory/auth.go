@@ -0,0 +1,60 @@
+package memory
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetApiKey(id uuid.UUID) (store.ApiKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	apiKey, ok := s.apiKeys[id]
+	if !ok {
+		return store.ApiKey{}, store.ErrNotFound
+	}
+	return apiKey, nil
+}
+
+func (s *Store) GetApiKeyByHash(hash string) (store.ApiKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, apiKey := range s.apiKeys {
+		if apiKey.Hash == hash {
+			return apiKey, nil
+		}
+	}
+	return store.ApiKey{}, store.ErrNotFound
+}
+
+func (s *Store) CreateApiKey(apiKey store.ApiKey) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch apiKey.Role {
+	case store.RoleAdmin, store.RoleAnalyst, store.RoleTeacher, store.RoleStudent:
+	default:
+		return uuid.Nil, checkViolation("api_keys", "api_key_role")
+	}
+	if (apiKey.Role == store.RoleStudent) != (apiKey.StudentId != uuid.Nil) ||
+		(apiKey.Role == store.RoleTeacher) != (apiKey.TeacherId != uuid.Nil) {
+		return uuid.Nil, checkViolation("api_keys", "subject")
+	}
+	if _, ok := s.students[apiKey.StudentId]; apiKey.StudentId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("api_keys", "student_id")
+	}
+	if _, ok := s.teachers[apiKey.TeacherId]; apiKey.TeacherId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("api_keys", "teacher_id")
+	}
+	for _, existing := range s.apiKeys {
+		if existing.Hash == apiKey.Hash {
+			return uuid.Nil, uniqueColumnViolation("api_keys", "api_key_hash")
+		}
+	}
+
+	apiKey.Id = uuid.NewV4()
+	s.apiKeys[apiKey.Id] = apiKey
+	return apiKey.Id, nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetCalendarSemesters(organizationId uuid.UUID) ([]store.CalendarSemester, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var semesters []store.CalendarSemester
+	for key, semester := range s.calendarSemesters {
+		if key.organizationId == organizationId {
+			semesters = append(semesters, semester)
+		}
+	}
+
+	sort.Slice(semesters, func(i, j int) bool { return semesters[i].Start.Before(semesters[j].Start) })
+	return semesters, nil
+}
+
+func (s *Store) CreateCalendarSemester(semester store.CalendarSemester) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	semester.Start, semester.End = date(semester.Start), date(semester.End)
+	semester.EnrollmentStart, semester.EnrollmentEnd = date(semester.EnrollmentStart), date(semester.EnrollmentEnd)
+	// Postgres checks CHECK constraints in alphabetical order of their names
+	if semester.Start.After(semester.End) {
+		return checkViolation("calendar_semesters", "dates")
+	}
+	if semester.EnrollmentStart.IsZero() != semester.EnrollmentEnd.IsZero() || !semester.EnrollmentStart.IsZero() &&
+		(semester.EnrollmentStart.After(semester.EnrollmentEnd) || semester.EnrollmentEnd.After(semester.End)) {
+		return checkViolation("calendar_semesters", "enrollment")
+	}
+	if semester.Term != store.TermAutumn && semester.Term != store.TermSpring {
+		return checkViolation("calendar_semesters", "term")
+	}
+	if _, ok := s.organizations[semester.OrganizationId]; !ok {
+		return foreignKeyViolation("calendar_semesters", "organization_id")
+	}
+
+	s.calendarSemesters[calendarKey{semester.OrganizationId, semester.Year, semester.Term}] = semester
+	return nil
+}
+
+func (s *Store) GetCalendarPeriods(organizationId uuid.UUID) ([]store.CalendarPeriod, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var periods []store.CalendarPeriod
+	for _, period := range s.calendarPeriods {
+		if period.OrganizationId == organizationId {
+			periods = append(periods, period)
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		if !periods[i].Start.Equal(periods[j].Start) {
+			return periods[i].Start.Before(periods[j].Start)
+		}
+		return periods[i].Id.String() < periods[j].Id.String()
+	})
+	return periods, nil
+}
+
+func (s *Store) CreateCalendarPeriod(period store.CalendarPeriod) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	period.Start, period.End = date(period.Start), date(period.End)
+	if period.Start.After(period.End) {
+		return uuid.Nil, checkViolation("calendar_periods", "dates")
+	}
+	if period.Kind != store.PeriodHoliday && period.Kind != store.PeriodSession {
+		return uuid.Nil, checkViolation("calendar_periods", "kind")
+	}
+	if _, ok := s.organizations[period.OrganizationId]; !ok {
+		return uuid.Nil, foreignKeyViolation("calendar_periods", "organization_id")
+	}
+
+	period.Id = uuid.NewV4()
+	s.calendarPeriods[period.Id] = period
+	return period.Id, nil
+}
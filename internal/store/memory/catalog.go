@@ -0,0 +1,980 @@
+package memory
+
+import (
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetKnowledge(id uuid.UUID) (store.Knowledge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	knowledge, ok := s.knowledge[id]
+	if !ok {
+		return knowledge, store.ErrNotFound
+	}
+	return knowledge, nil
+}
+
+func (s *Store) GetKnowledgeByTitle(title string) (store.Knowledge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.titleId("knowledge", title)
+	if !ok {
+		return store.Knowledge{}, store.ErrNotFound
+	}
+	return s.knowledge[id], nil
+}
+
+func (s *Store) CreateKnowledge(title string) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("knowledge", title); ok {
+		return id, nil
+	}
+
+	id := s.addTitle("knowledge", title)
+	s.knowledge[id] = store.Knowledge{Id: id, Title: title}
+	return id, nil
+}
+
+func (s *Store) GetKnowledgeByCompetency(competencyId uuid.UUID) ([]store.Knowledge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var knowledge []store.Knowledge
+	for link := range s.knowledgeCompetency {
+		if link[1] == competencyId {
+			knowledge = append(knowledge, s.knowledge[link[0]])
+		}
+	}
+
+	sort.Slice(knowledge, func(i, j int) bool { return knowledge[i].Title < knowledge[j].Title })
+	return knowledge, nil
+}
+
+func (s *Store) CreateKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.knowledge[knowledgeId]; !ok {
+		return foreignKeyViolation("knowledge_competency", "knowledge_id")
+	}
+	if _, ok := s.competencies[competencyId]; !ok {
+		return foreignKeyViolation("knowledge_competency", "competency_id")
+	}
+	link := link2{knowledgeId, competencyId}
+	if s.knowledgeCompetency[link] {
+		return uniqueViolation("knowledge_competency")
+	}
+
+	s.knowledgeCompetency[link] = true
+	return nil
+}
+
+func (s *Store) UpdateKnowledgeParent(id uuid.UUID, parentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	knowledge, ok := s.knowledge[id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if parentId == id {
+		return checkViolation("knowledge", "parent")
+	}
+	if _, ok := s.knowledge[parentId]; parentId != uuid.Nil && !ok {
+		return foreignKeyViolation("knowledge", "parent_id")
+	}
+
+	knowledge.ParentId = parentId
+	s.knowledge[id] = knowledge
+	return nil
+}
+
+func (s *Store) GetTechnology(id uuid.UUID) (store.Technology, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	technology, ok := s.technologies[id]
+	if !ok {
+		return technology, store.ErrNotFound
+	}
+	return technology, nil
+}
+
+func (s *Store) GetTechnologyByTitle(title string) (store.Technology, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.titleId("technologies", title)
+	if !ok {
+		return store.Technology{}, store.ErrNotFound
+	}
+	return s.technologies[id], nil
+}
+
+func (s *Store) CreateTechnology(title string) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("technologies", title); ok {
+		return id, nil
+	}
+
+	id := s.addTitle("technologies", title)
+	s.technologies[id] = store.Technology{Id: id, Title: title}
+	return id, nil
+}
+
+func (s *Store) GetCompetency(id uuid.UUID) (store.Competency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competency, ok := s.competencies[id]
+	if !ok {
+		return competency, store.ErrNotFound
+	}
+	return competency, nil
+}
+
+func (s *Store) GetCompetencyByTitle(title string) (store.Competency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.titleId("competencies", title)
+	if !ok {
+		return store.Competency{}, store.ErrNotFound
+	}
+	return s.competencies[id], nil
+}
+
+func (s *Store) CreateCompetency(competency store.Competency) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("competencies", competency.Title); ok {
+		return id, nil
+	}
+	if _, ok := s.technologies[competency.MainTechnologyId]; competency.MainTechnologyId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("competencies", "main_technology_id")
+	}
+	if _, ok := s.competencies[competency.ParentId]; competency.ParentId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("competencies", "parent_id")
+	}
+
+	competency.Id = s.addTitle("competencies", competency.Title)
+	s.competencies[competency.Id] = competency
+	return competency.Id, nil
+}
+
+func (s *Store) SaveCompetency(competency store.Competency) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, exists := s.titleId("competencies", competency.Title)
+	if exists && competency.ParentId == id {
+		return uuid.Nil, checkViolation("competencies", "parent")
+	}
+	if _, ok := s.technologies[competency.MainTechnologyId]; competency.MainTechnologyId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("competencies", "main_technology_id")
+	}
+	if _, ok := s.competencies[competency.ParentId]; competency.ParentId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("competencies", "parent_id")
+	}
+	if !exists {
+		id = s.addTitle("competencies", competency.Title)
+	}
+
+	competency.Id = id
+	s.competencies[id] = competency
+	return id, nil
+}
+
+func (s *Store) GetCompetenciesByProfession(professionId uuid.UUID) ([]store.Competency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var competencies []store.Competency
+	for link, level := range s.competencyProfession {
+		if link[1] == professionId {
+			competency := s.competencies[link[0]]
+			competency.Level = level
+			competencies = append(competencies, competency)
+		}
+	}
+
+	sortCompetencies(competencies)
+	return competencies, nil
+}
+
+func (s *Store) GetCompetenciesByCourse(courseId uuid.UUID) ([]store.Competency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var competencies []store.Competency
+	for link, level := range s.courseCompetency {
+		if link[0] == courseId {
+			competency := s.competencies[link[1]]
+			competency.Level = level
+			competencies = append(competencies, competency)
+		}
+	}
+
+	sortCompetencies(competencies)
+	return competencies, nil
+}
+
+func (s *Store) GetCompetenciesByPersonalProject(portfolioId uuid.UUID, projectId uuid.UUID) ([]store.Competency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var competencies []store.Competency
+	for link, level := range s.projectPortfolioCompetency {
+		if link[0] == projectId && link[1] == portfolioId {
+			competency := s.competencies[link[2]]
+			competency.Level = level
+			competencies = append(competencies, competency)
+		}
+	}
+
+	sortCompetencies(competencies)
+	return competencies, nil
+}
+
+func (s *Store) GetProfession(id uuid.UUID) (store.Profession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	profession, ok := s.professions[id]
+	if !ok {
+		return profession, store.ErrNotFound
+	}
+	return profession, nil
+}
+
+func (s *Store) GetProfessionByTitle(title string) (store.Profession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.titleId("professions", title)
+	if !ok {
+		return store.Profession{}, store.ErrNotFound
+	}
+	return s.professions[id], nil
+}
+
+func (s *Store) CreateProfession(profession store.Profession) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("professions", profession.Title); ok {
+		return id, nil
+	}
+
+	profession.Id = s.addTitle("professions", profession.Title)
+	s.professions[profession.Id] = profession
+	return profession.Id, nil
+}
+
+func (s *Store) SaveProfession(profession store.Profession) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.titleId("professions", profession.Title)
+	if !ok {
+		id = s.addTitle("professions", profession.Title)
+	}
+
+	profession.Id = id
+	s.professions[id] = profession
+	return id, nil
+}
+
+func (s *Store) CreateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("competency_profession", "level")
+	}
+	if _, ok := s.competencies[competencyId]; !ok {
+		return foreignKeyViolation("competency_profession", "competency_id")
+	}
+	if _, ok := s.professions[professionId]; !ok {
+		return foreignKeyViolation("competency_profession", "profession_id")
+	}
+	link := link2{competencyId, professionId}
+	if _, ok := s.competencyProfession[link]; ok {
+		return uniqueViolation("competency_profession")
+	}
+
+	s.competencyProfession[link] = level
+	return nil
+}
+
+func (s *Store) UpdateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("competency_profession", "level")
+	}
+	link := link2{competencyId, professionId}
+	if _, ok := s.competencyProfession[link]; !ok {
+		return store.ErrNotFound
+	}
+
+	s.competencyProfession[link] = level
+	return nil
+}
+
+// validLevel mirrors the level check of competency link tables.
+func validLevel(level uint8) bool {
+	return level >= store.LevelAware && level <= store.LevelAdvanced
+}
+
+func (s *Store) GetProject(id uuid.UUID) (store.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return project, store.ErrNotFound
+	}
+	return project, nil
+}
+
+func (s *Store) CreateProject(project store.Project) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("projects", project.Title); ok {
+		return id, nil
+	}
+	if _, ok := s.technologies[project.MainTechnologyId]; project.MainTechnologyId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("projects", "main_technology_id")
+	}
+
+	project.Id = s.addTitle("projects", project.Title)
+	s.projects[project.Id] = project
+	return project.Id, nil
+}
+
+func (s *Store) GetOrganization(id uuid.UUID) (store.Organization, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organization, ok := s.organizations[id]
+	if !ok {
+		return organization, store.ErrNotFound
+	}
+	return organization, nil
+}
+
+func (s *Store) CreateOrganization(title string) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("organizations", title); ok {
+		return id, nil
+	}
+
+	id := s.addTitle("organizations", title)
+	s.organizations[id] = store.Organization{Id: id, Title: title}
+	return id, nil
+}
+
+func (s *Store) GetGradingScale(organizationId uuid.UUID) (store.GradingScale, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scale, ok := s.gradingScales[organizationId]
+	if !ok {
+		return scale, store.ErrNotFound
+	}
+	return scale, nil
+}
+
+func (s *Store) SaveGradingScale(scale store.GradingScale) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Postgres checks CHECK constraints in alphabetical order of their names
+	if scale.MinGrade > scale.PassGrade || scale.PassGrade > scale.MaxGrade {
+		return checkViolation("grading_scales", "grades")
+	}
+	if scale.MinGrade == 0 {
+		return checkViolation("grading_scales", "min_grade")
+	}
+	if _, ok := s.organizations[scale.OrganizationId]; !ok {
+		return foreignKeyViolation("grading_scales", "organization_id")
+	}
+
+	s.gradingScales[scale.OrganizationId] = scale
+	return nil
+}
+
+func (s *Store) GetEducationalProgram(id uuid.UUID) (store.EducationalProgram, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	educationalProgram, ok := s.educationalPrograms[id]
+	if !ok {
+		return educationalProgram, store.ErrNotFound
+	}
+	return educationalProgram, nil
+}
+
+func (s *Store) CreateEducationalProgram(educationalProgram store.EducationalProgram) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validSemesterCredits(educationalProgram) {
+		return uuid.Nil, checkViolation("educational_programs", "semester_credits")
+	}
+	if id, ok := s.titleId("educational_programs", educationalProgram.Title); ok {
+		return id, nil
+	}
+	if _, ok := s.organizations[educationalProgram.OrganizationId]; !ok {
+		return uuid.Nil, foreignKeyViolation("educational_programs", "organizations_id")
+	}
+
+	educationalProgram.Id = s.addTitle("educational_programs", educationalProgram.Title)
+	s.educationalPrograms[educationalProgram.Id] = educationalProgram
+	return educationalProgram.Id, nil
+}
+
+func (s *Store) SaveEducationalProgram(educationalProgram store.EducationalProgram) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validSemesterCredits(educationalProgram) {
+		return uuid.Nil, checkViolation("educational_programs", "semester_credits")
+	}
+	if _, ok := s.organizations[educationalProgram.OrganizationId]; !ok {
+		return uuid.Nil, foreignKeyViolation("educational_programs", "organizations_id")
+	}
+	id, ok := s.titleId("educational_programs", educationalProgram.Title)
+	if !ok {
+		id = s.addTitle("educational_programs", educationalProgram.Title)
+	}
+
+	educationalProgram.Id = id
+	s.educationalPrograms[id] = educationalProgram
+	return id, nil
+}
+
+// validSemesterCredits mirrors educational_programs_semester_credits_check.
+func validSemesterCredits(educationalProgram store.EducationalProgram) bool {
+	return educationalProgram.MaxSemesterCredits == 0 || educationalProgram.MinSemesterCredits <= educationalProgram.MaxSemesterCredits
+}
+
+func (s *Store) GetDiscipline(id uuid.UUID) (store.Discipline, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	discipline, ok := s.disciplines[id]
+	if !ok {
+		return discipline, store.ErrNotFound
+	}
+	return discipline, nil
+}
+
+func (s *Store) CreateDiscipline(discipline store.Discipline) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("disciplines", discipline.Title); ok {
+		return id, nil
+	}
+	if _, ok := s.educationalPrograms[discipline.EducationalProgramId]; !ok {
+		return uuid.Nil, foreignKeyViolation("disciplines", "educational_program_id")
+	}
+
+	discipline.Id = s.addTitle("disciplines", discipline.Title)
+	s.disciplines[discipline.Id] = discipline
+	return discipline.Id, nil
+}
+
+func (s *Store) SaveDiscipline(discipline store.Discipline) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.educationalPrograms[discipline.EducationalProgramId]; !ok {
+		return uuid.Nil, foreignKeyViolation("disciplines", "educational_program_id")
+	}
+	id, ok := s.titleId("disciplines", discipline.Title)
+	if !ok {
+		id = s.addTitle("disciplines", discipline.Title)
+	}
+
+	discipline.Id = id
+	s.disciplines[id] = discipline
+	return id, nil
+}
+
+func (s *Store) GetCourse(id uuid.UUID) (store.Course, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	course, ok := s.courses[id]
+	if !ok {
+		return course, store.ErrNotFound
+	}
+	return course, nil
+}
+
+func (s *Store) CreateCourse(course store.Course) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("courses", course.Title); ok {
+		return id, nil
+	}
+	if _, ok := s.disciplines[course.DisciplineId]; !ok {
+		return uuid.Nil, foreignKeyViolation("courses", "discipline_id")
+	}
+
+	course.Id = s.addTitle("courses", course.Title)
+	s.courses[course.Id] = course
+	return course.Id, nil
+}
+
+func (s *Store) SaveCourse(course store.Course) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.disciplines[course.DisciplineId]; !ok {
+		return uuid.Nil, foreignKeyViolation("courses", "discipline_id")
+	}
+	id, ok := s.titleId("courses", course.Title)
+	if !ok {
+		id = s.addTitle("courses", course.Title)
+	}
+
+	course.Id, course.Capacity = id, s.courses[id].Capacity
+	s.courses[id] = course
+	return id, nil
+}
+
+func (s *Store) GetCoursesByDiscipline(disciplineId uuid.UUID) ([]store.Course, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var courses []store.Course
+	for _, course := range s.courses {
+		if course.DisciplineId == disciplineId {
+			courses = append(courses, course)
+		}
+	}
+	sortCourses(courses)
+	return courses, nil
+}
+
+func (s *Store) CreateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("course_competency", "level")
+	}
+	if _, ok := s.courses[courseId]; !ok {
+		return foreignKeyViolation("course_competency", "course_id")
+	}
+	if _, ok := s.competencies[competencyId]; !ok {
+		return foreignKeyViolation("course_competency", "competency_id")
+	}
+	link := link2{courseId, competencyId}
+	if _, ok := s.courseCompetency[link]; ok {
+		return uniqueViolation("course_competency")
+	}
+
+	s.courseCompetency[link] = level
+	return nil
+}
+
+func (s *Store) UpdateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("course_competency", "level")
+	}
+	link := link2{courseId, competencyId}
+	if _, ok := s.courseCompetency[link]; !ok {
+		return store.ErrNotFound
+	}
+
+	s.courseCompetency[link] = level
+	return nil
+}
+
+func (s *Store) GetCoursesByCompetencies(competencyIds []uuid.UUID) ([]store.CourseCompetency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(competencyIds))
+	for _, id := range competencyIds {
+		wanted[id] = true
+	}
+
+	var courses []store.CourseCompetency
+	for link, level := range s.courseCompetency {
+		if wanted[link[1]] {
+			course := s.courses[link[0]]
+			courses = append(courses, store.CourseCompetency{CourseId: course.Id, CourseTitle: course.Title, CourseCredits: course.Credits,
+				CourseDisciplineId: course.DisciplineId, CourseAlternative: course.Alternative, CompetencyId: link[1], Level: level})
+		}
+	}
+
+	sort.Slice(courses, func(i, j int) bool {
+		if courses[i].CourseTitle != courses[j].CourseTitle {
+			return courses[i].CourseTitle < courses[j].CourseTitle
+		}
+		return courses[i].CompetencyId.String() < courses[j].CompetencyId.String()
+	})
+	return courses, nil
+}
+
+func (s *Store) GetCoursePrerequisites() ([]store.CoursePrerequisite, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prerequisites := make([]store.CoursePrerequisite, 0, len(s.coursePrerequisite))
+	for link, kind := range s.coursePrerequisite {
+		prerequisites = append(prerequisites, store.CoursePrerequisite{CourseId: link[0], PrerequisiteId: link[1], Kind: kind})
+	}
+
+	sort.Slice(prerequisites, func(i, j int) bool {
+		if prerequisites[i].CourseId != prerequisites[j].CourseId {
+			return prerequisites[i].CourseId.String() < prerequisites[j].CourseId.String()
+		}
+		return prerequisites[i].PrerequisiteId.String() < prerequisites[j].PrerequisiteId.String()
+	})
+	return prerequisites, nil
+}
+
+func (s *Store) CreateCoursePrerequisite(prerequisite store.CoursePrerequisite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prerequisite.Kind != store.PrerequisiteHard && prerequisite.Kind != store.PrerequisiteSoft {
+		return checkViolation("course_prerequisite", "kind")
+	}
+	if prerequisite.CourseId == prerequisite.PrerequisiteId {
+		return checkViolation("course_prerequisite", "self")
+	}
+	if _, ok := s.courses[prerequisite.CourseId]; !ok {
+		return foreignKeyViolation("course_prerequisite", "course_id")
+	}
+	if _, ok := s.courses[prerequisite.PrerequisiteId]; !ok {
+		return foreignKeyViolation("course_prerequisite", "prerequisite_id")
+	}
+
+	s.coursePrerequisite[link2{prerequisite.CourseId, prerequisite.PrerequisiteId}] = prerequisite.Kind
+	return nil
+}
+
+func (s *Store) DeleteCoursePrerequisite(courseId uuid.UUID, prerequisiteId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{courseId, prerequisiteId}
+	if _, ok := s.coursePrerequisite[link]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.coursePrerequisite, link)
+	return nil
+}
+
+func (s *Store) GetCatalog() (store.Catalog, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var catalog store.Catalog
+	for _, organization := range s.organizations {
+		catalog.Organizations = append(catalog.Organizations, organization)
+	}
+	for _, educationalProgram := range s.educationalPrograms {
+		catalog.EducationalPrograms = append(catalog.EducationalPrograms, educationalProgram)
+	}
+	for _, discipline := range s.disciplines {
+		catalog.Disciplines = append(catalog.Disciplines, discipline)
+	}
+	for _, course := range s.courses {
+		catalog.Courses = append(catalog.Courses, course)
+	}
+	for _, technology := range s.technologies {
+		catalog.Technologies = append(catalog.Technologies, technology)
+	}
+	for _, knowledge := range s.knowledge {
+		catalog.Knowledge = append(catalog.Knowledge, knowledge)
+	}
+	for _, skill := range s.skills {
+		catalog.Skills = append(catalog.Skills, skill)
+	}
+	for _, competency := range s.competencies {
+		catalog.Competencies = append(catalog.Competencies, competency)
+	}
+	for _, profession := range s.professions {
+		catalog.Professions = append(catalog.Professions, profession)
+	}
+	for link := range s.knowledgeCompetency {
+		catalog.KnowledgeCompetencies = append(catalog.KnowledgeCompetencies, store.CatalogLink{FromId: link[0], ToId: link[1]})
+	}
+	for link := range s.skillCompetency {
+		catalog.SkillCompetencies = append(catalog.SkillCompetencies, store.CatalogLink{FromId: link[0], ToId: link[1]})
+	}
+	for link, level := range s.competencyProfession {
+		catalog.CompetencyProfessions = append(catalog.CompetencyProfessions, store.CatalogLink{FromId: link[0], ToId: link[1], Level: level})
+	}
+	for link, level := range s.courseCompetency {
+		catalog.CourseCompetencies = append(catalog.CourseCompetencies, store.CatalogLink{FromId: link[0], ToId: link[1], Level: level})
+	}
+	for _, teacher := range s.teachers {
+		catalog.Teachers = append(catalog.Teachers, teacher)
+	}
+	for link := range s.courseTeacher {
+		catalog.CourseTeachers = append(catalog.CourseTeachers, store.CatalogLink{FromId: link[0], ToId: link[1]})
+	}
+
+	sort.Slice(catalog.Organizations, func(i, j int) bool { return catalog.Organizations[i].Title < catalog.Organizations[j].Title })
+	sort.Slice(catalog.EducationalPrograms, func(i, j int) bool {
+		return catalog.EducationalPrograms[i].Title < catalog.EducationalPrograms[j].Title
+	})
+	sort.Slice(catalog.Disciplines, func(i, j int) bool { return catalog.Disciplines[i].Title < catalog.Disciplines[j].Title })
+	sortCourses(catalog.Courses)
+	sort.Slice(catalog.Technologies, func(i, j int) bool { return catalog.Technologies[i].Title < catalog.Technologies[j].Title })
+	sort.Slice(catalog.Knowledge, func(i, j int) bool { return catalog.Knowledge[i].Title < catalog.Knowledge[j].Title })
+	sortSkills(catalog.Skills)
+	sortCompetencies(catalog.Competencies)
+	sort.Slice(catalog.Professions, func(i, j int) bool { return catalog.Professions[i].Title < catalog.Professions[j].Title })
+	sortTeachers(catalog.Teachers)
+	return catalog, nil
+}
+
+func (s *Store) DeleteKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{knowledgeId, competencyId}
+	if !s.knowledgeCompetency[link] {
+		return store.ErrNotFound
+	}
+	delete(s.knowledgeCompetency, link)
+	return nil
+}
+
+func (s *Store) UpdateKnowledge(knowledge store.Knowledge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.knowledge[knowledge.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if knowledge.ParentId == knowledge.Id {
+		return checkViolation("knowledge", "parent")
+	}
+	if s.titleTaken("knowledge", knowledge.Id, knowledge.Title) {
+		return uniqueColumnViolation("knowledge", "title")
+	}
+	if _, ok := s.knowledge[knowledge.ParentId]; knowledge.ParentId != uuid.Nil && !ok {
+		return foreignKeyViolation("knowledge", "parent_id")
+	}
+
+	s.retitle("knowledge", knowledge.Id, existing.Title, knowledge.Title)
+	s.knowledge[knowledge.Id] = knowledge
+	return nil
+}
+
+func (s *Store) UpdateTechnology(technology store.Technology) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.technologies[technology.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("technologies", technology.Id, technology.Title) {
+		return uniqueColumnViolation("technologies", "title")
+	}
+
+	s.retitle("technologies", technology.Id, existing.Title, technology.Title)
+	s.technologies[technology.Id] = technology
+	return nil
+}
+
+func (s *Store) UpdateCompetency(competency store.Competency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.competencies[competency.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if competency.ParentId == competency.Id {
+		return checkViolation("competencies", "parent")
+	}
+	if s.titleTaken("competencies", competency.Id, competency.Title) {
+		return uniqueColumnViolation("competencies", "title")
+	}
+	if _, ok := s.technologies[competency.MainTechnologyId]; competency.MainTechnologyId != uuid.Nil && !ok {
+		return foreignKeyViolation("competencies", "main_technology_id")
+	}
+	if _, ok := s.competencies[competency.ParentId]; competency.ParentId != uuid.Nil && !ok {
+		return foreignKeyViolation("competencies", "parent_id")
+	}
+
+	s.retitle("competencies", competency.Id, existing.Title, competency.Title)
+	competency.Level = 0
+	s.competencies[competency.Id] = competency
+	return nil
+}
+
+func (s *Store) DeleteCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{competencyId, professionId}
+	if _, ok := s.competencyProfession[link]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.competencyProfession, link)
+	return nil
+}
+
+func (s *Store) UpdateProfession(profession store.Profession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.professions[profession.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("professions", profession.Id, profession.Title) {
+		return uniqueColumnViolation("professions", "title")
+	}
+
+	s.retitle("professions", profession.Id, existing.Title, profession.Title)
+	s.professions[profession.Id] = profession
+	return nil
+}
+
+func (s *Store) UpdateProject(project store.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.projects[project.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("projects", project.Id, project.Title) {
+		return uniqueColumnViolation("projects", "title")
+	}
+	if _, ok := s.technologies[project.MainTechnologyId]; project.MainTechnologyId != uuid.Nil && !ok {
+		return foreignKeyViolation("projects", "main_technology_id")
+	}
+
+	s.retitle("projects", project.Id, existing.Title, project.Title)
+	s.projects[project.Id] = project
+	return nil
+}
+
+func (s *Store) UpdateOrganization(organization store.Organization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.organizations[organization.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("organizations", organization.Id, organization.Title) {
+		return uniqueColumnViolation("organizations", "title")
+	}
+
+	s.retitle("organizations", organization.Id, existing.Title, organization.Title)
+	s.organizations[organization.Id] = organization
+	return nil
+}
+
+func (s *Store) UpdateEducationalProgram(educationalProgram store.EducationalProgram) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.educationalPrograms[educationalProgram.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if !validSemesterCredits(educationalProgram) {
+		return checkViolation("educational_programs", "semester_credits")
+	}
+	if s.titleTaken("educational_programs", educationalProgram.Id, educationalProgram.Title) {
+		return uniqueColumnViolation("educational_programs", "title")
+	}
+	if _, ok := s.organizations[educationalProgram.OrganizationId]; !ok {
+		return foreignKeyViolation("educational_programs", "organizations_id")
+	}
+
+	s.retitle("educational_programs", educationalProgram.Id, existing.Title, educationalProgram.Title)
+	s.educationalPrograms[educationalProgram.Id] = educationalProgram
+	return nil
+}
+
+func (s *Store) UpdateDiscipline(discipline store.Discipline) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.disciplines[discipline.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("disciplines", discipline.Id, discipline.Title) {
+		return uniqueColumnViolation("disciplines", "title")
+	}
+	if _, ok := s.educationalPrograms[discipline.EducationalProgramId]; !ok {
+		return foreignKeyViolation("disciplines", "educational_program_id")
+	}
+
+	s.retitle("disciplines", discipline.Id, existing.Title, discipline.Title)
+	s.disciplines[discipline.Id] = discipline
+	return nil
+}
+
+func (s *Store) DeleteCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{courseId, competencyId}
+	if _, ok := s.courseCompetency[link]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.courseCompetency, link)
+	return nil
+}
+
+func (s *Store) UpdateCourse(course store.Course) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.courses[course.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("courses", course.Id, course.Title) {
+		return uniqueColumnViolation("courses", "title")
+	}
+	if _, ok := s.disciplines[course.DisciplineId]; !ok {
+		return foreignKeyViolation("courses", "discipline_id")
+	}
+
+	s.retitle("courses", course.Id, existing.Title, course.Title)
+	s.courses[course.Id] = course
+	return nil
+}
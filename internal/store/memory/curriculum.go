@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) CreateElectivePool(pool store.ElectivePool) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pool.Choose == 0 {
+		return uuid.Nil, checkViolation("elective_pools", "choose")
+	}
+	if _, ok := s.educationalPrograms[pool.EducationalProgramId]; !ok {
+		return uuid.Nil, foreignKeyViolation("elective_pools", "educational_program_id")
+	}
+
+	pool.Id = uuid.NewV4()
+	for id, existing := range s.electivePools {
+		if existing.EducationalProgramId == pool.EducationalProgramId && existing.Title == pool.Title {
+			pool.Id = id
+		}
+	}
+	s.electivePools[pool.Id] = pool
+	return pool.Id, nil
+}
+
+func (s *Store) GetElectivePools(educationalProgramId uuid.UUID) ([]store.ElectivePool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var pools []store.ElectivePool
+	for _, pool := range s.electivePools {
+		if pool.EducationalProgramId == educationalProgramId {
+			pools = append(pools, pool)
+		}
+	}
+
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Title < pools[j].Title })
+	return pools, nil
+}
+
+func (s *Store) CreateCurriculumDiscipline(discipline store.CurriculumDiscipline) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if discipline.Semester < 1 || discipline.Semester > 12 {
+		return checkViolation("curriculum_disciplines", "semester")
+	}
+	if _, ok := s.educationalPrograms[discipline.EducationalProgramId]; !ok {
+		return foreignKeyViolation("curriculum_disciplines", "educational_program_id")
+	}
+	if _, ok := s.disciplines[discipline.DisciplineId]; !ok {
+		return foreignKeyViolation("curriculum_disciplines", "discipline_id")
+	}
+	// the pool has to belong to the same educational program
+	if pool, ok := s.electivePools[discipline.ElectivePoolId]; discipline.ElectivePoolId != uuid.Nil &&
+		(!ok || pool.EducationalProgramId != discipline.EducationalProgramId) {
+		return foreignKeyViolation("curriculum_disciplines", "elective_pool_id")
+	}
+
+	s.curriculum[link2{discipline.EducationalProgramId, discipline.DisciplineId}] = discipline
+	return nil
+}
+
+func (s *Store) GetCurriculum(educationalProgramId uuid.UUID) ([]store.CurriculumDiscipline, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var disciplines []store.CurriculumDiscipline
+	for link, discipline := range s.curriculum {
+		if link[0] == educationalProgramId {
+			disciplines = append(disciplines, discipline)
+		}
+	}
+
+	sort.Slice(disciplines, func(i, j int) bool {
+		if disciplines[i].Semester != disciplines[j].Semester {
+			return disciplines[i].Semester < disciplines[j].Semester
+		}
+		return disciplines[i].DisciplineId.String() < disciplines[j].DisciplineId.String()
+	})
+	return disciplines, nil
+}
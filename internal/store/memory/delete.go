@@ -0,0 +1,402 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// row is a row of a table of the store, key is the id of entity tables and the map key of the other ones.
+type row struct {
+	table string
+	key   any
+}
+
+// referencing returns rows of the table the references function accepts.
+func referencing[K comparable, V any](table string, rows map[K]V, references func(key K, value V) bool) []row {
+	var found []row
+	for key, value := range rows {
+		if references(key, value) {
+			found = append(found, row{table, key})
+		}
+	}
+	return found
+}
+
+// linkTo accepts links whose i-th id is the id.
+func linkTo[V any](i int, id uuid.UUID) func(key link2, value V) bool {
+	return func(key link2, value V) bool { return key[i] == id }
+}
+
+// referencingRows returns rows referencing the row by ON DELETE CASCADE foreign keys, s.mu must be held.
+func (s *Store) referencingRows(r row) []row {
+	var rows []row
+	id, _ := r.key.(uuid.UUID)
+	switch r.table {
+	case store.TableKnowledge:
+		rows = append(rows, referencing("knowledge_competency", s.knowledgeCompetency, linkTo[bool](0, id))...)
+	case store.TableTechnologies:
+		rows = append(rows, referencing(store.TableCompetencies, s.competencies, func(_ uuid.UUID, competency store.Competency) bool {
+			return competency.MainTechnologyId == id
+		})...)
+	case store.TableCompetencies:
+		rows = append(rows, referencing("knowledge_competency", s.knowledgeCompetency, linkTo[bool](1, id))...)
+		rows = append(rows, referencing("competency_profession", s.competencyProfession, linkTo[uint8](0, id))...)
+		rows = append(rows, referencing("course_competency", s.courseCompetency, linkTo[uint8](1, id))...)
+		rows = append(rows, referencing("project_portfolio_competency", s.projectPortfolioCompetency, func(key link3, _ uint8) bool {
+			return key[2] == id
+		})...)
+		rows = append(rows, referencing("skill_competency", s.skillCompetency, linkTo[bool](1, id))...)
+	case store.TableProfessions:
+		rows = append(rows, referencing("competency_profession", s.competencyProfession, linkTo[uint8](1, id))...)
+	case store.TableProjects:
+		rows = append(rows, referencing("project_portfolio", s.projectPortfolio, linkTo[store.ProjectPortfolio](0, id))...)
+		rows = append(rows, referencing("project_portfolio_competency", s.projectPortfolioCompetency, func(key link3, _ uint8) bool {
+			return key[0] == id
+		})...)
+	case store.TableOrganizations:
+		rows = append(rows, referencing(store.TableEducationalPrograms, s.educationalPrograms,
+			func(_ uuid.UUID, educationalProgram store.EducationalProgram) bool {
+				return educationalProgram.OrganizationId == id
+			})...)
+		rows = append(rows, referencing("calendar_semesters", s.calendarSemesters, func(key calendarKey, _ store.CalendarSemester) bool {
+			return key.organizationId == id
+		})...)
+		rows = append(rows, referencing("calendar_periods", s.calendarPeriods, func(_ uuid.UUID, period store.CalendarPeriod) bool {
+			return period.OrganizationId == id
+		})...)
+		rows = append(rows, referencing("grading_scales", s.gradingScales, func(key uuid.UUID, _ store.GradingScale) bool {
+			return key == id
+		})...)
+	case store.TableEducationalPrograms:
+		rows = append(rows, referencing(store.TableDisciplines, s.disciplines, func(_ uuid.UUID, discipline store.Discipline) bool {
+			return discipline.EducationalProgramId == id
+		})...)
+		rows = append(rows, referencing("elective_pools", s.electivePools, func(_ uuid.UUID, pool store.ElectivePool) bool {
+			return pool.EducationalProgramId == id
+		})...)
+		rows = append(rows, referencing("curriculum_disciplines", s.curriculum, linkTo[store.CurriculumDiscipline](0, id))...)
+		rows = append(rows, referencing("enrollments", s.enrollments, func(_ uuid.UUID, enrollment store.Enrollment) bool {
+			return enrollment.EducationalProgramId == id
+		})...)
+	case "elective_pools":
+		rows = append(rows, referencing("curriculum_disciplines", s.curriculum, func(_ link2, discipline store.CurriculumDiscipline) bool {
+			return discipline.ElectivePoolId == id
+		})...)
+	case store.TableDisciplines:
+		rows = append(rows, referencing(store.TableCourses, s.courses, func(_ uuid.UUID, course store.Course) bool {
+			return course.DisciplineId == id
+		})...)
+		rows = append(rows, referencing("curriculum_disciplines", s.curriculum, linkTo[store.CurriculumDiscipline](1, id))...)
+	case store.TableCourses:
+		rows = append(rows, referencing("study_groups", s.studyGroups, linkTo[bool](0, id))...)
+		rows = append(rows, referencing(store.TableTrajectories, s.trajectories, func(_ uuid.UUID, trajectory store.Trajectory) bool {
+			return trajectory.CourseId == id
+		})...)
+		rows = append(rows, referencing("course_competency", s.courseCompetency, linkTo[uint8](0, id))...)
+		rows = append(rows, referencing("course_sessions", s.courseSessions, func(_ uuid.UUID, session store.CourseSession) bool {
+			return session.CourseId == id
+		})...)
+		rows = append(rows, referencing("course_prerequisite", s.coursePrerequisite, func(key link2, _ string) bool {
+			return key[0] == id || key[1] == id
+		})...)
+		rows = append(rows, referencing("course_teacher", s.courseTeacher, linkTo[bool](0, id))...)
+		rows = append(rows, referencing("course_waitlists", s.courseWaitlists, linkTo[int64](0, id))...)
+		rows = append(rows, referencing("study_group_drops", s.studyGroupDrops, linkTo[time.Time](0, id))...)
+	case "course_sessions":
+		for _, entry := range s.timetable {
+			if entry.CourseSessionId == id {
+				rows = append(rows, row{"timetable", entry})
+			}
+		}
+	case store.TablePortfolios:
+		rows = append(rows, referencing("project_portfolio", s.projectPortfolio, linkTo[store.ProjectPortfolio](1, id))...)
+		rows = append(rows, referencing("project_portfolio_competency", s.projectPortfolioCompetency, func(key link3, _ uint8) bool {
+			return key[1] == id
+		})...)
+		rows = append(rows, referencing(store.TableStudents, s.students, func(_ uuid.UUID, student store.Student) bool {
+			return student.PortfolioId == id
+		})...)
+	case store.TableStudents:
+		rows = append(rows, referencing("study_groups", s.studyGroups, linkTo[bool](1, id))...)
+		rows = append(rows, referencing(store.TableTrajectories, s.trajectories, func(_ uuid.UUID, trajectory store.Trajectory) bool {
+			return trajectory.StudentId == id
+		})...)
+		rows = append(rows, referencing("api_keys", s.apiKeys, func(_ uuid.UUID, apiKey store.ApiKey) bool {
+			return apiKey.StudentId == id
+		})...)
+		rows = append(rows, referencing("enrollments", s.enrollments, func(_ uuid.UUID, enrollment store.Enrollment) bool {
+			return enrollment.StudentId == id
+		})...)
+		rows = append(rows, referencing("course_waitlists", s.courseWaitlists, linkTo[int64](1, id))...)
+		rows = append(rows, referencing("study_group_drops", s.studyGroupDrops, linkTo[time.Time](1, id))...)
+	case store.TableTrajectories:
+		rows = append(rows, referencing(store.TableTrajectories, s.trajectories, func(_ uuid.UUID, trajectory store.Trajectory) bool {
+			return trajectory.RetakeOf == id
+		})...)
+	case "enrollments":
+		rows = append(rows, referencing("academic_leaves", s.academicLeaves, func(_ uuid.UUID, leave store.AcademicLeave) bool {
+			return leave.EnrollmentId == id
+		})...)
+	case store.TableTeachers:
+		rows = append(rows, referencing("course_teacher", s.courseTeacher, linkTo[bool](1, id))...)
+		rows = append(rows, referencing("teacher_availability", s.teacherAvailability, func(key store.TeacherAvailability, _ bool) bool {
+			return key.TeacherId == id
+		})...)
+		rows = append(rows, referencing("api_keys", s.apiKeys, func(_ uuid.UUID, apiKey store.ApiKey) bool {
+			return apiKey.TeacherId == id
+		})...)
+	case store.TableSkills:
+		rows = append(rows, referencing("skill_competency", s.skillCompetency, linkTo[bool](0, id))...)
+	}
+	return rows
+}
+
+// restrictingRows returns rows referencing the row by foreign keys without ON DELETE action, s.mu must be held.
+func (s *Store) restrictingRows(r row) []row {
+	id, _ := r.key.(uuid.UUID)
+	switch r.table {
+	case store.TableTechnologies:
+		return referencing(store.TableProjects, s.projects, func(_ uuid.UUID, project store.Project) bool {
+			return project.MainTechnologyId == id
+		})
+	}
+	return nil
+}
+
+// restricted returns the rows that keep the found rows from being deleted and are not deleted themselves, s.mu must be held.
+func (s *Store) restricted(found map[row]bool) []row {
+	var rows []row
+	for r := range found {
+		for _, restricting := range s.restrictingRows(r) {
+			if !found[restricting] && !slices.Contains(rows, restricting) {
+				rows = append(rows, restricting)
+			}
+		}
+	}
+	return rows
+}
+
+// exists reports whether the entity table has the row, known is false for tables rows of which are not deleted by id.
+func (s *Store) exists(table string, id uuid.UUID) (exists bool, known bool) {
+	switch table {
+	case store.TableKnowledge:
+		_, exists = s.knowledge[id]
+	case store.TableTechnologies:
+		_, exists = s.technologies[id]
+	case store.TableSkills:
+		_, exists = s.skills[id]
+	case store.TableCompetencies:
+		_, exists = s.competencies[id]
+	case store.TableProfessions:
+		_, exists = s.professions[id]
+	case store.TableProjects:
+		_, exists = s.projects[id]
+	case store.TableOrganizations:
+		_, exists = s.organizations[id]
+	case store.TableEducationalPrograms:
+		_, exists = s.educationalPrograms[id]
+	case store.TableDisciplines:
+		_, exists = s.disciplines[id]
+	case store.TableCourses:
+		_, exists = s.courses[id]
+	case store.TableTeachers:
+		_, exists = s.teachers[id]
+	case store.TablePortfolios:
+		exists = s.portfolios[id]
+	case store.TableStudents:
+		_, exists = s.students[id]
+	case store.TableTrajectories:
+		_, exists = s.trajectories[id]
+	case "api_keys":
+		_, exists = s.apiKeys[id]
+	default:
+		return false, false
+	}
+	return exists, true
+}
+
+// cascade collects the rows referencing the row directly or through other referencing rows, s.mu must be held.
+func (s *Store) cascade(r row, found map[row]bool) {
+	for _, dependent := range s.referencingRows(r) {
+		if !found[dependent] {
+			found[dependent] = true
+			s.cascade(dependent, found)
+		}
+	}
+}
+
+func (s *Store) GetDependents(table string, id uuid.UUID) ([]store.Dependent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, known := s.exists(table, id); !known {
+		return nil, fmt.Errorf("unknown table %q", table)
+	}
+
+	root := row{table, id}
+	found := map[row]bool{root: true}
+	s.cascade(root, found)
+	restricted := s.restricted(found)
+	delete(found, root)
+
+	counts := make(map[store.Dependent]int)
+	for dependent := range found {
+		counts[store.Dependent{Table: dependent.table}]++
+	}
+	for _, dependent := range restricted {
+		counts[store.Dependent{Table: dependent.table, Restrict: true}]++
+	}
+	dependents := make([]store.Dependent, 0, len(counts))
+	for dependent, count := range counts {
+		dependent.Count = count
+		dependents = append(dependents, dependent)
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Table != dependents[j].Table {
+			return dependents[i].Table < dependents[j].Table
+		}
+		return !dependents[i].Restrict && dependents[j].Restrict
+	})
+	return dependents, nil
+}
+
+func (s *Store) Delete(table string, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exists, known := s.exists(table, id)
+	if !known {
+		return fmt.Errorf("unknown table %q", table)
+	} else if !exists {
+		return store.ErrNotFound
+	}
+
+	root := row{table, id}
+	found := map[row]bool{root: true}
+	s.cascade(root, found)
+
+	// projects.main_technology_id has no ON DELETE action, so the technology of a project can not be deleted
+	if restricted := s.restricted(found); len(restricted) > 0 {
+		return foreignKeyViolation("projects", "main_technology_id")
+	}
+
+	for r := range found {
+		s.deleteRow(r)
+	}
+
+	// ON DELETE SET NULL references
+	for id, knowledge := range s.knowledge {
+		if _, ok := s.knowledge[knowledge.ParentId]; knowledge.ParentId != uuid.Nil && !ok {
+			knowledge.ParentId = uuid.Nil
+			s.knowledge[id] = knowledge
+		}
+	}
+	for id, competency := range s.competencies {
+		if _, ok := s.competencies[competency.ParentId]; competency.ParentId != uuid.Nil && !ok {
+			competency.ParentId = uuid.Nil
+			s.competencies[id] = competency
+		}
+	}
+	for id, teacher := range s.teachers {
+		if _, ok := s.organizations[teacher.OrganizationId]; teacher.OrganizationId != uuid.Nil && !ok {
+			teacher.OrganizationId = uuid.Nil
+			s.teachers[id] = teacher
+		}
+	}
+	return nil
+}
+
+// deleteRow removes the row from its table and from the title index, s.mu must be held.
+func (s *Store) deleteRow(r row) {
+	switch r.table {
+	case store.TableKnowledge:
+		delete(s.titles[r.table], s.knowledge[r.key.(uuid.UUID)].Title)
+		delete(s.knowledge, r.key.(uuid.UUID))
+	case store.TableTechnologies:
+		delete(s.titles[r.table], s.technologies[r.key.(uuid.UUID)].Title)
+		delete(s.technologies, r.key.(uuid.UUID))
+	case store.TableSkills:
+		delete(s.titles[r.table], s.skills[r.key.(uuid.UUID)].Title)
+		delete(s.skills, r.key.(uuid.UUID))
+	case store.TableCompetencies:
+		delete(s.titles[r.table], s.competencies[r.key.(uuid.UUID)].Title)
+		delete(s.competencies, r.key.(uuid.UUID))
+	case store.TableProfessions:
+		delete(s.titles[r.table], s.professions[r.key.(uuid.UUID)].Title)
+		delete(s.professions, r.key.(uuid.UUID))
+	case store.TableProjects:
+		delete(s.titles[r.table], s.projects[r.key.(uuid.UUID)].Title)
+		delete(s.projects, r.key.(uuid.UUID))
+	case store.TableOrganizations:
+		delete(s.titles[r.table], s.organizations[r.key.(uuid.UUID)].Title)
+		delete(s.organizations, r.key.(uuid.UUID))
+	case store.TableEducationalPrograms:
+		delete(s.titles[r.table], s.educationalPrograms[r.key.(uuid.UUID)].Title)
+		delete(s.educationalPrograms, r.key.(uuid.UUID))
+	case store.TableDisciplines:
+		delete(s.titles[r.table], s.disciplines[r.key.(uuid.UUID)].Title)
+		delete(s.disciplines, r.key.(uuid.UUID))
+	case store.TableCourses:
+		delete(s.titles[r.table], s.courses[r.key.(uuid.UUID)].Title)
+		delete(s.courses, r.key.(uuid.UUID))
+	case store.TableTeachers:
+		delete(s.titles[r.table], s.teachers[r.key.(uuid.UUID)].FullName)
+		delete(s.teachers, r.key.(uuid.UUID))
+	case store.TablePortfolios:
+		delete(s.portfolios, r.key.(uuid.UUID))
+	case store.TableStudents:
+		delete(s.students, r.key.(uuid.UUID))
+	case store.TableTrajectories:
+		delete(s.trajectories, r.key.(uuid.UUID))
+	case "enrollments":
+		delete(s.enrollments, r.key.(uuid.UUID))
+	case "academic_leaves":
+		delete(s.academicLeaves, r.key.(uuid.UUID))
+	case "api_keys":
+		delete(s.apiKeys, r.key.(uuid.UUID))
+	case "calendar_semesters":
+		delete(s.calendarSemesters, r.key.(calendarKey))
+	case "calendar_periods":
+		delete(s.calendarPeriods, r.key.(uuid.UUID))
+	case "grading_scales":
+		delete(s.gradingScales, r.key.(uuid.UUID))
+	case "elective_pools":
+		delete(s.electivePools, r.key.(uuid.UUID))
+	case "curriculum_disciplines":
+		delete(s.curriculum, r.key.(link2))
+	case "course_sessions":
+		delete(s.courseSessions, r.key.(uuid.UUID))
+	case "timetable":
+		s.timetable = slices.DeleteFunc(s.timetable, func(entry store.TimetableEntry) bool { return entry == r.key })
+	case "teacher_availability":
+		delete(s.teacherAvailability, r.key.(store.TeacherAvailability))
+	case "knowledge_competency":
+		delete(s.knowledgeCompetency, r.key.(link2))
+	case "skill_competency":
+		delete(s.skillCompetency, r.key.(link2))
+	case "competency_profession":
+		delete(s.competencyProfession, r.key.(link2))
+	case "course_competency":
+		delete(s.courseCompetency, r.key.(link2))
+	case "course_teacher":
+		delete(s.courseTeacher, r.key.(link2))
+	case "course_prerequisite":
+		delete(s.coursePrerequisite, r.key.(link2))
+	case "project_portfolio":
+		delete(s.projectPortfolio, r.key.(link2))
+	case "project_portfolio_competency":
+		delete(s.projectPortfolioCompetency, r.key.(link3))
+	case "study_groups":
+		delete(s.studyGroups, r.key.(link2))
+	case "course_waitlists":
+		delete(s.courseWaitlists, r.key.(link2))
+	case "study_group_drops":
+		delete(s.studyGroupDrops, r.key.(link2))
+	}
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) CreateEnrollment(enrollment store.Enrollment) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enrollment.Id = uuid.NewV4()
+	if err := s.checkEnrollment(enrollment); err != nil {
+		return uuid.Nil, err
+	}
+	if _, ok := s.students[enrollment.StudentId]; !ok {
+		return uuid.Nil, foreignKeyViolation("enrollments", "student_id")
+	}
+	if _, ok := s.educationalPrograms[enrollment.EducationalProgramId]; !ok {
+		return uuid.Nil, foreignKeyViolation("enrollments", "educational_program_id")
+	}
+	if err := s.checkOpenEnrollment(enrollment); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.enrollments[enrollment.Id] = enrollment
+	return enrollment.Id, nil
+}
+
+func (s *Store) UpdateEnrollment(enrollment store.Enrollment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.enrollments[enrollment.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	existing.End, existing.Status = enrollment.End, enrollment.Status
+	if err := s.checkEnrollment(existing); err != nil {
+		return err
+	}
+	if err := s.checkOpenEnrollment(existing); err != nil {
+		return err
+	}
+
+	s.enrollments[existing.Id] = existing
+	return nil
+}
+
+// checkEnrollment mirrors check constraints of enrollments.
+func (s *Store) checkEnrollment(enrollment store.Enrollment) error {
+	open := enrollment.Status == store.EnrollmentActive || enrollment.Status == store.EnrollmentLeave
+	switch {
+	case !open && enrollment.Status != store.EnrollmentExpelled && enrollment.Status != store.EnrollmentGraduated &&
+		enrollment.Status != store.EnrollmentTransferred:
+		return checkViolation("enrollments", "status")
+	case !enrollment.End.IsZero() && enrollment.End.Before(enrollment.Start):
+		return checkViolation("enrollments", "dates")
+	case open != enrollment.End.IsZero():
+		return checkViolation("enrollments", "end")
+	}
+	return nil
+}
+
+// checkOpenEnrollment mirrors the unique index of open enrollments of the student.
+func (s *Store) checkOpenEnrollment(enrollment store.Enrollment) error {
+	if !enrollment.End.IsZero() {
+		return nil
+	}
+	for id, existing := range s.enrollments {
+		if id != enrollment.Id && existing.StudentId == enrollment.StudentId && existing.End.IsZero() {
+			return uniqueColumnViolation("enrollments", "student_id")
+		}
+	}
+	return nil
+}
+
+func (s *Store) GetStudentEnrollments(studentId uuid.UUID) ([]store.Enrollment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.studentEnrollments(studentId), nil
+}
+
+// studentEnrollments returns enrollments of the student ordered by start, the open one goes after the closed ones.
+func (s *Store) studentEnrollments(studentId uuid.UUID) []store.Enrollment {
+	var enrollments []store.Enrollment
+	for _, enrollment := range s.enrollments {
+		if enrollment.StudentId == studentId {
+			enrollments = append(enrollments, enrollment)
+		}
+	}
+
+	sort.Slice(enrollments, func(i, j int) bool {
+		a, b := enrollments[i], enrollments[j]
+		switch {
+		case !a.Start.Equal(b.Start):
+			return a.Start.Before(b.Start)
+		case a.End.IsZero() != b.End.IsZero():
+			return b.End.IsZero()
+		case !a.End.Equal(b.End):
+			return a.End.Before(b.End)
+		}
+		return a.Id.String() < b.Id.String()
+	})
+	return enrollments
+}
+
+func (s *Store) CreateAcademicLeave(leave store.AcademicLeave) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !leave.End.IsZero() && leave.End.Before(leave.Start) {
+		return uuid.Nil, checkViolation("academic_leaves", "dates")
+	}
+	if _, ok := s.enrollments[leave.EnrollmentId]; !ok {
+		return uuid.Nil, foreignKeyViolation("academic_leaves", "enrollment_id")
+	}
+
+	leave.Id = uuid.NewV4()
+	s.academicLeaves[leave.Id] = leave
+	return leave.Id, nil
+}
+
+func (s *Store) UpdateAcademicLeave(leave store.AcademicLeave) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.academicLeaves[leave.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if !leave.End.IsZero() && leave.End.Before(existing.Start) {
+		return checkViolation("academic_leaves", "dates")
+	}
+
+	existing.End = leave.End
+	s.academicLeaves[existing.Id] = existing
+	return nil
+}
+
+func (s *Store) GetStudentAcademicLeaves(studentId uuid.UUID) ([]store.AcademicLeave, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var leaves []store.AcademicLeave
+	for _, leave := range s.academicLeaves {
+		if s.enrollments[leave.EnrollmentId].StudentId == studentId {
+			leaves = append(leaves, leave)
+		}
+	}
+
+	sort.Slice(leaves, func(i, j int) bool {
+		if !leaves[i].Start.Equal(leaves[j].Start) {
+			return leaves[i].Start.Before(leaves[j].Start)
+		}
+		return leaves[i].Id.String() < leaves[j].Id.String()
+	})
+	return leaves, nil
+}
@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// listPage orders rows by the sort key of the page like ORDER BY key, id does and cuts the page out of them.
+// keys compares rows by every sort key of the list except "id".
+func listPage[T any](rows []T, page store.Page, id func(row T) uuid.UUID, keys map[string]func(a, b T) int) ([]T, int, error) {
+	compare, ok := keys[page.Sort]
+	if page.Sort == "id" {
+		compare, ok = func(a, b T) int { return compareIds(id(a), id(b)) }, true
+	}
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sort key %q", page.Sort)
+	}
+
+	slices.SortFunc(rows, func(a, b T) int {
+		c := compare(a, b)
+		if page.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return compareIds(id(a), id(b))
+	})
+
+	total := len(rows)
+	start := min(max(page.Offset, 0), total)
+	end := min(start+max(page.Limit, 0), total)
+	return rows[start:end], total, nil
+}
+
+// compareIds orders ids as Postgres orders uuid values.
+func compareIds(a, b uuid.UUID) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+// filtered returns values of the table the keep function accepts.
+func filtered[K comparable, T any](table map[K]T, keep func(row T) bool) []T {
+	var rows []T
+	for _, row := range table {
+		if keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func all[T any](T) bool { return true }
+
+func (s *Store) ListKnowledge(page store.Page) ([]store.Knowledge, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.knowledge, all), page, func(row store.Knowledge) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Knowledge) int{
+			"title": func(a, b store.Knowledge) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListTechnologies(page store.Page) ([]store.Technology, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.technologies, all), page, func(row store.Technology) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Technology) int{
+			"title": func(a, b store.Technology) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListSkills(page store.Page) ([]store.Skill, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.skills, all), page, func(row store.Skill) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Skill) int{
+			"title": func(a, b store.Skill) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListCompetencies(page store.Page, mainTechnologyId uuid.UUID) ([]store.Competency, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := filtered(s.competencies, func(row store.Competency) bool {
+		return mainTechnologyId == uuid.Nil || row.MainTechnologyId == mainTechnologyId
+	})
+	return listPage(rows, page, func(row store.Competency) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Competency) int{
+			"title": func(a, b store.Competency) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListProfessions(page store.Page) ([]store.Profession, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.professions, all), page, func(row store.Profession) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Profession) int{
+			"title": func(a, b store.Profession) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListProjects(page store.Page, mainTechnologyId uuid.UUID) ([]store.Project, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := filtered(s.projects, func(row store.Project) bool {
+		return mainTechnologyId == uuid.Nil || row.MainTechnologyId == mainTechnologyId
+	})
+	return listPage(rows, page, func(row store.Project) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Project) int{
+			"title": func(a, b store.Project) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListOrganizations(page store.Page) ([]store.Organization, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.organizations, all), page, func(row store.Organization) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Organization) int{
+			"title": func(a, b store.Organization) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListEducationalPrograms(page store.Page, organizationId uuid.UUID) ([]store.EducationalProgram, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := filtered(s.educationalPrograms, func(row store.EducationalProgram) bool {
+		return organizationId == uuid.Nil || row.OrganizationId == organizationId
+	})
+	return listPage(rows, page, func(row store.EducationalProgram) uuid.UUID { return row.Id },
+		map[string]func(a, b store.EducationalProgram) int{
+			"title": func(a, b store.EducationalProgram) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListDisciplines(page store.Page, educationalProgramId uuid.UUID) ([]store.Discipline, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := filtered(s.disciplines, func(row store.Discipline) bool {
+		return educationalProgramId == uuid.Nil || row.EducationalProgramId == educationalProgramId
+	})
+	return listPage(rows, page, func(row store.Discipline) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Discipline) int{
+			"title": func(a, b store.Discipline) int { return cmp.Compare(a.Title, b.Title) },
+		})
+}
+
+func (s *Store) ListCourses(page store.Page, disciplineId uuid.UUID) ([]store.Course, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// the first full name of the course teachers, like min() it is NULL for courses without teachers
+	firstTeacher := make(map[uuid.UUID]string)
+	for link := range s.courseTeacher {
+		fullName := s.teachers[link[1]].FullName
+		if first, ok := firstTeacher[link[0]]; !ok || fullName < first {
+			firstTeacher[link[0]] = fullName
+		}
+	}
+
+	rows := filtered(s.courses, func(row store.Course) bool {
+		return disciplineId == uuid.Nil || row.DisciplineId == disciplineId
+	})
+	return listPage(rows, page, func(row store.Course) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Course) int{
+			"title": func(a, b store.Course) int { return cmp.Compare(a.Title, b.Title) },
+			"teacher": func(a, b store.Course) int {
+				teacherA, okA := firstTeacher[a.Id]
+				teacherB, okB := firstTeacher[b.Id]
+				if okA != okB {
+					// NULL is greater than any value in Postgres ordering
+					if okA {
+						return -1
+					}
+					return 1
+				}
+				return cmp.Compare(teacherA, teacherB)
+			},
+		})
+}
+
+func (s *Store) ListTeachers(page store.Page, organizationId uuid.UUID) ([]store.Teacher, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := filtered(s.teachers, func(row store.Teacher) bool {
+		return organizationId == uuid.Nil || row.OrganizationId == organizationId
+	})
+	return listPage(rows, page, func(row store.Teacher) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Teacher) int{
+			"fullName": func(a, b store.Teacher) int { return cmp.Compare(a.FullName, b.FullName) },
+		})
+}
+
+func (s *Store) ListStudents(page store.Page) ([]store.Student, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return listPage(filtered(s.students, all), page, func(row store.Student) uuid.UUID { return row.Id },
+		map[string]func(a, b store.Student) int{
+			"fullName": func(a, b store.Student) int { return cmp.Compare(a.FullName, b.FullName) },
+			"admition": func(a, b store.Student) int { return a.Admition.Compare(b.Admition) },
+		})
+}
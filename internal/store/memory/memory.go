@@ -0,0 +1,304 @@
+// Package memory implements store.Store in process memory. It follows the constraints of the Postgres schema
+// (foreign keys, primary keys of link tables, semester checks, upsert by title) and reports violations
+// with the same *pq.Error codes and constraint names, so it can stand in for the database in tests.
+package memory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+type link2 [2]uuid.UUID
+type link3 [3]uuid.UUID
+
+type Store struct {
+	mu sync.RWMutex
+	tables
+}
+
+// tables is the whole data of the store, a copy of it is kept while Transaction runs.
+type tables struct {
+	knowledge           map[uuid.UUID]store.Knowledge
+	skills              map[uuid.UUID]store.Skill
+	technologies        map[uuid.UUID]store.Technology
+	competencies        map[uuid.UUID]store.Competency
+	professions         map[uuid.UUID]store.Profession
+	projects            map[uuid.UUID]store.Project
+	organizations       map[uuid.UUID]store.Organization
+	gradingScales       map[uuid.UUID]store.GradingScale // organization
+	educationalPrograms map[uuid.UUID]store.EducationalProgram
+	disciplines         map[uuid.UUID]store.Discipline
+	courses             map[uuid.UUID]store.Course
+	teachers            map[uuid.UUID]store.Teacher
+	portfolios          map[uuid.UUID]bool
+	students            map[uuid.UUID]store.Student
+	trajectories        map[uuid.UUID]store.Trajectory
+	enrollments         map[uuid.UUID]store.Enrollment
+	academicLeaves      map[uuid.UUID]store.AcademicLeave
+
+	// title indexes of tables with unique titles: table -> title -> id
+	titles map[string]map[string]uuid.UUID
+
+	knowledgeCompetency        map[link2]bool   // knowledge, competency
+	skillCompetency            map[link2]bool   // skill, competency
+	competencyProfession       map[link2]uint8  // competency, profession -> level
+	courseCompetency           map[link2]uint8  // course, competency -> level
+	courseTeacher              map[link2]bool   // course, teacher
+	coursePrerequisite         map[link2]string // course, prerequisite -> kind
+	projectPortfolio           map[link2]store.ProjectPortfolio
+	projectPortfolioCompetency map[link3]uint8     // project, portfolio, competency -> level
+	studyGroups                map[link2]bool      // course, student
+	courseWaitlists            map[link2]int64     // course, student -> waitlist number
+	studyGroupDrops            map[link2]time.Time // course, student -> drop date
+
+	waitlistNumber int64 // the last value of the waitlist_number sequence
+
+	calendarSemesters map[calendarKey]store.CalendarSemester
+	calendarPeriods   map[uuid.UUID]store.CalendarPeriod
+
+	electivePools map[uuid.UUID]store.ElectivePool
+	curriculum    map[link2]store.CurriculumDiscipline // educational program, discipline
+
+	timeSlots           map[uuid.UUID]store.TimeSlot
+	rooms               map[uuid.UUID]store.Room
+	courseSessions      map[uuid.UUID]store.CourseSession
+	teacherAvailability map[store.TeacherAvailability]bool
+	timetable           []store.TimetableEntry
+
+	apiKeys map[uuid.UUID]store.ApiKey
+}
+
+// calendarKey is the primary key of calendar_semesters.
+type calendarKey struct {
+	organizationId uuid.UUID
+	year           int
+	term           uint8
+}
+
+var _ store.Store = (*Store)(nil)
+
+func New() *Store {
+	return &Store{tables: tables{
+		knowledge:                  make(map[uuid.UUID]store.Knowledge),
+		skills:                     make(map[uuid.UUID]store.Skill),
+		technologies:               make(map[uuid.UUID]store.Technology),
+		competencies:               make(map[uuid.UUID]store.Competency),
+		professions:                make(map[uuid.UUID]store.Profession),
+		projects:                   make(map[uuid.UUID]store.Project),
+		organizations:              make(map[uuid.UUID]store.Organization),
+		gradingScales:              make(map[uuid.UUID]store.GradingScale),
+		educationalPrograms:        make(map[uuid.UUID]store.EducationalProgram),
+		disciplines:                make(map[uuid.UUID]store.Discipline),
+		courses:                    make(map[uuid.UUID]store.Course),
+		teachers:                   make(map[uuid.UUID]store.Teacher),
+		portfolios:                 make(map[uuid.UUID]bool),
+		students:                   make(map[uuid.UUID]store.Student),
+		trajectories:               make(map[uuid.UUID]store.Trajectory),
+		enrollments:                make(map[uuid.UUID]store.Enrollment),
+		academicLeaves:             make(map[uuid.UUID]store.AcademicLeave),
+		titles:                     make(map[string]map[string]uuid.UUID),
+		knowledgeCompetency:        make(map[link2]bool),
+		skillCompetency:            make(map[link2]bool),
+		competencyProfession:       make(map[link2]uint8),
+		courseCompetency:           make(map[link2]uint8),
+		courseTeacher:              make(map[link2]bool),
+		coursePrerequisite:         make(map[link2]string),
+		projectPortfolio:           make(map[link2]store.ProjectPortfolio),
+		projectPortfolioCompetency: make(map[link3]uint8),
+		studyGroups:                make(map[link2]bool),
+		courseWaitlists:            make(map[link2]int64),
+		studyGroupDrops:            make(map[link2]time.Time),
+		calendarSemesters:          make(map[calendarKey]store.CalendarSemester),
+		calendarPeriods:            make(map[uuid.UUID]store.CalendarPeriod),
+		electivePools:              make(map[uuid.UUID]store.ElectivePool),
+		curriculum:                 make(map[link2]store.CurriculumDiscipline),
+		timeSlots:                  make(map[uuid.UUID]store.TimeSlot),
+		rooms:                      make(map[uuid.UUID]store.Room),
+		courseSessions:             make(map[uuid.UUID]store.CourseSession),
+		teacherAvailability:        make(map[store.TeacherAvailability]bool),
+		apiKeys:                    make(map[uuid.UUID]store.ApiKey),
+	}}
+}
+
+// Transaction keeps a copy of the tables and brings it back when fn fails.
+// Unlike Postgres it does not isolate fn from concurrent calls, which is enough for tests.
+func (s *Store) Transaction(fn func(tx store.Store) error) error {
+	s.mu.RLock()
+	saved := s.tables.clone()
+	s.mu.RUnlock()
+
+	if err := fn(s); err != nil {
+		s.mu.Lock()
+		s.tables = saved
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Lock only checks the table, transactions of the memory store are not isolated.
+func (s *Store) Lock(table string, id uuid.UUID) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, known := s.exists(table, id); !known {
+		return fmt.Errorf("unknown table %q", table)
+	}
+	return nil
+}
+
+// clone copies every table, rows are values, so changes of the copy do not reach the original.
+func (t tables) clone() tables {
+	titles := make(map[string]map[string]uuid.UUID, len(t.titles))
+	for table, index := range t.titles {
+		titles[table] = maps.Clone(index)
+	}
+
+	return tables{
+		knowledge:                  maps.Clone(t.knowledge),
+		skills:                     maps.Clone(t.skills),
+		technologies:               maps.Clone(t.technologies),
+		competencies:               maps.Clone(t.competencies),
+		professions:                maps.Clone(t.professions),
+		projects:                   maps.Clone(t.projects),
+		organizations:              maps.Clone(t.organizations),
+		gradingScales:              maps.Clone(t.gradingScales),
+		educationalPrograms:        maps.Clone(t.educationalPrograms),
+		disciplines:                maps.Clone(t.disciplines),
+		courses:                    maps.Clone(t.courses),
+		teachers:                   maps.Clone(t.teachers),
+		portfolios:                 maps.Clone(t.portfolios),
+		students:                   maps.Clone(t.students),
+		trajectories:               maps.Clone(t.trajectories),
+		enrollments:                maps.Clone(t.enrollments),
+		academicLeaves:             maps.Clone(t.academicLeaves),
+		titles:                     titles,
+		knowledgeCompetency:        maps.Clone(t.knowledgeCompetency),
+		skillCompetency:            maps.Clone(t.skillCompetency),
+		competencyProfession:       maps.Clone(t.competencyProfession),
+		courseCompetency:           maps.Clone(t.courseCompetency),
+		courseTeacher:              maps.Clone(t.courseTeacher),
+		coursePrerequisite:         maps.Clone(t.coursePrerequisite),
+		projectPortfolio:           maps.Clone(t.projectPortfolio),
+		projectPortfolioCompetency: maps.Clone(t.projectPortfolioCompetency),
+		studyGroups:                maps.Clone(t.studyGroups),
+		courseWaitlists:            maps.Clone(t.courseWaitlists),
+		studyGroupDrops:            maps.Clone(t.studyGroupDrops),
+		waitlistNumber:             t.waitlistNumber,
+		calendarSemesters:          maps.Clone(t.calendarSemesters),
+		calendarPeriods:            maps.Clone(t.calendarPeriods),
+		electivePools:              maps.Clone(t.electivePools),
+		curriculum:                 maps.Clone(t.curriculum),
+		timeSlots:                  maps.Clone(t.timeSlots),
+		rooms:                      maps.Clone(t.rooms),
+		courseSessions:             maps.Clone(t.courseSessions),
+		teacherAvailability:        maps.Clone(t.teacherAvailability),
+		timetable:                  slices.Clone(t.timetable),
+		apiKeys:                    maps.Clone(t.apiKeys),
+	}
+}
+
+func foreignKeyViolation(table string, column string) error {
+	return &pq.Error{
+		Severity:   "ERROR",
+		Code:       store.CodeForeignKeyViolation,
+		Message:    `insert or update on table "` + table + `" violates foreign key constraint "` + table + "_" + column + `_fkey"`,
+		Table:      table,
+		Column:     column,
+		Constraint: table + "_" + column + "_fkey",
+	}
+}
+
+func uniqueViolation(table string) error {
+	return &pq.Error{
+		Severity:   "ERROR",
+		Code:       store.CodeUniqueViolation,
+		Message:    `duplicate key value violates unique constraint "` + table + `_pkey"`,
+		Table:      table,
+		Constraint: table + "_pkey",
+	}
+}
+
+// uniqueColumnViolation is a violation of UNIQUE of the column, uniqueViolation is the one of the primary key.
+func uniqueColumnViolation(table string, column string) error {
+	return &pq.Error{
+		Severity:   "ERROR",
+		Code:       store.CodeUniqueViolation,
+		Message:    `duplicate key value violates unique constraint "` + table + "_" + column + `_key"`,
+		Table:      table,
+		Column:     column,
+		Constraint: table + "_" + column + "_key",
+	}
+}
+
+func checkViolation(table string, column string) error {
+	return &pq.Error{
+		Severity:   "ERROR",
+		Code:       store.CodeCheckViolation,
+		Message:    `new row for relation "` + table + `" violates check constraint "` + table + "_" + column + `_check"`,
+		Table:      table,
+		Column:     column,
+		Constraint: table + "_" + column + "_check",
+	}
+}
+
+// titleId returns id of the row with the title. As with ON CONFLICT (title) DO UPDATE in Postgres,
+// an existing title wins over checks of the other columns.
+func (s *Store) titleId(table string, title string) (uuid.UUID, bool) {
+	id, ok := s.titles[table][title]
+	return id, ok
+}
+
+func (s *Store) addTitle(table string, title string) uuid.UUID {
+	index, ok := s.titles[table]
+	if !ok {
+		index = make(map[string]uuid.UUID)
+		s.titles[table] = index
+	}
+
+	id := uuid.NewV4()
+	index[title] = id
+	return id
+}
+
+// titleTaken reports whether another row of the table has the title, updates to it violate UNIQUE of the column.
+func (s *Store) titleTaken(table string, id uuid.UUID, title string) bool {
+	other, ok := s.titleId(table, title)
+	return ok && other != id
+}
+
+// retitle moves the row from the old title to the new one in the title index.
+func (s *Store) retitle(table string, id uuid.UUID, old string, title string) {
+	delete(s.titles[table], old)
+	s.titles[table][title] = id
+}
+
+// date drops the time of day as DATE columns do.
+func date(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func sortCompetencies(competencies []store.Competency) {
+	sort.Slice(competencies, func(i, j int) bool { return competencies[i].Title < competencies[j].Title })
+}
+
+func sortCourses(courses []store.Course) {
+	sort.Slice(courses, func(i, j int) bool { return courses[i].Title < courses[j].Title })
+}
+
+func sortTeachers(teachers []store.Teacher) {
+	sort.Slice(teachers, func(i, j int) bool { return teachers[i].FullName < teachers[j].FullName })
+}
+
+func sortSkills(skills []store.Skill) {
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Title < skills[j].Title })
+}
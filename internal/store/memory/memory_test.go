@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store/memory"
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store/storetest"
+)
+
+func TestContract(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		return memory.New()
+	})
+}
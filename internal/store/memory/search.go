@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// titleSimilarity is the lowest trigram similarity of a title found despite typos, as pg_trgm.word_similarity_threshold.
+const titleSimilarity = 0.6
+
+// Search imitates the Russian full-text search of Postgres with a crude stemmer: words match when one starts with
+// the stem of the other, the word without its last two letters. Titles are compared by trigrams as word_similarity does.
+func (s *Store) Search(query string, limit int) ([]store.SearchHit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	words := searchWords(query)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	var hits []store.SearchHit
+	add := func(kind string, id uuid.UUID, title string, text string) {
+		titleWords, textWords := searchWords(title), searchWords(text)
+		var inTitle, inText int
+		for _, word := range words {
+			switch {
+			case matchesAny(word, titleWords):
+				inTitle++
+			case matchesAny(word, textWords):
+				inText++
+			}
+		}
+		similarity := wordSimilarity(words, titleWords)
+		found := inTitle+inText == len(words)
+		if !found && similarity < titleSimilarity {
+			return
+		}
+
+		rank := similarity
+		if found {
+			rank += (float64(inTitle) + 0.4*float64(inText)) / float64(len(words)) * 0.6
+		}
+		snippet := text
+		if strings.TrimSpace(snippet) == "" {
+			snippet = title
+		}
+		hits = append(hits, store.SearchHit{Kind: kind, Id: id, Title: title, Snippet: highlight(snippet, words), Rank: rank})
+	}
+
+	for _, knowledge := range s.knowledge {
+		add(store.SearchKnowledge, knowledge.Id, knowledge.Title, "")
+	}
+	for _, technology := range s.technologies {
+		add(store.SearchTechnology, technology.Id, technology.Title, "")
+	}
+	for _, competency := range s.competencies {
+		var skills []string
+		for link := range s.skillCompetency {
+			if link[1] == competency.Id {
+				skills = append(skills, s.skills[link[0]].Title)
+			}
+		}
+		sort.Strings(skills)
+		add(store.SearchCompetency, competency.Id, competency.Title, strings.Join(skills, ", "))
+	}
+	for _, profession := range s.professions {
+		add(store.SearchProfession, profession.Id, profession.Title, profession.Description)
+	}
+	for _, project := range s.projects {
+		add(store.SearchProject, project.Id, project.Title, strings.Join([]string{project.Description, project.Result, project.LifeScenario}, " "))
+	}
+	for _, organization := range s.organizations {
+		add(store.SearchOrganization, organization.Id, organization.Title, "")
+	}
+	for _, educationalProgram := range s.educationalPrograms {
+		add(store.SearchEducationalProgram, educationalProgram.Id, educationalProgram.Title, educationalProgram.Description)
+	}
+	for _, discipline := range s.disciplines {
+		add(store.SearchDiscipline, discipline.Id, discipline.Title, discipline.Description)
+	}
+	for _, course := range s.courses {
+		add(store.SearchCourse, course.Id, course.Title, course.Description)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind < hits[j].Kind
+		}
+		return hits[i].Title < hits[j].Title
+	})
+
+	perKind := make(map[string]int)
+	limited := hits[:0]
+	for _, hit := range hits {
+		if perKind[hit.Kind] < limit {
+			perKind[hit.Kind]++
+			limited = append(limited, hit)
+		}
+	}
+	return limited, nil
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+func stem(word string) string {
+	runes := []rune(word)
+	if len(runes) <= 3 {
+		return word
+	}
+	return string(runes[:max(3, len(runes)-2)])
+}
+
+func matches(a string, b string) bool {
+	return strings.HasPrefix(b, stem(a)) || strings.HasPrefix(a, stem(b))
+}
+
+func matchesAny(word string, words []string) bool {
+	for _, other := range words {
+		if matches(word, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func trigrams(words []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// wordSimilarity is the greatest share of the query trigrams found in a run of consecutive title words.
+func wordSimilarity(words []string, titleWords []string) float64 {
+	query := trigrams(words)
+	best := 0.0
+	for start := range titleWords {
+		for end := start + 1; end <= len(titleWords); end++ {
+			common := 0
+			for trigram := range trigrams(titleWords[start:end]) {
+				if query[trigram] {
+					common++
+				}
+			}
+			best = max(best, float64(common)/float64(len(query)))
+		}
+	}
+	return best
+}
+
+// highlight wraps words of the text matching the query words in <b> and </b> as ts_headline does.
+func highlight(text string, words []string) string {
+	var b strings.Builder
+	word := []rune{}
+	flush := func() {
+		if len(word) > 0 && matchesAny(strings.ToLower(string(word)), words) {
+			b.WriteString("<b>" + string(word) + "</b>")
+		} else {
+			b.WriteString(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
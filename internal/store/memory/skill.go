@@ -0,0 +1,73 @@
+package memory
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetSkill(id uuid.UUID) (store.Skill, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	skill, ok := s.skills[id]
+	if !ok {
+		return skill, store.ErrNotFound
+	}
+	return skill, nil
+}
+
+func (s *Store) CreateSkill(title string) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("skills", title); ok {
+		return id, nil
+	}
+
+	id := s.addTitle("skills", title)
+	s.skills[id] = store.Skill{Id: id, Title: title}
+	return id, nil
+}
+
+func (s *Store) GetSkillsByCompetency(competencyId uuid.UUID) ([]store.Skill, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var skills []store.Skill
+	for link := range s.skillCompetency {
+		if link[1] == competencyId {
+			skills = append(skills, s.skills[link[0]])
+		}
+	}
+
+	sortSkills(skills)
+	return skills, nil
+}
+
+func (s *Store) CreateSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.skills[skillId]; !ok {
+		return foreignKeyViolation("skill_competency", "skill_id")
+	}
+	if _, ok := s.competencies[competencyId]; !ok {
+		return foreignKeyViolation("skill_competency", "competency_id")
+	}
+
+	s.skillCompetency[link2{skillId, competencyId}] = true
+	return nil
+}
+
+func (s *Store) DeleteSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{skillId, competencyId}
+	if !s.skillCompetency[link] {
+		return store.ErrNotFound
+	}
+	delete(s.skillCompetency, link)
+	return nil
+}
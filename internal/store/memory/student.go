@@ -0,0 +1,616 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) CreatePortfolio() (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := uuid.NewV4()
+	s.portfolios[id] = true
+	return id, nil
+}
+
+func (s *Store) GetProjectPortfolios(portfolioId uuid.UUID) ([]store.ProjectPortfolio, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var projects []store.ProjectPortfolio
+	for link, project := range s.projectPortfolio {
+		if link[1] == portfolioId {
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Semester != projects[j].Semester {
+			return projects[i].Semester < projects[j].Semester
+		}
+		return projects[i].ProjectId.String() < projects[j].ProjectId.String()
+	})
+	return projects, nil
+}
+
+func (s *Store) CreateProjectPortfolio(projectPortfolio store.ProjectPortfolio) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Postgres checks CHECK constraints before foreign keys
+	if projectPortfolio.Semester == 0 {
+		return checkViolation("project_portfolio", "semester")
+	}
+	if _, ok := s.projects[projectPortfolio.ProjectId]; !ok {
+		return foreignKeyViolation("project_portfolio", "project_id")
+	}
+	if !s.portfolios[projectPortfolio.PortfolioId] {
+		return foreignKeyViolation("project_portfolio", "portfolio_id")
+	}
+	link := link2{projectPortfolio.ProjectId, projectPortfolio.PortfolioId}
+	if _, ok := s.projectPortfolio[link]; ok {
+		return uniqueViolation("project_portfolio")
+	}
+
+	s.projectPortfolio[link] = projectPortfolio
+	return nil
+}
+
+func (s *Store) CreateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("project_portfolio_competency", "level")
+	}
+	if _, ok := s.competencies[competencyId]; !ok {
+		return foreignKeyViolation("project_portfolio_competency", "competency_id")
+	}
+	if _, ok := s.projects[projectId]; !ok {
+		return foreignKeyViolation("project_portfolio_competency", "project_id")
+	}
+	if !s.portfolios[portfolioId] {
+		return foreignKeyViolation("project_portfolio_competency", "portfolio_id")
+	}
+	link := link3{projectId, portfolioId, competencyId}
+	if _, ok := s.projectPortfolioCompetency[link]; ok {
+		return uniqueViolation("project_portfolio_competency")
+	}
+
+	s.projectPortfolioCompetency[link] = level
+	return nil
+}
+
+func (s *Store) UpdateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validLevel(level) {
+		return checkViolation("project_portfolio_competency", "level")
+	}
+	link := link3{projectId, portfolioId, competencyId}
+	if _, ok := s.projectPortfolioCompetency[link]; !ok {
+		return store.ErrNotFound
+	}
+
+	s.projectPortfolioCompetency[link] = level
+	return nil
+}
+
+func (s *Store) UpdateProjectPortfolio(projectPortfolio store.ProjectPortfolio) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{projectPortfolio.ProjectId, projectPortfolio.PortfolioId}
+	if _, ok := s.projectPortfolio[link]; !ok {
+		return store.ErrNotFound
+	}
+	if projectPortfolio.Semester == 0 {
+		return checkViolation("project_portfolio", "semester")
+	}
+
+	s.projectPortfolio[link] = projectPortfolio
+	return nil
+}
+
+func (s *Store) DeleteProjectPortfolio(projectId uuid.UUID, portfolioId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{projectId, portfolioId}
+	if _, ok := s.projectPortfolio[link]; !ok {
+		return store.ErrNotFound
+	}
+	for competency := range s.projectPortfolioCompetency {
+		if competency[0] == projectId && competency[1] == portfolioId {
+			delete(s.projectPortfolioCompetency, competency)
+		}
+	}
+	delete(s.projectPortfolio, link)
+	return nil
+}
+
+func (s *Store) DeleteProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link3{projectId, portfolioId, competencyId}
+	if _, ok := s.projectPortfolioCompetency[link]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.projectPortfolioCompetency, link)
+	return nil
+}
+
+func (s *Store) GetStudent(id uuid.UUID) (store.Student, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	student, ok := s.students[id]
+	if !ok {
+		return student, store.ErrNotFound
+	}
+	return student, nil
+}
+
+func (s *Store) GetStudentByPortfolio(portfolioId uuid.UUID) (store.Student, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found store.Student
+	for _, student := range s.students {
+		if student.PortfolioId != portfolioId || portfolioId == uuid.Nil {
+			continue
+		}
+		// a portfolio may be shared, the first student by id owns it like in Postgres
+		if found.Id == uuid.Nil || student.Id.String() < found.Id.String() {
+			found = student
+		}
+	}
+	if found.Id == uuid.Nil {
+		return found, store.ErrNotFound
+	}
+	return found, nil
+}
+
+func (s *Store) CreateStudent(student store.Student) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if student.PortfolioId != uuid.Nil && !s.portfolios[student.PortfolioId] {
+		return uuid.Nil, foreignKeyViolation("students", "portfolio_id")
+	}
+
+	student.Id = uuid.NewV4()
+	student.Admition = date(student.Admition)
+	s.students[student.Id] = student
+	return student.Id, nil
+}
+
+func (s *Store) UpdateStudent(student store.Student) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.students[student.Id]; !ok {
+		return store.ErrNotFound
+	}
+	if student.PortfolioId != uuid.Nil && !s.portfolios[student.PortfolioId] {
+		return foreignKeyViolation("students", "portfolio_id")
+	}
+
+	student.Admition = date(student.Admition)
+	s.students[student.Id] = student
+	return nil
+}
+
+func (s *Store) CreateStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.courses[courseId]; !ok {
+		return foreignKeyViolation("study_groups", "course_id")
+	}
+	if _, ok := s.students[studentId]; !ok {
+		return foreignKeyViolation("study_groups", "student_id")
+	}
+	link := link2{courseId, studentId}
+	if s.studyGroups[link] {
+		return uniqueViolation("study_groups")
+	}
+
+	s.studyGroups[link] = true
+	return nil
+}
+
+func (s *Store) GetStudyGroupCourses(studentId uuid.UUID) ([]store.Course, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var courses []store.Course
+	for link := range s.studyGroups {
+		if link[1] == studentId {
+			courses = append(courses, s.courses[link[0]])
+		}
+	}
+
+	sortCourses(courses)
+	return courses, nil
+}
+
+func (s *Store) CountStudyGroup(courseId uuid.UUID) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for link := range s.studyGroups {
+		if link[0] == courseId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Store) DeleteStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{courseId, studentId}
+	if !s.studyGroups[link] {
+		return store.ErrNotFound
+	}
+	delete(s.studyGroups, link)
+	return nil
+}
+
+func (s *Store) CreateWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.courses[courseId]; !ok {
+		return foreignKeyViolation("course_waitlists", "course_id")
+	}
+	if _, ok := s.students[studentId]; !ok {
+		return foreignKeyViolation("course_waitlists", "student_id")
+	}
+	link := link2{courseId, studentId}
+	if _, ok := s.courseWaitlists[link]; ok {
+		return uniqueViolation("course_waitlists")
+	}
+
+	s.waitlistNumber++
+	s.courseWaitlists[link] = s.waitlistNumber
+	return nil
+}
+
+func (s *Store) DeleteWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{courseId, studentId}
+	if _, ok := s.courseWaitlists[link]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.courseWaitlists, link)
+	return nil
+}
+
+// waitlist returns the waitlist of the course, s.mu must be held.
+func (s *Store) waitlist(courseId uuid.UUID) []uuid.UUID {
+	var students []uuid.UUID
+	for link := range s.courseWaitlists {
+		if link[0] == courseId {
+			students = append(students, link[1])
+		}
+	}
+	sort.Slice(students, func(i, j int) bool {
+		return s.courseWaitlists[link2{courseId, students[i]}] < s.courseWaitlists[link2{courseId, students[j]}]
+	})
+	return students
+}
+
+func (s *Store) GetCourseWaitlist(courseId uuid.UUID) ([]uuid.UUID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.waitlist(courseId), nil
+}
+
+func (s *Store) CreateStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID, day time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.courses[courseId]; !ok {
+		return foreignKeyViolation("study_group_drops", "course_id")
+	}
+	if _, ok := s.students[studentId]; !ok {
+		return foreignKeyViolation("study_group_drops", "student_id")
+	}
+
+	s.studyGroupDrops[link2{courseId, studentId}] = date(day)
+	return nil
+}
+
+func (s *Store) DeleteStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.studyGroupDrops, link2{courseId, studentId})
+	return nil
+}
+
+func (s *Store) GetStudyGroupEntries(studentId uuid.UUID) ([]store.StudyGroupEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []store.StudyGroupEntry
+	for link := range s.studyGroups {
+		if link[1] == studentId {
+			entries = append(entries, store.StudyGroupEntry{CourseId: link[0], StudentId: studentId, Status: store.StudyGroupEnrolled})
+		}
+	}
+	for link := range s.courseWaitlists {
+		if link[1] == studentId {
+			position := slices.Index(s.waitlist(link[0]), studentId) + 1
+			entries = append(entries, store.StudyGroupEntry{CourseId: link[0], StudentId: studentId,
+				Status: store.StudyGroupWaitlisted, Position: position})
+		}
+	}
+	for link, day := range s.studyGroupDrops {
+		if link[1] == studentId {
+			entries = append(entries, store.StudyGroupEntry{CourseId: link[0], StudentId: studentId,
+				Status: store.StudyGroupDropped, DropDate: day})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if titleI, titleJ := s.courses[entries[i].CourseId].Title, s.courses[entries[j].CourseId].Title; titleI != titleJ {
+			return titleI < titleJ
+		}
+		return entries[i].Status < entries[j].Status
+	})
+	return entries, nil
+}
+
+func (s *Store) GetStudentCourses(studentId uuid.UUID) ([]store.StudentCourse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// UNION removes duplicated rows
+	seen := make(map[store.StudentCourse]bool)
+	var courses []store.StudentCourse
+	add := func(course store.StudentCourse) {
+		course.DisciplineId, course.Alternative = s.courses[course.CourseId].DisciplineId, s.courses[course.CourseId].Alternative
+		if !seen[course] {
+			seen[course] = true
+			courses = append(courses, course)
+		}
+	}
+	for _, trajectory := range s.trajectories {
+		if trajectory.StudentId == studentId && trajectory.Result == store.TrajectoryPassed {
+			add(store.StudentCourse{CourseId: trajectory.CourseId})
+		}
+	}
+	for link := range s.studyGroups {
+		if link[1] == studentId {
+			add(store.StudentCourse{CourseId: link[0], Current: true})
+		}
+	}
+
+	return courses, nil
+}
+
+func (s *Store) GetTrajectory(id uuid.UUID) (store.Trajectory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trajectory, ok := s.trajectories[id]
+	if !ok {
+		return trajectory, store.ErrNotFound
+	}
+	return trajectory, nil
+}
+
+func (s *Store) GetStudentTrajectories(studentId uuid.UUID) ([]store.Trajectory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var trajectories []store.Trajectory
+	for _, trajectory := range s.trajectories {
+		if trajectory.StudentId == studentId {
+			trajectories = append(trajectories, trajectory)
+		}
+	}
+
+	sort.Slice(trajectories, func(i, j int) bool {
+		if trajectories[i].Semester != trajectories[j].Semester {
+			return trajectories[i].Semester < trajectories[j].Semester
+		}
+		return trajectories[i].Id.String() < trajectories[j].Id.String()
+	})
+	return trajectories, nil
+}
+
+func (s *Store) CreateTrajectory(trajectory store.Trajectory) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trajectory.Grade, trajectory.Result, trajectory.CompletionDate = 0, "", time.Time{}
+	// Postgres checks CHECK constraints in alphabetical order of their names
+	if trajectory.Attempt == 0 {
+		return uuid.Nil, checkViolation("trajectories", "attempt")
+	}
+	if (trajectory.RetakeOf == uuid.Nil) != (trajectory.Attempt == 1) {
+		return uuid.Nil, checkViolation("trajectories", "retake")
+	}
+	if trajectory.Semester == 0 {
+		return uuid.Nil, checkViolation("trajectories", "semester")
+	}
+	if _, ok := s.students[trajectory.StudentId]; !ok {
+		return uuid.Nil, foreignKeyViolation("trajectories", "student_id")
+	}
+	if _, ok := s.courses[trajectory.CourseId]; !ok {
+		return uuid.Nil, foreignKeyViolation("trajectories", "course_id")
+	}
+	if _, ok := s.trajectories[trajectory.RetakeOf]; !ok && trajectory.RetakeOf != uuid.Nil {
+		return uuid.Nil, foreignKeyViolation("trajectories", "retake_of")
+	}
+	for _, existing := range s.trajectories {
+		if trajectory.RetakeOf != uuid.Nil && existing.RetakeOf == trajectory.RetakeOf && existing.Attempt == trajectory.Attempt {
+			return uuid.Nil, uniqueColumnViolation("trajectories", "retake_of_attempt")
+		}
+	}
+
+	trajectory.Id = uuid.NewV4()
+	s.trajectories[trajectory.Id] = trajectory
+	return trajectory.Id, nil
+}
+
+func (s *Store) UpdateTrajectory(trajectory store.Trajectory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.trajectories[trajectory.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if trajectory.Semester == 0 {
+		return checkViolation("trajectories", "semester")
+	}
+	if _, ok := s.students[trajectory.StudentId]; !ok {
+		return foreignKeyViolation("trajectories", "student_id")
+	}
+	if _, ok := s.courses[trajectory.CourseId]; !ok {
+		return foreignKeyViolation("trajectories", "course_id")
+	}
+
+	existing.StudentId, existing.CourseId, existing.Semester = trajectory.StudentId, trajectory.CourseId, trajectory.Semester
+	s.trajectories[existing.Id] = existing
+	return nil
+}
+
+func (s *Store) UpdateTrajectoryOutcome(trajectory store.Trajectory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.trajectories[trajectory.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	trajectory.CompletionDate = date(trajectory.CompletionDate)
+	if !trajectory.CompletionDate.IsZero() && trajectory.Result == "" {
+		return checkViolation("trajectories", "completion")
+	}
+	if trajectory.Result != "" && trajectory.Result != store.TrajectoryPassed && trajectory.Result != store.TrajectoryFailed {
+		return checkViolation("trajectories", "result")
+	}
+
+	existing.Grade, existing.Result, existing.CompletionDate = trajectory.Grade, trajectory.Result, trajectory.CompletionDate
+	s.trajectories[existing.Id] = existing
+	return nil
+}
+
+func (s *Store) GetCompetencySources(studentId uuid.UUID, portfolioId uuid.UUID) ([]store.CompetencySource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sources []store.CompetencySource
+	for link, level := range s.projectPortfolioCompetency {
+		if link[1] == portfolioId {
+			sources = append(sources, store.CompetencySource{
+				CompetencyId: link[2], Kind: store.SourcePortfolioProject, Id: link[0], Title: s.projects[link[0]].Title, Level: level,
+			})
+		}
+	}
+
+	addCourse := func(courseId uuid.UUID, kind string) {
+		for link, level := range s.courseCompetency {
+			if link[0] == courseId {
+				sources = append(sources, store.CompetencySource{
+					CompetencyId: link[1], Kind: kind, Id: courseId, Title: s.courses[courseId].Title, Level: level,
+				})
+			}
+		}
+	}
+	for _, trajectory := range s.trajectories {
+		if trajectory.StudentId == studentId && trajectory.Result == store.TrajectoryPassed {
+			addCourse(trajectory.CourseId, store.SourceTrajectoryCourse)
+		}
+	}
+	for link := range s.studyGroups {
+		if link[1] == studentId {
+			addCourse(link[0], store.SourceStudyGroupCourse)
+		}
+	}
+
+	return sources, nil
+}
+
+func (s *Store) GetStudentOrganization(studentId uuid.UUID) (uuid.UUID, error) {
+	if educationalProgram, ok := s.enrolledProgram(studentId); ok {
+		return educationalProgram.OrganizationId, nil
+	}
+	return s.mostTaken(studentId, func(educationalProgram store.EducationalProgram) uuid.UUID {
+		return educationalProgram.OrganizationId
+	})
+}
+
+func (s *Store) GetStudentEducationalProgram(studentId uuid.UUID) (uuid.UUID, error) {
+	if educationalProgram, ok := s.enrolledProgram(studentId); ok {
+		return educationalProgram.Id, nil
+	}
+	return s.mostTaken(studentId, func(educationalProgram store.EducationalProgram) uuid.UUID {
+		return educationalProgram.Id
+	})
+}
+
+// enrolledProgram returns the educational program of the current enrollment of the student.
+func (s *Store) enrolledProgram(studentId uuid.UUID) (store.EducationalProgram, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	enrollments := s.studentEnrollments(studentId)
+	if len(enrollments) == 0 {
+		return store.EducationalProgram{}, false
+	}
+	return s.educationalPrograms[enrollments[len(enrollments)-1].EducationalProgramId], true
+}
+
+// mostTaken returns the key of the educational program most of the student courses give, ties go to the smaller key.
+func (s *Store) mostTaken(studentId uuid.UUID, key func(educationalProgram store.EducationalProgram) uuid.UUID) (uuid.UUID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	count := func(courseId uuid.UUID) {
+		discipline := s.disciplines[s.courses[courseId].DisciplineId]
+		counts[key(s.educationalPrograms[discipline.EducationalProgramId])]++
+	}
+	for _, trajectory := range s.trajectories {
+		if trajectory.StudentId == studentId {
+			count(trajectory.CourseId)
+		}
+	}
+	for link := range s.studyGroups {
+		if link[1] == studentId {
+			count(link[0])
+		}
+	}
+
+	most := uuid.Nil
+	for id, n := range counts {
+		if most == uuid.Nil || n > counts[most] ||
+			n == counts[most] && id.String() < most.String() {
+			most = id
+		}
+	}
+	if most == uuid.Nil {
+		return most, store.ErrNotFound
+	}
+	return most, nil
+}
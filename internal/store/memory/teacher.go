@@ -0,0 +1,128 @@
+package memory
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetTeacher(id uuid.UUID) (store.Teacher, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teacher, ok := s.teachers[id]
+	if !ok {
+		return teacher, store.ErrNotFound
+	}
+	return teacher, nil
+}
+
+func (s *Store) CreateTeacher(teacher store.Teacher) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.titleId("teachers", teacher.FullName); ok {
+		return id, nil
+	}
+	if _, ok := s.organizations[teacher.OrganizationId]; teacher.OrganizationId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("teachers", "organization_id")
+	}
+
+	teacher.Id = s.addTitle("teachers", teacher.FullName)
+	s.teachers[teacher.Id] = teacher
+	return teacher.Id, nil
+}
+
+func (s *Store) SaveTeacher(teacher store.Teacher) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.organizations[teacher.OrganizationId]; teacher.OrganizationId != uuid.Nil && !ok {
+		return uuid.Nil, foreignKeyViolation("teachers", "organization_id")
+	}
+	id, ok := s.titleId("teachers", teacher.FullName)
+	if !ok {
+		id = s.addTitle("teachers", teacher.FullName)
+	}
+
+	teacher.Id = id
+	s.teachers[id] = teacher
+	return id, nil
+}
+
+func (s *Store) CreateCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.courses[courseId]; !ok {
+		return foreignKeyViolation("course_teacher", "course_id")
+	}
+	if _, ok := s.teachers[teacherId]; !ok {
+		return foreignKeyViolation("course_teacher", "teacher_id")
+	}
+
+	s.courseTeacher[link2{courseId, teacherId}] = true
+	return nil
+}
+
+func (s *Store) GetTeachersByCourse(courseId uuid.UUID) ([]store.Teacher, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var teachers []store.Teacher
+	for link := range s.courseTeacher {
+		if link[0] == courseId {
+			teachers = append(teachers, s.teachers[link[1]])
+		}
+	}
+
+	sortTeachers(teachers)
+	return teachers, nil
+}
+
+func (s *Store) GetCoursesByTeacher(teacherId uuid.UUID) ([]store.Course, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var courses []store.Course
+	for link := range s.courseTeacher {
+		if link[1] == teacherId {
+			courses = append(courses, s.courses[link[0]])
+		}
+	}
+
+	sortCourses(courses)
+	return courses, nil
+}
+
+func (s *Store) DeleteCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := link2{courseId, teacherId}
+	if !s.courseTeacher[link] {
+		return store.ErrNotFound
+	}
+	delete(s.courseTeacher, link)
+	return nil
+}
+
+func (s *Store) UpdateTeacher(teacher store.Teacher) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.teachers[teacher.Id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.titleTaken("teachers", teacher.Id, teacher.FullName) {
+		return uniqueColumnViolation("teachers", "full_name")
+	}
+	if _, ok := s.organizations[teacher.OrganizationId]; teacher.OrganizationId != uuid.Nil && !ok {
+		return foreignKeyViolation("teachers", "organization_id")
+	}
+
+	s.retitle("teachers", teacher.Id, existing.FullName, teacher.FullName)
+	s.teachers[teacher.Id] = teacher
+	return nil
+}
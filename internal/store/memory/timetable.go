@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetTimeSlots() ([]store.TimeSlot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var timeSlots []store.TimeSlot
+	for _, timeSlot := range s.timeSlots {
+		timeSlots = append(timeSlots, timeSlot)
+	}
+
+	sort.Slice(timeSlots, func(i, j int) bool {
+		if timeSlots[i].Weekday != timeSlots[j].Weekday {
+			return timeSlots[i].Weekday < timeSlots[j].Weekday
+		}
+		return timeSlots[i].Start < timeSlots[j].Start
+	})
+	return timeSlots, nil
+}
+
+func (s *Store) CreateTimeSlot(timeSlot store.TimeSlot) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Postgres checks CHECK constraints before looking for the conflicting row
+	if timeSlot.Start >= timeSlot.End {
+		return uuid.Nil, checkViolation("time_slots", "times")
+	}
+	if timeSlot.Weekday < 1 || timeSlot.Weekday > 7 {
+		return uuid.Nil, checkViolation("time_slots", "weekday")
+	}
+	for id, existing := range s.timeSlots {
+		if existing.Weekday == timeSlot.Weekday && existing.Start == timeSlot.Start {
+			existing.End = timeSlot.End
+			s.timeSlots[id] = existing
+			return id, nil
+		}
+	}
+
+	timeSlot.Id = uuid.NewV4()
+	s.timeSlots[timeSlot.Id] = timeSlot
+	return timeSlot.Id, nil
+}
+
+func (s *Store) GetRooms() ([]store.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rooms []store.Room
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		if rooms[i].Capacity != rooms[j].Capacity {
+			return rooms[i].Capacity < rooms[j].Capacity
+		}
+		return rooms[i].Title < rooms[j].Title
+	})
+	return rooms, nil
+}
+
+func (s *Store) CreateRoom(room store.Room) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if room.Capacity <= 0 {
+		return uuid.Nil, checkViolation("rooms", "capacity")
+	}
+	if id, ok := s.titleId("rooms", room.Title); ok {
+		room.Id = id
+		s.rooms[id] = room
+		return id, nil
+	}
+
+	room.Id = s.addTitle("rooms", room.Title)
+	s.rooms[room.Id] = room
+	return room.Id, nil
+}
+
+func (s *Store) GetCourseSessions() ([]store.CourseSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var courseSessions []store.CourseSession
+	for _, courseSession := range s.courseSessions {
+		courseSessions = append(courseSessions, courseSession)
+	}
+
+	sort.Slice(courseSessions, func(i, j int) bool {
+		if courseSessions[i].CourseId != courseSessions[j].CourseId {
+			return courseSessions[i].CourseId.String() < courseSessions[j].CourseId.String()
+		}
+		return courseSessions[i].Kind < courseSessions[j].Kind
+	})
+	return courseSessions, nil
+}
+
+func (s *Store) CreateCourseSession(courseSession store.CourseSession) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if courseSession.Kind != store.SessionLecture && courseSession.Kind != store.SessionPractice && courseSession.Kind != store.SessionLab {
+		return uuid.Nil, checkViolation("course_sessions", "kind")
+	}
+	if courseSession.WeeklyHours == 0 {
+		return uuid.Nil, checkViolation("course_sessions", "weekly_hours")
+	}
+	for id, existing := range s.courseSessions {
+		if existing.CourseId == courseSession.CourseId && existing.Kind == courseSession.Kind {
+			existing.WeeklyHours = courseSession.WeeklyHours
+			s.courseSessions[id] = existing
+			return id, nil
+		}
+	}
+	if _, ok := s.courses[courseSession.CourseId]; !ok {
+		return uuid.Nil, foreignKeyViolation("course_sessions", "course_id")
+	}
+
+	courseSession.Id = uuid.NewV4()
+	s.courseSessions[courseSession.Id] = courseSession
+	return courseSession.Id, nil
+}
+
+func (s *Store) GetTeacherAvailability() ([]store.TeacherAvailability, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var availability []store.TeacherAvailability
+	for teacherAvailability := range s.teacherAvailability {
+		availability = append(availability, teacherAvailability)
+	}
+
+	sort.Slice(availability, func(i, j int) bool {
+		if availability[i].TeacherId != availability[j].TeacherId {
+			return availability[i].TeacherId.String() < availability[j].TeacherId.String()
+		}
+		return availability[i].TimeSlotId.String() < availability[j].TimeSlotId.String()
+	})
+	return availability, nil
+}
+
+func (s *Store) CreateTeacherAvailability(teacherAvailability store.TeacherAvailability) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.timeSlots[teacherAvailability.TimeSlotId]; !ok {
+		return foreignKeyViolation("teacher_availability", "time_slot_id")
+	}
+	if _, ok := s.teachers[teacherAvailability.TeacherId]; !ok {
+		return foreignKeyViolation("teacher_availability", "teacher_id")
+	}
+	if s.teacherAvailability[teacherAvailability] {
+		return uniqueViolation("teacher_availability")
+	}
+
+	s.teacherAvailability[teacherAvailability] = true
+	return nil
+}
+
+func (s *Store) GetStudyGroups() ([]store.StudyGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var studyGroups []store.StudyGroup
+	for link := range s.studyGroups {
+		studyGroups = append(studyGroups, store.StudyGroup{CourseId: link[0], StudentId: link[1]})
+	}
+
+	return studyGroups, nil
+}
+
+func (s *Store) GetTimetable() ([]store.TimetableEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]store.TimetableEntry(nil), s.timetable...), nil
+}
+
+func (s *Store) ReplaceTimetable(entries []store.TimetableEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	occupied := make(map[link2]bool)
+	for _, entry := range entries {
+		if _, ok := s.courseSessions[entry.CourseSessionId]; !ok {
+			return foreignKeyViolation("timetable", "course_session_id")
+		}
+		if _, ok := s.timeSlots[entry.TimeSlotId]; !ok {
+			return foreignKeyViolation("timetable", "time_slot_id")
+		}
+		if _, ok := s.rooms[entry.RoomId]; !ok {
+			return foreignKeyViolation("timetable", "room_id")
+		}
+		link := link2{entry.TimeSlotId, entry.RoomId}
+		if occupied[link] {
+			return uniqueViolation("timetable")
+		}
+		occupied[link] = true
+	}
+
+	s.timetable = append([]store.TimetableEntry(nil), entries...)
+	return nil
+}
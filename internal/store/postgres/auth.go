@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetApiKey(id uuid.UUID) (store.ApiKey, error) {
+	var apiKey store.ApiKey
+	err := s.db.QueryRow(`SELECT api_key_id, api_key_hash, api_key_title, api_key_role, COALESCE(student_id, uuid_nil()),
+		COALESCE(teacher_id, uuid_nil()) FROM api_keys WHERE api_key_id = $1`, id).
+		Scan(&apiKey.Id, &apiKey.Hash, &apiKey.Title, &apiKey.Role, &apiKey.StudentId, &apiKey.TeacherId)
+	return apiKey, err
+}
+
+func (s *Store) GetApiKeyByHash(hash string) (store.ApiKey, error) {
+	var apiKey store.ApiKey
+	err := s.db.QueryRow(`SELECT api_key_id, api_key_hash, api_key_title, api_key_role, COALESCE(student_id, uuid_nil()),
+		COALESCE(teacher_id, uuid_nil()) FROM api_keys WHERE api_key_hash = $1`, hash).
+		Scan(&apiKey.Id, &apiKey.Hash, &apiKey.Title, &apiKey.Role, &apiKey.StudentId, &apiKey.TeacherId)
+	return apiKey, err
+}
+
+func (s *Store) CreateApiKey(apiKey store.ApiKey) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO api_keys (api_key_hash, api_key_title, api_key_role, student_id, teacher_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING api_key_id`,
+		apiKey.Hash, apiKey.Title, apiKey.Role, nullId(apiKey.StudentId), nullId(apiKey.TeacherId))
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"database/sql"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetCalendarSemesters(organizationId uuid.UUID) ([]store.CalendarSemester, error) {
+	rows, err := s.db.Query(`SELECT organization_id, academic_year, term, start_date, end_date, enrollment_start, enrollment_end
+		FROM calendar_semesters WHERE organization_id = $1 ORDER BY start_date`, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var semesters []store.CalendarSemester
+	for rows.Next() {
+		var semester store.CalendarSemester
+		var enrollmentStart, enrollmentEnd sql.NullTime
+		if err = rows.Scan(&semester.OrganizationId, &semester.Year, &semester.Term, &semester.Start, &semester.End,
+			&enrollmentStart, &enrollmentEnd); err != nil {
+			return nil, err
+		}
+		semester.EnrollmentStart, semester.EnrollmentEnd = enrollmentStart.Time, enrollmentEnd.Time
+
+		semesters = append(semesters, semester)
+	}
+
+	return semesters, rows.Err()
+}
+
+func (s *Store) CreateCalendarSemester(semester store.CalendarSemester) error {
+	_, err := s.db.Exec(`INSERT INTO calendar_semesters (organization_id, academic_year, term, start_date, end_date,
+			enrollment_start, enrollment_end)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (organization_id, academic_year, term)
+		DO UPDATE SET start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date,
+			enrollment_start = EXCLUDED.enrollment_start, enrollment_end = EXCLUDED.enrollment_end`,
+		semester.OrganizationId, semester.Year, semester.Term, semester.Start, semester.End,
+		nullTime(semester.EnrollmentStart), nullTime(semester.EnrollmentEnd))
+	return err
+}
+
+func (s *Store) GetCalendarPeriods(organizationId uuid.UUID) ([]store.CalendarPeriod, error) {
+	rows, err := s.db.Query(`SELECT calendar_period_id, organization_id, kind, COALESCE(title, ''), start_date, end_date
+		FROM calendar_periods WHERE organization_id = $1 ORDER BY start_date, calendar_period_id`, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []store.CalendarPeriod
+	for rows.Next() {
+		var period store.CalendarPeriod
+		if err = rows.Scan(&period.Id, &period.OrganizationId, &period.Kind, &period.Title, &period.Start, &period.End); err != nil {
+			return nil, err
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+func (s *Store) CreateCalendarPeriod(period store.CalendarPeriod) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO calendar_periods (organization_id, kind, title, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5) RETURNING calendar_period_id`,
+		period.OrganizationId, period.Kind, period.Title, period.Start, period.End)
+}
@@ -0,0 +1,559 @@
+package postgres
+
+import (
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const competencyColumns = `competencies.competency_id, competencies.title, COALESCE(competencies.parent_id, uuid_nil()),
+	COALESCE(competencies.main_technology_id, uuid_nil())`
+
+const knowledgeColumns = `knowledge.knowledge_id, knowledge.title, COALESCE(knowledge.parent_id, uuid_nil())`
+
+const courseColumns = `courses.course_id, courses.title, COALESCE(courses.description, ''), courses.discipline_id,
+	courses.credits, courses.hours, courses.alternative, courses.capacity`
+
+const educationalProgramColumns = `educational_programs.educational_program_id, educational_programs.title,
+	COALESCE(educational_programs.description, ''), educational_programs.organizations_id,
+	educational_programs.min_semester_credits, educational_programs.max_semester_credits`
+
+func (s *Store) queryKnowledge(query string, args ...any) ([]store.Knowledge, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var knowledge []store.Knowledge
+	for rows.Next() {
+		var item store.Knowledge
+		if err = rows.Scan(&item.Id, &item.Title, &item.ParentId); err != nil {
+			return nil, err
+		}
+
+		knowledge = append(knowledge, item)
+	}
+
+	return knowledge, rows.Err()
+}
+
+func (s *Store) GetKnowledge(id uuid.UUID) (store.Knowledge, error) {
+	var knowledge store.Knowledge
+	err := s.db.QueryRow(`SELECT `+knowledgeColumns+` FROM knowledge WHERE knowledge_id = $1`, id).
+		Scan(&knowledge.Id, &knowledge.Title, &knowledge.ParentId)
+	return knowledge, err
+}
+
+func (s *Store) GetKnowledgeByTitle(title string) (store.Knowledge, error) {
+	var knowledge store.Knowledge
+	err := s.db.QueryRow(`SELECT `+knowledgeColumns+` FROM knowledge WHERE title = $1`, title).
+		Scan(&knowledge.Id, &knowledge.Title, &knowledge.ParentId)
+	return knowledge, err
+}
+
+func (s *Store) CreateKnowledge(title string) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO knowledge (title) VALUES ($1)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING knowledge_id`, title)
+}
+
+func (s *Store) GetKnowledgeByCompetency(competencyId uuid.UUID) ([]store.Knowledge, error) {
+	return s.queryKnowledge(`SELECT `+knowledgeColumns+` FROM knowledge
+		JOIN knowledge_competency ON knowledge_competency.knowledge_id = knowledge.knowledge_id
+		WHERE knowledge_competency.competency_id = $1 ORDER BY knowledge.title`, competencyId)
+}
+
+func (s *Store) CreateKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
+	_, err := s.db.Exec(`INSERT INTO knowledge_competency (knowledge_id, competency_id) VALUES ($1, $2)`, knowledgeId, competencyId)
+	return err
+}
+
+func (s *Store) UpdateKnowledgeParent(id uuid.UUID, parentId uuid.UUID) error {
+	return s.updateOne(`UPDATE knowledge SET parent_id = $2 WHERE knowledge_id = $1`, id, nullId(parentId))
+}
+
+func (s *Store) GetTechnology(id uuid.UUID) (store.Technology, error) {
+	var technology store.Technology
+	err := s.db.QueryRow(`SELECT technology_id, title FROM technologies WHERE technology_id = $1`, id).Scan(&technology.Id, &technology.Title)
+	return technology, err
+}
+
+func (s *Store) GetTechnologyByTitle(title string) (store.Technology, error) {
+	var technology store.Technology
+	err := s.db.QueryRow(`SELECT technology_id, title FROM technologies WHERE title = $1`, title).Scan(&technology.Id, &technology.Title)
+	return technology, err
+}
+
+func (s *Store) CreateTechnology(title string) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO technologies (title) VALUES ($1)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING technology_id`, title)
+}
+
+func (s *Store) GetCompetency(id uuid.UUID) (store.Competency, error) {
+	var competency store.Competency
+	err := s.db.QueryRow(`SELECT `+competencyColumns+` FROM competencies WHERE competency_id = $1`, id).
+		Scan(&competency.Id, &competency.Title, &competency.ParentId, &competency.MainTechnologyId)
+	return competency, err
+}
+
+func (s *Store) GetCompetencyByTitle(title string) (store.Competency, error) {
+	var competency store.Competency
+	err := s.db.QueryRow(`SELECT `+competencyColumns+` FROM competencies WHERE title = $1`, title).
+		Scan(&competency.Id, &competency.Title, &competency.ParentId, &competency.MainTechnologyId)
+	return competency, err
+}
+
+func (s *Store) CreateCompetency(competency store.Competency) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO competencies (title, parent_id, main_technology_id) VALUES ($1, $2, $3)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING competency_id`,
+		competency.Title, nullId(competency.ParentId), nullId(competency.MainTechnologyId))
+}
+
+func (s *Store) SaveCompetency(competency store.Competency) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO competencies (title, parent_id, main_technology_id) VALUES ($1, $2, $3)
+		ON CONFLICT (title) DO UPDATE SET parent_id = excluded.parent_id, main_technology_id = excluded.main_technology_id
+		RETURNING competency_id`,
+		competency.Title, nullId(competency.ParentId), nullId(competency.MainTechnologyId))
+}
+
+func (s *Store) GetCompetenciesByProfession(professionId uuid.UUID) ([]store.Competency, error) {
+	return s.queryCompetencies(`SELECT `+competencyColumns+`, competency_profession.level FROM competencies
+		JOIN competency_profession ON competency_profession.competency_id = competencies.competency_id
+		WHERE competency_profession.profession_id = $1 ORDER BY competencies.title`, professionId)
+}
+
+func (s *Store) GetCompetenciesByCourse(courseId uuid.UUID) ([]store.Competency, error) {
+	return s.queryCompetencies(`SELECT `+competencyColumns+`, course_competency.level FROM competencies
+		JOIN course_competency ON course_competency.competency_id = competencies.competency_id
+		WHERE course_competency.course_id = $1 ORDER BY competencies.title`, courseId)
+}
+
+func (s *Store) GetCompetenciesByPersonalProject(portfolioId uuid.UUID, projectId uuid.UUID) ([]store.Competency, error) {
+	return s.queryCompetencies(`SELECT `+competencyColumns+`, project_portfolio_competency.level FROM competencies
+		JOIN project_portfolio_competency ON project_portfolio_competency.competency_id = competencies.competency_id
+		WHERE project_portfolio_competency.portfolio_id = $1 AND project_portfolio_competency.project_id = $2
+		ORDER BY competencies.title`, portfolioId, projectId)
+}
+
+func (s *Store) GetProfession(id uuid.UUID) (store.Profession, error) {
+	var profession store.Profession
+	err := s.db.QueryRow(`SELECT profession_id, title, COALESCE(description, '') FROM professions WHERE profession_id = $1`, id).
+		Scan(&profession.Id, &profession.Title, &profession.Description)
+	return profession, err
+}
+
+func (s *Store) GetProfessionByTitle(title string) (store.Profession, error) {
+	var profession store.Profession
+	err := s.db.QueryRow(`SELECT profession_id, title, COALESCE(description, '') FROM professions WHERE title = $1`, title).
+		Scan(&profession.Id, &profession.Title, &profession.Description)
+	return profession, err
+}
+
+func (s *Store) CreateProfession(profession store.Profession) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO professions (title, description) VALUES ($1, $2)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING profession_id`, profession.Title, profession.Description)
+}
+
+func (s *Store) SaveProfession(profession store.Profession) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO professions (title, description) VALUES ($1, $2)
+		ON CONFLICT (title) DO UPDATE SET description = excluded.description RETURNING profession_id`,
+		profession.Title, profession.Description)
+}
+
+func (s *Store) CreateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error {
+	_, err := s.db.Exec(`INSERT INTO competency_profession (competency_id, profession_id, level) VALUES ($1, $2, $3)`,
+		competencyId, professionId, level)
+	return err
+}
+
+func (s *Store) UpdateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error {
+	return s.updateOne(`UPDATE competency_profession SET level = $3 WHERE competency_id = $1 AND profession_id = $2`,
+		competencyId, professionId, level)
+}
+
+func (s *Store) GetProject(id uuid.UUID) (store.Project, error) {
+	var project store.Project
+	err := s.db.QueryRow(`SELECT project_id, title, COALESCE(description, ''), COALESCE(result, ''), COALESCE(life_scenario, ''),
+		COALESCE(main_technology_id, uuid_nil()) FROM projects WHERE project_id = $1`, id).
+		Scan(&project.Id, &project.Title, &project.Description, &project.Result, &project.LifeScenario, &project.MainTechnologyId)
+	return project, err
+}
+
+func (s *Store) CreateProject(project store.Project) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO projects (title, description, result, life_scenario, main_technology_id) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING project_id`,
+		project.Title, project.Description, project.Result, project.LifeScenario, nullId(project.MainTechnologyId))
+}
+
+func (s *Store) GetOrganization(id uuid.UUID) (store.Organization, error) {
+	var organization store.Organization
+	err := s.db.QueryRow(`SELECT organization_id, COALESCE(title, '') FROM organizations WHERE organization_id = $1`, id).
+		Scan(&organization.Id, &organization.Title)
+	return organization, err
+}
+
+func (s *Store) CreateOrganization(title string) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO organizations (title) VALUES ($1)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING organization_id`, title)
+}
+
+func (s *Store) GetGradingScale(organizationId uuid.UUID) (store.GradingScale, error) {
+	scale := store.GradingScale{OrganizationId: organizationId}
+	err := s.db.QueryRow(`SELECT min_grade, max_grade, pass_grade FROM grading_scales WHERE organization_id = $1`, organizationId).
+		Scan(&scale.MinGrade, &scale.MaxGrade, &scale.PassGrade)
+	return scale, err
+}
+
+func (s *Store) SaveGradingScale(scale store.GradingScale) error {
+	_, err := s.db.Exec(`INSERT INTO grading_scales (organization_id, min_grade, max_grade, pass_grade) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id) DO UPDATE SET min_grade = excluded.min_grade, max_grade = excluded.max_grade,
+		pass_grade = excluded.pass_grade`, scale.OrganizationId, scale.MinGrade, scale.MaxGrade, scale.PassGrade)
+	return err
+}
+
+func (s *Store) GetEducationalProgram(id uuid.UUID) (store.EducationalProgram, error) {
+	var educationalProgram store.EducationalProgram
+	err := s.db.QueryRow(`SELECT `+educationalProgramColumns+` FROM educational_programs WHERE educational_program_id = $1`, id).
+		Scan(&educationalProgram.Id, &educationalProgram.Title, &educationalProgram.Description, &educationalProgram.OrganizationId,
+			&educationalProgram.MinSemesterCredits, &educationalProgram.MaxSemesterCredits)
+	return educationalProgram, err
+}
+
+func (s *Store) CreateEducationalProgram(educationalProgram store.EducationalProgram) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO educational_programs (title, description, organizations_id, min_semester_credits, max_semester_credits)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING educational_program_id`,
+		educationalProgram.Title, educationalProgram.Description, educationalProgram.OrganizationId,
+		educationalProgram.MinSemesterCredits, educationalProgram.MaxSemesterCredits)
+}
+
+func (s *Store) SaveEducationalProgram(educationalProgram store.EducationalProgram) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO educational_programs (title, description, organizations_id, min_semester_credits, max_semester_credits)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (title) DO UPDATE SET description = excluded.description,
+		organizations_id = excluded.organizations_id, min_semester_credits = excluded.min_semester_credits,
+		max_semester_credits = excluded.max_semester_credits RETURNING educational_program_id`,
+		educationalProgram.Title, educationalProgram.Description, educationalProgram.OrganizationId,
+		educationalProgram.MinSemesterCredits, educationalProgram.MaxSemesterCredits)
+}
+
+func (s *Store) GetDiscipline(id uuid.UUID) (store.Discipline, error) {
+	var discipline store.Discipline
+	err := s.db.QueryRow(`SELECT discipline_id, title, COALESCE(description, ''), educational_program_id
+		FROM disciplines WHERE discipline_id = $1`, id).
+		Scan(&discipline.Id, &discipline.Title, &discipline.Description, &discipline.EducationalProgramId)
+	return discipline, err
+}
+
+func (s *Store) CreateDiscipline(discipline store.Discipline) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO disciplines (title, description, educational_program_id) VALUES ($1, $2, $3)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING discipline_id`,
+		discipline.Title, discipline.Description, discipline.EducationalProgramId)
+}
+
+func (s *Store) SaveDiscipline(discipline store.Discipline) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO disciplines (title, description, educational_program_id) VALUES ($1, $2, $3)
+		ON CONFLICT (title) DO UPDATE SET description = excluded.description, educational_program_id = excluded.educational_program_id
+		RETURNING discipline_id`,
+		discipline.Title, discipline.Description, discipline.EducationalProgramId)
+}
+
+func (s *Store) GetCourse(id uuid.UUID) (store.Course, error) {
+	var course store.Course
+	err := s.db.QueryRow(`SELECT `+courseColumns+` FROM courses WHERE course_id = $1`, id).
+		Scan(&course.Id, &course.Title, &course.Description, &course.DisciplineId, &course.Credits, &course.Hours, &course.Alternative,
+			&course.Capacity)
+	return course, err
+}
+
+func (s *Store) CreateCourse(course store.Course) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO courses (title, description, discipline_id, credits, hours, alternative, capacity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING course_id`,
+		course.Title, course.Description, course.DisciplineId, course.Credits, course.Hours, course.Alternative, course.Capacity)
+}
+
+func (s *Store) SaveCourse(course store.Course) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO courses (title, description, discipline_id, credits, hours, alternative)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (title) DO UPDATE SET description = excluded.description,
+		discipline_id = excluded.discipline_id, credits = excluded.credits, hours = excluded.hours,
+		alternative = excluded.alternative RETURNING course_id`,
+		course.Title, course.Description, course.DisciplineId, course.Credits, course.Hours, course.Alternative)
+}
+
+func (s *Store) GetCoursesByDiscipline(disciplineId uuid.UUID) ([]store.Course, error) {
+	return s.queryCourses(`SELECT `+courseColumns+` FROM courses WHERE discipline_id = $1 ORDER BY title`, disciplineId)
+}
+
+func (s *Store) CreateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	_, err := s.db.Exec(`INSERT INTO course_competency (course_id, competency_id, level) VALUES ($1, $2, $3)`,
+		courseId, competencyId, level)
+	return err
+}
+
+func (s *Store) UpdateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	return s.updateOne(`UPDATE course_competency SET level = $3 WHERE course_id = $1 AND competency_id = $2`,
+		courseId, competencyId, level)
+}
+
+func (s *Store) GetCoursesByCompetencies(competencyIds []uuid.UUID) ([]store.CourseCompetency, error) {
+	if len(competencyIds) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(competencyIds))
+	for _, id := range competencyIds {
+		ids = append(ids, id.String())
+	}
+	rows, err := s.db.Query(`SELECT courses.course_id, courses.title, courses.credits, courses.discipline_id, courses.alternative,
+		course_competency.competency_id, course_competency.level FROM courses
+		JOIN course_competency ON course_competency.course_id = courses.course_id
+		WHERE course_competency.competency_id = ANY($1::uuid[]) ORDER BY courses.title, course_competency.competency_id`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []store.CourseCompetency
+	for rows.Next() {
+		var course store.CourseCompetency
+		if err = rows.Scan(&course.CourseId, &course.CourseTitle, &course.CourseCredits, &course.CourseDisciplineId,
+			&course.CourseAlternative, &course.CompetencyId, &course.Level); err != nil {
+			return nil, err
+		}
+
+		courses = append(courses, course)
+	}
+
+	return courses, rows.Err()
+}
+
+func (s *Store) GetCoursePrerequisites() ([]store.CoursePrerequisite, error) {
+	rows, err := s.db.Query(`SELECT course_id, prerequisite_id, kind FROM course_prerequisite ORDER BY course_id, prerequisite_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []store.CoursePrerequisite
+	for rows.Next() {
+		var prerequisite store.CoursePrerequisite
+		if err = rows.Scan(&prerequisite.CourseId, &prerequisite.PrerequisiteId, &prerequisite.Kind); err != nil {
+			return nil, err
+		}
+
+		prerequisites = append(prerequisites, prerequisite)
+	}
+
+	return prerequisites, rows.Err()
+}
+
+func (s *Store) CreateCoursePrerequisite(prerequisite store.CoursePrerequisite) error {
+	_, err := s.db.Exec(`INSERT INTO course_prerequisite (course_id, prerequisite_id, kind) VALUES ($1, $2, $3)
+		ON CONFLICT (course_id, prerequisite_id) DO UPDATE SET kind = EXCLUDED.kind`,
+		prerequisite.CourseId, prerequisite.PrerequisiteId, prerequisite.Kind)
+	return err
+}
+
+func (s *Store) DeleteCoursePrerequisite(courseId uuid.UUID, prerequisiteId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM course_prerequisite WHERE course_id = $1 AND prerequisite_id = $2`, courseId, prerequisiteId)
+}
+
+// queryCatalogLinks returns rows of the link table, from and to are its columns and level is the expression of the level.
+func (s *Store) queryCatalogLinks(table string, from string, to string, level string) ([]store.CatalogLink, error) {
+	rows, err := s.db.Query(`SELECT ` + from + `, ` + to + `, ` + level + ` FROM ` + table + ` ORDER BY ` + from + `, ` + to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []store.CatalogLink
+	for rows.Next() {
+		var link store.CatalogLink
+		if err = rows.Scan(&link.FromId, &link.ToId, &link.Level); err != nil {
+			return nil, err
+		}
+
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// queryTitled returns id and title of every row of the table ordered by title.
+func (s *Store) queryTitled(table string, idColumn string) ([]store.Technology, error) {
+	rows, err := s.db.Query(`SELECT ` + idColumn + `, COALESCE(title, '') FROM ` + table + ` ORDER BY title`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []store.Technology
+	for rows.Next() {
+		var item store.Technology
+		if err = rows.Scan(&item.Id, &item.Title); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (s *Store) GetCatalog() (store.Catalog, error) {
+	var catalog store.Catalog
+	err := s.transaction(func(tx *Store) error {
+		organizations, err := tx.queryTitled("organizations", "organization_id")
+		if err != nil {
+			return err
+		}
+		for _, organization := range organizations {
+			catalog.Organizations = append(catalog.Organizations, store.Organization(organization))
+		}
+		if catalog.Technologies, err = tx.queryTitled("technologies", "technology_id"); err != nil {
+			return err
+		}
+		if catalog.Knowledge, err = tx.queryKnowledge(`SELECT ` + knowledgeColumns + ` FROM knowledge ORDER BY knowledge.title`); err != nil {
+			return err
+		}
+		if catalog.Skills, err = tx.querySkills(`SELECT ` + skillColumns + ` FROM skills ORDER BY skills.title`); err != nil {
+			return err
+		}
+
+		rows, err := tx.db.Query(`SELECT ` + educationalProgramColumns + ` FROM educational_programs ORDER BY title`)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var educationalProgram store.EducationalProgram
+			if err = rows.Scan(&educationalProgram.Id, &educationalProgram.Title, &educationalProgram.Description,
+				&educationalProgram.OrganizationId, &educationalProgram.MinSemesterCredits, &educationalProgram.MaxSemesterCredits); err != nil {
+				rows.Close()
+				return err
+			}
+			catalog.EducationalPrograms = append(catalog.EducationalPrograms, educationalProgram)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		rows, err = tx.db.Query(`SELECT discipline_id, title, COALESCE(description, ''), educational_program_id
+			FROM disciplines ORDER BY title`)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var discipline store.Discipline
+			if err = rows.Scan(&discipline.Id, &discipline.Title, &discipline.Description, &discipline.EducationalProgramId); err != nil {
+				rows.Close()
+				return err
+			}
+			catalog.Disciplines = append(catalog.Disciplines, discipline)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if catalog.Courses, err = tx.queryCourses(`SELECT ` + courseColumns + ` FROM courses ORDER BY courses.title`); err != nil {
+			return err
+		}
+		if catalog.Competencies, err = tx.queryCompetencies(`SELECT ` + competencyColumns + `, 0 FROM competencies
+			ORDER BY competencies.title`); err != nil {
+			return err
+		}
+
+		rows, err = tx.db.Query(`SELECT profession_id, title, COALESCE(description, '') FROM professions ORDER BY title`)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var profession store.Profession
+			if err = rows.Scan(&profession.Id, &profession.Title, &profession.Description); err != nil {
+				rows.Close()
+				return err
+			}
+			catalog.Professions = append(catalog.Professions, profession)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if catalog.KnowledgeCompetencies, err = tx.queryCatalogLinks("knowledge_competency", "knowledge_id", "competency_id", "0"); err != nil {
+			return err
+		}
+		if catalog.SkillCompetencies, err = tx.queryCatalogLinks("skill_competency", "skill_id", "competency_id", "0"); err != nil {
+			return err
+		}
+		if catalog.CompetencyProfessions, err = tx.queryCatalogLinks("competency_profession", "competency_id", "profession_id", "level"); err != nil {
+			return err
+		}
+		if catalog.CourseCompetencies, err = tx.queryCatalogLinks("course_competency", "course_id", "competency_id", "level"); err != nil {
+			return err
+		}
+		if catalog.Teachers, err = tx.queryTeachers(`SELECT ` + teacherColumns + ` FROM teachers ORDER BY teachers.full_name`); err != nil {
+			return err
+		}
+		catalog.CourseTeachers, err = tx.queryCatalogLinks("course_teacher", "course_id", "teacher_id", "0")
+		return err
+	})
+
+	return catalog, err
+}
+
+func (s *Store) DeleteKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM knowledge_competency WHERE knowledge_id = $1 AND competency_id = $2`, knowledgeId, competencyId)
+}
+
+func (s *Store) UpdateKnowledge(knowledge store.Knowledge) error {
+	return s.updateOne(`UPDATE knowledge SET title = $2, parent_id = $3 WHERE knowledge_id = $1`,
+		knowledge.Id, knowledge.Title, nullId(knowledge.ParentId))
+}
+
+func (s *Store) UpdateTechnology(technology store.Technology) error {
+	return s.updateOne(`UPDATE technologies SET title = $2 WHERE technology_id = $1`, technology.Id, technology.Title)
+}
+
+func (s *Store) UpdateCompetency(competency store.Competency) error {
+	return s.updateOne(`UPDATE competencies SET title = $2, parent_id = $3, main_technology_id = $4 WHERE competency_id = $1`,
+		competency.Id, competency.Title, nullId(competency.ParentId), nullId(competency.MainTechnologyId))
+}
+
+func (s *Store) DeleteCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM competency_profession WHERE competency_id = $1 AND profession_id = $2`, competencyId, professionId)
+}
+
+func (s *Store) UpdateProfession(profession store.Profession) error {
+	return s.updateOne(`UPDATE professions SET title = $2, description = $3 WHERE profession_id = $1`,
+		profession.Id, profession.Title, profession.Description)
+}
+
+func (s *Store) UpdateProject(project store.Project) error {
+	return s.updateOne(`UPDATE projects SET title = $2, description = $3, result = $4, life_scenario = $5, main_technology_id = $6
+		WHERE project_id = $1`, project.Id, project.Title, project.Description, project.Result, project.LifeScenario,
+		nullId(project.MainTechnologyId))
+}
+
+func (s *Store) UpdateOrganization(organization store.Organization) error {
+	return s.updateOne(`UPDATE organizations SET title = $2 WHERE organization_id = $1`, organization.Id, organization.Title)
+}
+
+func (s *Store) UpdateEducationalProgram(educationalProgram store.EducationalProgram) error {
+	return s.updateOne(`UPDATE educational_programs SET title = $2, description = $3, organizations_id = $4,
+		min_semester_credits = $5, max_semester_credits = $6 WHERE educational_program_id = $1`,
+		educationalProgram.Id, educationalProgram.Title, educationalProgram.Description, educationalProgram.OrganizationId,
+		educationalProgram.MinSemesterCredits, educationalProgram.MaxSemesterCredits)
+}
+
+func (s *Store) UpdateDiscipline(discipline store.Discipline) error {
+	return s.updateOne(`UPDATE disciplines SET title = $2, description = $3, educational_program_id = $4 WHERE discipline_id = $1`,
+		discipline.Id, discipline.Title, discipline.Description, discipline.EducationalProgramId)
+}
+
+func (s *Store) DeleteCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM course_competency WHERE course_id = $1 AND competency_id = $2`, courseId, competencyId)
+}
+
+func (s *Store) UpdateCourse(course store.Course) error {
+	return s.updateOne(`UPDATE courses SET title = $2, description = $3, discipline_id = $4, credits = $5, hours = $6,
+		alternative = $7, capacity = $8 WHERE course_id = $1`, course.Id, course.Title, course.Description, course.DisciplineId,
+		course.Credits, course.Hours, course.Alternative, course.Capacity)
+}
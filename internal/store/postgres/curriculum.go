@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) CreateElectivePool(pool store.ElectivePool) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO elective_pools (educational_program_id, title, choose) VALUES ($1, $2, $3)
+		ON CONFLICT (educational_program_id, title) DO UPDATE SET choose = excluded.choose RETURNING elective_pool_id`,
+		pool.EducationalProgramId, pool.Title, pool.Choose)
+}
+
+func (s *Store) GetElectivePools(educationalProgramId uuid.UUID) ([]store.ElectivePool, error) {
+	rows, err := s.db.Query(`SELECT elective_pool_id, educational_program_id, title, choose FROM elective_pools
+		WHERE educational_program_id = $1 ORDER BY title`, educationalProgramId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []store.ElectivePool
+	for rows.Next() {
+		var pool store.ElectivePool
+		if err = rows.Scan(&pool.Id, &pool.EducationalProgramId, &pool.Title, &pool.Choose); err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func (s *Store) CreateCurriculumDiscipline(discipline store.CurriculumDiscipline) error {
+	_, err := s.db.Exec(`INSERT INTO curriculum_disciplines (educational_program_id, discipline_id, semester, elective_pool_id)
+		VALUES ($1, $2, $3, $4) ON CONFLICT (educational_program_id, discipline_id)
+		DO UPDATE SET semester = excluded.semester, elective_pool_id = excluded.elective_pool_id`,
+		discipline.EducationalProgramId, discipline.DisciplineId, discipline.Semester, nullId(discipline.ElectivePoolId))
+	return err
+}
+
+func (s *Store) GetCurriculum(educationalProgramId uuid.UUID) ([]store.CurriculumDiscipline, error) {
+	rows, err := s.db.Query(`SELECT educational_program_id, discipline_id, semester, COALESCE(elective_pool_id, uuid_nil())
+		FROM curriculum_disciplines WHERE educational_program_id = $1 ORDER BY semester, discipline_id`, educationalProgramId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disciplines []store.CurriculumDiscipline
+	for rows.Next() {
+		var discipline store.CurriculumDiscipline
+		if err = rows.Scan(&discipline.EducationalProgramId, &discipline.DisciplineId, &discipline.Semester,
+			&discipline.ElectivePoolId); err != nil {
+			return nil, err
+		}
+
+		disciplines = append(disciplines, discipline)
+	}
+
+	return disciplines, rows.Err()
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+type cascadeRef struct {
+	table  string
+	column string
+}
+
+// primaryKeys of the tables that other tables reference.
+var primaryKeys = map[string]string{
+	"knowledge":            "knowledge_id",
+	"technologies":         "technology_id",
+	"competencies":         "competency_id",
+	"professions":          "profession_id",
+	"projects":             "project_id",
+	"organizations":        "organization_id",
+	"educational_programs": "educational_program_id",
+	"disciplines":          "discipline_id",
+	"courses":              "course_id",
+	"portfolios":           "portfolio_id",
+	"students":             "student_id",
+	"trajectories":         "trajectory_id",
+	"enrollments":          "enrollment_id",
+	"course_sessions":      "course_session_id",
+	"api_keys":             "api_key_id",
+	"teachers":             "teacher_id",
+	"skills":               "skill_id",
+}
+
+// cascades mirrors ON DELETE CASCADE foreign keys from migrations.
+var cascades = map[string][]cascadeRef{
+	"knowledge":            {{"knowledge_competency", "knowledge_id"}},
+	"technologies":         {{"competencies", "main_technology_id"}},
+	"competencies":         {{"knowledge_competency", "competency_id"}, {"competency_profession", "competency_id"}, {"course_competency", "competency_id"}, {"project_portfolio_competency", "competency_id"}, {"skill_competency", "competency_id"}},
+	"professions":          {{"competency_profession", "profession_id"}},
+	"projects":             {{"project_portfolio", "project_id"}, {"project_portfolio_competency", "project_id"}},
+	"organizations":        {{"educational_programs", "organizations_id"}, {"calendar_semesters", "organization_id"}, {"calendar_periods", "organization_id"}, {"grading_scales", "organization_id"}},
+	"educational_programs": {{"disciplines", "educational_program_id"}, {"elective_pools", "educational_program_id"}, {"curriculum_disciplines", "educational_program_id"}, {"enrollments", "educational_program_id"}},
+	"disciplines":          {{"courses", "discipline_id"}, {"curriculum_disciplines", "discipline_id"}},
+	"courses":              {{"study_groups", "course_id"}, {"trajectories", "course_id"}, {"course_competency", "course_id"}, {"course_sessions", "course_id"}, {"course_prerequisite", "course_id"}, {"course_prerequisite", "prerequisite_id"}, {"course_teacher", "course_id"}, {"course_waitlists", "course_id"}, {"study_group_drops", "course_id"}},
+	"course_sessions":      {{"timetable", "course_session_id"}},
+	"portfolios":           {{"project_portfolio", "portfolio_id"}, {"project_portfolio_competency", "portfolio_id"}, {"students", "portfolio_id"}},
+	"students":             {{"study_groups", "student_id"}, {"trajectories", "student_id"}, {"api_keys", "student_id"}, {"enrollments", "student_id"}, {"course_waitlists", "student_id"}, {"study_group_drops", "student_id"}},
+	"trajectories":         {{"trajectories", "retake_of"}},
+	"enrollments":          {{"academic_leaves", "enrollment_id"}},
+	"teachers":             {{"course_teacher", "teacher_id"}, {"teacher_availability", "teacher_id"}, {"api_keys", "teacher_id"}},
+	"skills":               {{"skill_competency", "skill_id"}},
+}
+
+// restricts mirrors foreign keys without ON DELETE action, rows referencing by them keep the row from being deleted.
+var restricts = map[string][]cascadeRef{
+	"technologies": {{"projects", "main_technology_id"}},
+}
+
+// GetDependents walks cascade foreign keys from the row. A row may be reached by several keys,
+// e.g. a curriculum discipline through its educational program and its discipline, so rows are told apart by ctid.
+func (s *Store) GetDependents(table string, id uuid.UUID) ([]store.Dependent, error) {
+	if _, ok := primaryKeys[table]; !ok {
+		return nil, fmt.Errorf("unknown table %q", table)
+	}
+
+	seen := make(map[string]map[string]bool)
+	restricting := make(map[string]map[string]bool)
+	if err := s.walkDependents(table, []string{id.String()}, seen, restricting); err != nil {
+		return nil, err
+	}
+
+	dependents := make([]store.Dependent, 0, len(seen))
+	for dependent, rows := range seen {
+		dependents = append(dependents, store.Dependent{Table: dependent, Count: len(rows)})
+	}
+	for dependent, rows := range restricting {
+		count := 0
+		for ctid := range rows {
+			if !seen[dependent][ctid] { // deleted by cascade before the restricted row
+				count++
+			}
+		}
+		if count > 0 {
+			dependents = append(dependents, store.Dependent{Table: dependent, Count: count, Restrict: true})
+		}
+	}
+	sortDependents(dependents)
+	return dependents, nil
+}
+
+// sortDependents orders dependents by table, the restricting rows of a table after the cascaded ones.
+func sortDependents(dependents []store.Dependent) {
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Table != dependents[j].Table {
+			return dependents[i].Table < dependents[j].Table
+		}
+		return !dependents[i].Restrict && dependents[j].Restrict
+	})
+}
+
+// walkDependents marks rows referencing rows of the table with the ids and walks further from the new ones,
+// rows referencing them by restricts are marked in restricting.
+func (s *Store) walkDependents(table string, ids []string, seen map[string]map[string]bool, restricting map[string]map[string]bool) error {
+	for _, ref := range restricts[table] {
+		rows, err := s.db.Query(`SELECT ctid::text FROM `+ref.table+` WHERE `+ref.column+` = ANY($1::uuid[])`, pq.Array(ids))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var ctid string
+			if err = rows.Scan(&ctid); err != nil {
+				rows.Close()
+				return err
+			}
+			if restricting[ref.table] == nil {
+				restricting[ref.table] = make(map[string]bool)
+			}
+			restricting[ref.table][ctid] = true
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range cascades[table] {
+		pk, hasPk := primaryKeys[ref.table]
+		key := `''`
+		if hasPk {
+			key = pk + `::text`
+		}
+
+		rows, err := s.db.Query(`SELECT ctid::text, `+key+` FROM `+ref.table+` WHERE `+ref.column+` = ANY($1::uuid[])`, pq.Array(ids))
+		if err != nil {
+			return err
+		}
+		var next []string
+		for rows.Next() {
+			var ctid, id string
+			if err = rows.Scan(&ctid, &id); err != nil {
+				rows.Close()
+				return err
+			}
+			if seen[ref.table][ctid] {
+				continue
+			}
+			if seen[ref.table] == nil {
+				seen[ref.table] = make(map[string]bool)
+			}
+			seen[ref.table][ctid] = true
+			next = append(next, id)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if hasPk && len(next) > 0 {
+			if err = s.walkDependents(ref.table, next, seen, restricting); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(table string, id uuid.UUID) error {
+	pk, ok := primaryKeys[table]
+	if !ok {
+		return fmt.Errorf("unknown table %q", table)
+	}
+	return s.updateOne(`DELETE FROM `+table+` WHERE `+pk+` = $1`, id)
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"database/sql"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) CreateEnrollment(enrollment store.Enrollment) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO enrollments (student_id, educational_program_id, start_date, end_date, status)
+		VALUES ($1, $2, $3, $4, $5) RETURNING enrollment_id`,
+		enrollment.StudentId, enrollment.EducationalProgramId, enrollment.Start, nullTime(enrollment.End), enrollment.Status)
+}
+
+func (s *Store) UpdateEnrollment(enrollment store.Enrollment) error {
+	return s.updateOne(`UPDATE enrollments SET end_date = $2, status = $3 WHERE enrollment_id = $1`,
+		enrollment.Id, nullTime(enrollment.End), enrollment.Status)
+}
+
+func (s *Store) GetStudentEnrollments(studentId uuid.UUID) ([]store.Enrollment, error) {
+	rows, err := s.db.Query(`SELECT enrollment_id, student_id, educational_program_id, start_date, end_date, status
+		FROM enrollments WHERE student_id = $1 ORDER BY start_date, end_date NULLS LAST, enrollment_id`, studentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []store.Enrollment
+	for rows.Next() {
+		var enrollment store.Enrollment
+		var end sql.NullTime
+		if err = rows.Scan(&enrollment.Id, &enrollment.StudentId, &enrollment.EducationalProgramId, &enrollment.Start,
+			&end, &enrollment.Status); err != nil {
+			return nil, err
+		}
+
+		enrollment.End = end.Time
+		enrollments = append(enrollments, enrollment)
+	}
+
+	return enrollments, rows.Err()
+}
+
+func (s *Store) CreateAcademicLeave(leave store.AcademicLeave) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO academic_leaves (enrollment_id, start_date, end_date) VALUES ($1, $2, $3)
+		RETURNING academic_leave_id`, leave.EnrollmentId, leave.Start, nullTime(leave.End))
+}
+
+func (s *Store) UpdateAcademicLeave(leave store.AcademicLeave) error {
+	return s.updateOne(`UPDATE academic_leaves SET end_date = $2 WHERE academic_leave_id = $1`, leave.Id, nullTime(leave.End))
+}
+
+func (s *Store) GetStudentAcademicLeaves(studentId uuid.UUID) ([]store.AcademicLeave, error) {
+	rows, err := s.db.Query(`SELECT academic_leaves.academic_leave_id, academic_leaves.enrollment_id,
+			academic_leaves.start_date, academic_leaves.end_date
+		FROM academic_leaves JOIN enrollments ON enrollments.enrollment_id = academic_leaves.enrollment_id
+		WHERE enrollments.student_id = $1 ORDER BY academic_leaves.start_date, academic_leaves.academic_leave_id`, studentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leaves []store.AcademicLeave
+	for rows.Next() {
+		var leave store.AcademicLeave
+		var end sql.NullTime
+		if err = rows.Scan(&leave.Id, &leave.EnrollmentId, &leave.Start, &end); err != nil {
+			return nil, err
+		}
+
+		leave.End = end.Time
+		leaves = append(leaves, leave)
+	}
+
+	return leaves, rows.Err()
+}
@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// listQuery is a single collection select, sortColumns maps sort keys of store.Page to SQL expressions.
+type listQuery struct {
+	columns      string
+	from         string
+	idColumn     string
+	sortColumns  map[string]string
+	filterColumn string
+	filterId     uuid.UUID
+}
+
+// list runs count and page queries and calls scan for every row of the page.
+func (s *Store) list(query listQuery, page store.Page, scan func(rows *sql.Rows) error) (int, error) {
+	sortColumn, ok := query.sortColumns[page.Sort]
+	if page.Sort == "id" {
+		sortColumn, ok = query.idColumn, true
+	}
+	if !ok {
+		return 0, fmt.Errorf("unknown sort key %q", page.Sort)
+	}
+
+	var args []any
+	where := ""
+	if query.filterColumn != "" && query.filterId != uuid.Nil {
+		where = " WHERE " + query.filterColumn + " = $1"
+		args = append(args, query.filterId)
+	}
+
+	var total int
+	if err := s.db.QueryRow(`SELECT count(*) FROM `+query.from+where, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	direction := "ASC"
+	if page.Desc {
+		direction = "DESC"
+	}
+	rows, err := s.db.Query(fmt.Sprintf(`SELECT %s FROM %s%s ORDER BY %s %s, %s LIMIT $%d OFFSET $%d`,
+		query.columns, query.from, where, sortColumn, direction, query.idColumn, len(args)+1, len(args)+2),
+		append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, rows.Err()
+}
+
+func (s *Store) ListKnowledge(page store.Page) ([]store.Knowledge, int, error) {
+	var knowledge []store.Knowledge
+	total, err := s.list(listQuery{
+		columns:     knowledgeColumns,
+		from:        `knowledge`,
+		idColumn:    `knowledge.knowledge_id`,
+		sortColumns: map[string]string{"title": `knowledge.title`},
+	}, page, func(rows *sql.Rows) error {
+		var item store.Knowledge
+		if err := rows.Scan(&item.Id, &item.Title, &item.ParentId); err != nil {
+			return err
+		}
+		knowledge = append(knowledge, item)
+		return nil
+	})
+	return knowledge, total, err
+}
+
+func (s *Store) ListTechnologies(page store.Page) ([]store.Technology, int, error) {
+	var technologies []store.Technology
+	total, err := s.list(listQuery{
+		columns:     `technology_id, title`,
+		from:        `technologies`,
+		idColumn:    `technology_id`,
+		sortColumns: map[string]string{"title": `title`},
+	}, page, func(rows *sql.Rows) error {
+		var technology store.Technology
+		if err := rows.Scan(&technology.Id, &technology.Title); err != nil {
+			return err
+		}
+		technologies = append(technologies, technology)
+		return nil
+	})
+	return technologies, total, err
+}
+
+func (s *Store) ListSkills(page store.Page) ([]store.Skill, int, error) {
+	var skills []store.Skill
+	total, err := s.list(listQuery{
+		columns:     skillColumns,
+		from:        `skills`,
+		idColumn:    `skills.skill_id`,
+		sortColumns: map[string]string{"title": `skills.title`},
+	}, page, func(rows *sql.Rows) error {
+		var skill store.Skill
+		if err := rows.Scan(&skill.Id, &skill.Title); err != nil {
+			return err
+		}
+		skills = append(skills, skill)
+		return nil
+	})
+	return skills, total, err
+}
+
+func (s *Store) ListCompetencies(page store.Page, mainTechnologyId uuid.UUID) ([]store.Competency, int, error) {
+	var competencies []store.Competency
+	total, err := s.list(listQuery{
+		columns:      competencyColumns,
+		from:         `competencies`,
+		idColumn:     `competencies.competency_id`,
+		sortColumns:  map[string]string{"title": `competencies.title`},
+		filterColumn: `competencies.main_technology_id`,
+		filterId:     mainTechnologyId,
+	}, page, func(rows *sql.Rows) error {
+		var competency store.Competency
+		if err := rows.Scan(&competency.Id, &competency.Title, &competency.ParentId, &competency.MainTechnologyId); err != nil {
+			return err
+		}
+		competencies = append(competencies, competency)
+		return nil
+	})
+	return competencies, total, err
+}
+
+func (s *Store) ListProfessions(page store.Page) ([]store.Profession, int, error) {
+	var professions []store.Profession
+	total, err := s.list(listQuery{
+		columns:     `profession_id, title, COALESCE(description, '')`,
+		from:        `professions`,
+		idColumn:    `profession_id`,
+		sortColumns: map[string]string{"title": `title`},
+	}, page, func(rows *sql.Rows) error {
+		var profession store.Profession
+		if err := rows.Scan(&profession.Id, &profession.Title, &profession.Description); err != nil {
+			return err
+		}
+		professions = append(professions, profession)
+		return nil
+	})
+	return professions, total, err
+}
+
+func (s *Store) ListProjects(page store.Page, mainTechnologyId uuid.UUID) ([]store.Project, int, error) {
+	var projects []store.Project
+	total, err := s.list(listQuery{
+		columns: `project_id, title, COALESCE(description, ''), COALESCE(result, ''), COALESCE(life_scenario, ''),
+			COALESCE(main_technology_id, uuid_nil())`,
+		from:         `projects`,
+		idColumn:     `project_id`,
+		sortColumns:  map[string]string{"title": `title`},
+		filterColumn: `main_technology_id`,
+		filterId:     mainTechnologyId,
+	}, page, func(rows *sql.Rows) error {
+		var project store.Project
+		if err := rows.Scan(&project.Id, &project.Title, &project.Description, &project.Result, &project.LifeScenario,
+			&project.MainTechnologyId); err != nil {
+			return err
+		}
+		projects = append(projects, project)
+		return nil
+	})
+	return projects, total, err
+}
+
+func (s *Store) ListOrganizations(page store.Page) ([]store.Organization, int, error) {
+	var organizations []store.Organization
+	total, err := s.list(listQuery{
+		columns:     `organization_id, COALESCE(title, '')`,
+		from:        `organizations`,
+		idColumn:    `organization_id`,
+		sortColumns: map[string]string{"title": `title`},
+	}, page, func(rows *sql.Rows) error {
+		var organization store.Organization
+		if err := rows.Scan(&organization.Id, &organization.Title); err != nil {
+			return err
+		}
+		organizations = append(organizations, organization)
+		return nil
+	})
+	return organizations, total, err
+}
+
+func (s *Store) ListEducationalPrograms(page store.Page, organizationId uuid.UUID) ([]store.EducationalProgram, int, error) {
+	var educationalPrograms []store.EducationalProgram
+	total, err := s.list(listQuery{
+		columns:      educationalProgramColumns,
+		from:         `educational_programs`,
+		idColumn:     `educational_programs.educational_program_id`,
+		sortColumns:  map[string]string{"title": `educational_programs.title`},
+		filterColumn: `educational_programs.organizations_id`,
+		filterId:     organizationId,
+	}, page, func(rows *sql.Rows) error {
+		var educationalProgram store.EducationalProgram
+		if err := rows.Scan(&educationalProgram.Id, &educationalProgram.Title, &educationalProgram.Description,
+			&educationalProgram.OrganizationId, &educationalProgram.MinSemesterCredits, &educationalProgram.MaxSemesterCredits); err != nil {
+			return err
+		}
+		educationalPrograms = append(educationalPrograms, educationalProgram)
+		return nil
+	})
+	return educationalPrograms, total, err
+}
+
+func (s *Store) ListDisciplines(page store.Page, educationalProgramId uuid.UUID) ([]store.Discipline, int, error) {
+	var disciplines []store.Discipline
+	total, err := s.list(listQuery{
+		columns:      `discipline_id, title, COALESCE(description, ''), educational_program_id`,
+		from:         `disciplines`,
+		idColumn:     `discipline_id`,
+		sortColumns:  map[string]string{"title": `title`},
+		filterColumn: `educational_program_id`,
+		filterId:     educationalProgramId,
+	}, page, func(rows *sql.Rows) error {
+		var discipline store.Discipline
+		if err := rows.Scan(&discipline.Id, &discipline.Title, &discipline.Description, &discipline.EducationalProgramId); err != nil {
+			return err
+		}
+		disciplines = append(disciplines, discipline)
+		return nil
+	})
+	return disciplines, total, err
+}
+
+func (s *Store) ListCourses(page store.Page, disciplineId uuid.UUID) ([]store.Course, int, error) {
+	var courses []store.Course
+	total, err := s.list(listQuery{
+		columns:  courseColumns,
+		from:     `courses`,
+		idColumn: `courses.course_id`,
+		sortColumns: map[string]string{
+			"title": `courses.title`,
+			// NULL of courses without teachers goes last in ascending order and first in descending one
+			"teacher": `(SELECT min(teachers.full_name) FROM course_teacher JOIN teachers ON teachers.teacher_id = course_teacher.teacher_id
+				WHERE course_teacher.course_id = courses.course_id)`,
+		},
+		filterColumn: `courses.discipline_id`,
+		filterId:     disciplineId,
+	}, page, func(rows *sql.Rows) error {
+		var course store.Course
+		if err := rows.Scan(&course.Id, &course.Title, &course.Description, &course.DisciplineId, &course.Credits, &course.Hours,
+			&course.Alternative, &course.Capacity); err != nil {
+			return err
+		}
+		courses = append(courses, course)
+		return nil
+	})
+	return courses, total, err
+}
+
+func (s *Store) ListTeachers(page store.Page, organizationId uuid.UUID) ([]store.Teacher, int, error) {
+	var teachers []store.Teacher
+	total, err := s.list(listQuery{
+		columns:      teacherColumns,
+		from:         `teachers`,
+		idColumn:     `teachers.teacher_id`,
+		sortColumns:  map[string]string{"fullName": `teachers.full_name`},
+		filterColumn: `teachers.organization_id`,
+		filterId:     organizationId,
+	}, page, func(rows *sql.Rows) error {
+		var teacher store.Teacher
+		if err := rows.Scan(&teacher.Id, &teacher.FullName, &teacher.OrganizationId, &teacher.Email, &teacher.Phone); err != nil {
+			return err
+		}
+		teachers = append(teachers, teacher)
+		return nil
+	})
+	return teachers, total, err
+}
+
+func (s *Store) ListStudents(page store.Page) ([]store.Student, int, error) {
+	var students []store.Student
+	total, err := s.list(listQuery{
+		columns:     `student_id, full_name, COALESCE(portfolio_id, uuid_nil()), admition`,
+		from:        `students`,
+		idColumn:    `student_id`,
+		sortColumns: map[string]string{"fullName": `full_name`, "admition": `admition`},
+	}, page, func(rows *sql.Rows) error {
+		var student store.Student
+		if err := rows.Scan(&student.Id, &student.FullName, &student.PortfolioId, &student.Admition); err != nil {
+			return err
+		}
+		students = append(students, student)
+		return nil
+	})
+	return students, total, err
+}
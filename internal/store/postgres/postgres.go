@@ -0,0 +1,140 @@
+// Package postgres implements store.Store on top of the Postgres schema from migrations.
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// querier is the part of *sql.DB and *sql.Tx queries are made with.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type Store struct {
+	db   querier
+	conn *sql.DB // nil inside a transaction
+}
+
+var _ store.Store = (*Store)(nil)
+
+func New(db *sql.DB) *Store {
+	return &Store{db: db, conn: db}
+}
+
+func (s *Store) Transaction(fn func(tx store.Store) error) error {
+	return s.transaction(func(tx *Store) error { return fn(tx) })
+}
+
+func (s *Store) transaction(fn func(tx *Store) error) error {
+	if s.conn == nil {
+		return fn(s)
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(&Store{db: tx}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) Lock(table string, id uuid.UUID) error {
+	pk, ok := primaryKeys[table]
+	if !ok {
+		return fmt.Errorf("unknown table %q", table)
+	}
+	if s.conn != nil {
+		return nil // not in a transaction, the lock would be released at once
+	}
+	_, err := s.db.Exec(`SELECT `+pk+` FROM `+table+` WHERE `+pk+` = $1 FOR UPDATE`, id)
+	return err
+}
+
+// nullId stores uuid.Nil of optional references as NULL.
+func nullId(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
+
+// nullTime stores the zero time of open periods as NULL.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// updateOne runs the update or the deletion of a single row, store.ErrNotFound is returned when there is no such row.
+func (s *Store) updateOne(query string, args ...any) error {
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err == nil && affected == 0 {
+		return store.ErrNotFound
+	}
+	return err
+}
+
+func (s *Store) createId(query string, args ...any) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := s.db.QueryRow(query, args...).Scan(&id)
+	return id, err
+}
+
+// queryCompetencies scans competencyColumns followed by the level of the link the competencies are read through.
+func (s *Store) queryCompetencies(query string, args ...any) ([]store.Competency, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var competencies []store.Competency
+	for rows.Next() {
+		var competency store.Competency
+		if err = rows.Scan(&competency.Id, &competency.Title, &competency.ParentId, &competency.MainTechnologyId, &competency.Level); err != nil {
+			return nil, err
+		}
+
+		competencies = append(competencies, competency)
+	}
+
+	return competencies, rows.Err()
+}
+
+func (s *Store) queryCourses(query string, args ...any) ([]store.Course, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []store.Course
+	for rows.Next() {
+		var course store.Course
+		if err = rows.Scan(&course.Id, &course.Title, &course.Description, &course.DisciplineId, &course.Credits, &course.Hours,
+			&course.Alternative, &course.Capacity); err != nil {
+			return nil, err
+		}
+
+		courses = append(courses, course)
+	}
+
+	return courses, rows.Err()
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// searchTable is the catalog table with its search_vector column, text is the SQL expression of the searched description.
+// The table may be a subquery that gives the same columns.
+type searchTable struct {
+	kind     string
+	table    string
+	idColumn string
+	text     string
+}
+
+var searchTables = []searchTable{
+	{store.SearchKnowledge, "knowledge", "knowledge_id", "''"},
+	{store.SearchTechnology, "technologies", "technology_id", "''"},
+	{store.SearchCompetency, competencySearchTable, "competency_id", "skills"},
+	{store.SearchProfession, "professions", "profession_id", "COALESCE(description, '')"},
+	{store.SearchProject, "projects", "project_id", "concat_ws(' ', description, result, life_scenario)"},
+	{store.SearchOrganization, "organizations", "organization_id", "''"},
+	{store.SearchEducationalProgram, "educational_programs", "educational_program_id", "COALESCE(description, '')"},
+	{store.SearchDiscipline, "disciplines", "discipline_id", "COALESCE(description, '')"},
+	{store.SearchCourse, "courses", "course_id", "COALESCE(description, '')"},
+}
+
+// competencySearchTable adds skills of the competency to its search document, they live in their own table.
+const competencySearchTable = `(SELECT competencies.competency_id, competencies.title, COALESCE(competency_skills.skills, '') AS skills,
+	competencies.search_vector || setweight(to_tsvector('russian', COALESCE(competency_skills.skills, '')), 'B') AS search_vector
+	FROM competencies LEFT JOIN LATERAL (SELECT string_agg(skills.title, ', ' ORDER BY skills.title) AS skills FROM skill_competency
+		JOIN skills ON skills.skill_id = skill_competency.skill_id
+		WHERE skill_competency.competency_id = competencies.competency_id) AS competency_skills ON true) AS competencies`
+
+// searchQuery ranks full-text matches of the Russian dictionary (ts_rank) together with trigram similarity
+// of the title (word_similarity), so both a word in another form and a typo in the title are found.
+var searchQuery = func() string {
+	parts := make([]string, 0, len(searchTables))
+	for _, table := range searchTables {
+		parts = append(parts, `SELECT '`+table.kind+`' AS kind, `+table.idColumn+` AS id, COALESCE(title, '') AS title,
+			ts_headline('russian', COALESCE(NULLIF(`+table.text+`, ''), title, ''), query.q,
+				'StartSel=<b>, StopSel=</b>, MaxWords=20, MinWords=5, MaxFragments=2, FragmentDelimiter=" … "') AS snippet,
+			ts_rank(search_vector, query.q) + word_similarity(query.text, COALESCE(title, '')) AS rank
+		FROM `+table.table+`, query WHERE search_vector @@ query.q OR query.text <% title`)
+	}
+
+	return `WITH query AS (SELECT websearch_to_tsquery('russian', $1::text) AS q, $1::text AS text)
+		SELECT kind, id, title, snippet, rank FROM (
+			SELECT *, row_number() OVER (PARTITION BY kind ORDER BY rank DESC, title) AS place FROM (
+				` + strings.Join(parts, "\n\t\t\t\tUNION ALL ") + `
+			) hits
+		) ranked WHERE place <= $2 ORDER BY rank DESC, kind, title`
+}()
+
+func (s *Store) Search(query string, limit int) ([]store.SearchHit, error) {
+	rows, err := s.db.Query(searchQuery, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []store.SearchHit
+	for rows.Next() {
+		var hit store.SearchHit
+		if err = rows.Scan(&hit.Kind, &hit.Id, &hit.Title, &hit.Snippet, &hit.Rank); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
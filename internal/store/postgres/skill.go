@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const skillColumns = `skills.skill_id, skills.title`
+
+func (s *Store) querySkills(query string, args ...any) ([]store.Skill, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []store.Skill
+	for rows.Next() {
+		var skill store.Skill
+		if err = rows.Scan(&skill.Id, &skill.Title); err != nil {
+			return nil, err
+		}
+
+		skills = append(skills, skill)
+	}
+
+	return skills, rows.Err()
+}
+
+func (s *Store) GetSkill(id uuid.UUID) (store.Skill, error) {
+	var skill store.Skill
+	err := s.db.QueryRow(`SELECT `+skillColumns+` FROM skills WHERE skill_id = $1`, id).Scan(&skill.Id, &skill.Title)
+	return skill, err
+}
+
+func (s *Store) CreateSkill(title string) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO skills (title) VALUES ($1)
+		ON CONFLICT (title) DO UPDATE SET title = excluded.title RETURNING skill_id`, title)
+}
+
+func (s *Store) GetSkillsByCompetency(competencyId uuid.UUID) ([]store.Skill, error) {
+	return s.querySkills(`SELECT `+skillColumns+` FROM skills
+		JOIN skill_competency ON skill_competency.skill_id = skills.skill_id
+		WHERE skill_competency.competency_id = $1 ORDER BY skills.title`, competencyId)
+}
+
+func (s *Store) CreateSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error {
+	_, err := s.db.Exec(`INSERT INTO skill_competency (skill_id, competency_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		skillId, competencyId)
+	return err
+}
+
+func (s *Store) DeleteSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM skill_competency WHERE skill_id = $1 AND competency_id = $2`, skillId, competencyId)
+}
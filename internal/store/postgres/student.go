@@ -0,0 +1,354 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const trajectoryColumns = `trajectory_id, COALESCE(student_id, uuid_nil()), COALESCE(course_id, uuid_nil()), COALESCE(semester, 0),
+	grade, COALESCE(result, ''), attempt, completion_date, COALESCE(retake_of, uuid_nil())`
+
+func (s *Store) CreatePortfolio() (uuid.UUID, error) {
+	return s.createId(`INSERT INTO portfolios (portfolio_id) VALUES (DEFAULT) RETURNING portfolio_id`)
+}
+
+func (s *Store) GetProjectPortfolios(portfolioId uuid.UUID) ([]store.ProjectPortfolio, error) {
+	rows, err := s.db.Query(`SELECT project_id, portfolio_id, COALESCE(team_role, ''), COALESCE(semester, 0) FROM project_portfolio
+		WHERE portfolio_id = $1 ORDER BY semester, project_id`, portfolioId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []store.ProjectPortfolio
+	for rows.Next() {
+		var project store.ProjectPortfolio
+		if err = rows.Scan(&project.ProjectId, &project.PortfolioId, &project.TeamRole, &project.Semester); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (s *Store) CreateProjectPortfolio(projectPortfolio store.ProjectPortfolio) error {
+	_, err := s.db.Exec(`INSERT INTO project_portfolio (project_id, portfolio_id, team_role, semester) VALUES ($1, $2, $3, $4)`,
+		projectPortfolio.ProjectId, projectPortfolio.PortfolioId, projectPortfolio.TeamRole, projectPortfolio.Semester)
+	return err
+}
+
+func (s *Store) CreateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	_, err := s.db.Exec(`INSERT INTO project_portfolio_competency (competency_id, project_id, portfolio_id, level) VALUES ($1, $2, $3, $4)`,
+		competencyId, projectId, portfolioId, level)
+	return err
+}
+
+func (s *Store) UpdateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error {
+	return s.updateOne(`UPDATE project_portfolio_competency SET level = $4 WHERE project_id = $1 AND portfolio_id = $2 AND competency_id = $3`,
+		projectId, portfolioId, competencyId, level)
+}
+
+func (s *Store) UpdateProjectPortfolio(projectPortfolio store.ProjectPortfolio) error {
+	return s.updateOne(`UPDATE project_portfolio SET team_role = $3, semester = $4 WHERE project_id = $1 AND portfolio_id = $2`,
+		projectPortfolio.ProjectId, projectPortfolio.PortfolioId, projectPortfolio.TeamRole, projectPortfolio.Semester)
+}
+
+func (s *Store) DeleteProjectPortfolio(projectId uuid.UUID, portfolioId uuid.UUID) error {
+	return s.updateOne(`WITH competencies AS (DELETE FROM project_portfolio_competency WHERE project_id = $1 AND portfolio_id = $2)
+		DELETE FROM project_portfolio WHERE project_id = $1 AND portfolio_id = $2`, projectId, portfolioId)
+}
+
+func (s *Store) DeleteProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM project_portfolio_competency WHERE project_id = $1 AND portfolio_id = $2 AND competency_id = $3`,
+		projectId, portfolioId, competencyId)
+}
+
+func (s *Store) GetStudent(id uuid.UUID) (store.Student, error) {
+	var student store.Student
+	err := s.db.QueryRow(`SELECT student_id, full_name, COALESCE(portfolio_id, uuid_nil()), admition FROM students WHERE student_id = $1`, id).
+		Scan(&student.Id, &student.FullName, &student.PortfolioId, &student.Admition)
+	return student, err
+}
+
+func (s *Store) GetStudentByPortfolio(portfolioId uuid.UUID) (store.Student, error) {
+	var student store.Student
+	err := s.db.QueryRow(`SELECT student_id, full_name, portfolio_id, admition FROM students
+		WHERE portfolio_id = $1 ORDER BY student_id LIMIT 1`, portfolioId).
+		Scan(&student.Id, &student.FullName, &student.PortfolioId, &student.Admition)
+	return student, err
+}
+
+func (s *Store) CreateStudent(student store.Student) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO students (full_name, portfolio_id, admition) VALUES ($1, $2, $3) RETURNING student_id`,
+		student.FullName, nullId(student.PortfolioId), student.Admition)
+}
+
+func (s *Store) UpdateStudent(student store.Student) error {
+	return s.updateOne(`UPDATE students SET full_name = $2, portfolio_id = $3, admition = $4 WHERE student_id = $1`,
+		student.Id, student.FullName, nullId(student.PortfolioId), student.Admition)
+}
+
+func (s *Store) CreateStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
+	_, err := s.db.Exec(`INSERT INTO study_groups (course_id, student_id) VALUES ($1, $2)`, courseId, studentId)
+	return err
+}
+
+func (s *Store) GetStudyGroupCourses(studentId uuid.UUID) ([]store.Course, error) {
+	return s.queryCourses(`SELECT `+courseColumns+` FROM courses
+		JOIN study_groups ON study_groups.course_id = courses.course_id
+		WHERE study_groups.student_id = $1 ORDER BY courses.title`, studentId)
+}
+
+func (s *Store) CountStudyGroup(courseId uuid.UUID) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT count(*) FROM study_groups WHERE course_id = $1`, courseId).Scan(&count)
+	return count, err
+}
+
+func (s *Store) DeleteStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM study_groups WHERE course_id = $1 AND student_id = $2`, courseId, studentId)
+}
+
+func (s *Store) CreateWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error {
+	_, err := s.db.Exec(`INSERT INTO course_waitlists (course_id, student_id) VALUES ($1, $2)`, courseId, studentId)
+	return err
+}
+
+func (s *Store) DeleteWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM course_waitlists WHERE course_id = $1 AND student_id = $2`, courseId, studentId)
+}
+
+func (s *Store) GetCourseWaitlist(courseId uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := s.db.Query(`SELECT student_id FROM course_waitlists WHERE course_id = $1 ORDER BY waitlist_number`, courseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []uuid.UUID
+	for rows.Next() {
+		var studentId uuid.UUID
+		if err = rows.Scan(&studentId); err != nil {
+			return nil, err
+		}
+
+		students = append(students, studentId)
+	}
+
+	return students, rows.Err()
+}
+
+func (s *Store) CreateStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID, day time.Time) error {
+	_, err := s.db.Exec(`INSERT INTO study_group_drops (course_id, student_id, drop_date) VALUES ($1, $2, $3)
+		ON CONFLICT (course_id, student_id) DO UPDATE SET drop_date = EXCLUDED.drop_date`, courseId, studentId, day)
+	return err
+}
+
+func (s *Store) DeleteStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID) error {
+	_, err := s.db.Exec(`DELETE FROM study_group_drops WHERE course_id = $1 AND student_id = $2`, courseId, studentId)
+	return err
+}
+
+func (s *Store) GetStudyGroupEntries(studentId uuid.UUID) ([]store.StudyGroupEntry, error) {
+	rows, err := s.db.Query(`SELECT entries.course_id, entries.status, entries.position, entries.drop_date FROM (
+			SELECT course_id, student_id, $2::text AS status, 0::bigint AS position, NULL::date AS drop_date FROM study_groups
+			UNION ALL SELECT course_id, student_id, $3::text,
+				row_number() OVER (PARTITION BY course_id ORDER BY waitlist_number), NULL FROM course_waitlists
+			UNION ALL SELECT course_id, student_id, $4::text, 0, drop_date FROM study_group_drops
+		) entries JOIN courses ON courses.course_id = entries.course_id
+		WHERE entries.student_id = $1 ORDER BY courses.title, entries.status`,
+		studentId, store.StudyGroupEnrolled, store.StudyGroupWaitlisted, store.StudyGroupDropped)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []store.StudyGroupEntry
+	for rows.Next() {
+		entry := store.StudyGroupEntry{StudentId: studentId}
+		var dropDate sql.NullTime
+		if err = rows.Scan(&entry.CourseId, &entry.Status, &entry.Position, &dropDate); err != nil {
+			return nil, err
+		}
+		entry.DropDate = dropDate.Time
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (s *Store) GetStudentCourses(studentId uuid.UUID) ([]store.StudentCourse, error) {
+	rows, err := s.db.Query(`SELECT courses.course_id, taken.current, courses.discipline_id, courses.alternative FROM (
+			SELECT course_id, false AS current FROM trajectories WHERE student_id = $1 AND result = $2
+			UNION SELECT course_id, true FROM study_groups WHERE student_id = $1
+		) taken JOIN courses ON courses.course_id = taken.course_id`, studentId, store.TrajectoryPassed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []store.StudentCourse
+	for rows.Next() {
+		var course store.StudentCourse
+		if err = rows.Scan(&course.CourseId, &course.Current, &course.DisciplineId, &course.Alternative); err != nil {
+			return nil, err
+		}
+
+		courses = append(courses, course)
+	}
+
+	return courses, rows.Err()
+}
+
+func (s *Store) GetTrajectory(id uuid.UUID) (store.Trajectory, error) {
+	var trajectory store.Trajectory
+	var completionDate sql.NullTime
+	err := s.db.QueryRow(`SELECT `+trajectoryColumns+` FROM trajectories WHERE trajectory_id = $1`, id).
+		Scan(&trajectory.Id, &trajectory.StudentId, &trajectory.CourseId, &trajectory.Semester,
+			&trajectory.Grade, &trajectory.Result, &trajectory.Attempt, &completionDate, &trajectory.RetakeOf)
+	trajectory.CompletionDate = completionDate.Time
+	return trajectory, err
+}
+
+func (s *Store) GetStudentTrajectories(studentId uuid.UUID) ([]store.Trajectory, error) {
+	rows, err := s.db.Query(`SELECT `+trajectoryColumns+` FROM trajectories WHERE student_id = $1 ORDER BY semester, trajectory_id`,
+		studentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trajectories []store.Trajectory
+	for rows.Next() {
+		var trajectory store.Trajectory
+		var completionDate sql.NullTime
+		if err = rows.Scan(&trajectory.Id, &trajectory.StudentId, &trajectory.CourseId, &trajectory.Semester,
+			&trajectory.Grade, &trajectory.Result, &trajectory.Attempt, &completionDate, &trajectory.RetakeOf); err != nil {
+			return nil, err
+		}
+
+		trajectory.CompletionDate = completionDate.Time
+		trajectories = append(trajectories, trajectory)
+	}
+
+	return trajectories, rows.Err()
+}
+
+func (s *Store) CreateTrajectory(trajectory store.Trajectory) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO trajectories (student_id, course_id, semester, attempt, retake_of) VALUES ($1, $2, $3, $4, $5)
+		RETURNING trajectory_id`, trajectory.StudentId, trajectory.CourseId, trajectory.Semester, trajectory.Attempt,
+		nullId(trajectory.RetakeOf))
+}
+
+func (s *Store) UpdateTrajectory(trajectory store.Trajectory) error {
+	return s.updateOne(`UPDATE trajectories SET student_id = $2, course_id = $3, semester = $4 WHERE trajectory_id = $1`,
+		trajectory.Id, trajectory.StudentId, trajectory.CourseId, trajectory.Semester)
+}
+
+func (s *Store) UpdateTrajectoryOutcome(trajectory store.Trajectory) error {
+	return s.updateOne(`UPDATE trajectories SET grade = $2, result = NULLIF($3, ''), completion_date = $4 WHERE trajectory_id = $1`,
+		trajectory.Id, trajectory.Grade, trajectory.Result, nullTime(trajectory.CompletionDate))
+}
+
+func (s *Store) GetCompetencySources(studentId uuid.UUID, portfolioId uuid.UUID) ([]store.CompetencySource, error) {
+	rows, err := s.db.Query(`SELECT project_portfolio_competency.competency_id, $3::text, projects.project_id, projects.title,
+			project_portfolio_competency.level
+			FROM project_portfolio_competency JOIN projects ON projects.project_id = project_portfolio_competency.project_id
+			WHERE project_portfolio_competency.portfolio_id = $1
+		UNION ALL SELECT course_competency.competency_id, $4::text, courses.course_id, courses.title, course_competency.level
+			FROM trajectories JOIN courses ON courses.course_id = trajectories.course_id
+			JOIN course_competency ON course_competency.course_id = courses.course_id
+			WHERE trajectories.student_id = $2 AND trajectories.result = $6
+		UNION ALL SELECT course_competency.competency_id, $5::text, courses.course_id, courses.title, course_competency.level
+			FROM study_groups JOIN courses ON courses.course_id = study_groups.course_id
+			JOIN course_competency ON course_competency.course_id = courses.course_id
+			WHERE study_groups.student_id = $2`,
+		portfolioId, studentId, store.SourcePortfolioProject, store.SourceTrajectoryCourse, store.SourceStudyGroupCourse,
+		store.TrajectoryPassed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sources []store.CompetencySource
+	for rows.Next() {
+		var source store.CompetencySource
+		if err = rows.Scan(&source.CompetencyId, &source.Kind, &source.Id, &source.Title, &source.Level); err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, rows.Err()
+}
+
+func (s *Store) GetStudentOrganization(studentId uuid.UUID) (uuid.UUID, error) {
+	var organizationId uuid.UUID
+	err := s.db.QueryRow(`SELECT educational_programs.organizations_id FROM enrollments
+		JOIN educational_programs ON educational_programs.educational_program_id = enrollments.educational_program_id
+		WHERE enrollments.student_id = $1
+		ORDER BY enrollments.start_date DESC, enrollments.end_date DESC NULLS FIRST, enrollments.enrollment_id DESC LIMIT 1`,
+		studentId).Scan(&organizationId)
+	if !errors.Is(err, store.ErrNotFound) {
+		return organizationId, err
+	}
+
+	err = s.db.QueryRow(`SELECT educational_programs.organizations_id FROM (
+			SELECT course_id FROM trajectories WHERE student_id = $1
+			UNION ALL SELECT course_id FROM study_groups WHERE student_id = $1
+		) AS taken
+		JOIN courses ON courses.course_id = taken.course_id
+		JOIN disciplines ON disciplines.discipline_id = courses.discipline_id
+		JOIN educational_programs ON educational_programs.educational_program_id = disciplines.educational_program_id
+		GROUP BY educational_programs.organizations_id
+		ORDER BY count(*) DESC, educational_programs.organizations_id LIMIT 1`, studentId).Scan(&organizationId)
+	return organizationId, err
+}
+
+func (s *Store) GetStudentEducationalProgram(studentId uuid.UUID) (uuid.UUID, error) {
+	var educationalProgramId uuid.UUID
+	err := s.db.QueryRow(`SELECT educational_program_id FROM enrollments WHERE student_id = $1
+		ORDER BY start_date DESC, end_date DESC NULLS FIRST, enrollment_id DESC LIMIT 1`, studentId).Scan(&educationalProgramId)
+	if !errors.Is(err, store.ErrNotFound) {
+		return educationalProgramId, err
+	}
+
+	err = s.db.QueryRow(`SELECT disciplines.educational_program_id FROM (
+			SELECT course_id FROM trajectories WHERE student_id = $1
+			UNION ALL SELECT course_id FROM study_groups WHERE student_id = $1
+		) AS taken
+		JOIN courses ON courses.course_id = taken.course_id
+		JOIN disciplines ON disciplines.discipline_id = courses.discipline_id
+		GROUP BY disciplines.educational_program_id
+		ORDER BY count(*) DESC, disciplines.educational_program_id LIMIT 1`, studentId).Scan(&educationalProgramId)
+	return educationalProgramId, err
+}
+
+func (s *Store) GetStudyGroups() ([]store.StudyGroup, error) {
+	rows, err := s.db.Query(`SELECT course_id, student_id FROM study_groups`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var studyGroups []store.StudyGroup
+	for rows.Next() {
+		var studyGroup store.StudyGroup
+		if err = rows.Scan(&studyGroup.CourseId, &studyGroup.StudentId); err != nil {
+			return nil, err
+		}
+
+		studyGroups = append(studyGroups, studyGroup)
+	}
+
+	return studyGroups, rows.Err()
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+const teacherColumns = `teachers.teacher_id, teachers.full_name, COALESCE(teachers.organization_id, uuid_nil()),
+	COALESCE(teachers.email, ''), COALESCE(teachers.phone, '')`
+
+func (s *Store) queryTeachers(query string, args ...any) ([]store.Teacher, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teachers []store.Teacher
+	for rows.Next() {
+		var teacher store.Teacher
+		if err = rows.Scan(&teacher.Id, &teacher.FullName, &teacher.OrganizationId, &teacher.Email, &teacher.Phone); err != nil {
+			return nil, err
+		}
+
+		teachers = append(teachers, teacher)
+	}
+
+	return teachers, rows.Err()
+}
+
+func (s *Store) GetTeacher(id uuid.UUID) (store.Teacher, error) {
+	var teacher store.Teacher
+	err := s.db.QueryRow(`SELECT `+teacherColumns+` FROM teachers WHERE teacher_id = $1`, id).
+		Scan(&teacher.Id, &teacher.FullName, &teacher.OrganizationId, &teacher.Email, &teacher.Phone)
+	return teacher, err
+}
+
+func (s *Store) CreateTeacher(teacher store.Teacher) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO teachers (full_name, organization_id, email, phone) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (full_name) DO UPDATE SET full_name = excluded.full_name RETURNING teacher_id`,
+		teacher.FullName, nullId(teacher.OrganizationId), teacher.Email, teacher.Phone)
+}
+
+func (s *Store) SaveTeacher(teacher store.Teacher) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO teachers (full_name, organization_id, email, phone) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (full_name) DO UPDATE SET organization_id = excluded.organization_id, email = excluded.email,
+		phone = excluded.phone RETURNING teacher_id`,
+		teacher.FullName, nullId(teacher.OrganizationId), teacher.Email, teacher.Phone)
+}
+
+func (s *Store) CreateCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error {
+	_, err := s.db.Exec(`INSERT INTO course_teacher (course_id, teacher_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		courseId, teacherId)
+	return err
+}
+
+func (s *Store) GetTeachersByCourse(courseId uuid.UUID) ([]store.Teacher, error) {
+	return s.queryTeachers(`SELECT `+teacherColumns+` FROM teachers
+		JOIN course_teacher ON course_teacher.teacher_id = teachers.teacher_id
+		WHERE course_teacher.course_id = $1 ORDER BY teachers.full_name`, courseId)
+}
+
+func (s *Store) GetCoursesByTeacher(teacherId uuid.UUID) ([]store.Course, error) {
+	return s.queryCourses(`SELECT `+courseColumns+` FROM courses
+		JOIN course_teacher ON course_teacher.course_id = courses.course_id
+		WHERE course_teacher.teacher_id = $1 ORDER BY courses.title`, teacherId)
+}
+
+func (s *Store) DeleteCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error {
+	return s.updateOne(`DELETE FROM course_teacher WHERE course_id = $1 AND teacher_id = $2`, courseId, teacherId)
+}
+
+func (s *Store) UpdateTeacher(teacher store.Teacher) error {
+	return s.updateOne(`UPDATE teachers SET full_name = $2, organization_id = $3, email = NULLIF($4, ''), phone = NULLIF($5, '')
+		WHERE teacher_id = $1`, teacher.Id, teacher.FullName, nullId(teacher.OrganizationId), teacher.Email, teacher.Phone)
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+func (s *Store) GetTimeSlots() ([]store.TimeSlot, error) {
+	rows, err := s.db.Query(`SELECT time_slot_id, weekday, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI')
+		FROM time_slots ORDER BY weekday, start_time`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var timeSlots []store.TimeSlot
+	for rows.Next() {
+		var timeSlot store.TimeSlot
+		if err = rows.Scan(&timeSlot.Id, &timeSlot.Weekday, &timeSlot.Start, &timeSlot.End); err != nil {
+			return nil, err
+		}
+
+		timeSlots = append(timeSlots, timeSlot)
+	}
+
+	return timeSlots, rows.Err()
+}
+
+func (s *Store) CreateTimeSlot(timeSlot store.TimeSlot) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO time_slots (weekday, start_time, end_time) VALUES ($1, $2, $3)
+		ON CONFLICT (weekday, start_time) DO UPDATE SET end_time = EXCLUDED.end_time RETURNING time_slot_id`,
+		timeSlot.Weekday, timeSlot.Start, timeSlot.End)
+}
+
+func (s *Store) GetRooms() ([]store.Room, error) {
+	rows, err := s.db.Query(`SELECT room_id, title, capacity FROM rooms ORDER BY capacity, title`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []store.Room
+	for rows.Next() {
+		var room store.Room
+		if err = rows.Scan(&room.Id, &room.Title, &room.Capacity); err != nil {
+			return nil, err
+		}
+
+		rooms = append(rooms, room)
+	}
+
+	return rooms, rows.Err()
+}
+
+func (s *Store) CreateRoom(room store.Room) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO rooms (title, capacity) VALUES ($1, $2)
+		ON CONFLICT (title) DO UPDATE SET capacity = EXCLUDED.capacity RETURNING room_id`, room.Title, room.Capacity)
+}
+
+func (s *Store) GetCourseSessions() ([]store.CourseSession, error) {
+	rows, err := s.db.Query(`SELECT course_session_id, course_id, kind, weekly_hours FROM course_sessions ORDER BY course_id, kind`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courseSessions []store.CourseSession
+	for rows.Next() {
+		var courseSession store.CourseSession
+		if err = rows.Scan(&courseSession.Id, &courseSession.CourseId, &courseSession.Kind, &courseSession.WeeklyHours); err != nil {
+			return nil, err
+		}
+
+		courseSessions = append(courseSessions, courseSession)
+	}
+
+	return courseSessions, rows.Err()
+}
+
+func (s *Store) CreateCourseSession(courseSession store.CourseSession) (uuid.UUID, error) {
+	return s.createId(`INSERT INTO course_sessions (course_id, kind, weekly_hours) VALUES ($1, $2, $3)
+		ON CONFLICT (course_id, kind) DO UPDATE SET weekly_hours = EXCLUDED.weekly_hours RETURNING course_session_id`,
+		courseSession.CourseId, courseSession.Kind, courseSession.WeeklyHours)
+}
+
+func (s *Store) GetTeacherAvailability() ([]store.TeacherAvailability, error) {
+	rows, err := s.db.Query(`SELECT teacher_id, time_slot_id FROM teacher_availability ORDER BY teacher_id, time_slot_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var availability []store.TeacherAvailability
+	for rows.Next() {
+		var teacherAvailability store.TeacherAvailability
+		if err = rows.Scan(&teacherAvailability.TeacherId, &teacherAvailability.TimeSlotId); err != nil {
+			return nil, err
+		}
+
+		availability = append(availability, teacherAvailability)
+	}
+
+	return availability, rows.Err()
+}
+
+func (s *Store) CreateTeacherAvailability(teacherAvailability store.TeacherAvailability) error {
+	_, err := s.db.Exec(`INSERT INTO teacher_availability (teacher_id, time_slot_id) VALUES ($1, $2)`,
+		teacherAvailability.TeacherId, teacherAvailability.TimeSlotId)
+	return err
+}
+
+func (s *Store) GetTimetable() ([]store.TimetableEntry, error) {
+	rows, err := s.db.Query(`SELECT course_session_id, time_slot_id, room_id FROM timetable`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []store.TimetableEntry
+	for rows.Next() {
+		var entry store.TimetableEntry
+		if err = rows.Scan(&entry.CourseSessionId, &entry.TimeSlotId, &entry.RoomId); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (s *Store) ReplaceTimetable(entries []store.TimetableEntry) error {
+	return s.transaction(func(tx *Store) error {
+		if _, err := tx.db.Exec(`DELETE FROM timetable`); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			_, err := tx.db.Exec(`INSERT INTO timetable (course_session_id, time_slot_id, room_id) VALUES ($1, $2, $3)`,
+				entry.CourseSessionId, entry.TimeSlotId, entry.RoomId)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
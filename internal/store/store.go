@@ -0,0 +1,791 @@
+// Package store describes storage of the catalog and students behind interfaces,
+// so the business rules of the app package do not depend on a running database.
+//
+// Implementations must behave the same way: missing rows are reported with ErrNotFound,
+// broken references and duplicated links with *pq.Error carrying Postgres codes and constraint names.
+// Create methods of titled entities return id of the existing row when the title is already taken.
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ErrNotFound is sql.ErrNoRows, so callers can keep checking for the database/sql error.
+var ErrNotFound = sql.ErrNoRows
+
+// Postgres error codes implementations report.
+const (
+	CodeForeignKeyViolation = "23503"
+	CodeUniqueViolation     = "23505"
+	CodeCheckViolation      = "23514"
+)
+
+// Kinds of CompetencySource.
+const (
+	SourcePortfolioProject = "portfolioProject"
+	SourceTrajectoryCourse = "trajectoryCourse"
+	SourceStudyGroupCourse = "studyGroupCourse"
+)
+
+// Terms of CalendarSemester.
+const (
+	TermAutumn uint8 = 1
+	TermSpring uint8 = 2
+)
+
+// Kinds of CalendarPeriod.
+const (
+	PeriodHoliday = "holiday"
+	PeriodSession = "session"
+)
+
+// Statuses of StudyGroupEntry.
+const (
+	StudyGroupEnrolled   = "enrolled"
+	StudyGroupWaitlisted = "waitlisted"
+	StudyGroupDropped    = "dropped"
+)
+
+// Results of Trajectory.
+const (
+	TrajectoryPassed = "passed"
+	TrajectoryFailed = "failed"
+)
+
+// Proficiency levels of competency links: the level the profession requires and the ones courses and projects give.
+// A higher level includes the lower ones, links without a level are basic.
+const (
+	LevelAware        uint8 = 1
+	LevelBasic        uint8 = 2
+	LevelIntermediate uint8 = 3
+	LevelAdvanced     uint8 = 4
+)
+
+// Kinds of CoursePrerequisite.
+const (
+	PrerequisiteHard = "hard" // the course can not be taken before the prerequisite
+	PrerequisiteSoft = "soft" // the prerequisite is only recommended
+)
+
+// Roles of ApiKey.
+const (
+	RoleAdmin   = "admin"
+	RoleAnalyst = "analyst" // curriculum analyst
+	RoleTeacher = "teacher"
+	RoleStudent = "student"
+)
+
+// Kinds of SearchHit, the catalog tables searched.
+const (
+	SearchKnowledge          = "knowledge"
+	SearchTechnology         = "technology"
+	SearchCompetency         = "competency"
+	SearchProfession         = "profession"
+	SearchProject            = "project"
+	SearchOrganization       = "organization"
+	SearchEducationalProgram = "educationalProgram"
+	SearchDiscipline         = "discipline"
+	SearchCourse             = "course"
+)
+
+// Statuses of Enrollment, active and academicLeave ones are open.
+const (
+	EnrollmentActive      = "active"
+	EnrollmentLeave       = "academicLeave"
+	EnrollmentExpelled    = "expelled"
+	EnrollmentGraduated   = "graduated"
+	EnrollmentTransferred = "transferred" // closed by the transfer to another educational program
+)
+
+// Tables of entities deleted by id, dependents of the deleted rows are reported with the names of their tables.
+const (
+	TableKnowledge           = "knowledge"
+	TableTechnologies        = "technologies"
+	TableSkills              = "skills"
+	TableCompetencies        = "competencies"
+	TableProfessions         = "professions"
+	TableProjects            = "projects"
+	TableOrganizations       = "organizations"
+	TableEducationalPrograms = "educational_programs"
+	TableDisciplines         = "disciplines"
+	TableCourses             = "courses"
+	TableTeachers            = "teachers"
+	TablePortfolios          = "portfolios"
+	TableStudents            = "students"
+	TableTrajectories        = "trajectories"
+)
+
+// Kinds of CourseSession.
+const (
+	SessionLecture  = "lecture"
+	SessionPractice = "practice"
+	SessionLab      = "lab"
+)
+
+// Knowledge may be grouped under a parent knowledge, ParentId uuid.Nil means a root of the taxonomy.
+type Knowledge struct {
+	Id       uuid.UUID
+	Title    string
+	ParentId uuid.UUID
+}
+
+type Technology struct {
+	Id    uuid.UUID
+	Title string
+}
+
+// Competency may be grouped under a parent competency, ParentId uuid.Nil means a root of the taxonomy.
+type Competency struct {
+	Id               uuid.UUID
+	Title            string
+	ParentId         uuid.UUID
+	MainTechnologyId uuid.UUID // uuid.Nil means no main technology
+	Level            uint8     // level of the link the competency is read through, 0 for the competency itself
+}
+
+// Skill is a practical ability, competencies share skills with the same title.
+type Skill struct {
+	Id    uuid.UUID
+	Title string
+}
+
+type Profession struct {
+	Id          uuid.UUID
+	Title       string
+	Description string
+}
+
+type Project struct {
+	Id               uuid.UUID
+	Title            string
+	Description      string
+	Result           string
+	LifeScenario     string
+	MainTechnologyId uuid.UUID // uuid.Nil means no main technology
+}
+
+type Organization struct {
+	Id    uuid.UUID
+	Title string
+}
+
+// EducationalProgram limits credits a student of the program takes in a semester, MaxSemesterCredits 0 means no limit.
+type EducationalProgram struct {
+	Id                 uuid.UUID
+	Title              string
+	Description        string
+	OrganizationId     uuid.UUID
+	MinSemesterCredits uint8
+	MaxSemesterCredits uint8
+}
+
+type Discipline struct {
+	Id                   uuid.UUID
+	Title                string
+	Description          string
+	EducationalProgramId uuid.UUID
+}
+
+// Course workload is given in credit units and academic hours, zero means the workload is unknown.
+// Alternative courses of one discipline are interchangeable options, a student takes one of them.
+// Capacity limits students of the study group of the course, zero means no limit.
+type Course struct {
+	Id           uuid.UUID
+	Title        string
+	Description  string
+	DisciplineId uuid.UUID
+	Credits      uint8
+	Hours        uint16
+	Alternative  bool
+	Capacity     uint16
+}
+
+// Teacher works in the organization and teaches courses, OrganizationId uuid.Nil means the organization is unknown.
+type Teacher struct {
+	Id             uuid.UUID
+	FullName       string
+	OrganizationId uuid.UUID
+	Email          string
+	Phone          string
+}
+
+// CourseCompetency is a course together with one of the competencies it gives.
+type CourseCompetency struct {
+	CourseId           uuid.UUID
+	CourseTitle        string
+	CourseCredits      uint8
+	CourseDisciplineId uuid.UUID
+	CourseAlternative  bool
+	CompetencyId       uuid.UUID
+	Level              uint8
+}
+
+// CoursePrerequisite is a course that has to be taken before the course.
+type CoursePrerequisite struct {
+	CourseId       uuid.UUID
+	PrerequisiteId uuid.UUID
+	Kind           string
+}
+
+type ProjectPortfolio struct {
+	ProjectId   uuid.UUID
+	PortfolioId uuid.UUID
+	TeamRole    string
+	Semester    uint8
+}
+
+type Student struct {
+	Id          uuid.UUID
+	FullName    string
+	PortfolioId uuid.UUID
+	Admition    time.Time
+}
+
+// StudentCourse is a course the student has taken, Current is set for courses of the current semester (study groups).
+type StudentCourse struct {
+	CourseId     uuid.UUID
+	Current      bool
+	DisciplineId uuid.UUID
+	Alternative  bool
+}
+
+// Trajectory is a course the student took in a past semester. Grade and Result stay empty until the outcome is recorded,
+// retakes of a failed course link to the first attempt by RetakeOf.
+type Trajectory struct {
+	Id             uuid.UUID
+	StudentId      uuid.UUID
+	CourseId       uuid.UUID
+	Semester       uint8
+	Grade          uint8
+	Result         string
+	Attempt        uint8
+	CompletionDate time.Time
+	RetakeOf       uuid.UUID
+}
+
+// GradingScale is the grades an organization gives, a course is passed with PassGrade or higher.
+type GradingScale struct {
+	OrganizationId uuid.UUID
+	MinGrade       uint8
+	MaxGrade       uint8
+	PassGrade      uint8
+}
+
+// CompetencySource is a project or a course through which the student got the competency.
+type CompetencySource struct {
+	CompetencyId uuid.UUID
+	Kind         string
+	Id           uuid.UUID
+	Title        string
+	Level        uint8
+}
+
+// CalendarSemester is the dates of one semester of the academic year, Year 2023 is the academic year 2023/2024.
+// Students enroll in courses of the semester from EnrollmentStart to EnrollmentEnd inclusive, zero dates mean
+// the window is not set.
+type CalendarSemester struct {
+	OrganizationId  uuid.UUID
+	Year            int
+	Term            uint8
+	Start           time.Time
+	End             time.Time
+	EnrollmentStart time.Time
+	EnrollmentEnd   time.Time
+}
+
+// CalendarPeriod is a holiday or an examination session of the organization, both dates are inclusive.
+type CalendarPeriod struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	Kind           string
+	Title          string
+	Start          time.Time
+	End            time.Time
+}
+
+// Enrollment is the period the student studies in the educational program, End is zero while the enrollment is open.
+type Enrollment struct {
+	Id                   uuid.UUID
+	StudentId            uuid.UUID
+	EducationalProgramId uuid.UUID
+	Start                time.Time
+	End                  time.Time
+	Status               string
+}
+
+// AcademicLeave is a break in the enrollment, End is zero while the student is on leave.
+type AcademicLeave struct {
+	Id           uuid.UUID
+	EnrollmentId uuid.UUID
+	Start        time.Time
+	End          time.Time
+}
+
+// TimeSlot is a weekly time slot, Weekday 1 is Monday, Start and End are formatted as 15:04.
+type TimeSlot struct {
+	Id      uuid.UUID
+	Weekday uint8
+	Start   string
+	End     string
+}
+
+type Room struct {
+	Id       uuid.UUID
+	Title    string
+	Capacity int
+}
+
+// CourseSession is a kind of classes of the course with its weekly load in academic hours.
+type CourseSession struct {
+	Id          uuid.UUID
+	CourseId    uuid.UUID
+	Kind        string
+	WeeklyHours uint8
+}
+
+// TeacherAvailability is a time slot the teacher can work in.
+type TeacherAvailability struct {
+	TeacherId  uuid.UUID
+	TimeSlotId uuid.UUID
+}
+
+type StudyGroup struct {
+	CourseId  uuid.UUID
+	StudentId uuid.UUID
+}
+
+// StudyGroupEntry is the place of the student on the course of the current semester,
+// Position counts waitlisted students of the course from 1, DropDate is set for dropped students.
+type StudyGroupEntry struct {
+	CourseId  uuid.UUID
+	StudentId uuid.UUID
+	Status    string
+	Position  int
+	DropDate  time.Time
+}
+
+// CatalogLink is a row of a link table of the catalog.
+type CatalogLink struct {
+	FromId uuid.UUID
+	ToId   uuid.UUID
+	Level  uint8 // proficiency level of competency links, 0 for other links
+}
+
+// Catalog is every row of the curated catalog tables.
+type Catalog struct {
+	Organizations         []Organization
+	EducationalPrograms   []EducationalProgram
+	Disciplines           []Discipline
+	Courses               []Course
+	Technologies          []Technology
+	Knowledge             []Knowledge
+	Skills                []Skill
+	Competencies          []Competency
+	Professions           []Profession
+	KnowledgeCompetencies []CatalogLink // knowledge -> competency
+	SkillCompetencies     []CatalogLink // skill -> competency
+	CompetencyProfessions []CatalogLink // competency -> profession
+	CourseCompetencies    []CatalogLink // course -> competency
+	Teachers              []Teacher     // ordered by full name
+	CourseTeachers        []CatalogLink // course -> teacher
+}
+
+// ApiKey lets its owner call the API with the role, only SHA-256 of the key is kept.
+type ApiKey struct {
+	Id        uuid.UUID
+	Hash      string
+	Title     string
+	Role      string
+	StudentId uuid.UUID // set only for the student role
+	TeacherId uuid.UUID // set only for the teacher role
+}
+
+// TimetableEntry places the course session into the time slot and the room.
+type TimetableEntry struct {
+	CourseSessionId uuid.UUID
+	TimeSlotId      uuid.UUID
+	RoomId          uuid.UUID
+}
+
+// ElectivePool is a group of disciplines of the educational program, a student takes Choose of them.
+type ElectivePool struct {
+	Id                   uuid.UUID
+	EducationalProgramId uuid.UUID
+	Title                string
+	Choose               uint8
+}
+
+// CurriculumDiscipline places the discipline into a semester of the curriculum of the educational program.
+type CurriculumDiscipline struct {
+	EducationalProgramId uuid.UUID
+	DisciplineId         uuid.UUID
+	Semester             uint8
+	ElectivePoolId       uuid.UUID // uuid.Nil means the discipline is required
+}
+
+// Page selects Limit rows after Offset ones of a list ordered by the Sort key, rows with equal keys are ordered by id.
+// Every list accepts the "id" key, the other keys are given by the List methods.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// Dependent is the number of rows of the table removed together with the deleted row.
+// Restrict rows are not removed, they reference the row by a foreign key without ON DELETE action and keep it from being deleted.
+type Dependent struct {
+	Table    string
+	Count    int
+	Restrict bool
+}
+
+// SearchHit is the catalog entity found by the search query.
+type SearchHit struct {
+	Kind    string
+	Id      uuid.UUID
+	Title   string
+	Snippet string  // the matched part of the description, or the title, with matched words wrapped in <b> and </b>
+	Rank    float64 // higher is better, ranks of different kinds are comparable
+}
+
+type KnowledgeStore interface {
+	GetKnowledge(id uuid.UUID) (Knowledge, error)
+	GetKnowledgeByTitle(title string) (Knowledge, error)
+	CreateKnowledge(title string) (uuid.UUID, error)
+	// GetKnowledgeByCompetency returns knowledge of the competency ordered by title.
+	GetKnowledgeByCompetency(competencyId uuid.UUID) ([]Knowledge, error)
+	CreateKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error
+	DeleteKnowledgeCompetency(knowledgeId uuid.UUID, competencyId uuid.UUID) error
+	// ListKnowledge returns the page of knowledge sorted by "title" or "id" and the number of all knowledge.
+	ListKnowledge(page Page) ([]Knowledge, int, error)
+	// UpdateKnowledge replaces the title and the parent of the knowledge.
+	UpdateKnowledge(knowledge Knowledge) error
+	// UpdateKnowledgeParent moves the knowledge under the parent, uuid.Nil makes it a root.
+	UpdateKnowledgeParent(id uuid.UUID, parentId uuid.UUID) error
+}
+
+type SkillStore interface {
+	GetSkill(id uuid.UUID) (Skill, error)
+	// CreateSkill returns id of the existing skill with the same title.
+	CreateSkill(title string) (uuid.UUID, error)
+	// GetSkillsByCompetency returns skills of the competency ordered by title.
+	GetSkillsByCompetency(competencyId uuid.UUID) ([]Skill, error)
+	// CreateSkillCompetency does nothing when the competency already has the skill.
+	CreateSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error
+	DeleteSkillCompetency(skillId uuid.UUID, competencyId uuid.UUID) error
+	// ListSkills returns the page of skills sorted by "title" or "id" and the number of all skills.
+	ListSkills(page Page) ([]Skill, int, error)
+}
+
+type TechnologyStore interface {
+	GetTechnology(id uuid.UUID) (Technology, error)
+	GetTechnologyByTitle(title string) (Technology, error)
+	CreateTechnology(title string) (uuid.UUID, error)
+	// ListTechnologies returns the page of technologies sorted by "title" or "id" and the number of all technologies.
+	ListTechnologies(page Page) ([]Technology, int, error)
+	UpdateTechnology(technology Technology) error
+}
+
+type CompetencyStore interface {
+	GetCompetency(id uuid.UUID) (Competency, error)
+	GetCompetencyByTitle(title string) (Competency, error)
+	CreateCompetency(competency Competency) (uuid.UUID, error)
+	// SaveCompetency creates the competency or replaces columns of the one with the same title, the parent included.
+	SaveCompetency(competency Competency) (uuid.UUID, error)
+	// GetCompetenciesByProfession returns competencies required by the profession ordered by title.
+	GetCompetenciesByProfession(professionId uuid.UUID) ([]Competency, error)
+	// GetCompetenciesByCourse returns competencies the course gives ordered by title.
+	GetCompetenciesByCourse(courseId uuid.UUID) ([]Competency, error)
+	// GetCompetenciesByPersonalProject returns competencies confirmed by the project of the portfolio ordered by title.
+	GetCompetenciesByPersonalProject(portfolioId uuid.UUID, projectId uuid.UUID) ([]Competency, error)
+	// ListCompetencies returns the page of competencies sorted by "title" or "id" and the number of all competencies,
+	// mainTechnologyId other than uuid.Nil keeps only competencies with the main technology.
+	ListCompetencies(page Page, mainTechnologyId uuid.UUID) ([]Competency, int, error)
+	// UpdateCompetency replaces the title, the parent and the main technology of the competency.
+	UpdateCompetency(competency Competency) error
+}
+
+type ProfessionStore interface {
+	GetProfession(id uuid.UUID) (Profession, error)
+	GetProfessionByTitle(title string) (Profession, error)
+	CreateProfession(profession Profession) (uuid.UUID, error)
+	// SaveProfession creates the profession or replaces columns of the one with the same title.
+	SaveProfession(profession Profession) (uuid.UUID, error)
+	CreateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error
+	// UpdateCompetencyProfession replaces the level of the competency the profession requires.
+	UpdateCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID, level uint8) error
+	DeleteCompetencyProfession(competencyId uuid.UUID, professionId uuid.UUID) error
+	// ListProfessions returns the page of professions sorted by "title" or "id" and the number of all professions.
+	ListProfessions(page Page) ([]Profession, int, error)
+	UpdateProfession(profession Profession) error
+}
+
+type ProjectStore interface {
+	GetProject(id uuid.UUID) (Project, error)
+	CreateProject(project Project) (uuid.UUID, error)
+	// ListProjects returns the page of projects sorted by "title" or "id" and the number of all projects,
+	// mainTechnologyId other than uuid.Nil keeps only projects with the main technology.
+	ListProjects(page Page, mainTechnologyId uuid.UUID) ([]Project, int, error)
+	UpdateProject(project Project) error
+}
+
+type OrganizationStore interface {
+	GetOrganization(id uuid.UUID) (Organization, error)
+	CreateOrganization(title string) (uuid.UUID, error)
+	// GetGradingScale returns ErrNotFound when the organization has not set its grading scale.
+	GetGradingScale(organizationId uuid.UUID) (GradingScale, error)
+	// SaveGradingScale replaces the grading scale of the organization.
+	SaveGradingScale(scale GradingScale) error
+	// ListOrganizations returns the page of organizations sorted by "title" or "id" and the number of all organizations.
+	ListOrganizations(page Page) ([]Organization, int, error)
+	UpdateOrganization(organization Organization) error
+}
+
+type EducationalProgramStore interface {
+	GetEducationalProgram(id uuid.UUID) (EducationalProgram, error)
+	CreateEducationalProgram(educationalProgram EducationalProgram) (uuid.UUID, error)
+	// SaveEducationalProgram creates the educational program or replaces columns of the one with the same title.
+	SaveEducationalProgram(educationalProgram EducationalProgram) (uuid.UUID, error)
+	// ListEducationalPrograms returns the page of educational programs sorted by "title" or "id" and the number
+	// of all of them, organizationId other than uuid.Nil keeps only programs of the organization.
+	ListEducationalPrograms(page Page, organizationId uuid.UUID) ([]EducationalProgram, int, error)
+	UpdateEducationalProgram(educationalProgram EducationalProgram) error
+}
+
+type DisciplineStore interface {
+	GetDiscipline(id uuid.UUID) (Discipline, error)
+	CreateDiscipline(discipline Discipline) (uuid.UUID, error)
+	// SaveDiscipline creates the discipline or replaces columns of the one with the same title.
+	SaveDiscipline(discipline Discipline) (uuid.UUID, error)
+	// ListDisciplines returns the page of disciplines sorted by "title" or "id" and the number of all disciplines,
+	// educationalProgramId other than uuid.Nil keeps only disciplines of the educational program.
+	ListDisciplines(page Page, educationalProgramId uuid.UUID) ([]Discipline, int, error)
+	UpdateDiscipline(discipline Discipline) error
+}
+
+type CourseStore interface {
+	GetCourse(id uuid.UUID) (Course, error)
+	CreateCourse(course Course) (uuid.UUID, error)
+	// SaveCourse creates the course or replaces columns of the one with the same title, the capacity is not saved.
+	SaveCourse(course Course) (uuid.UUID, error)
+	CreateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error
+	// UpdateCourseCompetency replaces the level of the competency the course gives.
+	UpdateCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID, level uint8) error
+	DeleteCourseCompetency(courseId uuid.UUID, competencyId uuid.UUID) error
+	// ListCourses returns the page of courses sorted by "title", "id" or "teacher" and the number of all courses,
+	// disciplineId other than uuid.Nil keeps only courses of the discipline. The "teacher" key is the first full name
+	// of the course teachers, courses without teachers go last.
+	ListCourses(page Page, disciplineId uuid.UUID) ([]Course, int, error)
+	// UpdateCourse replaces every column of the course, the capacity included.
+	UpdateCourse(course Course) error
+	// GetCoursesByDiscipline returns courses of the discipline ordered by title.
+	GetCoursesByDiscipline(disciplineId uuid.UUID) ([]Course, error)
+	// GetCoursesByCompetencies returns pairs of courses and given competencies ordered by course title.
+	GetCoursesByCompetencies(competencyIds []uuid.UUID) ([]CourseCompetency, error)
+	// GetCoursePrerequisites returns every prerequisite of every course ordered by course and prerequisite.
+	GetCoursePrerequisites() ([]CoursePrerequisite, error)
+	// CreateCoursePrerequisite replaces the kind of the existing prerequisite.
+	CreateCoursePrerequisite(prerequisite CoursePrerequisite) error
+	DeleteCoursePrerequisite(courseId uuid.UUID, prerequisiteId uuid.UUID) error
+}
+
+type TeacherStore interface {
+	GetTeacher(id uuid.UUID) (Teacher, error)
+	// CreateTeacher returns id of the existing teacher with the same full name.
+	CreateTeacher(teacher Teacher) (uuid.UUID, error)
+	// SaveTeacher creates the teacher or replaces columns of the one with the same full name.
+	SaveTeacher(teacher Teacher) (uuid.UUID, error)
+	// CreateCourseTeacher does nothing when the teacher already teaches the course.
+	CreateCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error
+	// GetTeachersByCourse returns teachers of the course ordered by full name.
+	GetTeachersByCourse(courseId uuid.UUID) ([]Teacher, error)
+	// GetCoursesByTeacher returns courses the teacher teaches ordered by title.
+	GetCoursesByTeacher(teacherId uuid.UUID) ([]Course, error)
+	DeleteCourseTeacher(courseId uuid.UUID, teacherId uuid.UUID) error
+	// ListTeachers returns the page of teachers sorted by "fullName" or "id" and the number of all teachers,
+	// organizationId other than uuid.Nil keeps only teachers of the organization.
+	ListTeachers(page Page, organizationId uuid.UUID) ([]Teacher, int, error)
+	UpdateTeacher(teacher Teacher) error
+}
+
+type PortfolioStore interface {
+	CreatePortfolio() (uuid.UUID, error)
+	// GetProjectPortfolios returns projects of the portfolio ordered by semester.
+	GetProjectPortfolios(portfolioId uuid.UUID) ([]ProjectPortfolio, error)
+	CreateProjectPortfolio(projectPortfolio ProjectPortfolio) error
+	CreateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error
+	// UpdateProjectPortfolioCompetency replaces the level of the competency the project of the portfolio confirms.
+	UpdateProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID, level uint8) error
+	// UpdateProjectPortfolio replaces the team role and the semester of the project in the portfolio.
+	UpdateProjectPortfolio(projectPortfolio ProjectPortfolio) error
+	// DeleteProjectPortfolio removes the project from the portfolio together with the competencies confirmed by it.
+	DeleteProjectPortfolio(projectId uuid.UUID, portfolioId uuid.UUID) error
+	DeleteProjectPortfolioCompetency(projectId uuid.UUID, portfolioId uuid.UUID, competencyId uuid.UUID) error
+}
+
+type StudentStore interface {
+	GetStudent(id uuid.UUID) (Student, error)
+	GetStudentByPortfolio(portfolioId uuid.UUID) (Student, error)
+	CreateStudent(student Student) (uuid.UUID, error)
+	// ListStudents returns the page of students sorted by "fullName", "id" or "admition" and the number of all students.
+	ListStudents(page Page) ([]Student, int, error)
+	UpdateStudent(student Student) error
+	CreateStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error
+	// GetStudyGroupCourses returns courses the student studies in the current semester ordered by title.
+	GetStudyGroupCourses(studentId uuid.UUID) ([]Course, error)
+	// CountStudyGroup returns the number of students studying the course in the current semester,
+	// enrollments lock the course first so that concurrent ones do not take the same seat.
+	CountStudyGroup(courseId uuid.UUID) (int, error)
+	DeleteStudyGroup(courseId uuid.UUID, studentId uuid.UUID) error
+	// CreateWaitlistEntry puts the student at the end of the waitlist of the course.
+	CreateWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error
+	DeleteWaitlistEntry(courseId uuid.UUID, studentId uuid.UUID) error
+	// GetCourseWaitlist returns students waiting for a seat on the course in the order they were put in the waitlist.
+	GetCourseWaitlist(courseId uuid.UUID) ([]uuid.UUID, error)
+	// CreateStudyGroupDrop replaces the date of the drop given before.
+	CreateStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID, day time.Time) error
+	// DeleteStudyGroupDrop does nothing when the student has not dropped the course.
+	DeleteStudyGroupDrop(courseId uuid.UUID, studentId uuid.UUID) error
+	// GetStudyGroupEntries returns courses the student is enrolled in, waitlisted for or dropped ordered by course title.
+	GetStudyGroupEntries(studentId uuid.UUID) ([]StudyGroupEntry, error)
+	// GetStudentCourses returns courses the student has passed or is taking.
+	GetStudentCourses(studentId uuid.UUID) ([]StudentCourse, error)
+	GetTrajectory(id uuid.UUID) (Trajectory, error)
+	// GetStudentTrajectories returns courses the student took in past semesters ordered by semester.
+	GetStudentTrajectories(studentId uuid.UUID) ([]Trajectory, error)
+	// CreateTrajectory saves the trajectory without its outcome.
+	CreateTrajectory(trajectory Trajectory) (uuid.UUID, error)
+	// UpdateTrajectory replaces the student, the course and the semester of the trajectory.
+	UpdateTrajectory(trajectory Trajectory) error
+	// UpdateTrajectoryOutcome replaces the grade, the result and the completion date of the trajectory.
+	UpdateTrajectoryOutcome(trajectory Trajectory) error
+	// GetCompetencySources returns every project of the portfolio, passed course and current course of the student
+	// that gives a competency.
+	GetCompetencySources(studentId uuid.UUID, portfolioId uuid.UUID) ([]CompetencySource, error)
+	// GetStudentOrganization returns the organization of the current enrollment of the student,
+	// without enrollments the organization most of the student courses belong to.
+	GetStudentOrganization(studentId uuid.UUID) (uuid.UUID, error)
+	// GetStudentEducationalProgram returns the educational program of the current enrollment of the student,
+	// without enrollments the educational program most of the student courses belong to.
+	GetStudentEducationalProgram(studentId uuid.UUID) (uuid.UUID, error)
+}
+
+type EnrollmentStore interface {
+	CreateEnrollment(enrollment Enrollment) (uuid.UUID, error)
+	// UpdateEnrollment replaces the end and the status of the enrollment.
+	UpdateEnrollment(enrollment Enrollment) error
+	// GetStudentEnrollments returns enrollments of the student ordered by start, the last one is the current one.
+	GetStudentEnrollments(studentId uuid.UUID) ([]Enrollment, error)
+	CreateAcademicLeave(leave AcademicLeave) (uuid.UUID, error)
+	// UpdateAcademicLeave replaces the end of the leave.
+	UpdateAcademicLeave(leave AcademicLeave) error
+	// GetStudentAcademicLeaves returns leaves of every enrollment of the student ordered by start.
+	GetStudentAcademicLeaves(studentId uuid.UUID) ([]AcademicLeave, error)
+}
+
+type CalendarStore interface {
+	// GetCalendarSemesters returns semesters of the organization ordered by start date.
+	GetCalendarSemesters(organizationId uuid.UUID) ([]CalendarSemester, error)
+	// CreateCalendarSemester sets dates of the semester, dates given before are replaced.
+	CreateCalendarSemester(semester CalendarSemester) error
+	// GetCalendarPeriods returns holidays and sessions of the organization ordered by start date.
+	GetCalendarPeriods(organizationId uuid.UUID) ([]CalendarPeriod, error)
+	CreateCalendarPeriod(period CalendarPeriod) (uuid.UUID, error)
+}
+
+type TimetableStore interface {
+	// GetTimeSlots returns time slots ordered by weekday and start.
+	GetTimeSlots() ([]TimeSlot, error)
+	// CreateTimeSlot returns id of the existing time slot starting at the same time of the weekday.
+	CreateTimeSlot(timeSlot TimeSlot) (uuid.UUID, error)
+	// GetRooms returns rooms ordered by capacity and title.
+	GetRooms() ([]Room, error)
+	// CreateRoom returns id of the existing room with the title, its capacity is replaced.
+	CreateRoom(room Room) (uuid.UUID, error)
+	// GetCourseSessions returns sessions of all courses ordered by course and kind.
+	GetCourseSessions() ([]CourseSession, error)
+	// CreateCourseSession returns id of the existing session of the same kind, its weekly hours are replaced.
+	CreateCourseSession(courseSession CourseSession) (uuid.UUID, error)
+	// GetTeacherAvailability returns available time slots of all teachers ordered by teacher and time slot.
+	GetTeacherAvailability() ([]TeacherAvailability, error)
+	CreateTeacherAvailability(teacherAvailability TeacherAvailability) error
+	// GetStudyGroups returns every course and student pair of the current semester.
+	GetStudyGroups() ([]StudyGroup, error)
+	GetTimetable() ([]TimetableEntry, error)
+	// ReplaceTimetable atomically replaces the whole timetable with the entries.
+	ReplaceTimetable(entries []TimetableEntry) error
+}
+
+type ApiKeyStore interface {
+	GetApiKey(id uuid.UUID) (ApiKey, error)
+	GetApiKeyByHash(hash string) (ApiKey, error)
+	CreateApiKey(apiKey ApiKey) (uuid.UUID, error)
+}
+
+type CurriculumStore interface {
+	// CreateElectivePool returns id of the existing pool with the title in the educational program, its choose is replaced.
+	CreateElectivePool(pool ElectivePool) (uuid.UUID, error)
+	// GetElectivePools returns pools of the educational program ordered by title.
+	GetElectivePools(educationalProgramId uuid.UUID) ([]ElectivePool, error)
+	// CreateCurriculumDiscipline replaces the semester and the pool of the discipline already in the curriculum.
+	CreateCurriculumDiscipline(discipline CurriculumDiscipline) error
+	// GetCurriculum returns disciplines of the educational program ordered by semester and discipline.
+	GetCurriculum(educationalProgramId uuid.UUID) ([]CurriculumDiscipline, error)
+}
+
+type SearchStore interface {
+	// Search finds catalog entities whose titles, descriptions and skills contain the words of the Russian query
+	// in any form or whose titles are similar to the query by trigrams, so typos are forgiven.
+	// Hits are ordered by rank, at most limit hits of every kind are returned.
+	Search(query string, limit int) ([]SearchHit, error)
+}
+
+// DeleteStore removes entities by id together with the rows ON DELETE CASCADE foreign keys remove.
+type DeleteStore interface {
+	// GetDependents returns the numbers of rows referencing the row of the table directly or through other dependents,
+	// they would be removed together with it. Tables are ordered by name, the ones without dependents are left out.
+	GetDependents(table string, id uuid.UUID) ([]Dependent, error)
+	// Delete removes the row of the table with its dependents, references of ON DELETE SET NULL keys are cleared.
+	Delete(table string, id uuid.UUID) error
+}
+
+type CatalogStore interface {
+	// GetCatalog returns rows of every catalog table, entities ordered by title.
+	GetCatalog() (Catalog, error)
+}
+
+// Store is the whole storage the app works with.
+type Store interface {
+	KnowledgeStore
+	SkillStore
+	TechnologyStore
+	CompetencyStore
+	ProfessionStore
+	ProjectStore
+	OrganizationStore
+	EducationalProgramStore
+	DisciplineStore
+	CourseStore
+	TeacherStore
+	PortfolioStore
+	StudentStore
+	EnrollmentStore
+	CalendarStore
+	TimetableStore
+	CatalogStore
+	CurriculumStore
+	SearchStore
+	ApiKeyStore
+	DeleteStore
+
+	// Transaction runs fn on a store whose changes are kept only when fn returns nil.
+	// Calls of Transaction inside fn join the running transaction.
+	Transaction(fn func(tx Store) error) error
+	// Lock keeps concurrent transactions from locking the row of the entity table until the transaction ends,
+	// so checks made in the transaction stay true until it commits. Outside a transaction it does nothing.
+	Lock(table string, id uuid.UUID) error
+}
@@ -0,0 +1,1442 @@
+// Package storetest is the contract test suite every store.Store implementation has to pass.
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/M-Koscheev/urfu-project-smart-schedule-former/internal/store"
+)
+
+// Run runs the suite, newStore has to return an empty store for every subtest.
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.Store)
+	}{
+		{"TitleUpsert", testTitleUpsert},
+		{"NotFound", testNotFound},
+		{"ForeignKeys", testForeignKeys},
+		{"DuplicatedLinks", testDuplicatedLinks},
+		{"CatalogLinks", testCatalogLinks},
+		{"CoursesByCompetencies", testCoursesByCompetencies},
+		{"CoursePrerequisites", testCoursePrerequisites},
+		{"Portfolio", testPortfolio},
+		{"Student", testStudent},
+		{"CompetencySources", testCompetencySources},
+		{"Calendar", testCalendar},
+		{"GradingScale", testGradingScale},
+		{"Timetable", testTimetable},
+		{"Transaction", testTransaction},
+		{"Catalog", testCatalog},
+		{"Workload", testWorkload},
+		{"ApiKeys", testApiKeys},
+		{"Search", testSearch},
+		{"Alternatives", testAlternatives},
+		{"Curriculum", testCurriculum},
+		{"Enrollments", testEnrollments},
+		{"Teachers", testTeachers},
+		{"Skills", testSkills},
+		{"Taxonomy", testTaxonomy},
+		{"StudyGroups", testStudyGroups},
+		{"Lists", testLists},
+		{"Updates", testUpdates},
+		{"Deletes", testDeletes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+// must unwraps results of the calls the subtest relies on, failures there mean the store is broken altogether.
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error: %v", err))
+	}
+	return value
+}
+
+func mustDo(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func requirePqError(t *testing.T, err error, code string, constraint string) {
+	t.Helper()
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		t.Fatalf("expected *pq.Error with code %s, got %v", code, err)
+	}
+	if string(pqErr.Code) != code || pqErr.Constraint != constraint {
+		t.Fatalf("expected code %s and constraint %s, got %s and %s", code, constraint, pqErr.Code, pqErr.Constraint)
+	}
+}
+
+// catalog is a minimal chain organization -> program -> discipline -> course.
+type catalog struct {
+	organizationId uuid.UUID
+	programId      uuid.UUID
+	disciplineId   uuid.UUID
+}
+
+func newCatalog(t *testing.T, s store.Store) catalog {
+	t.Helper()
+	var c catalog
+	c.organizationId = must(s.CreateOrganization("urfu"))
+	c.programId = must(s.CreateEducationalProgram(store.EducationalProgram{Title: "software engineering", OrganizationId: c.organizationId}))
+	c.disciplineId = must(s.CreateDiscipline(store.Discipline{Title: "programming", EducationalProgramId: c.programId}))
+	return c
+}
+
+func (c catalog) course(t *testing.T, s store.Store, title string, competencyIds ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	id := must(s.CreateCourse(store.Course{Title: title, DisciplineId: c.disciplineId}))
+	for _, competencyId := range competencyIds {
+		mustDo(t, s.CreateCourseCompetency(id, competencyId, store.LevelBasic))
+	}
+	return id
+}
+
+func competency(t *testing.T, s store.Store, title string) uuid.UUID {
+	t.Helper()
+	return must(s.CreateCompetency(store.Competency{Title: title}))
+}
+
+func testTitleUpsert(t *testing.T, s store.Store) {
+	id := must(s.CreateKnowledge("sql"))
+	if again := must(s.CreateKnowledge("sql")); again != id {
+		t.Fatalf("expected existing id %s for the same title, got %s", id, again)
+	}
+	if got := must(s.GetKnowledge(id)); got != (store.Knowledge{Id: id, Title: "sql"}) {
+		t.Fatalf("unexpected knowledge %+v", got)
+	}
+
+	technologyId := must(s.CreateTechnology("go"))
+	parentId := competency(t, s, "development")
+	competencyId := must(s.CreateCompetency(store.Competency{Title: "backend", ParentId: parentId, MainTechnologyId: technologyId}))
+	// the existing title wins, other columns are left as they were
+	if again := must(s.CreateCompetency(store.Competency{Title: "backend"})); again != competencyId {
+		t.Fatalf("expected existing id %s for the same title, got %s", competencyId, again)
+	}
+	want := store.Competency{Id: competencyId, Title: "backend", ParentId: parentId, MainTechnologyId: technologyId}
+	if got := must(s.GetCompetency(competencyId)); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	professionId := must(s.CreateProfession(store.Profession{Title: "developer", Description: "writes code"}))
+	if got := must(s.GetProfession(professionId)); got.Description != "writes code" {
+		t.Fatalf("unexpected profession %+v", got)
+	}
+
+	if got := must(s.GetKnowledgeByTitle("sql")); got.Id != id {
+		t.Fatalf("expected knowledge %s by title, got %+v", id, got)
+	}
+	if got := must(s.GetTechnologyByTitle("go")); got.Id != technologyId {
+		t.Fatalf("expected technology %s by title, got %+v", technologyId, got)
+	}
+	if got := must(s.GetCompetencyByTitle("backend")); got != want {
+		t.Fatalf("expected %+v by title, got %+v", want, got)
+	}
+	if got := must(s.GetProfessionByTitle("developer")); got.Id != professionId {
+		t.Fatalf("expected profession %s by title, got %+v", professionId, got)
+	}
+}
+
+func testNotFound(t *testing.T, s store.Store) {
+	id := uuid.NewV4()
+	checks := map[string]error{}
+	_, checks["knowledge"] = s.GetKnowledge(id)
+	_, checks["skill"] = s.GetSkill(id)
+	_, checks["technology"] = s.GetTechnology(id)
+	_, checks["competency"] = s.GetCompetency(id)
+	_, checks["profession"] = s.GetProfession(id)
+	_, checks["project"] = s.GetProject(id)
+	_, checks["organization"] = s.GetOrganization(id)
+	_, checks["educational program"] = s.GetEducationalProgram(id)
+	_, checks["discipline"] = s.GetDiscipline(id)
+	_, checks["course"] = s.GetCourse(id)
+	_, checks["student"] = s.GetStudent(id)
+	_, checks["trajectory"] = s.GetTrajectory(id)
+	_, checks["knowledge by title"] = s.GetKnowledgeByTitle("missing")
+	_, checks["technology by title"] = s.GetTechnologyByTitle("missing")
+	_, checks["competency by title"] = s.GetCompetencyByTitle("missing")
+	_, checks["profession by title"] = s.GetProfessionByTitle("missing")
+	for name, err := range checks {
+		if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", name, err)
+		}
+	}
+}
+
+func testForeignKeys(t *testing.T, s store.Store) {
+	_, err := s.CreateCompetency(store.Competency{Title: "backend", MainTechnologyId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "competencies_main_technology_id_fkey")
+
+	// no main technology is allowed
+	id := must(s.CreateProject(store.Project{Title: "scheduler"}))
+	if got := must(s.GetProject(id)); got.MainTechnologyId != uuid.Nil {
+		t.Fatalf("expected no main technology, got %s", got.MainTechnologyId)
+	}
+
+	_, err = s.CreateEducationalProgram(store.EducationalProgram{Title: "software engineering", OrganizationId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "educational_programs_organizations_id_fkey")
+
+	_, err = s.CreateDiscipline(store.Discipline{Title: "programming", EducationalProgramId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "disciplines_educational_program_id_fkey")
+
+	_, err = s.CreateCourse(store.Course{Title: "go", DisciplineId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "courses_discipline_id_fkey")
+
+	knowledgeId := must(s.CreateKnowledge("sql"))
+	err = s.CreateKnowledgeCompetency(knowledgeId, uuid.NewV4())
+	requirePqError(t, err, store.CodeForeignKeyViolation, "knowledge_competency_competency_id_fkey")
+
+	_, err = s.CreateStudent(store.Student{FullName: "student", PortfolioId: uuid.NewV4(), Admition: time.Now()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "students_portfolio_id_fkey")
+}
+
+func testDuplicatedLinks(t *testing.T, s store.Store) {
+	knowledgeId := must(s.CreateKnowledge("sql"))
+	competencyId := competency(t, s, "backend")
+	mustDo(t, s.CreateKnowledgeCompetency(knowledgeId, competencyId))
+	requirePqError(t, s.CreateKnowledgeCompetency(knowledgeId, competencyId), store.CodeUniqueViolation, "knowledge_competency_pkey")
+
+	professionId := must(s.CreateProfession(store.Profession{Title: "developer"}))
+	mustDo(t, s.CreateCompetencyProfession(competencyId, professionId, store.LevelBasic))
+	requirePqError(t, s.CreateCompetencyProfession(competencyId, professionId, store.LevelAdvanced), store.CodeUniqueViolation,
+		"competency_profession_pkey")
+}
+
+func testCatalogLinks(t *testing.T, s store.Store) {
+	professionId := must(s.CreateProfession(store.Profession{Title: "developer"}))
+	b := competency(t, s, "b")
+	a := competency(t, s, "a")
+	c := competency(t, s, "c")
+	for _, id := range []uuid.UUID{b, c, a} {
+		mustDo(t, s.CreateCompetencyProfession(id, professionId, store.LevelBasic))
+	}
+	requirePqError(t, s.CreateCompetencyProfession(competency(t, s, "d"), professionId, 0), store.CodeCheckViolation,
+		"competency_profession_level_check")
+	mustDo(t, s.UpdateCompetencyProfession(b, professionId, store.LevelAdvanced))
+	requirePqError(t, s.UpdateCompetencyProfession(b, professionId, store.LevelAdvanced+1), store.CodeCheckViolation,
+		"competency_profession_level_check")
+	if err := s.UpdateCompetencyProfession(b, uuid.NewV4(), store.LevelAware); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing link, got %v", err)
+	}
+
+	required := must(s.GetCompetenciesByProfession(professionId))
+	if len(required) != 3 || required[0].Id != a || required[1].Id != b || required[2].Id != c {
+		t.Fatalf("expected competencies ordered by title, got %+v", required)
+	}
+	if required[0].Level != store.LevelBasic || required[1].Level != store.LevelAdvanced {
+		t.Fatalf("expected levels of the links, got %+v", required)
+	}
+
+	second := must(s.CreateKnowledge("second"))
+	first := must(s.CreateKnowledge("first"))
+	mustDo(t, s.CreateKnowledgeCompetency(second, a))
+	mustDo(t, s.CreateKnowledgeCompetency(first, a))
+	knowledge := must(s.GetKnowledgeByCompetency(a))
+	if len(knowledge) != 2 || knowledge[0].Id != first || knowledge[1].Id != second {
+		t.Fatalf("expected knowledge ordered by title, got %+v", knowledge)
+	}
+
+	courseId := newCatalog(t, s).course(t, s, "go", c, b)
+	requirePqError(t, s.CreateCourseCompetency(courseId, a, store.LevelAdvanced+1), store.CodeCheckViolation, "course_competency_level_check")
+	mustDo(t, s.UpdateCourseCompetency(courseId, c, store.LevelIntermediate))
+	if err := s.UpdateCourseCompetency(courseId, a, store.LevelAware); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing link, got %v", err)
+	}
+	given := must(s.GetCompetenciesByCourse(courseId))
+	if len(given) != 2 || given[0].Id != b || given[1].Id != c || given[0].Level != store.LevelBasic || given[1].Level != store.LevelIntermediate {
+		t.Fatalf("expected course competencies ordered by title, got %+v", given)
+	}
+}
+
+func testCoursesByCompetencies(t *testing.T, s store.Store) {
+	a := competency(t, s, "a")
+	b := competency(t, s, "b")
+	other := competency(t, s, "other")
+	c := newCatalog(t, s)
+	java := c.course(t, s, "java", a)
+	golang := c.course(t, s, "go", a, b)
+	c.course(t, s, "python", other)
+
+	if pairs := must(s.GetCoursesByCompetencies(nil)); len(pairs) != 0 {
+		t.Fatalf("expected no courses for no competencies, got %+v", pairs)
+	}
+
+	pairs := must(s.GetCoursesByCompetencies([]uuid.UUID{a, b}))
+	if len(pairs) != 3 || pairs[0].CourseId != golang || pairs[1].CourseId != golang || pairs[2].CourseId != java {
+		t.Fatalf("expected pairs ordered by course title, got %+v", pairs)
+	}
+	if pairs[0].CourseTitle != "go" || pairs[2].CompetencyId != a {
+		t.Fatalf("unexpected pairs %+v", pairs)
+	}
+}
+
+func testCoursePrerequisites(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	intro := c.course(t, s, "intro")
+	advanced := c.course(t, s, "advanced")
+	algorithms := c.course(t, s, "algorithms")
+
+	err := s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: intro, Kind: "optional"})
+	requirePqError(t, err, store.CodeCheckViolation, "course_prerequisite_kind_check")
+	err = s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: advanced, Kind: store.PrerequisiteHard})
+	requirePqError(t, err, store.CodeCheckViolation, "course_prerequisite_self_check")
+	err = s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: uuid.NewV4(), Kind: store.PrerequisiteHard})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "course_prerequisite_prerequisite_id_fkey")
+
+	mustDo(t, s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: intro, Kind: store.PrerequisiteSoft}))
+	mustDo(t, s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: algorithms, Kind: store.PrerequisiteSoft}))
+	// the second call replaces the kind
+	mustDo(t, s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: advanced, PrerequisiteId: intro, Kind: store.PrerequisiteHard}))
+
+	prerequisites := must(s.GetCoursePrerequisites())
+	sort.Slice(prerequisites, func(i, j int) bool { return prerequisites[i].Kind < prerequisites[j].Kind })
+	want := []store.CoursePrerequisite{
+		{CourseId: advanced, PrerequisiteId: intro, Kind: store.PrerequisiteHard},
+		{CourseId: advanced, PrerequisiteId: algorithms, Kind: store.PrerequisiteSoft},
+	}
+	if !reflect.DeepEqual(prerequisites, want) {
+		t.Fatalf("expected %+v, got %+v", want, prerequisites)
+	}
+}
+
+func testPortfolio(t *testing.T, s store.Store) {
+	portfolioId := must(s.CreatePortfolio())
+	late := must(s.CreateProject(store.Project{Title: "late"}))
+	early := must(s.CreateProject(store.Project{Title: "early"}))
+	competencyId := competency(t, s, "teamwork")
+
+	err := s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: early, PortfolioId: portfolioId})
+	requirePqError(t, err, store.CodeCheckViolation, "project_portfolio_semester_check")
+
+	mustDo(t, s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: late, PortfolioId: portfolioId, TeamRole: "lead", Semester: 4}))
+	mustDo(t, s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: early, PortfolioId: portfolioId, Semester: 2}))
+	err = s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: early, PortfolioId: portfolioId, Semester: 3})
+	requirePqError(t, err, store.CodeUniqueViolation, "project_portfolio_pkey")
+
+	projects := must(s.GetProjectPortfolios(portfolioId))
+	want := []store.ProjectPortfolio{
+		{ProjectId: early, PortfolioId: portfolioId, Semester: 2},
+		{ProjectId: late, PortfolioId: portfolioId, TeamRole: "lead", Semester: 4},
+	}
+	if len(projects) != 2 || projects[0] != want[0] || projects[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, projects)
+	}
+
+	requirePqError(t, s.CreateProjectPortfolioCompetency(late, portfolioId, competencyId, 0), store.CodeCheckViolation,
+		"project_portfolio_competency_level_check")
+	mustDo(t, s.CreateProjectPortfolioCompetency(late, portfolioId, competencyId, store.LevelBasic))
+	requirePqError(t, s.UpdateProjectPortfolioCompetency(late, portfolioId, competencyId, store.LevelAdvanced+1), store.CodeCheckViolation,
+		"project_portfolio_competency_level_check")
+	if err := s.UpdateProjectPortfolioCompetency(late, portfolioId, uuid.NewV4(), store.LevelAware); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing link, got %v", err)
+	}
+	mustDo(t, s.UpdateProjectPortfolioCompetency(late, portfolioId, competencyId, store.LevelAdvanced))
+	competencies := must(s.GetCompetenciesByPersonalProject(portfolioId, late))
+	if len(competencies) != 1 || competencies[0].Id != competencyId || competencies[0].Level != store.LevelAdvanced {
+		t.Fatalf("unexpected project competencies %+v", competencies)
+	}
+}
+
+func testStudent(t *testing.T, s store.Store) {
+	portfolioId := must(s.CreatePortfolio())
+	admition := time.Date(2022, time.September, 1, 15, 30, 0, 0, time.UTC)
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: portfolioId, Admition: admition}))
+
+	student := must(s.GetStudent(studentId))
+	if student.FullName != "student" || student.PortfolioId != portfolioId || student.Admition.Format("2006-01-02") != "2022-09-01" {
+		t.Fatalf("unexpected student %+v", student)
+	}
+
+	c := newCatalog(t, s)
+	past := c.course(t, s, "past")
+	current := c.course(t, s, "current")
+	mustDo(t, s.CreateStudyGroup(current, studentId))
+	requirePqError(t, s.CreateStudyGroup(current, studentId), store.CodeUniqueViolation, "study_groups_pkey")
+
+	_, err := s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Attempt: 1})
+	requirePqError(t, err, store.CodeCheckViolation, "trajectories_semester_check")
+	_, err = s.CreateTrajectory(store.Trajectory{StudentId: uuid.NewV4(), CourseId: past, Semester: 1, Attempt: 1})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "trajectories_student_id_fkey")
+	_, err = s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 1, Attempt: 2})
+	requirePqError(t, err, store.CodeCheckViolation, "trajectories_retake_check")
+	_, err = s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 1, Attempt: 2, RetakeOf: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "trajectories_retake_of_fkey")
+
+	trajectoryId := must(s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 1, Attempt: 1}))
+	want := store.Trajectory{Id: trajectoryId, StudentId: studentId, CourseId: past, Semester: 1, Attempt: 1}
+	if got := must(s.GetTrajectory(trajectoryId)); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	// courses without an outcome are not taken yet
+	if courses := must(s.GetStudentCourses(studentId)); len(courses) != 1 || courses[0].CourseId != current {
+		t.Fatalf("expected only the current course, got %+v", courses)
+	}
+	requirePqError(t, s.UpdateTrajectoryOutcome(store.Trajectory{Id: trajectoryId, Grade: 2, CompletionDate: admition}),
+		store.CodeCheckViolation, "trajectories_completion_check")
+	if err = s.UpdateTrajectoryOutcome(store.Trajectory{Id: uuid.NewV4(), Result: store.TrajectoryFailed}); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing trajectory, got %v", err)
+	}
+	mustDo(t, s.UpdateTrajectoryOutcome(store.Trajectory{Id: trajectoryId, Grade: 2, Result: store.TrajectoryFailed,
+		CompletionDate: admition.AddDate(0, 4, 0)}))
+	failed := must(s.GetTrajectory(trajectoryId))
+	if failed.Grade != 2 || failed.Result != store.TrajectoryFailed || failed.Attempt != 1 ||
+		failed.CompletionDate.Format("2006-01-02") != "2023-01-01" {
+		t.Fatalf("unexpected outcome %+v", failed)
+	}
+
+	retake := must(s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 3, Attempt: 2,
+		RetakeOf: trajectoryId}))
+	_, err = s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 3, Attempt: 2,
+		RetakeOf: trajectoryId})
+	requirePqError(t, err, store.CodeUniqueViolation, "trajectories_retake_of_attempt_key")
+	mustDo(t, s.UpdateTrajectoryOutcome(store.Trajectory{Id: retake, Grade: 4, Result: store.TrajectoryPassed}))
+	trajectories := must(s.GetStudentTrajectories(studentId))
+	if len(trajectories) != 2 || trajectories[0].Id != trajectoryId || trajectories[1].Id != retake ||
+		trajectories[1].RetakeOf != trajectoryId || trajectories[1].Attempt != 2 || !trajectories[1].CompletionDate.IsZero() {
+		t.Fatalf("expected trajectories ordered by semester, got %+v", trajectories)
+	}
+
+	groups := must(s.GetStudyGroupCourses(studentId))
+	if len(groups) != 1 || groups[0].Id != current {
+		t.Fatalf("unexpected study group courses %+v", groups)
+	}
+
+	courses := must(s.GetStudentCourses(studentId))
+	sort.Slice(courses, func(i, j int) bool { return !courses[i].Current && courses[j].Current })
+	wantCourses := []store.StudentCourse{{CourseId: past, DisciplineId: c.disciplineId}, {CourseId: current, Current: true, DisciplineId: c.disciplineId}}
+	if len(courses) != 2 || courses[0] != wantCourses[0] || courses[1] != wantCourses[1] {
+		t.Fatalf("expected %+v, got %+v", wantCourses, courses)
+	}
+
+	if organizationId := must(s.GetStudentOrganization(studentId)); organizationId != c.organizationId {
+		t.Fatalf("expected organization %s, got %s", c.organizationId, organizationId)
+	}
+	if _, err = s.GetStudentOrganization(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for student without courses, got %v", err)
+	}
+}
+
+func testCompetencySources(t *testing.T, s store.Store) {
+	byProject := competency(t, s, "by project")
+	byTrajectory := competency(t, s, "by trajectory")
+	byStudyGroup := competency(t, s, "by study group")
+	competency(t, s, "missing")
+
+	portfolioId := must(s.CreatePortfolio())
+	projectId := must(s.CreateProject(store.Project{Title: "project"}))
+	mustDo(t, s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, Semester: 1}))
+	mustDo(t, s.CreateProjectPortfolioCompetency(projectId, portfolioId, byProject, store.LevelIntermediate))
+
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: portfolioId, Admition: time.Now()}))
+	c := newCatalog(t, s)
+	past := c.course(t, s, "past", byTrajectory)
+	current := c.course(t, s, "current", byStudyGroup)
+	failed := c.course(t, s, "failed", competency(t, s, "by failed course"))
+	must(s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: failed, Semester: 1, Attempt: 1}))
+	passed := must(s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: past, Semester: 1, Attempt: 1}))
+	mustDo(t, s.UpdateTrajectoryOutcome(store.Trajectory{Id: passed, Grade: 5, Result: store.TrajectoryPassed}))
+	mustDo(t, s.CreateStudyGroup(current, studentId))
+
+	sources := must(s.GetCompetencySources(studentId, portfolioId))
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Kind < sources[j].Kind })
+	want := []store.CompetencySource{
+		{CompetencyId: byProject, Kind: store.SourcePortfolioProject, Id: projectId, Title: "project", Level: store.LevelIntermediate},
+		{CompetencyId: byStudyGroup, Kind: store.SourceStudyGroupCourse, Id: current, Title: "current", Level: store.LevelBasic},
+		{CompetencyId: byTrajectory, Kind: store.SourceTrajectoryCourse, Id: past, Title: "past", Level: store.LevelBasic},
+	}
+	if len(sources) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, sources)
+	}
+	for i := range want {
+		if sources[i] != want[i] {
+			t.Fatalf("expected %+v, got %+v", want, sources)
+		}
+	}
+}
+
+func testGradingScale(t *testing.T, s store.Store) {
+	organizationId := must(s.CreateOrganization("urfu"))
+	if _, err := s.GetGradingScale(organizationId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without the grading scale, got %v", err)
+	}
+
+	scale := store.GradingScale{OrganizationId: organizationId, MinGrade: 1, MaxGrade: 5, PassGrade: 6}
+	requirePqError(t, s.SaveGradingScale(scale), store.CodeCheckViolation, "grading_scales_grades_check")
+	scale.MinGrade, scale.PassGrade = 0, 0
+	requirePqError(t, s.SaveGradingScale(scale), store.CodeCheckViolation, "grading_scales_min_grade_check")
+	scale.OrganizationId, scale.MinGrade, scale.PassGrade = uuid.NewV4(), 1, 3
+	requirePqError(t, s.SaveGradingScale(scale), store.CodeForeignKeyViolation, "grading_scales_organization_id_fkey")
+
+	scale.OrganizationId = organizationId
+	mustDo(t, s.SaveGradingScale(scale))
+	scale.MaxGrade, scale.PassGrade = 100, 60
+	mustDo(t, s.SaveGradingScale(scale))
+	if got := must(s.GetGradingScale(organizationId)); got != scale {
+		t.Fatalf("expected the replaced scale %+v, got %+v", scale, got)
+	}
+}
+
+func testCalendar(t *testing.T, s store.Store) {
+	organizationId := must(s.CreateOrganization("urfu"))
+	day := func(month time.Month, day int) time.Time { return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC) }
+
+	autumn := store.CalendarSemester{OrganizationId: organizationId, Year: 2023, Term: store.TermAutumn, Start: day(time.September, 1), End: day(time.August, 1)}
+	requirePqError(t, s.CreateCalendarSemester(autumn), store.CodeCheckViolation, "calendar_semesters_dates_check")
+	autumn.End = day(time.December, 31)
+	autumn.Term = 3
+	requirePqError(t, s.CreateCalendarSemester(autumn), store.CodeCheckViolation, "calendar_semesters_term_check")
+	autumn.Term = store.TermAutumn
+	autumn.OrganizationId = uuid.NewV4()
+	requirePqError(t, s.CreateCalendarSemester(autumn), store.CodeForeignKeyViolation, "calendar_semesters_organization_id_fkey")
+	autumn.OrganizationId = organizationId
+
+	autumn.EnrollmentStart = day(time.August, 20)
+	requirePqError(t, s.CreateCalendarSemester(autumn), store.CodeCheckViolation, "calendar_semesters_enrollment_check")
+	autumn.EnrollmentEnd = day(time.January, 10).AddDate(1, 0, 0)
+	requirePqError(t, s.CreateCalendarSemester(autumn), store.CodeCheckViolation, "calendar_semesters_enrollment_check")
+	autumn.EnrollmentEnd = day(time.September, 10)
+
+	mustDo(t, s.CreateCalendarSemester(autumn))
+	// dates of the same semester are replaced
+	autumn.Start = day(time.September, 4)
+	mustDo(t, s.CreateCalendarSemester(autumn))
+	spring := store.CalendarSemester{OrganizationId: organizationId, Year: 2022, Term: store.TermSpring, Start: day(time.February, 6), End: day(time.June, 30)}
+	mustDo(t, s.CreateCalendarSemester(spring))
+
+	semesters := must(s.GetCalendarSemesters(organizationId))
+	if len(semesters) != 2 || semesters[0].Year != 2022 || semesters[1].Start.Format("2006-01-02") != "2023-09-04" {
+		t.Fatalf("unexpected semesters %+v", semesters)
+	}
+	if !semesters[0].EnrollmentStart.IsZero() || semesters[1].EnrollmentStart.Format("2006-01-02") != "2023-08-20" ||
+		semesters[1].EnrollmentEnd.Format("2006-01-02") != "2023-09-10" {
+		t.Fatalf("unexpected enrollment windows %+v", semesters)
+	}
+	if other := must(s.GetCalendarSemesters(uuid.NewV4())); len(other) != 0 {
+		t.Fatalf("expected no semesters of unknown organization, got %+v", other)
+	}
+
+	period := store.CalendarPeriod{OrganizationId: organizationId, Kind: "vacation", Title: "new year", Start: day(time.December, 30), End: day(time.December, 31)}
+	_, err := s.CreateCalendarPeriod(period)
+	requirePqError(t, err, store.CodeCheckViolation, "calendar_periods_kind_check")
+	period.Kind = store.PeriodHoliday
+	period.Start = day(time.December, 31)
+	period.End = day(time.December, 30)
+	_, err = s.CreateCalendarPeriod(period)
+	requirePqError(t, err, store.CodeCheckViolation, "calendar_periods_dates_check")
+
+	period.Start = day(time.December, 30)
+	period.End = day(time.December, 31)
+	holidayId := must(s.CreateCalendarPeriod(period))
+	sessionId := must(s.CreateCalendarPeriod(store.CalendarPeriod{
+		OrganizationId: organizationId, Kind: store.PeriodSession, Start: day(time.June, 1), End: day(time.June, 30),
+	}))
+
+	periods := must(s.GetCalendarPeriods(organizationId))
+	if len(periods) != 2 || periods[0].Id != sessionId || periods[1].Id != holidayId || periods[1].Title != "new year" {
+		t.Fatalf("unexpected periods %+v", periods)
+	}
+}
+
+func testTimetable(t *testing.T, s store.Store) {
+	_, err := s.CreateTimeSlot(store.TimeSlot{Weekday: 1, Start: "10:00", End: "08:30"})
+	requirePqError(t, err, store.CodeCheckViolation, "time_slots_times_check")
+	_, err = s.CreateTimeSlot(store.TimeSlot{Weekday: 8, Start: "08:30", End: "10:00"})
+	requirePqError(t, err, store.CodeCheckViolation, "time_slots_weekday_check")
+
+	tuesday := must(s.CreateTimeSlot(store.TimeSlot{Weekday: 2, Start: "08:30", End: "10:00"}))
+	monday := must(s.CreateTimeSlot(store.TimeSlot{Weekday: 1, Start: "10:15", End: "11:45"}))
+	// the slot starting at the same time of the weekday is updated
+	if again := must(s.CreateTimeSlot(store.TimeSlot{Weekday: 1, Start: "10:15", End: "11:50"})); again != monday {
+		t.Fatalf("expected existing time slot %s, got %s", monday, again)
+	}
+	timeSlots := must(s.GetTimeSlots())
+	want := []store.TimeSlot{{Id: monday, Weekday: 1, Start: "10:15", End: "11:50"}, {Id: tuesday, Weekday: 2, Start: "08:30", End: "10:00"}}
+	if len(timeSlots) != 2 || timeSlots[0] != want[0] || timeSlots[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, timeSlots)
+	}
+
+	_, err = s.CreateRoom(store.Room{Title: "Р-101"})
+	requirePqError(t, err, store.CodeCheckViolation, "rooms_capacity_check")
+	hall := must(s.CreateRoom(store.Room{Title: "Р-101", Capacity: 100}))
+	if again := must(s.CreateRoom(store.Room{Title: "Р-101", Capacity: 120})); again != hall {
+		t.Fatalf("expected existing room %s, got %s", hall, again)
+	}
+	small := must(s.CreateRoom(store.Room{Title: "Р-202", Capacity: 20}))
+	rooms := must(s.GetRooms())
+	if len(rooms) != 2 || rooms[0].Id != small || rooms[1].Capacity != 120 {
+		t.Fatalf("unexpected rooms %+v", rooms)
+	}
+
+	c := newCatalog(t, s)
+	courseId := c.course(t, s, "go")
+	_, err = s.CreateCourseSession(store.CourseSession{CourseId: courseId, Kind: "seminar", WeeklyHours: 2})
+	requirePqError(t, err, store.CodeCheckViolation, "course_sessions_kind_check")
+	_, err = s.CreateCourseSession(store.CourseSession{CourseId: courseId, Kind: store.SessionLecture})
+	requirePqError(t, err, store.CodeCheckViolation, "course_sessions_weekly_hours_check")
+	_, err = s.CreateCourseSession(store.CourseSession{CourseId: uuid.NewV4(), Kind: store.SessionLecture, WeeklyHours: 2})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "course_sessions_course_id_fkey")
+	lecture := must(s.CreateCourseSession(store.CourseSession{CourseId: courseId, Kind: store.SessionLecture, WeeklyHours: 2}))
+	if again := must(s.CreateCourseSession(store.CourseSession{CourseId: courseId, Kind: store.SessionLecture, WeeklyHours: 4})); again != lecture {
+		t.Fatalf("expected existing session %s, got %s", lecture, again)
+	}
+	sessions := must(s.GetCourseSessions())
+	if len(sessions) != 1 || sessions[0] != (store.CourseSession{Id: lecture, CourseId: courseId, Kind: store.SessionLecture, WeeklyHours: 4}) {
+		t.Fatalf("unexpected sessions %+v", sessions)
+	}
+
+	teacherId := must(s.CreateTeacher(store.Teacher{FullName: "teacher"}))
+	requirePqError(t, s.CreateTeacherAvailability(store.TeacherAvailability{TeacherId: teacherId, TimeSlotId: uuid.NewV4()}),
+		store.CodeForeignKeyViolation, "teacher_availability_time_slot_id_fkey")
+	requirePqError(t, s.CreateTeacherAvailability(store.TeacherAvailability{TeacherId: uuid.NewV4(), TimeSlotId: monday}),
+		store.CodeForeignKeyViolation, "teacher_availability_teacher_id_fkey")
+	mustDo(t, s.CreateTeacherAvailability(store.TeacherAvailability{TeacherId: teacherId, TimeSlotId: monday}))
+	requirePqError(t, s.CreateTeacherAvailability(store.TeacherAvailability{TeacherId: teacherId, TimeSlotId: monday}),
+		store.CodeUniqueViolation, "teacher_availability_pkey")
+	if availability := must(s.GetTeacherAvailability()); len(availability) != 1 || availability[0].TimeSlotId != monday {
+		t.Fatalf("unexpected availability %+v", availability)
+	}
+
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", Admition: time.Now()}))
+	mustDo(t, s.CreateStudyGroup(courseId, studentId))
+	if groups := must(s.GetStudyGroups()); len(groups) != 1 || groups[0] != (store.StudyGroup{CourseId: courseId, StudentId: studentId}) {
+		t.Fatalf("unexpected study groups %+v", groups)
+	}
+
+	first := store.TimetableEntry{CourseSessionId: lecture, TimeSlotId: monday, RoomId: hall}
+	requirePqError(t, s.ReplaceTimetable([]store.TimetableEntry{first, first}), store.CodeUniqueViolation, "timetable_pkey")
+	requirePqError(t, s.ReplaceTimetable([]store.TimetableEntry{{CourseSessionId: lecture, TimeSlotId: monday, RoomId: uuid.NewV4()}}),
+		store.CodeForeignKeyViolation, "timetable_room_id_fkey")
+	mustDo(t, s.ReplaceTimetable([]store.TimetableEntry{first}))
+	second := store.TimetableEntry{CourseSessionId: lecture, TimeSlotId: tuesday, RoomId: small}
+	// failed replacement keeps the previous timetable
+	requirePqError(t, s.ReplaceTimetable([]store.TimetableEntry{second, second}), store.CodeUniqueViolation, "timetable_pkey")
+	if entries := must(s.GetTimetable()); len(entries) != 1 || entries[0] != first {
+		t.Fatalf("expected %+v, got %+v", first, entries)
+	}
+	mustDo(t, s.ReplaceTimetable([]store.TimetableEntry{second}))
+	if entries := must(s.GetTimetable()); len(entries) != 1 || entries[0] != second {
+		t.Fatalf("expected %+v, got %+v", second, entries)
+	}
+}
+
+func testTransaction(t *testing.T, s store.Store) {
+	failure := errors.New("failure")
+	err := s.Transaction(func(tx store.Store) error {
+		competencyId := must(tx.CreateCompetency(store.Competency{Title: "backend"}))
+		mustDo(t, tx.CreateKnowledgeCompetency(must(tx.CreateKnowledge("sql")), competencyId))
+		// nested transactions join the outer one
+		mustDo(t, tx.Transaction(func(tx store.Store) error {
+			_, err := tx.CreateKnowledge("http")
+			return err
+		}))
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	for _, title := range []string{"sql", "http"} {
+		if _, err = s.GetKnowledgeByTitle(title); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("expected %s to be rolled back, got %v", title, err)
+		}
+	}
+	if _, err = s.GetCompetencyByTitle("backend"); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected competency to be rolled back, got %v", err)
+	}
+
+	mustDo(t, s.Transaction(func(tx store.Store) error {
+		_, err := tx.CreateKnowledge("sql")
+		return err
+	}))
+	must(s.GetKnowledgeByTitle("sql"))
+}
+
+func testCatalog(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	technologyId := must(s.CreateTechnology("go"))
+	knowledgeId := must(s.CreateKnowledge("sql"))
+	competencyId := must(s.CreateCompetency(store.Competency{Title: "backend"}))
+	professionId := must(s.CreateProfession(store.Profession{Title: "developer"}))
+	courseId := must(s.CreateCourse(store.Course{Title: "go", DisciplineId: c.disciplineId}))
+	mustDo(t, s.CreateKnowledgeCompetency(knowledgeId, competencyId))
+	mustDo(t, s.CreateCompetencyProfession(competencyId, professionId, store.LevelAdvanced))
+	mustDo(t, s.CreateCourseCompetency(courseId, competencyId, store.LevelBasic))
+
+	skillId := must(s.CreateSkill("http"))
+	mustDo(t, s.CreateSkillCompetency(skillId, competencyId))
+
+	// saving replaces columns and keeps the id
+	competency := store.Competency{Title: "backend", MainTechnologyId: technologyId}
+	if id := must(s.SaveCompetency(competency)); id != competencyId {
+		t.Fatalf("expected id %s of the saved competency, got %s", competencyId, id)
+	}
+	competency.Id = competencyId
+	course := store.Course{Id: courseId, Title: "go", Description: "basics", DisciplineId: c.disciplineId, Credits: 5, Hours: 180}
+	must(s.SaveCourse(course))
+	must(s.SaveProfession(store.Profession{Title: "developer", Description: "writes code"}))
+	must(s.SaveDiscipline(store.Discipline{Title: "programming", Description: "code", EducationalProgramId: c.programId}))
+	must(s.SaveEducationalProgram(store.EducationalProgram{Title: "software engineering", OrganizationId: c.organizationId,
+		MinSemesterCredits: 20, MaxSemesterCredits: 35}))
+	newId := must(s.SaveCourse(store.Course{Title: "algorithms", DisciplineId: c.disciplineId}))
+
+	_, err := s.SaveCourse(store.Course{Title: "go", DisciplineId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "courses_discipline_id_fkey")
+	_, err = s.SaveCompetency(store.Competency{Title: "backend", MainTechnologyId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "competencies_main_technology_id_fkey")
+
+	catalog := must(s.GetCatalog())
+	if len(catalog.Organizations) != 1 || len(catalog.EducationalPrograms) != 1 || len(catalog.Technologies) != 1 ||
+		len(catalog.Knowledge) != 1 || len(catalog.Professions) != 1 || len(catalog.Skills) != 1 {
+		t.Fatalf("unexpected catalog %+v", catalog)
+	}
+	if want := []store.Course{{Id: newId, Title: "algorithms", DisciplineId: c.disciplineId}, course}; !reflect.DeepEqual(catalog.Courses, want) {
+		t.Fatalf("expected courses %+v ordered by title, got %+v", want, catalog.Courses)
+	}
+	if want := []store.Competency{competency}; !reflect.DeepEqual(catalog.Competencies, want) {
+		t.Fatalf("expected competencies %+v, got %+v", want, catalog.Competencies)
+	}
+	if got := catalog.EducationalPrograms[0]; got.MinSemesterCredits != 20 || got.MaxSemesterCredits != 35 {
+		t.Fatalf("unexpected educational program %+v", got)
+	}
+	if got := catalog.Disciplines[0]; got.Description != "code" {
+		t.Fatalf("unexpected discipline %+v", got)
+	}
+	if got := catalog.Professions[0]; got.Description != "writes code" {
+		t.Fatalf("unexpected profession %+v", got)
+	}
+	links := map[string][]store.CatalogLink{
+		"knowledge competency":  {{FromId: knowledgeId, ToId: competencyId}},
+		"skill competency":      {{FromId: skillId, ToId: competencyId}},
+		"competency profession": {{FromId: competencyId, ToId: professionId, Level: store.LevelAdvanced}},
+		"course competency":     {{FromId: courseId, ToId: competencyId, Level: store.LevelBasic}},
+	}
+	got := map[string][]store.CatalogLink{
+		"knowledge competency":  catalog.KnowledgeCompetencies,
+		"skill competency":      catalog.SkillCompetencies,
+		"competency profession": catalog.CompetencyProfessions,
+		"course competency":     catalog.CourseCompetencies,
+	}
+	if !reflect.DeepEqual(got, links) {
+		t.Fatalf("expected links %+v, got %+v", links, got)
+	}
+}
+
+func testWorkload(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	_, err := s.CreateEducationalProgram(store.EducationalProgram{Title: "wrong", OrganizationId: c.organizationId,
+		MinSemesterCredits: 40, MaxSemesterCredits: 30})
+	requirePqError(t, err, store.CodeCheckViolation, "educational_programs_semester_credits_check")
+	// no maximum allows any minimum
+	programId := must(s.CreateEducationalProgram(store.EducationalProgram{Title: "unlimited", OrganizationId: c.organizationId,
+		MinSemesterCredits: 40}))
+	if got := must(s.GetEducationalProgram(programId)); got.MinSemesterCredits != 40 || got.MaxSemesterCredits != 0 {
+		t.Fatalf("unexpected educational program %+v", got)
+	}
+
+	competencyId := competency(t, s, "backend")
+	courseId := must(s.CreateCourse(store.Course{Title: "go", DisciplineId: c.disciplineId, Credits: 5, Hours: 180}))
+	if got := must(s.GetCourse(courseId)); got.Credits != 5 || got.Hours != 180 {
+		t.Fatalf("unexpected course %+v", got)
+	}
+	mustDo(t, s.CreateCourseCompetency(courseId, competencyId, store.LevelBasic))
+	if pairs := must(s.GetCoursesByCompetencies([]uuid.UUID{competencyId})); len(pairs) != 1 || pairs[0].CourseCredits != 5 {
+		t.Fatalf("expected credits of the course, got %+v", pairs)
+	}
+
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: must(s.CreatePortfolio()), Admition: time.Now()}))
+	if _, err = s.GetStudentEducationalProgram(studentId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for student without courses, got %v", err)
+	}
+	mustDo(t, s.CreateStudyGroup(courseId, studentId))
+	if got := must(s.GetStudentEducationalProgram(studentId)); got != c.programId {
+		t.Fatalf("expected educational program %s, got %s", c.programId, got)
+	}
+	if groups := must(s.GetStudyGroupCourses(studentId)); len(groups) != 1 || groups[0].Credits != 5 {
+		t.Fatalf("expected credits of the study group course, got %+v", groups)
+	}
+}
+
+func testApiKeys(t *testing.T, s store.Store) {
+	portfolioId := must(s.CreatePortfolio())
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: portfolioId, Admition: time.Now()}))
+	if got := must(s.GetStudentByPortfolio(portfolioId)); got.Id != studentId {
+		t.Fatalf("expected student %s, got %+v", studentId, got)
+	}
+	if _, err := s.GetStudentByPortfolio(must(s.CreatePortfolio())); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for portfolio without student, got %v", err)
+	}
+
+	_, err := s.CreateApiKey(store.ApiKey{Hash: "wrong", Title: "wrong", Role: "guest"})
+	requirePqError(t, err, store.CodeCheckViolation, "api_keys_api_key_role_check")
+	_, err = s.CreateApiKey(store.ApiKey{Hash: "student", Title: "student", Role: store.RoleStudent})
+	requirePqError(t, err, store.CodeCheckViolation, "api_keys_subject_check")
+	teacherId := must(s.CreateTeacher(store.Teacher{FullName: "teacher"}))
+	_, err = s.CreateApiKey(store.ApiKey{Hash: "admin", Title: "admin", Role: store.RoleAdmin, TeacherId: teacherId})
+	requirePqError(t, err, store.CodeCheckViolation, "api_keys_subject_check")
+	_, err = s.CreateApiKey(store.ApiKey{Hash: "teacher", Title: "teacher", Role: store.RoleTeacher, TeacherId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "api_keys_teacher_id_fkey")
+	_, err = s.CreateApiKey(store.ApiKey{Hash: "student", Title: "student", Role: store.RoleStudent, StudentId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "api_keys_student_id_fkey")
+
+	want := store.ApiKey{Hash: "student", Title: "student", Role: store.RoleStudent, StudentId: studentId}
+	want.Id = must(s.CreateApiKey(want))
+	if got := must(s.GetApiKeyByHash("student")); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if got := must(s.GetApiKey(want.Id)); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if _, err = s.GetApiKey(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown id, got %v", err)
+	}
+	_, err = s.CreateApiKey(store.ApiKey{Hash: "student", Title: "teacher", Role: store.RoleTeacher, TeacherId: teacherId})
+	requirePqError(t, err, store.CodeUniqueViolation, "api_keys_api_key_hash_key")
+	if _, err = s.GetApiKeyByHash("unknown"); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown hash, got %v", err)
+	}
+}
+
+func testSearch(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	courseId := must(s.CreateCourse(store.Course{Title: "Машинное обучение", Description: "Нейронные сети и градиентный бустинг",
+		DisciplineId: c.disciplineId}))
+	professionId := must(s.CreateProfession(store.Profession{Title: "Инженер машинного обучения"}))
+	competencyId := must(s.CreateCompetency(store.Competency{Title: "Анализ данных"}))
+	for _, title := range []string{"визуализация", "статистика"} {
+		mustDo(t, s.CreateSkillCompetency(must(s.CreateSkill(title)), competencyId))
+	}
+	must(s.CreateKnowledge("Линейная алгебра"))
+	for _, title := range []string{"Программирование 1", "Программирование 2", "Программирование 3"} {
+		must(s.CreateCourse(store.Course{Title: title, DisciplineId: c.disciplineId}))
+	}
+
+	find := func(query string, limit int, kind string, id uuid.UUID) store.SearchHit {
+		t.Helper()
+		for _, hit := range must(s.Search(query, limit)) {
+			if hit.Kind == kind && hit.Id == id {
+				return hit
+			}
+		}
+		t.Fatalf("expected %s %s to be found by %q", kind, id, query)
+		return store.SearchHit{}
+	}
+
+	// other word forms are found and the title matching better goes first
+	hits := must(s.Search("машинное обучение", 10))
+	if len(hits) < 2 || hits[0].Id != courseId {
+		t.Fatalf("expected the course first, got %+v", hits)
+	}
+	find("машинное обучение", 10, store.SearchProfession, professionId)
+	// a typo in the title
+	find("машиное обучене", 10, store.SearchCourse, courseId)
+	// words of the description are highlighted
+	if hit := find("нейронных сетях", 10, store.SearchCourse, courseId); !strings.Contains(hit.Snippet, "<b>") {
+		t.Fatalf("expected highlighted snippet, got %q", hit.Snippet)
+	}
+	find("данные", 10, store.SearchCompetency, competencyId)
+	find("статистика", 10, store.SearchCompetency, competencyId)
+
+	// the limit applies to every kind
+	if hits := must(s.Search("программирование", 2)); len(hits) != 2 || hits[0].Kind != store.SearchCourse || hits[1].Kind != store.SearchCourse {
+		t.Fatalf("expected 2 courses, got %+v", hits)
+	}
+	if hits := must(s.Search("квантовая хромодинамика", 10)); len(hits) != 0 {
+		t.Fatalf("expected nothing found, got %+v", hits)
+	}
+}
+
+func testAlternatives(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	competencyId := competency(t, s, "backend")
+	kotlinId := must(s.CreateCourse(store.Course{Title: "kotlin", DisciplineId: c.disciplineId, Alternative: true}))
+	goId := must(s.CreateCourse(store.Course{Title: "go", DisciplineId: c.disciplineId, Alternative: true}))
+	algorithmsId := c.course(t, s, "algorithms")
+	other := must(s.CreateDiscipline(store.Discipline{Title: "databases", EducationalProgramId: c.programId}))
+	must(s.CreateCourse(store.Course{Title: "sql", DisciplineId: other}))
+
+	if got := must(s.GetCourse(goId)); !got.Alternative {
+		t.Fatalf("expected alternative course, got %+v", got)
+	}
+	var titles []string
+	for _, course := range must(s.GetCoursesByDiscipline(c.disciplineId)) {
+		titles = append(titles, course.Title)
+	}
+	if want := []string{"algorithms", "go", "kotlin"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("expected courses %v of the discipline, got %v", want, titles)
+	}
+
+	mustDo(t, s.CreateCourseCompetency(kotlinId, competencyId, store.LevelAdvanced))
+	want := []store.CourseCompetency{{CourseId: kotlinId, CourseTitle: "kotlin", CourseDisciplineId: c.disciplineId,
+		CourseAlternative: true, CompetencyId: competencyId, Level: store.LevelAdvanced}}
+	if got := must(s.GetCoursesByCompetencies([]uuid.UUID{competencyId})); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: must(s.CreatePortfolio()), Admition: time.Now()}))
+	mustDo(t, s.CreateStudyGroup(goId, studentId))
+	trajectoryId := must(s.CreateTrajectory(store.Trajectory{StudentId: studentId, CourseId: algorithmsId, Semester: 1, Attempt: 1}))
+	mustDo(t, s.UpdateTrajectoryOutcome(store.Trajectory{Id: trajectoryId, Result: store.TrajectoryPassed}))
+	courses := must(s.GetStudentCourses(studentId))
+	sort.Slice(courses, func(i, j int) bool { return courses[i].Current })
+	if want := []store.StudentCourse{{CourseId: goId, Current: true, DisciplineId: c.disciplineId, Alternative: true},
+		{CourseId: algorithmsId, DisciplineId: c.disciplineId}}; !reflect.DeepEqual(courses, want) {
+		t.Fatalf("expected student courses %+v, got %+v", want, courses)
+	}
+}
+
+func testCurriculum(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	_, err := s.CreateElectivePool(store.ElectivePool{EducationalProgramId: c.programId, Title: "languages"})
+	requirePqError(t, err, store.CodeCheckViolation, "elective_pools_choose_check")
+	_, err = s.CreateElectivePool(store.ElectivePool{EducationalProgramId: uuid.NewV4(), Title: "languages", Choose: 1})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "elective_pools_educational_program_id_fkey")
+
+	poolId := must(s.CreateElectivePool(store.ElectivePool{EducationalProgramId: c.programId, Title: "languages", Choose: 1}))
+	if got := must(s.CreateElectivePool(store.ElectivePool{EducationalProgramId: c.programId, Title: "languages", Choose: 2})); got != poolId {
+		t.Fatalf("expected existing pool %s, got %s", poolId, got)
+	}
+	must(s.CreateElectivePool(store.ElectivePool{EducationalProgramId: c.programId, Title: "electives", Choose: 1}))
+	pools := must(s.GetElectivePools(c.programId))
+	if len(pools) != 2 || pools[0].Title != "electives" || pools[1] != (store.ElectivePool{Id: poolId,
+		EducationalProgramId: c.programId, Title: "languages", Choose: 2}) {
+		t.Fatalf("unexpected pools %+v", pools)
+	}
+
+	otherProgramId := must(s.CreateEducationalProgram(store.EducationalProgram{Title: "design", OrganizationId: c.organizationId}))
+	otherPoolId := must(s.CreateElectivePool(store.ElectivePool{EducationalProgramId: otherProgramId, Title: "languages", Choose: 1}))
+	databasesId := must(s.CreateDiscipline(store.Discipline{Title: "databases", EducationalProgramId: c.programId}))
+
+	err = s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: c.disciplineId, Semester: 13})
+	requirePqError(t, err, store.CodeCheckViolation, "curriculum_disciplines_semester_check")
+	err = s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: uuid.NewV4(), DisciplineId: c.disciplineId, Semester: 1})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "curriculum_disciplines_educational_program_id_fkey")
+	err = s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: uuid.NewV4(), Semester: 1})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "curriculum_disciplines_discipline_id_fkey")
+	// the pool of another program
+	err = s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: c.disciplineId,
+		Semester: 1, ElectivePoolId: otherPoolId})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "curriculum_disciplines_elective_pool_id_fkey")
+
+	mustDo(t, s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: databasesId,
+		Semester: 3, ElectivePoolId: poolId}))
+	mustDo(t, s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: databasesId,
+		Semester: 4}))
+	mustDo(t, s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: c.disciplineId,
+		Semester: 2, ElectivePoolId: poolId}))
+	want := []store.CurriculumDiscipline{
+		{EducationalProgramId: c.programId, DisciplineId: c.disciplineId, Semester: 2, ElectivePoolId: poolId},
+		{EducationalProgramId: c.programId, DisciplineId: databasesId, Semester: 4},
+	}
+	if got := must(s.GetCurriculum(c.programId)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected curriculum %+v, got %+v", want, got)
+	}
+	if got := must(s.GetCurriculum(otherProgramId)); len(got) != 0 {
+		t.Fatalf("expected empty curriculum, got %+v", got)
+	}
+}
+
+func testEnrollments(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	studentId := must(s.CreateStudent(store.Student{FullName: "student", PortfolioId: must(s.CreatePortfolio()), Admition: time.Now()}))
+	otherOrganizationId := must(s.CreateOrganization("mipt"))
+	otherProgramId := must(s.CreateEducationalProgram(store.EducationalProgram{Title: "physics", OrganizationId: otherOrganizationId}))
+	start := time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	transfer := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	// courses tell the program until the student is enrolled
+	mustDo(t, s.CreateStudyGroup(c.course(t, s, "go"), studentId))
+	if got := must(s.GetStudentEducationalProgram(studentId)); got != c.programId {
+		t.Fatalf("expected educational program %s of the courses, got %s", c.programId, got)
+	}
+
+	enrollment := store.Enrollment{StudentId: studentId, EducationalProgramId: c.programId, Start: start, Status: "unknown"}
+	_, err := s.CreateEnrollment(enrollment)
+	requirePqError(t, err, store.CodeCheckViolation, "enrollments_status_check")
+	enrollment.Status = store.EnrollmentGraduated
+	_, err = s.CreateEnrollment(enrollment)
+	requirePqError(t, err, store.CodeCheckViolation, "enrollments_end_check")
+	enrollment.Status, enrollment.End = store.EnrollmentExpelled, start.AddDate(0, 0, -1)
+	_, err = s.CreateEnrollment(enrollment)
+	requirePqError(t, err, store.CodeCheckViolation, "enrollments_dates_check")
+	_, err = s.CreateEnrollment(store.Enrollment{StudentId: uuid.NewV4(), EducationalProgramId: c.programId, Start: start,
+		Status: store.EnrollmentActive})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "enrollments_student_id_fkey")
+	_, err = s.CreateEnrollment(store.Enrollment{StudentId: studentId, EducationalProgramId: uuid.NewV4(), Start: start,
+		Status: store.EnrollmentActive})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "enrollments_educational_program_id_fkey")
+
+	first := store.Enrollment{StudentId: studentId, EducationalProgramId: c.programId, Start: start, Status: store.EnrollmentActive}
+	first.Id = must(s.CreateEnrollment(first))
+	_, err = s.CreateEnrollment(store.Enrollment{StudentId: studentId, EducationalProgramId: otherProgramId, Start: transfer,
+		Status: store.EnrollmentActive})
+	requirePqError(t, err, store.CodeUniqueViolation, "enrollments_student_id_key")
+
+	first.End, first.Status = transfer, store.EnrollmentTransferred
+	mustDo(t, s.UpdateEnrollment(first))
+	second := store.Enrollment{StudentId: studentId, EducationalProgramId: otherProgramId, Start: transfer, Status: store.EnrollmentActive}
+	second.Id = must(s.CreateEnrollment(second))
+	if err = s.UpdateEnrollment(store.Enrollment{Id: uuid.NewV4(), Status: store.EnrollmentActive}); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown enrollment, got %v", err)
+	}
+
+	enrollments := must(s.GetStudentEnrollments(studentId))
+	if len(enrollments) != 2 || !enrollments[0].End.Equal(transfer) || enrollments[0].Status != store.EnrollmentTransferred ||
+		enrollments[1].Id != second.Id || !enrollments[1].End.IsZero() {
+		t.Fatalf("unexpected enrollments %+v", enrollments)
+	}
+	if got := must(s.GetStudentEducationalProgram(studentId)); got != otherProgramId {
+		t.Fatalf("expected educational program %s of the enrollment, got %s", otherProgramId, got)
+	}
+	if got := must(s.GetStudentOrganization(studentId)); got != otherOrganizationId {
+		t.Fatalf("expected organization %s of the enrollment, got %s", otherOrganizationId, got)
+	}
+
+	leaveStart := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.CreateAcademicLeave(store.AcademicLeave{EnrollmentId: second.Id, Start: leaveStart, End: leaveStart.AddDate(0, 0, -1)})
+	requirePqError(t, err, store.CodeCheckViolation, "academic_leaves_dates_check")
+	_, err = s.CreateAcademicLeave(store.AcademicLeave{EnrollmentId: uuid.NewV4(), Start: leaveStart})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "academic_leaves_enrollment_id_fkey")
+	leave := store.AcademicLeave{EnrollmentId: second.Id, Start: leaveStart}
+	leave.Id = must(s.CreateAcademicLeave(leave))
+	leave.End = leaveStart.AddDate(1, 0, 0)
+	mustDo(t, s.UpdateAcademicLeave(leave))
+	if leaves := must(s.GetStudentAcademicLeaves(studentId)); len(leaves) != 1 || leaves[0].Id != leave.Id ||
+		!leaves[0].Start.Equal(leaveStart) || !leaves[0].End.Equal(leave.End) {
+		t.Fatalf("unexpected academic leaves %+v", leaves)
+	}
+}
+
+func testTeachers(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	_, err := s.CreateTeacher(store.Teacher{FullName: "Иванов И. И.", OrganizationId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "teachers_organization_id_fkey")
+
+	ivanov := must(s.CreateTeacher(store.Teacher{FullName: "Иванов И. И.", OrganizationId: c.organizationId, Email: "ivanov@urfu.ru"}))
+	// the existing full name wins, other columns are left as they were
+	if again := must(s.CreateTeacher(store.Teacher{FullName: "Иванов И. И.", Phone: "123"})); again != ivanov {
+		t.Fatalf("expected existing id %s for the same full name, got %s", ivanov, again)
+	}
+	want := store.Teacher{Id: ivanov, FullName: "Иванов И. И.", OrganizationId: c.organizationId, Email: "ivanov@urfu.ru"}
+	if got := must(s.GetTeacher(ivanov)); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	want = store.Teacher{Id: ivanov, FullName: "Иванов И. И.", Phone: "123"}
+	if saved := must(s.SaveTeacher(want)); saved != ivanov {
+		t.Fatalf("expected saved id %s, got %s", ivanov, saved)
+	}
+	if got := must(s.GetTeacher(ivanov)); got != want {
+		t.Fatalf("expected %+v after save, got %+v", want, got)
+	}
+	if _, err = s.GetTeacher(uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown teacher, got %v", err)
+	}
+
+	petrov := must(s.CreateTeacher(store.Teacher{FullName: "Петров П. П."}))
+	goId := c.course(t, s, "go")
+	algorithmsId := c.course(t, s, "algorithms")
+	requirePqError(t, s.CreateCourseTeacher(uuid.NewV4(), ivanov), store.CodeForeignKeyViolation, "course_teacher_course_id_fkey")
+	requirePqError(t, s.CreateCourseTeacher(goId, uuid.NewV4()), store.CodeForeignKeyViolation, "course_teacher_teacher_id_fkey")
+	mustDo(t, s.CreateCourseTeacher(goId, petrov))
+	mustDo(t, s.CreateCourseTeacher(goId, ivanov))
+	mustDo(t, s.CreateCourseTeacher(goId, ivanov))
+	mustDo(t, s.CreateCourseTeacher(algorithmsId, ivanov))
+
+	if teachers := must(s.GetTeachersByCourse(goId)); len(teachers) != 2 || teachers[0].Id != ivanov || teachers[1].Id != petrov {
+		t.Fatalf("expected teachers ordered by full name, got %+v", teachers)
+	}
+	if courses := must(s.GetCoursesByTeacher(ivanov)); len(courses) != 2 || courses[0].Id != algorithmsId || courses[1].Id != goId {
+		t.Fatalf("expected courses ordered by title, got %+v", courses)
+	}
+	if courses := must(s.GetCoursesByTeacher(uuid.NewV4())); len(courses) != 0 {
+		t.Fatalf("expected no courses of unknown teacher, got %+v", courses)
+	}
+
+	catalog := must(s.GetCatalog())
+	if len(catalog.Teachers) != 2 || catalog.Teachers[0].Id != ivanov || len(catalog.CourseTeachers) != 3 {
+		t.Fatalf("expected teachers and their links in the catalog, got %+v and %+v", catalog.Teachers, catalog.CourseTeachers)
+	}
+}
+
+func testStudyGroups(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	goId := must(s.CreateCourse(store.Course{Title: "go", DisciplineId: c.disciplineId, Capacity: 1}))
+	algorithmsId := c.course(t, s, "algorithms")
+	if course := must(s.GetCourse(goId)); course.Capacity != 1 {
+		t.Fatalf("expected capacity 1, got %+v", course)
+	}
+	// the catalog does not carry the capacity
+	must(s.SaveCourse(store.Course{Title: "go", DisciplineId: c.disciplineId, Credits: 3}))
+	if course := must(s.GetCourse(goId)); course.Capacity != 1 || course.Credits != 3 {
+		t.Fatalf("expected the capacity to be kept, got %+v", course)
+	}
+
+	first := must(s.CreateStudent(store.Student{FullName: "first"}))
+	second := must(s.CreateStudent(store.Student{FullName: "second"}))
+	third := must(s.CreateStudent(store.Student{FullName: "third"}))
+	mustDo(t, s.CreateStudyGroup(goId, first))
+	if count := must(s.CountStudyGroup(goId)); count != 1 {
+		t.Fatalf("expected one student of the course, got %d", count)
+	}
+	mustDo(t, s.Transaction(func(tx store.Store) error {
+		if err := tx.Lock(store.TableCourses, goId); err != nil {
+			return err
+		}
+		if count := must(tx.CountStudyGroup(goId)); count != 1 {
+			t.Fatalf("expected one student of the locked course, got %d", count)
+		}
+		return nil
+	}))
+	if err := s.Lock("study_groups", goId); err == nil {
+		t.Fatal("expected an error for a table without the primary key")
+	}
+
+	requirePqError(t, s.CreateWaitlistEntry(uuid.NewV4(), second), store.CodeForeignKeyViolation, "course_waitlists_course_id_fkey")
+	requirePqError(t, s.CreateWaitlistEntry(goId, uuid.NewV4()), store.CodeForeignKeyViolation, "course_waitlists_student_id_fkey")
+	mustDo(t, s.CreateWaitlistEntry(goId, third))
+	mustDo(t, s.CreateWaitlistEntry(goId, second))
+	mustDo(t, s.CreateWaitlistEntry(algorithmsId, second))
+	requirePqError(t, s.CreateWaitlistEntry(goId, second), store.CodeUniqueViolation, "course_waitlists_pkey")
+	if waitlist := must(s.GetCourseWaitlist(goId)); len(waitlist) != 2 || waitlist[0] != third || waitlist[1] != second {
+		t.Fatalf("expected students in the order they were waitlisted, got %v", waitlist)
+	}
+
+	requirePqError(t, s.CreateStudyGroupDrop(goId, uuid.NewV4(), time.Now()), store.CodeForeignKeyViolation, "study_group_drops_student_id_fkey")
+	mustDo(t, s.CreateStudyGroupDrop(algorithmsId, third, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)))
+	mustDo(t, s.CreateStudyGroupDrop(algorithmsId, third, time.Date(2023, time.September, 5, 0, 0, 0, 0, time.UTC)))
+	want := []store.StudyGroupEntry{
+		{CourseId: algorithmsId, StudentId: third, Status: store.StudyGroupDropped},
+		{CourseId: goId, StudentId: third, Status: store.StudyGroupWaitlisted, Position: 1},
+	}
+	entries := must(s.GetStudyGroupEntries(third))
+	if len(entries) != 2 || entries[0].DropDate.Format("2006-01-02") != "2023-09-05" {
+		t.Fatalf("expected the latest drop date, got %+v", entries)
+	}
+	entries[0].DropDate = time.Time{}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("expected %+v, got %+v", want, entries)
+	}
+
+	mustDo(t, s.DeleteWaitlistEntry(goId, third))
+	if err := s.DeleteWaitlistEntry(goId, third); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the removed waitlist entry, got %v", err)
+	}
+	mustDo(t, s.DeleteStudyGroup(goId, first))
+	if err := s.DeleteStudyGroup(goId, first); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the removed study group, got %v", err)
+	}
+	mustDo(t, s.DeleteStudyGroupDrop(algorithmsId, third))
+	mustDo(t, s.DeleteStudyGroupDrop(algorithmsId, third))
+	if entries := must(s.GetStudyGroupEntries(third)); len(entries) != 0 {
+		t.Fatalf("expected no entries, got %+v", entries)
+	}
+	want = []store.StudyGroupEntry{
+		{CourseId: algorithmsId, StudentId: second, Status: store.StudyGroupWaitlisted, Position: 1},
+		{CourseId: goId, StudentId: second, Status: store.StudyGroupWaitlisted, Position: 1},
+	}
+	if entries := must(s.GetStudyGroupEntries(second)); !reflect.DeepEqual(entries, want) {
+		t.Fatalf("expected %+v, got %+v", want, entries)
+	}
+}
+
+func testSkills(t *testing.T, s store.Store) {
+	backendId := competency(t, s, "backend")
+	frontendId := competency(t, s, "frontend")
+	httpId := must(s.CreateSkill("http"))
+	if again := must(s.CreateSkill("http")); again != httpId {
+		t.Fatalf("expected existing id %s for the same title, got %s", httpId, again)
+	}
+	if got := must(s.GetSkill(httpId)); got != (store.Skill{Id: httpId, Title: "http"}) {
+		t.Fatalf("unexpected skill %+v", got)
+	}
+	apiId := must(s.CreateSkill("api design"))
+
+	// competencies share skills, a repeated link is ignored
+	mustDo(t, s.CreateSkillCompetency(httpId, backendId))
+	mustDo(t, s.CreateSkillCompetency(apiId, backendId))
+	mustDo(t, s.CreateSkillCompetency(httpId, backendId))
+	mustDo(t, s.CreateSkillCompetency(httpId, frontendId))
+	want := []store.Skill{{Id: apiId, Title: "api design"}, {Id: httpId, Title: "http"}}
+	if got := must(s.GetSkillsByCompetency(backendId)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected skills %+v ordered by title, got %+v", want, got)
+	}
+	if got := must(s.GetSkillsByCompetency(frontendId)); len(got) != 1 || got[0].Id != httpId {
+		t.Fatalf("expected the shared skill, got %+v", got)
+	}
+
+	err := s.CreateSkillCompetency(uuid.NewV4(), backendId)
+	requirePqError(t, err, store.CodeForeignKeyViolation, "skill_competency_skill_id_fkey")
+	err = s.CreateSkillCompetency(httpId, uuid.NewV4())
+	requirePqError(t, err, store.CodeForeignKeyViolation, "skill_competency_competency_id_fkey")
+}
+
+func testTaxonomy(t *testing.T, s store.Store) {
+	developmentId := competency(t, s, "development")
+	backendId := must(s.CreateCompetency(store.Competency{Title: "backend", ParentId: developmentId}))
+	if got := must(s.GetCompetencyByTitle("backend")); got.ParentId != developmentId {
+		t.Fatalf("expected parent %s, got %+v", developmentId, got)
+	}
+	_, err := s.CreateCompetency(store.Competency{Title: "frontend", ParentId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "competencies_parent_id_fkey")
+	_, err = s.SaveCompetency(store.Competency{Title: "backend", ParentId: backendId})
+	requirePqError(t, err, store.CodeCheckViolation, "competencies_parent_check")
+	// saving moves the competency to the root
+	must(s.SaveCompetency(store.Competency{Title: "backend"}))
+	if got := must(s.GetCompetency(backendId)); got.ParentId != uuid.Nil {
+		t.Fatalf("expected the root competency, got %+v", got)
+	}
+
+	programmingId := must(s.CreateKnowledge("programming"))
+	sqlId := must(s.CreateKnowledge("sql"))
+	mustDo(t, s.UpdateKnowledgeParent(sqlId, programmingId))
+	if got := must(s.GetKnowledge(sqlId)); got.ParentId != programmingId {
+		t.Fatalf("expected parent %s, got %+v", programmingId, got)
+	}
+	if got := must(s.GetKnowledgeByTitle("sql")); got.ParentId != programmingId {
+		t.Fatalf("expected parent %s by title, got %+v", programmingId, got)
+	}
+	requirePqError(t, s.UpdateKnowledgeParent(sqlId, sqlId), store.CodeCheckViolation, "knowledge_parent_check")
+	requirePqError(t, s.UpdateKnowledgeParent(sqlId, uuid.NewV4()), store.CodeForeignKeyViolation, "knowledge_parent_id_fkey")
+	if err = s.UpdateKnowledgeParent(uuid.NewV4(), programmingId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing knowledge, got %v", err)
+	}
+
+	competencyId := competency(t, s, "databases")
+	mustDo(t, s.CreateKnowledgeCompetency(sqlId, competencyId))
+	if got := must(s.GetKnowledgeByCompetency(competencyId)); len(got) != 1 || got[0].ParentId != programmingId {
+		t.Fatalf("expected knowledge with its parent, got %+v", got)
+	}
+	mustDo(t, s.UpdateKnowledgeParent(sqlId, uuid.Nil))
+	catalog := must(s.GetCatalog())
+	for _, knowledge := range catalog.Knowledge {
+		if knowledge.ParentId != uuid.Nil {
+			t.Fatalf("expected root knowledge only, got %+v", catalog.Knowledge)
+		}
+	}
+}
+
+func testLists(t *testing.T, s store.Store) {
+	for _, title := range []string{"c", "a", "b"} {
+		must(s.CreateKnowledge(title))
+	}
+	titlesOf := func(knowledge []store.Knowledge) string {
+		var titles []string
+		for _, row := range knowledge {
+			titles = append(titles, row.Title)
+		}
+		return strings.Join(titles, ",")
+	}
+	pages := []struct {
+		page store.Page
+		want string
+	}{
+		{store.Page{Limit: 2, Sort: "title"}, "a,b"},
+		{store.Page{Limit: 2, Offset: 2, Sort: "title"}, "c"},
+		{store.Page{Limit: 2, Sort: "title", Desc: true}, "c,b"},
+		{store.Page{Limit: 2, Offset: 5, Sort: "title"}, ""},
+	}
+	for _, tt := range pages {
+		knowledge, total, err := s.ListKnowledge(tt.page)
+		mustDo(t, err)
+		if total != 3 || titlesOf(knowledge) != tt.want {
+			t.Fatalf("page %+v: expected %q of 3, got %q of %d", tt.page, tt.want, titlesOf(knowledge), total)
+		}
+	}
+	if _, _, err := s.ListKnowledge(store.Page{Limit: 2, Sort: "unknown"}); err == nil {
+		t.Fatal("expected error for unknown sort key")
+	}
+
+	c := newCatalog(t, s)
+	goId := c.course(t, s, "go")
+	algorithmsId := c.course(t, s, "algorithms")
+	sqlId := c.course(t, s, "sql")
+	otherDisciplineId := must(s.CreateDiscipline(store.Discipline{Title: "math", EducationalProgramId: c.programId}))
+	must(s.CreateCourse(store.Course{Title: "calculus", DisciplineId: otherDisciplineId}))
+	ivanov := must(s.CreateTeacher(store.Teacher{FullName: "Иванов И. И.", OrganizationId: c.organizationId}))
+	petrov := must(s.CreateTeacher(store.Teacher{FullName: "Петров П. П."}))
+	mustDo(t, s.CreateCourseTeacher(goId, petrov))
+	mustDo(t, s.CreateCourseTeacher(algorithmsId, ivanov))
+
+	// courses without teachers go last and come first in the descending order, like NULL in Postgres
+	for desc, want := range map[bool][]uuid.UUID{false: {algorithmsId, goId, sqlId}, true: {sqlId, goId, algorithmsId}} {
+		courses, total, err := s.ListCourses(store.Page{Limit: 10, Sort: "teacher", Desc: desc}, c.disciplineId)
+		mustDo(t, err)
+		var got []uuid.UUID
+		for _, course := range courses {
+			got = append(got, course.Id)
+		}
+		if total != 3 || !reflect.DeepEqual(got, want) {
+			t.Fatalf("desc %v: expected courses %v of the discipline, got %v of %d", desc, want, got, total)
+		}
+	}
+	if _, total, err := s.ListCourses(store.Page{Limit: 10, Sort: "id"}, uuid.Nil); err != nil || total != 4 {
+		t.Fatalf("expected all 4 courses without the filter, got %d and %v", total, err)
+	}
+
+	teachers, total, err := s.ListTeachers(store.Page{Limit: 10, Sort: "fullName"}, c.organizationId)
+	mustDo(t, err)
+	if total != 1 || len(teachers) != 1 || teachers[0].Id != ivanov {
+		t.Fatalf("expected teachers of the organization, got %+v of %d", teachers, total)
+	}
+
+	day := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	older := must(s.CreateStudent(store.Student{FullName: "b", PortfolioId: must(s.CreatePortfolio()), Admition: day.AddDate(-1, 0, 0)}))
+	newer := must(s.CreateStudent(store.Student{FullName: "a", PortfolioId: must(s.CreatePortfolio()), Admition: day}))
+	students, total, err := s.ListStudents(store.Page{Limit: 10, Sort: "admition", Desc: true})
+	mustDo(t, err)
+	if total != 2 || students[0].Id != newer || students[1].Id != older {
+		t.Fatalf("expected students ordered by admition, got %+v", students)
+	}
+}
+
+func testUpdates(t *testing.T, s store.Store) {
+	missing := uuid.NewV4()
+	if err := s.UpdateKnowledge(store.Knowledge{Id: missing, Title: "sql"}); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown knowledge, got %v", err)
+	}
+
+	databasesId := must(s.CreateKnowledge("databases"))
+	sqlId := must(s.CreateKnowledge("sql"))
+	requirePqError(t, s.UpdateKnowledge(store.Knowledge{Id: sqlId, Title: "databases"}), store.CodeUniqueViolation, "knowledge_title_key")
+	mustDo(t, s.UpdateKnowledge(store.Knowledge{Id: sqlId, Title: "postgres", ParentId: databasesId}))
+	if got := must(s.GetKnowledge(sqlId)); got != (store.Knowledge{Id: sqlId, Title: "postgres", ParentId: databasesId}) {
+		t.Fatalf("unexpected knowledge after update %+v", got)
+	}
+	// the old title is free again
+	if id := must(s.CreateKnowledge("sql")); id == sqlId {
+		t.Fatalf("expected new knowledge for the released title, got %s", id)
+	}
+
+	competencyId := competency(t, s, "backend")
+	err := s.UpdateCompetency(store.Competency{Id: competencyId, Title: "backend", MainTechnologyId: uuid.NewV4()})
+	requirePqError(t, err, store.CodeForeignKeyViolation, "competencies_main_technology_id_fkey")
+	technologyId := must(s.CreateTechnology("go"))
+	mustDo(t, s.UpdateCompetency(store.Competency{Id: competencyId, Title: "backend development", MainTechnologyId: technologyId}))
+	want := store.Competency{Id: competencyId, Title: "backend development", MainTechnologyId: technologyId}
+	if got := must(s.GetCompetency(competencyId)); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	c := newCatalog(t, s)
+	courseId := c.course(t, s, "go")
+	course := store.Course{Id: courseId, Title: "go", DisciplineId: uuid.NewV4()}
+	requirePqError(t, s.UpdateCourse(course), store.CodeForeignKeyViolation, "courses_discipline_id_fkey")
+	course = store.Course{Id: courseId, Title: "go", Description: "language", DisciplineId: c.disciplineId, Credits: 3, Hours: 108, Capacity: 20}
+	mustDo(t, s.UpdateCourse(course))
+	if got := must(s.GetCourse(courseId)); got != course {
+		t.Fatalf("expected %+v, got %+v", course, got)
+	}
+
+	ivanov := must(s.CreateTeacher(store.Teacher{FullName: "Иванов И. И."}))
+	petrov := must(s.CreateTeacher(store.Teacher{FullName: "Петров П. П."}))
+	requirePqError(t, s.UpdateTeacher(store.Teacher{Id: petrov, FullName: "Иванов И. И."}), store.CodeUniqueViolation, "teachers_full_name_key")
+	teacher := store.Teacher{Id: ivanov, FullName: "Иванов И. И.", OrganizationId: c.organizationId, Email: "ivanov@urfu.ru"}
+	mustDo(t, s.UpdateTeacher(teacher))
+	if got := must(s.GetTeacher(ivanov)); got != teacher {
+		t.Fatalf("expected %+v, got %+v", teacher, got)
+	}
+
+	portfolioId := must(s.CreatePortfolio())
+	projectId := must(s.CreateProject(store.Project{Title: "scheduler"}))
+	projectPortfolio := store.ProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, TeamRole: "lead", Semester: 2}
+	if err = s.UpdateProjectPortfolio(projectPortfolio); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the missing project of the portfolio, got %v", err)
+	}
+	mustDo(t, s.CreateProjectPortfolio(store.ProjectPortfolio{ProjectId: projectId, PortfolioId: portfolioId, Semester: 1}))
+	mustDo(t, s.UpdateProjectPortfolio(projectPortfolio))
+	if got := must(s.GetProjectPortfolios(portfolioId)); len(got) != 1 || got[0] != projectPortfolio {
+		t.Fatalf("expected %+v, got %+v", projectPortfolio, got)
+	}
+}
+
+func testDeletes(t *testing.T, s store.Store) {
+	c := newCatalog(t, s)
+	competencyId := competency(t, s, "backend")
+	courseId := c.course(t, s, "go", competencyId)
+	mustDo(t, s.CreateCurriculumDiscipline(store.CurriculumDiscipline{EducationalProgramId: c.programId, DisciplineId: c.disciplineId,
+		Semester: 1}))
+
+	// the curriculum discipline is reached through the program and through the discipline, it is counted once
+	want := []store.Dependent{
+		{Table: "course_competency", Count: 1},
+		{Table: store.TableCourses, Count: 1},
+		{Table: "curriculum_disciplines", Count: 1},
+		{Table: store.TableDisciplines, Count: 1},
+	}
+	if got := must(s.GetDependents(store.TableEducationalPrograms, c.programId)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected dependents %+v, got %+v", want, got)
+	}
+	if got := must(s.GetDependents(store.TableCompetencies, uuid.NewV4())); len(got) != 0 {
+		t.Fatalf("expected no dependents of unknown competency, got %+v", got)
+	}
+
+	if err := s.Delete(store.TableDisciplines, uuid.NewV4()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown discipline, got %v", err)
+	}
+	mustDo(t, s.Delete(store.TableEducationalPrograms, c.programId))
+	if _, err := s.GetCourse(courseId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected the course to be deleted with the program, got %v", err)
+	}
+	if got := must(s.GetCompetenciesByCourse(courseId)); len(got) != 0 {
+		t.Fatalf("expected no competencies of the deleted course, got %+v", got)
+	}
+	must(s.GetCompetency(competencyId))
+
+	// children of the deleted knowledge become roots
+	parentId := must(s.CreateKnowledge("databases"))
+	childId := must(s.CreateKnowledge("sql"))
+	mustDo(t, s.UpdateKnowledgeParent(childId, parentId))
+	mustDo(t, s.Delete(store.TableKnowledge, parentId))
+	if got := must(s.GetKnowledge(childId)); got.ParentId != uuid.Nil {
+		t.Fatalf("expected the child to lose its parent, got %+v", got)
+	}
+	// the title of the deleted row is free again
+	if id := must(s.CreateKnowledge("databases")); id == parentId {
+		t.Fatalf("expected new knowledge for the released title, got %s", id)
+	}
+
+	// projects do not cascade from their main technology
+	technologyId := must(s.CreateTechnology("go"))
+	must(s.CreateProject(store.Project{Title: "scheduler", MainTechnologyId: technologyId}))
+	must(s.CreateCompetency(store.Competency{Title: "goroutines", MainTechnologyId: technologyId}))
+	want = []store.Dependent{{Table: "competencies", Count: 1}, {Table: "projects", Count: 1, Restrict: true}}
+	if got := must(s.GetDependents(store.TableTechnologies, technologyId)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the project to restrict the deletion, got %+v", got)
+	}
+	requirePqError(t, s.Delete(store.TableTechnologies, technologyId), store.CodeForeignKeyViolation, "projects_main_technology_id_fkey")
+	must(s.GetTechnology(technologyId))
+
+	knowledgeId := must(s.CreateKnowledge("go"))
+	mustDo(t, s.CreateKnowledgeCompetency(knowledgeId, competencyId))
+	mustDo(t, s.DeleteKnowledgeCompetency(knowledgeId, competencyId))
+	if err := s.DeleteKnowledgeCompetency(knowledgeId, competencyId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the deleted link, got %v", err)
+	}
+
+	// the chain is built again, its program was deleted above
+	c = newCatalog(t, s)
+	firstId := c.course(t, s, "algorithms")
+	secondId := c.course(t, s, "data structures")
+	mustDo(t, s.CreateCoursePrerequisite(store.CoursePrerequisite{CourseId: secondId, PrerequisiteId: firstId, Kind: store.PrerequisiteHard}))
+	mustDo(t, s.DeleteCoursePrerequisite(secondId, firstId))
+	if err := s.DeleteCoursePrerequisite(secondId, firstId); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for the deleted prerequisite, got %v", err)
+	}
+}
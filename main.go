@@ -1,9 +1,34 @@
 package main
 
 import (
+	"os"
+
 	"github.com/M-Koscheev/urfu-project-smart-schedule-former/cmd"
 )
 
+// commands run instead of the server when their name is the first argument
+var commands = map[string]func(args []string) int{
+	"import-matrix":  cmd.ImportMatrix,
+	"export-catalog": cmd.ExportCatalog,
+	"import-catalog": cmd.ImportCatalog,
+	"create-api-key": cmd.CreateApiKey,
+}
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 API key created by an admin or with the create-api-key command
+//
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer " followed by the token issued at /api/v1/token/ for the API key
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			os.Exit(command(os.Args[2:]))
+		}
+	}
+
 	cmd.StartApp()
 }
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE calendar_semesters ( -- Даты семестров учебного года организации (1 - осенний, 2 - весенний)
+    organization_id UUID REFERENCES organizations(organization_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    academic_year SMALLINT NOT NULL, -- год начала учебного года: 2023 для 2023/2024
+    term SMALLINT CHECK (term IN (1, 2)),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT calendar_semesters_dates_check CHECK (start_date <= end_date),
+    PRIMARY KEY (organization_id, academic_year, term)
+);
+
+CREATE TABLE calendar_periods ( -- Каникулы, праздники и сессии организации
+    calendar_period_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(organization_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    kind VARCHAR NOT NULL CHECK (kind IN ('holiday', 'session')),
+    title VARCHAR,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT calendar_periods_dates_check CHECK (start_date <= end_date)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE calendar_periods;
+DROP TABLE calendar_semesters;
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE time_slots ( -- Пары недельного расписания (1 - понедельник, 7 - воскресенье)
+    time_slot_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CONSTRAINT time_slots_times_check CHECK (start_time < end_time),
+    UNIQUE (weekday, start_time)
+);
+
+CREATE TABLE rooms ( -- Аудитории
+    room_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR NOT NULL UNIQUE,
+    capacity INTEGER NOT NULL CHECK (capacity > 0)
+);
+
+CREATE TABLE course_sessions ( -- Занятия курса: лекции, практики и лабораторные с недельной нагрузкой в академических часах
+    course_session_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    kind VARCHAR NOT NULL CHECK (kind IN ('lecture', 'practice', 'lab')),
+    weekly_hours SMALLINT NOT NULL CHECK (weekly_hours > 0),
+    UNIQUE (course_id, kind)
+);
+
+CREATE TABLE teacher_availability ( -- Пары, в которые преподаватель может вести занятия. Нет строк - доступен всегда
+    teacher VARCHAR NOT NULL,
+    time_slot_id UUID REFERENCES time_slots(time_slot_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (teacher, time_slot_id)
+);
+
+CREATE TABLE timetable ( -- Недельное расписание, построенное планировщиком
+    course_session_id UUID NOT NULL REFERENCES course_sessions(course_session_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    time_slot_id UUID REFERENCES time_slots(time_slot_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    room_id UUID REFERENCES rooms(room_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (time_slot_id, room_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE timetable;
+DROP TABLE teacher_availability;
+DROP TABLE course_sessions;
+DROP TABLE rooms;
+DROP TABLE time_slots;
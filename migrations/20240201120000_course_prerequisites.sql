@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE course_prerequisite ( -- Пререквизиты курсов: hard - обязательный, soft - рекомендуемый
+    course_id UUID REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    prerequisite_id UUID REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    kind VARCHAR NOT NULL CHECK (kind IN ('hard', 'soft')),
+    CONSTRAINT course_prerequisite_self_check CHECK (course_id <> prerequisite_id),
+    PRIMARY KEY (course_id, prerequisite_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE course_prerequisite;
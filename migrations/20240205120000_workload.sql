@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE courses -- Трудоёмкость курса: зачётные единицы и академические часы
+    ADD COLUMN credits SMALLINT NOT NULL DEFAULT 0 CHECK (credits >= 0),
+    ADD COLUMN hours SMALLINT NOT NULL DEFAULT 0 CHECK (hours >= 0);
+
+ALTER TABLE educational_programs -- Допустимая нагрузка студента за семестр в зачётных единицах, 0 в максимуме - без ограничения
+    ADD COLUMN min_semester_credits SMALLINT NOT NULL DEFAULT 0 CHECK (min_semester_credits >= 0),
+    ADD COLUMN max_semester_credits SMALLINT NOT NULL DEFAULT 0 CHECK (max_semester_credits >= 0),
+    ADD CONSTRAINT educational_programs_semester_credits_check CHECK (max_semester_credits = 0 OR min_semester_credits <= max_semester_credits);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE educational_programs
+    DROP CONSTRAINT educational_programs_semester_credits_check,
+    DROP COLUMN max_semester_credits,
+    DROP COLUMN min_semester_credits;
+
+ALTER TABLE courses
+    DROP COLUMN hours,
+    DROP COLUMN credits;
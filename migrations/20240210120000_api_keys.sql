@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE api_keys ( -- Ключи доступа к API, сам ключ не хранится, только его SHA-256
+    api_key_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_hash VARCHAR NOT NULL UNIQUE,
+    api_key_title VARCHAR NOT NULL,
+    api_key_role VARCHAR NOT NULL CHECK (api_key_role IN ('admin', 'analyst', 'teacher', 'student')),
+    student_id UUID REFERENCES students(student_id) ON DELETE CASCADE ON UPDATE CASCADE, -- студент, которым является владелец ключа
+    teacher VARCHAR NOT NULL DEFAULT '', -- ФИО преподавателя, как в courses.teacher
+    CONSTRAINT api_keys_subject_check CHECK ((api_key_role = 'student') = (student_id IS NOT NULL)
+        AND (api_key_role = 'teacher') = (teacher <> ''))
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE api_keys;
@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm; -- триграммы для поиска с опечатками
+
+-- Поисковые документы каталога на русском: название важнее описания (вес A против B)
+ALTER TABLE knowledge ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A')) STORED;
+ALTER TABLE technologies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A')) STORED;
+ALTER TABLE competencies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(skills, '')), 'B')) STORED;
+ALTER TABLE professions ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B')) STORED;
+ALTER TABLE projects ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '') || ' ' || COALESCE(result, '') || ' ' || COALESCE(life_scenario, '')), 'B')) STORED;
+ALTER TABLE organizations ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A')) STORED;
+ALTER TABLE educational_programs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B')) STORED;
+ALTER TABLE disciplines ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B')) STORED;
+ALTER TABLE courses ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B')) STORED;
+
+CREATE INDEX knowledge_search_idx ON knowledge USING GIN (search_vector);
+CREATE INDEX technologies_search_idx ON technologies USING GIN (search_vector);
+CREATE INDEX competencies_search_idx ON competencies USING GIN (search_vector);
+CREATE INDEX professions_search_idx ON professions USING GIN (search_vector);
+CREATE INDEX projects_search_idx ON projects USING GIN (search_vector);
+CREATE INDEX organizations_search_idx ON organizations USING GIN (search_vector);
+CREATE INDEX educational_programs_search_idx ON educational_programs USING GIN (search_vector);
+CREATE INDEX disciplines_search_idx ON disciplines USING GIN (search_vector);
+CREATE INDEX courses_search_idx ON courses USING GIN (search_vector);
+
+-- Названия с опечатками и в другой форме ищутся по триграммам
+CREATE INDEX knowledge_title_trgm_idx ON knowledge USING GIN (title gin_trgm_ops);
+CREATE INDEX technologies_title_trgm_idx ON technologies USING GIN (title gin_trgm_ops);
+CREATE INDEX competencies_title_trgm_idx ON competencies USING GIN (title gin_trgm_ops);
+CREATE INDEX professions_title_trgm_idx ON professions USING GIN (title gin_trgm_ops);
+CREATE INDEX projects_title_trgm_idx ON projects USING GIN (title gin_trgm_ops);
+CREATE INDEX organizations_title_trgm_idx ON organizations USING GIN (title gin_trgm_ops);
+CREATE INDEX educational_programs_title_trgm_idx ON educational_programs USING GIN (title gin_trgm_ops);
+CREATE INDEX disciplines_title_trgm_idx ON disciplines USING GIN (title gin_trgm_ops);
+CREATE INDEX courses_title_trgm_idx ON courses USING GIN (title gin_trgm_ops);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX knowledge_title_trgm_idx, technologies_title_trgm_idx, competencies_title_trgm_idx, professions_title_trgm_idx,
+    projects_title_trgm_idx, organizations_title_trgm_idx, educational_programs_title_trgm_idx, disciplines_title_trgm_idx,
+    courses_title_trgm_idx;
+
+ALTER TABLE knowledge DROP COLUMN search_vector;
+ALTER TABLE technologies DROP COLUMN search_vector;
+ALTER TABLE competencies DROP COLUMN search_vector;
+ALTER TABLE professions DROP COLUMN search_vector;
+ALTER TABLE projects DROP COLUMN search_vector;
+ALTER TABLE organizations DROP COLUMN search_vector;
+ALTER TABLE educational_programs DROP COLUMN search_vector;
+ALTER TABLE disciplines DROP COLUMN search_vector;
+ALTER TABLE courses DROP COLUMN search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
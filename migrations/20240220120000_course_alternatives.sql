@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE courses -- Курс - один из взаимозаменяемых вариантов своей дисциплины (Go от geekbrains, Java от УрФУ, Kotlin от ИТМО), студент выбирает ровно один
+    ADD COLUMN alternative BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE courses
+    DROP COLUMN alternative;
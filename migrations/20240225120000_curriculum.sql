@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE elective_pools ( -- Группы дисциплин по выбору образовательной программы: студент выбирает choose дисциплин из группы
+    elective_pool_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    educational_program_id UUID NOT NULL REFERENCES educational_programs(educational_program_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    title VARCHAR NOT NULL,
+    choose SMALLINT NOT NULL CHECK (choose > 0),
+    UNIQUE (educational_program_id, title),
+    UNIQUE (elective_pool_id, educational_program_id)
+);
+
+CREATE TABLE curriculum_disciplines ( -- Учебный план программы: дисциплина, семестр и группа по выбору, без группы - обязательная
+    educational_program_id UUID REFERENCES educational_programs(educational_program_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    discipline_id UUID REFERENCES disciplines(discipline_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    semester SMALLINT NOT NULL CHECK (semester BETWEEN 1 AND 12),
+    elective_pool_id UUID,
+    -- группа по выбору должна принадлежать той же программе
+    CONSTRAINT curriculum_disciplines_elective_pool_id_fkey FOREIGN KEY (elective_pool_id, educational_program_id)
+        REFERENCES elective_pools(elective_pool_id, educational_program_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (educational_program_id, discipline_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE curriculum_disciplines;
+DROP TABLE elective_pools;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE enrollments ( -- Зачисления студентов на образовательные программы, перевод закрывает зачисление со статусом transferred
+    enrollment_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL REFERENCES students(student_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    educational_program_id UUID NOT NULL REFERENCES educational_programs(educational_program_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE, -- пусто, пока студент учится или находится в академическом отпуске
+    status VARCHAR NOT NULL CHECK (status IN ('active', 'academicLeave', 'expelled', 'graduated', 'transferred')),
+    CONSTRAINT enrollments_dates_check CHECK (start_date <= end_date),
+    CONSTRAINT enrollments_end_check CHECK ((end_date IS NULL) = (status IN ('active', 'academicLeave')))
+);
+
+-- у студента не больше одного открытого зачисления
+CREATE UNIQUE INDEX enrollments_student_id_key ON enrollments (student_id) WHERE end_date IS NULL;
+
+CREATE TABLE academic_leaves ( -- Академические отпуска: семестры, начавшиеся во время отпуска, не считаются
+    academic_leave_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    enrollment_id UUID NOT NULL REFERENCES enrollments(enrollment_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE, -- пусто, пока отпуск не закончился
+    CONSTRAINT academic_leaves_dates_check CHECK (start_date <= end_date)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE academic_leaves;
+DROP TABLE enrollments;
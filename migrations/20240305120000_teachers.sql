@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE teachers ( -- Преподаватели, ФИО уникально, как раньше строка courses.teacher
+    teacher_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    full_name VARCHAR NOT NULL UNIQUE,
+    organization_id UUID REFERENCES organizations(organization_id) ON DELETE SET NULL ON UPDATE CASCADE, -- организация, в которой работает преподаватель
+    email VARCHAR,
+    phone VARCHAR
+);
+
+CREATE TABLE course_teacher ( -- Преподаватели курса, у курса их может быть несколько
+    course_id UUID REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    teacher_id UUID REFERENCES teachers(teacher_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (course_id, teacher_id)
+);
+
+-- Строки courses.teacher, teacher_availability.teacher и api_keys.teacher становятся преподавателями.
+-- Организация берётся из образовательной программы первого по названию курса преподавателя.
+INSERT INTO teachers (full_name, organization_id)
+SELECT DISTINCT ON (full_name) full_name, organization_id FROM (
+    SELECT trim(courses.teacher) AS full_name, educational_programs.organizations_id AS organization_id, courses.title AS course
+    FROM courses
+    JOIN disciplines ON disciplines.discipline_id = courses.discipline_id
+    JOIN educational_programs ON educational_programs.educational_program_id = disciplines.educational_program_id
+    UNION ALL
+    SELECT trim(teacher), NULL, NULL FROM teacher_availability
+    UNION ALL
+    SELECT trim(teacher), NULL, NULL FROM api_keys
+) AS names
+WHERE full_name <> ''
+ORDER BY full_name, course NULLS LAST;
+
+INSERT INTO course_teacher (course_id, teacher_id)
+SELECT courses.course_id, teachers.teacher_id FROM courses JOIN teachers ON teachers.full_name = trim(courses.teacher);
+
+ALTER TABLE courses DROP COLUMN teacher;
+
+ALTER TABLE teacher_availability ADD COLUMN teacher_id UUID REFERENCES teachers(teacher_id) ON DELETE CASCADE ON UPDATE CASCADE;
+UPDATE teacher_availability SET teacher_id = teachers.teacher_id FROM teachers WHERE teachers.full_name = trim(teacher_availability.teacher);
+DELETE FROM teacher_availability WHERE teacher_id IS NULL;
+-- после trim одинаковые пары могли совпасть
+DELETE FROM teacher_availability a USING teacher_availability b
+    WHERE a.ctid < b.ctid AND a.teacher_id = b.teacher_id AND a.time_slot_id = b.time_slot_id;
+ALTER TABLE teacher_availability DROP COLUMN teacher;
+ALTER TABLE teacher_availability ALTER COLUMN teacher_id SET NOT NULL;
+ALTER TABLE teacher_availability ADD PRIMARY KEY (teacher_id, time_slot_id);
+
+ALTER TABLE api_keys ADD COLUMN teacher_id UUID REFERENCES teachers(teacher_id) ON DELETE CASCADE ON UPDATE CASCADE; -- преподаватель, которым является владелец ключа
+UPDATE api_keys SET teacher_id = teachers.teacher_id FROM teachers WHERE teachers.full_name = trim(api_keys.teacher);
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_subject_check;
+ALTER TABLE api_keys DROP COLUMN teacher;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_subject_check CHECK ((api_key_role = 'student') = (student_id IS NOT NULL)
+    AND (api_key_role = 'teacher') = (teacher_id IS NOT NULL));
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_subject_check;
+ALTER TABLE api_keys ADD COLUMN teacher VARCHAR NOT NULL DEFAULT '';
+UPDATE api_keys SET teacher = teachers.full_name FROM teachers WHERE teachers.teacher_id = api_keys.teacher_id;
+ALTER TABLE api_keys DROP COLUMN teacher_id;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_subject_check CHECK ((api_key_role = 'student') = (student_id IS NOT NULL)
+    AND (api_key_role = 'teacher') = (teacher <> ''));
+
+ALTER TABLE teacher_availability ADD COLUMN teacher VARCHAR;
+UPDATE teacher_availability SET teacher = teachers.full_name FROM teachers WHERE teachers.teacher_id = teacher_availability.teacher_id;
+ALTER TABLE teacher_availability DROP COLUMN teacher_id;
+ALTER TABLE teacher_availability ALTER COLUMN teacher SET NOT NULL;
+ALTER TABLE teacher_availability ADD PRIMARY KEY (teacher, time_slot_id);
+
+-- у курса остаётся только первый по алфавиту преподаватель
+ALTER TABLE courses ADD COLUMN teacher VARCHAR;
+UPDATE courses SET teacher = (SELECT min(teachers.full_name) FROM course_teacher
+    JOIN teachers ON teachers.teacher_id = course_teacher.teacher_id WHERE course_teacher.course_id = courses.course_id);
+
+DROP TABLE course_teacher;
+DROP TABLE teachers;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE courses -- Число мест на курсе в семестре, 0 - без ограничения
+    ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0);
+
+ALTER TABLE calendar_semesters -- Запись на курсы семестра открыта с enrollment_start по enrollment_end включительно
+    ADD COLUMN enrollment_start DATE,
+    ADD COLUMN enrollment_end DATE,
+    ADD CONSTRAINT calendar_semesters_enrollment_check CHECK ((enrollment_start IS NULL) = (enrollment_end IS NULL)
+        AND enrollment_start <= enrollment_end AND enrollment_end <= end_date);
+
+CREATE TABLE course_waitlists ( -- Очередь студентов, ожидающих места на курсе
+    waitlist_number BIGSERIAL NOT NULL UNIQUE, -- порядок постановки в очередь
+    course_id UUID REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    student_id UUID REFERENCES students(student_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (course_id, student_id)
+);
+
+CREATE TABLE study_group_drops ( -- Студенты, выбывшие с курса или покинувшие очередь, повторная запись удаляет строку
+    course_id UUID REFERENCES courses(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    student_id UUID REFERENCES students(student_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    drop_date DATE NOT NULL,
+    PRIMARY KEY (course_id, student_id)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE study_group_drops;
+DROP TABLE course_waitlists;
+
+ALTER TABLE calendar_semesters
+    DROP CONSTRAINT calendar_semesters_enrollment_check,
+    DROP COLUMN enrollment_end,
+    DROP COLUMN enrollment_start;
+
+ALTER TABLE courses DROP COLUMN capacity;
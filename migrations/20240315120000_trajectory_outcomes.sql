@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE trajectories -- Итог прохождения курса: оценка, зачтён ли курс, номер попытки и дата завершения
+    ADD COLUMN grade SMALLINT NOT NULL DEFAULT 0 CHECK (grade >= 0), -- 0 - оценка не выставлена
+    ADD COLUMN result VARCHAR CHECK (result IN ('passed', 'failed')), -- NULL - итога ещё нет
+    ADD COLUMN attempt SMALLINT NOT NULL DEFAULT 1 CHECK (attempt >= 1),
+    ADD COLUMN completion_date DATE,
+    ADD COLUMN retake_of UUID REFERENCES trajectories(trajectory_id) ON DELETE CASCADE ON UPDATE CASCADE, -- первая попытка
+    ADD CONSTRAINT trajectories_completion_check CHECK (completion_date IS NULL OR result IS NOT NULL),
+    ADD CONSTRAINT trajectories_retake_check CHECK ((retake_of IS NULL) = (attempt = 1));
+
+-- До появления итогов все курсы траекторий засчитывались, поэтому прежние записи считаются пройденными
+UPDATE trajectories SET result = 'passed';
+
+CREATE TABLE grading_scales ( -- Шкала оценок организации, без неё действует шкала от 2 до 5 с проходной оценкой 3
+    organization_id UUID PRIMARY KEY REFERENCES organizations(organization_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    min_grade SMALLINT NOT NULL CHECK (min_grade >= 1),
+    max_grade SMALLINT NOT NULL,
+    pass_grade SMALLINT NOT NULL, -- наименьшая оценка, с которой курс зачитывается
+    CONSTRAINT grading_scales_grades_check CHECK (min_grade <= pass_grade AND pass_grade <= max_grade)
+);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP TABLE grading_scales;
+
+ALTER TABLE trajectories
+    DROP CONSTRAINT trajectories_retake_check,
+    DROP CONSTRAINT trajectories_completion_check,
+    DROP COLUMN retake_of,
+    DROP COLUMN completion_date,
+    DROP COLUMN attempt,
+    DROP COLUMN result,
+    DROP COLUMN grade;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Уровень владения компетенцией: 1 - aware, 2 - basic, 3 - intermediate, 4 - advanced.
+-- Прежние связи не различали уровни, поэтому получают базовый уровень
+ALTER TABLE competency_profession -- Уровень, который требует профессия
+    ADD COLUMN level SMALLINT NOT NULL DEFAULT 2 CHECK (level BETWEEN 1 AND 4);
+
+ALTER TABLE course_competency -- Уровень, который даёт курс
+    ADD COLUMN level SMALLINT NOT NULL DEFAULT 2 CHECK (level BETWEEN 1 AND 4);
+
+ALTER TABLE project_portfolio_competency -- Уровень, подтверждённый проектом
+    ADD COLUMN level SMALLINT NOT NULL DEFAULT 2 CHECK (level BETWEEN 1 AND 4);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE project_portfolio_competency DROP COLUMN level;
+ALTER TABLE course_competency DROP COLUMN level;
+ALTER TABLE competency_profession DROP COLUMN level;
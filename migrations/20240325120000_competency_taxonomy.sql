@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+CREATE TABLE skills ( -- Навыки, название уникально, у компетенций могут быть общие навыки
+    skill_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR NOT NULL UNIQUE
+);
+
+CREATE TABLE skill_competency ( -- Навыки компетенции
+    skill_id UUID REFERENCES skills(skill_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    competency_id UUID REFERENCES competencies(competency_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (skill_id, competency_id)
+);
+
+-- Строки competencies.skills делятся по запятым на навыки, пустые части пропускаются
+INSERT INTO skills (title)
+SELECT DISTINCT trim(skill) FROM competencies, unnest(string_to_array(competencies.skills, ',')) AS skill
+WHERE trim(skill) <> '';
+
+INSERT INTO skill_competency (skill_id, competency_id)
+SELECT DISTINCT skills.skill_id, competencies.competency_id
+FROM competencies CROSS JOIN unnest(string_to_array(competencies.skills, ',')) AS skill
+JOIN skills ON skills.title = trim(skill);
+
+-- Поисковый документ компетенции теперь только из названия, навыки ищутся через skill_competency
+ALTER TABLE competencies DROP COLUMN search_vector;
+ALTER TABLE competencies DROP COLUMN skills;
+ALTER TABLE competencies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A')) STORED;
+CREATE INDEX competencies_search_idx ON competencies USING GIN (search_vector);
+
+-- Группировка компетенций и знаний в дерево, NULL - корень. При удалении родителя дети становятся корнями
+ALTER TABLE competencies
+    ADD COLUMN parent_id UUID REFERENCES competencies(competency_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    ADD CONSTRAINT competencies_parent_check CHECK (parent_id <> competency_id);
+ALTER TABLE knowledge
+    ADD COLUMN parent_id UUID REFERENCES knowledge(knowledge_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    ADD CONSTRAINT knowledge_parent_check CHECK (parent_id <> knowledge_id);
+
+CREATE INDEX competencies_parent_idx ON competencies (parent_id);
+CREATE INDEX knowledge_parent_idx ON knowledge (parent_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+DROP INDEX knowledge_parent_idx, competencies_parent_idx;
+
+ALTER TABLE knowledge
+    DROP CONSTRAINT knowledge_parent_check,
+    DROP COLUMN parent_id;
+ALTER TABLE competencies
+    DROP CONSTRAINT competencies_parent_check,
+    DROP COLUMN parent_id;
+
+-- навыки снова собираются в строку через запятую в порядке названий
+ALTER TABLE competencies ADD COLUMN skills VARCHAR;
+UPDATE competencies SET skills = (SELECT string_agg(skills.title, ', ' ORDER BY skills.title) FROM skill_competency
+    JOIN skills ON skills.skill_id = skill_competency.skill_id WHERE skill_competency.competency_id = competencies.competency_id);
+
+ALTER TABLE competencies DROP COLUMN search_vector;
+ALTER TABLE competencies ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(skills, '')), 'B')) STORED;
+CREATE INDEX competencies_search_idx ON competencies USING GIN (search_vector);
+
+DROP TABLE skill_competency;
+DROP TABLE skills;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- Повторные попытки, добавленные одновременно, могли получить один номер: они нумеруются заново по порядку семестров
+UPDATE trajectories SET attempt = numbered.attempt
+FROM (SELECT trajectory_id, row_number() OVER (PARTITION BY retake_of ORDER BY attempt, semester, trajectory_id) + 1 AS attempt
+    FROM trajectories WHERE retake_of IS NOT NULL) AS numbered
+WHERE trajectories.trajectory_id = numbered.trajectory_id;
+
+ALTER TABLE trajectories -- Номер попытки курса не повторяется
+    ADD CONSTRAINT trajectories_retake_of_attempt_key UNIQUE (retake_of, attempt);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+
+ALTER TABLE trajectories DROP CONSTRAINT trajectories_retake_of_attempt_key;